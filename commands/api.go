@@ -293,6 +293,10 @@ func apiCommand(_ *Command, args *Args) {
 				ui.Errorln()
 				ui.Errorln(scopeErr)
 			}
+			if permissionErr := github.ValidateSufficientPermissions(response.Response); permissionErr != nil {
+				ui.Errorln()
+				ui.Errorln(permissionErr)
+			}
 			os.Exit(22)
 		}
 
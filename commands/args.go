@@ -17,6 +17,9 @@ type Args struct {
 	beforeChain []*cmd.Cmd
 	afterChain  []*cmd.Cmd
 	Noop        bool
+	Remote      string
+	Repo        string
+	Host        string
 	Terminator  bool
 	noForward   bool
 	Callbacks   []func() error
@@ -184,19 +187,41 @@ func NewArgs(args []string) *Args {
 		command string
 		params  []string
 		noop    bool
+		remote  string
+		repo    string
+		host    string
 	)
 
 	cmdIdx := findCommandIndex(args)
-	globalFlags := args[:cmdIdx]
-	if cmdIdx > 0 {
-		args = args[cmdIdx:]
-		for i := len(globalFlags) - 1; i >= 0; i-- {
-			if globalFlags[i] == noopFlag {
-				noop = true
-				globalFlags = append(globalFlags[:i], globalFlags[i+1:]...)
+	globalFlags := []string{}
+	for i := 0; i < cmdIdx; i++ {
+		flag := args[i]
+		switch {
+		case flag == noopFlag:
+			noop = true
+		case flag == remoteFlag || flag == repoFlag || flag == hostFlag:
+			if i+1 < cmdIdx {
+				i++
+				switch flag {
+				case remoteFlag:
+					remote = args[i]
+				case repoFlag:
+					repo = args[i]
+				case hostFlag:
+					host = args[i]
+				}
 			}
+		case strings.HasPrefix(flag, remoteFlag+"="):
+			remote = strings.TrimPrefix(flag, remoteFlag+"=")
+		case strings.HasPrefix(flag, repoFlag+"="):
+			repo = strings.TrimPrefix(flag, repoFlag+"=")
+		case strings.HasPrefix(flag, hostFlag+"="):
+			host = strings.TrimPrefix(flag, hostFlag+"=")
+		default:
+			globalFlags = append(globalFlags, flag)
 		}
 	}
+	args = args[cmdIdx:]
 
 	if len(args) != 0 {
 		command = args[0]
@@ -209,6 +234,9 @@ func NewArgs(args []string) *Args {
 		Command:     command,
 		Params:      params,
 		Noop:        noop,
+		Remote:      remote,
+		Repo:        repo,
+		Host:        host,
 		beforeChain: make([]*cmd.Cmd, 0),
 		afterChain:  make([]*cmd.Cmd, 0),
 	}
@@ -216,6 +244,9 @@ func NewArgs(args []string) *Args {
 
 const (
 	noopFlag    = "--noop"
+	remoteFlag  = "--remote"
+	repoFlag    = "--repo"
+	hostFlag    = "--host"
 	versionFlag = "--version"
 	listCmds    = "--list-cmds="
 	helpFlag    = "--help"
@@ -240,7 +271,7 @@ func findCommandIndex(args []string) int {
 			break
 		} else {
 			commandIndex = i + 1
-			if arg == configFlag || arg == chdirFlag {
+			if arg == configFlag || arg == chdirFlag || arg == remoteFlag || arg == repoFlag || arg == hostFlag {
 				slurpNextValue = true
 			}
 		}
@@ -98,6 +98,25 @@ func TestArgs_GlobalFlags_NoopTwice(t *testing.T) {
 	assert.Equal(t, true, args.Noop)
 }
 
+func TestArgs_GlobalFlags_RemoteAndRepo(t *testing.T) {
+	args := NewArgs([]string{"--remote", "fork", "-c", "a=b", "--repo=octocat/hello", "pr", "list"})
+	assert.Equal(t, "pr", args.Command)
+	assert.Equal(t, []string{"-c", "a=b"}, args.GlobalFlags)
+	assert.Equal(t, []string{"list"}, args.Params)
+	assert.Equal(t, "fork", args.Remote)
+	assert.Equal(t, "octocat/hello", args.Repo)
+
+	args = NewArgs([]string{"--host=github.example.com", "--repo", "octocat/hello", "issue"})
+	assert.Equal(t, "issue", args.Command)
+	assert.Equal(t, []string{}, args.GlobalFlags)
+	assert.Equal(t, "github.example.com", args.Host)
+	assert.Equal(t, "octocat/hello", args.Repo)
+
+	args = NewArgs([]string{"pr", "--repo", "octocat/hello"})
+	assert.Equal(t, "", args.Repo)
+	assert.Equal(t, []string{"--repo", "octocat/hello"}, args.Params)
+}
+
 func TestArgs_GlobalFlags_Repeated(t *testing.T) {
 	args := NewArgs([]string{"-C", "mydir", "-c", "a=b", "--bare", "-c", "c=d", "-c", "e=f", "status"})
 	assert.Equal(t, "status", args.Command)
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdAuth = &Command{
+		Run: printHelp,
+		Usage: `
+auth refresh [--host <HOST>] [--scopes <SCOPES>]
+`,
+		Long: `Manage the credentials that hub uses for GitHub.
+
+## Commands:
+
+	* _refresh_:
+		Replace the access token stored in hub configuration with a new one. The
+		new token is obtained via the OAuth device flow: hub prints a one-time
+		code to enter on the verification page on GitHub and waits until it is
+		authorized. Once the new token is verified to belong to the same user, it
+		replaces the old token in the configuration file in a single step, and the
+		old token is revoked.
+
+## Options:
+	--host <HOST>
+		Refresh the token for <HOST> instead of the GitHub host of the current
+		repository (default: "github.com").
+
+	--scopes <SCOPES>
+		Request the comma-separated OAuth <SCOPES> for the new token (default: the
+		scopes of the old token, or "repo,gist").
+
+## Examples:
+		$ HUB_OAUTH_CLIENT_ID=Iv1.0123456789abcdef hub auth refresh
+		First copy your one-time code: 1A2B-3C4D
+		Then open https://github.com/login/device in a web browser to authorize it.
+		Refreshed the access token of mislav for github.com
+
+## Configuration:
+
+	* ''HUB_OAUTH_CLIENT_ID'':
+		The client ID of the OAuth app that issues the new token. The app must have
+		the device flow enabled.
+
+## Notes:
+
+Tokens given via ''GITHUB_TOKEN'' are not stored by hub and can't be refreshed.
+
+If the old token can't be revoked, for example because GitHub Enterprise Server
+doesn't support revoking credentials, hub warns about it and the old token
+should be deleted on GitHub manually.
+
+## See also:
+
+hub-whoami(1), hub(1)
+`,
+	}
+
+	cmdRefreshAuth = &Command{
+		Key: "refresh",
+		Run: refreshAuth,
+		KnownFlags: `
+		--host HOST
+		--scopes SCOPES
+`,
+	}
+)
+
+func init() {
+	cmdAuth.Use(cmdRefreshAuth)
+	CmdRunner.Use(cmdAuth)
+}
+
+func refreshAuth(cmd *Command, args *Args) {
+	host := args.Flag.Value("--host")
+	if host == "" {
+		_, host = projectOrDefaultHost()
+	}
+
+	config := github.CurrentConfig()
+	if config.DetectToken() != "" {
+		utils.Check(fmt.Errorf("error: can't refresh the access token given via GITHUB_TOKEN"))
+	}
+	hostConfig := config.Find(host)
+	if hostConfig == nil {
+		utils.Check(fmt.Errorf("error: not logged in to %s", host))
+	}
+
+	clientID := os.Getenv("HUB_OAUTH_CLIENT_ID")
+	if clientID == "" {
+		utils.Check(fmt.Errorf("error: refreshing the access token requires HUB_OAUTH_CLIENT_ID to be set"))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would refresh the access token of %s for %s\n", hostConfig.User, host)
+		return
+	}
+
+	oldToken := hostConfig.AccessToken
+	gh := github.NewClientWithHost(hostConfig)
+
+	scopes := commaSeparated(args.Flag.AllValues("--scopes"))
+	if len(scopes) == 0 {
+		if status, err := gh.FetchAuthStatus(); err == nil && len(status.Scopes) > 0 {
+			scopes = status.Scopes
+		} else {
+			scopes = []string{"repo", "gist"}
+		}
+	}
+
+	code, err := gh.RequestDeviceCode(clientID, scopes)
+	utils.Check(err)
+
+	ui.Errorf("First copy your one-time code: %s\n", code.UserCode)
+	ui.Errorf("Then open %s in a web browser to authorize it.\n", code.VerificationURI)
+
+	newToken, err := gh.PollDeviceToken(clientID, code)
+	utils.Check(err)
+
+	newClient := github.NewClientWithHost(&github.Host{
+		Host:        hostConfig.Host,
+		User:        hostConfig.User,
+		AccessToken: newToken,
+		Protocol:    hostConfig.Protocol,
+		UnixSocket:  hostConfig.UnixSocket,
+	})
+	user, err := newClient.CurrentUser()
+	utils.Check(err)
+	if !strings.EqualFold(user.Login, hostConfig.User) {
+		utils.Check(fmt.Errorf("Aborted: the new access token belongs to %s instead of %s", user.Login, hostConfig.User))
+	}
+
+	hostConfig.AccessToken = newToken
+	utils.Check(config.Save())
+
+	if err := gh.RevokeToken(oldToken); err != nil {
+		ui.Errorf("warning: the old access token could not be revoked; delete it on GitHub instead\n%s\n", err)
+	}
+
+	ui.Printf("Refreshed the access token of %s for %s\n", hostConfig.User, host)
+}
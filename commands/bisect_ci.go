@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdBisectCi = &Command{
+	Run:   bisectCi,
+	Usage: "bisect-ci <GOOD> <BAD>",
+	Long: `Find the first commit that broke CI by bisecting with recorded CI statuses.
+
+Drives git-bisect(1) between <GOOD> and <BAD>, using the CI status that GitHub
+has on record for each commit as the test instead of building anything
+locally. Commits with a successful status are marked as good, commits with a
+failing status as bad, and commits whose status is pending or missing are
+skipped.
+
+The bisection runs with ''--no-checkout'', so the working tree is left alone,
+and the bisect state is reset once the first failing commit has been found.
+
+## Examples:
+		$ hub bisect-ci v1.0 main
+		3f4e2a1  success -> good
+		9b7c0d2  failure -> bad
+		c41a8e5  pending -> skip
+		9b7c0d2 is the first bad commit
+
+## See also:
+
+hub-ci-status(1), git-bisect(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdBisectCi)
+}
+
+func bisectCi(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) != 2 {
+		utils.Check(command.UsageError(""))
+	}
+	good, bad := words[0], words[1]
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would bisect %s..%s using CI statuses from %s\n", good, bad, project)
+		return
+	}
+
+	output, err := git.Bisect("start", "--no-checkout", bad, good, "--")
+	utils.Check(err)
+
+	result, found := bisectResult(output)
+	if !found {
+		gh := github.NewClient(project.Host)
+		result, err = runBisectCi(gh, project)
+	}
+	git.Bisect("reset")
+	utils.Check(err)
+	ui.Print(result)
+}
+
+// runBisectCi marks commits until git-bisect(1) has found the first bad one or
+// only skipped commits remain, and returns the final message of git-bisect(1).
+func runBisectCi(gh *github.Client, project *github.Project) (string, error) {
+	for {
+		sha, err := git.Ref("BISECT_HEAD")
+		if err != nil {
+			return "", err
+		}
+
+		response, err := gh.FetchCIStatus(project, sha)
+		if err != nil {
+			return "", err
+		}
+		state := ciState(response.Statuses)
+		verdict := bisectVerdict(state)
+		if state == "" {
+			state = "no status"
+		}
+		ui.Printf("%.7s  %s -> %s\n", sha, state, verdict)
+
+		output, err := git.Bisect(verdict)
+		if err != nil {
+			return "", err
+		}
+		if result, found := bisectResult(output); found {
+			return result, nil
+		}
+	}
+}
+
+// bisectResult extracts the conclusion from the output of git-bisect(1), if
+// the bisection has ended.
+func bisectResult(output string) (string, bool) {
+	if strings.Contains(output, "is the first bad commit") {
+		return strings.SplitN(output, "\n", 2)[0] + "\n", true
+	}
+	if strings.Contains(output, "only 'skip'ped commits left") {
+		return output, true
+	}
+	return "", false
+}
+
+// bisectVerdict maps a CI state to the git-bisect(1) term for a commit.
+func bisectVerdict(state string) string {
+	switch state {
+	case "success", "neutral":
+		return "good"
+	case "failure", "error", "action_required", "cancelled", "timed_out":
+		return "bad"
+	default:
+		return "skip"
+	}
+}
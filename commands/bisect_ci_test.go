@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestBisectVerdict(t *testing.T) {
+	assert.Equal(t, "good", bisectVerdict("success"))
+	assert.Equal(t, "good", bisectVerdict("neutral"))
+	assert.Equal(t, "bad", bisectVerdict("failure"))
+	assert.Equal(t, "bad", bisectVerdict("timed_out"))
+	assert.Equal(t, "skip", bisectVerdict("pending"))
+	assert.Equal(t, "skip", bisectVerdict(""))
+}
+
+func TestBisectResult(t *testing.T) {
+	result, found := bisectResult("Bisecting: 2 revisions left to test after this (roughly 1 step)\n[414fd74] c3\n")
+	assert.Equal(t, "", result)
+	assert.Equal(t, false, found)
+
+	result, found = bisectResult("414fd7496760ae6e2dec9c658451ed0a0cc7b947 is the first bad commit\ncommit 414fd7496760ae6e2dec9c658451ed0a0cc7b947\n")
+	assert.Equal(t, "414fd7496760ae6e2dec9c658451ed0a0cc7b947 is the first bad commit\n", result)
+	assert.Equal(t, true, found)
+
+	result, found = bisectResult("There are only 'skip'ped commits left to test.\nThe first bad commit could be any of:\n")
+	assert.Equal(t, "There are only 'skip'ped commits left to test.\nThe first bad commit could be any of:\n", result)
+	assert.Equal(t, true, found)
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdBlamePr = &Command{
+	Run:   blamePr,
+	Usage: "blame-pr [-uc] <FILE> <LINE>",
+	Long: `Find the pull request that last changed a line of a file.
+
+## Options:
+	-u, --url
+		Print the pull request URL instead of opening it.
+
+	-c, --copy
+		Put the pull request URL in clipboard instead of opening it.
+
+	<FILE> <LINE>
+		The file and line number to look up. The commit that last changed the
+		line is found with ''git blame'', and then GitHub is asked which pull
+		request introduced that commit.
+
+## Examples:
+		$ hub blame-pr commands/browse.go 42
+		> open https://github.com/REPO/pull/1234
+
+		$ hub blame-pr -u commands/browse.go 42
+		#1234  Add blame links to browse (merged)
+		https://github.com/REPO/pull/1234
+
+## See also:
+
+hub-browse(1), hub-commit(1), hub(1), git-blame(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdBlamePr)
+}
+
+func blamePr(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) != 2 {
+		utils.Check(command.UsageError(""))
+	}
+	file := words[0]
+	line, err := strconv.Atoi(words[1])
+	if err != nil || line < 1 {
+		utils.Check(command.UsageError(fmt.Sprintf("invalid line number: %s", words[1])))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	sha, err := git.BlameLine(file, line)
+	utils.Check(err)
+	if strings.Trim(sha, "0") == "" {
+		utils.Check(fmt.Errorf("Aborted: line %d of %s has not been committed yet", line, file))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would look up the pull request for commit %s\n", sha)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	pulls, err := gh.FetchCommitPullRequests(project, sha)
+	utils.Check(err)
+
+	pr := blamedPullRequest(pulls)
+	if pr == nil {
+		utils.Check(fmt.Errorf("Aborted: no pull request found for commit %s", sha[:7]))
+	}
+
+	flagBlameURLPrint := args.Flag.Bool("--url")
+	flagBlameURLCopy := args.Flag.Bool("--copy")
+	if flagBlameURLPrint {
+		state := pr.State
+		if !pr.MergedAt.IsZero() {
+			state = "merged"
+		}
+		ui.Printf("#%d  %s (%s)\n", pr.Number, pr.Title, state)
+	}
+	printBrowseOrCopy(args, pr.HTMLURL, !flagBlameURLPrint && !flagBlameURLCopy, flagBlameURLCopy)
+}
+
+// blamedPullRequest picks the pull request that introduced a commit: the one
+// that merged it, or else the first one that contains it.
+func blamedPullRequest(pulls []github.PullRequest) *github.PullRequest {
+	for i, pr := range pulls {
+		if !pr.MergedAt.IsZero() {
+			return &pulls[i]
+		}
+	}
+	if len(pulls) > 0 {
+		return &pulls[0]
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestBlamedPullRequest(t *testing.T) {
+	assert.T(t, blamedPullRequest(nil) == nil)
+
+	pulls := []github.PullRequest{
+		{Number: 1, State: "open"},
+		{Number: 2, State: "closed", MergedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	assert.Equal(t, 2, blamedPullRequest(pulls).Number)
+
+	pulls = []github.PullRequest{
+		{Number: 3, State: "open"},
+	}
+	assert.Equal(t, 3, blamedPullRequest(pulls).Number)
+}
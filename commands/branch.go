@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdBranch = &Command{
+		Run:          branch,
+		GitExtension: true,
+		Usage: `
+branch report [--older-than <AGE>] [--delete-stale [--yes]]
+`,
+		Long: `Report on the branches of the current repository on GitHub.
+
+## Commands:
+
+	* _report_:
+		List the branches of the repository on GitHub, oldest first, along with
+		the age of their last commit, how many commits they are ahead of and
+		behind the default branch, their open pull requests, and the author of
+		their last commit. Branches older than <AGE> are marked as stale.
+
+## Options:
+	--older-than <AGE>
+		Consider branches stale if their last commit is older than <AGE>, given
+		in days such as "90d" or weeks such as "12w" (default: "90d").
+
+	--delete-stale
+		After the report, delete the stale branches that have no open pull
+		requests from the repository on GitHub. The branches to delete are
+		listed and have to be confirmed first.
+
+	-y, --yes
+		Delete stale branches without asking for confirmation.
+
+## Examples:
+		$ hub branch report --older-than 30d
+		BRANCH       AGE   AHEAD  BEHIND  PULL REQUESTS  AUTHOR
+		old-feature  120d  3      210                    mislav   stale
+		fix-login    12d   1      4       #1234          octocat
+
+## Notes:
+
+Other invocations of ''hub branch'' run git-branch(1) as usual. To create a
+branch named "report", use ''git branch -- report''.
+
+## See also:
+
+hub-sync(1), hub(1), git-branch(1)
+`,
+	}
+
+	cmdReportBranch = &Command{
+		Key:   "report",
+		Run:   reportBranch,
+		Usage: "branch report [--older-than <AGE>] [--delete-stale [--yes]]",
+		KnownFlags: `
+		--older-than AGE
+		--delete-stale
+		-y, --yes
+`,
+	}
+)
+
+func init() {
+	CmdRunner.Use(cmdBranch)
+}
+
+func branch(command *Command, args *Args) {
+	// `branch` can't have regular subcommands, since any other argument is
+	// forwarded to git-branch(1)
+	if !args.IsParamsEmpty() && args.FirstParam() == "report" {
+		args.RemoveParam(0)
+		utils.Check(cmdReportBranch.parseArguments(args))
+		cmdReportBranch.Run(cmdReportBranch, args)
+	}
+}
+
+// branchReportEntry is a branch as returned by the query in reportBranch.
+type branchReportEntry struct {
+	Name   string
+	Target struct {
+		CommittedDate time.Time
+		Author        struct {
+			Name string
+			User *struct {
+				Login string
+			}
+		}
+	}
+	AssociatedPullRequests struct {
+		Nodes []struct {
+			Number int
+		}
+	}
+	Compare struct {
+		AheadBy  int
+		BehindBy int
+	}
+}
+
+// author is the login of the author of the last commit, or their name.
+func (b *branchReportEntry) author() string {
+	if b.Target.Author.User != nil {
+		return b.Target.Author.User.Login
+	}
+	return b.Target.Author.Name
+}
+
+func reportBranch(command *Command, args *Args) {
+	maxAge := 90 * 24 * time.Hour
+	if args.Flag.HasReceived("--older-than") {
+		var err error
+		maxAge, err = parseAge(args.Flag.Value("--older-than"))
+		utils.Check(err)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would report on the branches of %s\n", project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	repo, err := gh.Repository(project)
+	utils.Check(err)
+	branches, err := fetchBranchReport(gh, project, repo.DefaultBranch)
+	utils.Check(err)
+
+	cutoff := time.Now().Add(-maxAge)
+	ui.Print(formatBranchReport(branches, time.Now(), cutoff))
+
+	if !args.Flag.Bool("--delete-stale") {
+		return
+	}
+
+	stale := []string{}
+	for _, b := range branches {
+		if b.Target.CommittedDate.Before(cutoff) && len(b.AssociatedPullRequests.Nodes) == 0 {
+			stale = append(stale, b.Name)
+		}
+	}
+	if len(stale) == 0 {
+		ui.Println("\nNo stale branches without pull requests to delete")
+		return
+	}
+
+	ui.Printf("\nStale branches without pull requests:\n  %s\n", strings.Join(stale, "\n  "))
+	if !args.Flag.Bool("--yes") {
+		ui.Printf("Delete %d stale branches from %s (y/N)? ", len(stale), project)
+		answer := ""
+		scanner := bufio.NewScanner(os.Stdin)
+		if scanner.Scan() {
+			answer = strings.ToLower(strings.TrimSpace(scanner.Text()))
+		}
+		utils.Check(scanner.Err())
+		if answer != "y" && answer != "yes" {
+			utils.Check(fmt.Errorf("Aborted: no branches were deleted"))
+		}
+	}
+
+	for _, name := range stale {
+		utils.Check(gh.DeleteBranch(project, name))
+		ui.Printf("Deleted branch %s\n", name)
+	}
+}
+
+// fetchBranchReport lists the branches of project other than defaultBranch,
+// oldest first, compared to defaultBranch.
+func fetchBranchReport(gh *github.Client, project *github.Project, defaultBranch string) ([]branchReportEntry, error) {
+	branches := []branchReportEntry{}
+	variables := map[string]interface{}{
+		"owner":         project.Owner,
+		"repo":          project.Name,
+		"defaultBranch": defaultBranch,
+	}
+
+	for {
+		response := struct {
+			Repository struct {
+				Refs struct {
+					Nodes    []branchReportEntry
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}{}
+		err := gh.GraphQL(`
+		query($owner: String!, $repo: String!, $defaultBranch: String!, $after: String) {
+			repository(owner: $owner, name: $repo) {
+				refs(refPrefix: "refs/heads/", first: 100, after: $after) {
+					nodes {
+						name
+						target {
+							... on Commit {
+								committedDate
+								author {
+									name
+									user {
+										login
+									}
+								}
+							}
+						}
+						associatedPullRequests(states: OPEN, first: 10) {
+							nodes {
+								number
+							}
+						}
+						compare(headRef: $defaultBranch) {
+							aheadBy
+							behindBy
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}`, variables, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range response.Repository.Refs.Nodes {
+			if b.Name != defaultBranch {
+				branches = append(branches, b)
+			}
+		}
+		if !response.Repository.Refs.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = response.Repository.Refs.PageInfo.EndCursor
+	}
+
+	sort.SliceStable(branches, func(i, j int) bool {
+		return branches[i].Target.CommittedDate.Before(branches[j].Target.CommittedDate)
+	})
+	return branches, nil
+}
+
+// formatBranchReport renders branches as a table, marking the ones whose last
+// commit is older than cutoff as stale.
+func formatBranchReport(branches []branchReportEntry, now, cutoff time.Time) string {
+	if len(branches) == 0 {
+		return "No branches other than the default branch\n"
+	}
+
+	rows := [][]string{{"BRANCH", "AGE", "AHEAD", "BEHIND", "PULL REQUESTS", "AUTHOR"}}
+	for _, b := range branches {
+		pulls := []string{}
+		for _, pr := range b.AssociatedPullRequests.Nodes {
+			pulls = append(pulls, fmt.Sprintf("#%d", pr.Number))
+		}
+		rows = append(rows, []string{
+			b.Name,
+			digestAge(now, b.Target.CommittedDate),
+			// the branch is the base of the comparison with the default branch
+			strconv.Itoa(b.Compare.BehindBy),
+			strconv.Itoa(b.Compare.AheadBy),
+			strings.Join(pulls, ","),
+			b.author(),
+		})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	var out strings.Builder
+	for i, row := range rows {
+		cells := []string{}
+		for j, cell := range row {
+			cells = append(cells, fmt.Sprintf("%-*s", widths[j], cell))
+		}
+		line := strings.Join(cells, "  ")
+		if i > 0 && branches[i-1].Target.CommittedDate.Before(cutoff) {
+			line += "  stale"
+		}
+		out.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return out.String()
+}
+
+// parseAge parses a duration given in days or weeks, such as "90d" or "12w".
+func parseAge(value string) (time.Duration, error) {
+	unit := 24 * time.Hour
+	number := value
+	if strings.HasSuffix(value, "d") {
+		number = strings.TrimSuffix(value, "d")
+	} else if strings.HasSuffix(value, "w") {
+		number = strings.TrimSuffix(value, "w")
+		unit = 7 * 24 * time.Hour
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("error: invalid age '%s'; expected a number of days or weeks such as 90d or 12w", value)
+	}
+	return time.Duration(n) * unit, nil
+}
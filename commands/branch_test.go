@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestParseAge(t *testing.T) {
+	age, err := parseAge("90d")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 90*24*time.Hour, age)
+
+	age, err = parseAge("12w")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 84*24*time.Hour, age)
+
+	age, err = parseAge("30")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 30*24*time.Hour, age)
+
+	_, err = parseAge("3 months")
+	assert.Equal(t, "error: invalid age '3 months'; expected a number of days or weeks such as 90d or 12w", err.Error())
+}
+
+func TestFormatBranchReport(t *testing.T) {
+	now := time.Date(2020, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	old := branchReportEntry{Name: "old-feature"}
+	old.Target.CommittedDate = now.AddDate(0, 0, -120)
+	old.Target.Author.Name = "Mislav"
+	old.Target.Author.User = &struct{ Login string }{Login: "mislav"}
+	old.Compare.AheadBy = 210
+	old.Compare.BehindBy = 3
+
+	fresh := branchReportEntry{Name: "fix-login"}
+	fresh.Target.CommittedDate = now.AddDate(0, 0, -12)
+	fresh.Target.Author.Name = "The Octocat"
+	fresh.Compare.AheadBy = 4
+	fresh.Compare.BehindBy = 1
+	fresh.AssociatedPullRequests.Nodes = []struct{ Number int }{{Number: 1234}}
+
+	cutoff := now.AddDate(0, 0, -90)
+	assert.Equal(t, `BRANCH       AGE   AHEAD  BEHIND  PULL REQUESTS  AUTHOR
+old-feature  120d  3      210                    mislav       stale
+fix-login    12d   1      4       #1234          The Octocat
+`, formatBranchReport([]branchReportEntry{old, fresh}, now, cutoff))
+
+	assert.Equal(t, "No branches other than the default branch\n", formatBranchReport(nil, now, cutoff))
+}
@@ -3,15 +3,21 @@ package commands
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
 	"github.com/github/hub/v2/utils"
 )
 
 var cmdBrowse = &Command{
-	Run:   browse,
-	Usage: "browse [-uc] [[<USER>/]<REPOSITORY>|--] [<SUBPAGE>]",
+	Run: browse,
+	Usage: `
+browse [-uc] [[<USER>/]<REPOSITORY>|--] [<SUBPAGE>]
+browse [-uc] --blame <FILE>[:<LINE>]
+`,
 	Long: `Open a GitHub repository in a web browser.
 
 ## Options:
@@ -21,6 +27,10 @@ var cmdBrowse = &Command{
 	-c, --copy
 		Put the URL in clipboard instead of opening it.
 
+	--blame <FILE>
+		Open the blame view of <FILE> on the current branch. Append ":<LINE>" to
+		<FILE> to scroll to a specific line.
+
 	[<USER>/]<REPOSITORY>
 		Defaults to repository in the current working directory.
 
@@ -40,6 +50,9 @@ var cmdBrowse = &Command{
 		$ hub browse gh wiki
 		> open https://github.com/USER/gh/wiki
 
+		$ hub browse --blame commands/browse.go:42
+		> open https://github.com/REPO/blame/BRANCH/commands/browse.go#L42
+
 ## See also:
 
 hub-compare(1), hub(1)
@@ -73,6 +86,15 @@ func browse(command *Command, args *Args) {
 		dest = ""
 	}
 
+	blameFile, blameLine := "", 0
+	if args.Flag.HasReceived("--blame") {
+		if dest != "" || subpage != "" {
+			utils.Check(command.UsageError(""))
+		}
+		blameFile, blameLine, err = parseBlameTarget(args.Flag.Value("--blame"))
+		utils.Check(err)
+	}
+
 	localRepo, _ := github.LocalRepo()
 	if dest != "" {
 		project = github.NewProject("", dest, "")
@@ -81,6 +103,13 @@ func browse(command *Command, args *Args) {
 		project, err = localRepo.MainProject()
 		branch = localRepo.MasterBranch()
 		utils.Check(err)
+	} else if _, err := localRepo.CurrentBranch(); err != nil && (subpage == "commits" || subpage == "tree" || blameFile != "") {
+		// in detached HEAD, show commits or files as of the current commit
+		project, err = localRepo.MainProject()
+		utils.Check(err)
+		sha, err := localRepo.CurrentCommit()
+		utils.Check(err)
+		branch = &github.Branch{Repo: localRepo, Name: sha}
 	} else {
 		currentBranch, err := localRepo.CurrentBranch()
 		if err != nil {
@@ -108,7 +137,13 @@ func browse(command *Command, args *Args) {
 		utils.Check(command.UsageError(""))
 	}
 
-	if subpage == "commits" {
+	if blameFile != "" {
+		segments := strings.Split(blameFile, "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		path = fmt.Sprintf("blame/%s/%s", branchInURL(branch), strings.Join(segments, "/"))
+	} else if subpage == "commits" {
 		path = fmt.Sprintf("commits/%s", branchInURL(branch))
 	} else if subpage == "tree" || subpage == "" {
 		if !branch.IsMaster() {
@@ -119,6 +154,9 @@ func browse(command *Command, args *Args) {
 	}
 
 	pageURL := project.WebURL("", "", path)
+	if blameLine > 0 {
+		pageURL += fmt.Sprintf("#L%d", blameLine)
+	}
 
 	args.NoForward()
 	flagBrowseURLPrint := args.Flag.Bool("--url")
@@ -134,3 +172,36 @@ func branchInURL(branch *github.Branch) string {
 	}
 	return strings.Join(newPath, "/")
 }
+
+// parseBlameTarget splits a "FILE[:LINE]" argument and returns the path of the
+// file relative to the root of the working tree.
+func parseBlameTarget(target string) (file string, line int, err error) {
+	file = target
+	if idx := strings.LastIndex(target, ":"); idx > 0 {
+		if n, e := strconv.Atoi(target[idx+1:]); e == nil {
+			file, line = target[:idx], n
+		}
+	}
+
+	workdir, err := git.WorkdirName()
+	if err != nil {
+		return
+	}
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return
+	}
+	if resolved, e := filepath.EvalSymlinks(workdir); e == nil {
+		workdir = resolved
+	}
+	if resolved, e := filepath.EvalSymlinks(absFile); e == nil {
+		absFile = resolved
+	}
+	relFile, err := filepath.Rel(workdir, absFile)
+	if err != nil || strings.HasPrefix(relFile, "..") {
+		return "", 0, fmt.Errorf("Aborted: %s is outside of the repository", file)
+	}
+
+	file = filepath.ToSlash(relFile)
+	return
+}
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdCache = &Command{
+		Run: printHelp,
+		Usage: `
+cache list [-k <PREFIX>] [-b <BRANCH>] [-f <FORMAT>]
+cache delete [-k <PREFIX>] [-b <BRANCH>]
+cache delete <ID>...
+`,
+		Long: `Manage GitHub Actions caches of the current repository.
+
+## Commands:
+
+	* _list_:
+		List caches, most recently used first.
+
+	* _delete_:
+		Delete the caches with the given <ID>s, or all caches that match
+		''--key'' and ''--branch''. At least one of them is required.
+
+## Options:
+	-k, --key <PREFIX>
+		Only include caches whose key starts with <PREFIX>.
+
+	-b, --branch <BRANCH>
+		Only include caches created for <BRANCH>. A full ref such as
+		"refs/pull/12/merge" may be given instead.
+
+	-f, --format <FORMAT>
+		Pretty print caches using format <FORMAT> (default:
+		"%>(12)%I  %<(40)%K  %>(10)%s  %<(20)%B  %ur%n"). See the "PRETTY FORMATS"
+		section of git-log(1) for some additional details on how placeholders are
+		used in format. The available placeholders are:
+
+		%I: cache ID
+
+		%K: cache key
+
+		%V: cache version
+
+		%s: size
+
+		%B: branch, or the full ref if it's not a branch
+
+		%R: full ref
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%uD: last used date-only (no time of day)
+
+		%ur: last used date, relative
+
+		%uI: last used date, ISO 8601 format
+
+## Examples:
+		$ hub cache list --branch main
+		$ hub cache delete --key Linux-node-modules- --branch main
+
+## See also:
+
+hub-run(1), hub(1)
+`,
+	}
+
+	cmdListCaches = &Command{
+		Key: "list",
+		Run: listCaches,
+		KnownFlags: `
+		-k, --key PREFIX
+		-b, --branch BRANCH
+		-f, --format FMT
+`,
+	}
+
+	cmdDeleteCaches = &Command{
+		Key: "delete",
+		Run: deleteCaches,
+		KnownFlags: `
+		-k, --key PREFIX
+		-b, --branch BRANCH
+`,
+	}
+)
+
+func init() {
+	cmdCache.Use(cmdListCaches)
+	cmdCache.Use(cmdDeleteCaches)
+	CmdRunner.Use(cmdCache)
+}
+
+// cacheFilters maps the --key and --branch flags onto the query parameters of
+// the Actions cache API.
+func cacheFilters(args *Args) map[string]interface{} {
+	filters := map[string]interface{}{}
+	if key := args.Flag.Value("--key"); key != "" {
+		filters["key"] = key
+	}
+	if branch := args.Flag.Value("--branch"); branch != "" {
+		if !strings.HasPrefix(branch, "refs/") {
+			branch = "refs/heads/" + branch
+		}
+		filters["ref"] = branch
+	}
+	return filters
+}
+
+func listCaches(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of caches for %s\n", project)
+		return
+	}
+
+	caches, err := gh.FetchActionsCaches(project, cacheFilters(args))
+	utils.Check(err)
+
+	flagCacheFormat := "%>(12)%I  %<(40)%K  %>(10)%s  %<(20)%B  %ur%n"
+	if args.Flag.HasReceived("--format") {
+		flagCacheFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(false, "")
+	for _, c := range caches {
+		ui.Print(formatActionsCache(c, flagCacheFormat, colorize))
+	}
+}
+
+func formatActionsCache(c github.ActionsCache, format string, colorize bool) string {
+	placeholders := map[string]string{
+		"I": strconv.Itoa(c.ID),
+		"K": c.Key,
+		"V": c.Version,
+		"s": formatByteSize(c.SizeInBytes),
+		"B": strings.TrimPrefix(c.Ref, "refs/heads/"),
+		"R": c.Ref,
+	}
+	addTimePlaceholders(placeholders, c.CreatedAt, c.LastAccessedAt)
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func deleteCaches(cmd *Command, args *Args) {
+	filters := cacheFilters(args)
+	if args.IsParamsEmpty() == (len(filters) == 0) {
+		utils.Check(cmd.UsageError("specify either cache IDs or --key and --branch"))
+	}
+
+	cacheIDs := []int{}
+	for _, param := range args.Params {
+		id, err := strconv.Atoi(param)
+		if err != nil {
+			utils.Check(fmt.Errorf("invalid cache ID: %s", param))
+		}
+		cacheIDs = append(cacheIDs, id)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		if len(filters) > 0 {
+			ui.Printf("Would delete matching caches for %s\n", project)
+		}
+		for _, id := range cacheIDs {
+			ui.Printf("Would delete cache %d for %s\n", id, project)
+		}
+		return
+	}
+
+	if len(filters) > 0 {
+		caches, err := gh.FetchActionsCaches(project, filters)
+		utils.Check(err)
+		if len(caches) == 0 {
+			ui.Errorln("No matching caches found.")
+		}
+		for _, c := range caches {
+			utils.Check(gh.DeleteActionsCache(project, c.ID))
+			ui.Printf("Deleted cache %d (%s).\n", c.ID, c.Key)
+		}
+		return
+	}
+
+	for _, id := range cacheIDs {
+		utils.Check(gh.DeleteActionsCache(project, id))
+		ui.Printf("Deleted cache %d.\n", id)
+	}
+}
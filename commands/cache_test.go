@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestCacheFilters(t *testing.T) {
+	args := NewArgs([]string{"cache", "list", "-k", "Linux-", "-b", "main"})
+	cmdListCaches.parseArguments(args)
+	assert.Equal(t, map[string]interface{}{"key": "Linux-", "ref": "refs/heads/main"}, cacheFilters(args))
+
+	args = NewArgs([]string{"cache", "list", "--branch", "refs/pull/12/merge"})
+	cmdListCaches.parseArguments(args)
+	assert.Equal(t, map[string]interface{}{"ref": "refs/pull/12/merge"}, cacheFilters(args))
+}
+
+func TestFormatActionsCache(t *testing.T) {
+	c := github.ActionsCache{
+		ID:             505,
+		Ref:            "refs/heads/main",
+		Key:            "Linux-node-abc123",
+		SizeInBytes:    2 * 1024 * 1024,
+		LastAccessedAt: time.Date(2021, 3, 15, 10, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, "505 Linux-node-abc123 2.0 MB main 15 Mar 2021\n", formatActionsCache(c, "%I %K %s %B %uD%n", false))
+
+	c.Ref = "refs/pull/12/merge"
+	assert.Equal(t, "refs/pull/12/merge", formatActionsCache(c, "%B", false))
+}
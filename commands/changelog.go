@@ -0,0 +1,533 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdChangelog = &Command{
+	Run:   changelog,
+	Usage: "changelog [--format <FORMAT>] [--conventional] <FROM>[..<TO>]",
+	Long: `Generate a changelog from the pull requests merged between two refs.
+
+## Options:
+	--format <FORMAT>
+		Output the changelog as "md" (default) or "json".
+
+	--conventional
+		Group entries by the Conventional Commits type prefixed to the title of
+		each pull request or commit, such as "feat:" or "fix(api):", instead of
+		by labels. See "Conventional Commits" below.
+
+	<FROM>[..<TO>]
+		The range of commits to include. <TO> defaults to "HEAD", in which case
+		the changelog is titled "Unreleased".
+
+## Sections:
+
+Each merged pull request is listed in a section of a Keep a Changelog document
+according to its labels:
+
+	* Added: "feature", "enhancement", "added"
+	* Changed: "changed", and pull requests without a matching label
+	* Deprecated: "deprecated", "deprecation"
+	* Removed: "removed", "removal"
+	* Fixed: "bug", "bugfix", "fix", "fixed"
+	* Security: "security"
+
+Labels are matched case-insensitively, also when prefixed with a category such
+as "type: bug". A pull request with labels for several sections is listed
+under the last of them in the order above. Pull requests labeled
+"skip-changelog" or "no-changelog" are left out.
+
+Commits that are not part of any merged pull request are listed under
+"Changed" by their subject line.
+
+## Conventional Commits:
+
+With ''--conventional'', titles are parsed as "<TYPE>[(<SCOPE>)][!]: <DESCRIPTION>"
+and listed by their description under the heading for <TYPE>:
+
+	* Features: "feat"
+	* Bug Fixes: "fix"
+	* Performance: "perf"
+	* Reverts: "revert"
+	* Documentation: "docs"
+	* Refactoring: "refactor"
+	* Styles: "style"
+	* Tests: "test"
+	* Build System: "build"
+	* Continuous Integration: "ci"
+	* Chores: "chore"
+	* Other Changes: titles without a known type
+
+Breaking changes, marked with "!" after the type or with a "breaking" or
+"breaking-change" label, are called out in a "Breaking Changes" section at
+the top instead. Labels for skipping pull requests still apply.
+
+## Examples:
+		$ hub changelog v2.13.0..v2.14.0
+		## [v2.14.0] - 2020-01-29
+
+		### Added
+
+		- Add ''--color'' flag to ''pr show'' (#2323)
+
+		### Fixed
+
+		- Fix ''pr checkout'' for forks with renamed default branch (#2331)
+
+		$ hub changelog v2.14.0 --format json > changes.json
+
+		$ hub changelog --conventional v3.0.0..v3.1.0
+		## [v3.1.0] - 2021-03-02
+
+		### Breaking Changes
+
+		- **api:** drop support for Enterprise 2.x (#2410)
+
+		### Features
+
+		- add ''--conventional'' to changelog (#2405)
+
+## See also:
+
+hub-release(1), hub(1)
+`,
+	KnownFlags: `
+		--format FORMAT
+		--conventional
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdChangelog)
+}
+
+// changelogSectionNames lists the sections of a Keep a Changelog document in
+// the order in which they appear.
+var changelogSectionNames = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+var changelogLabelSections = map[string]string{
+	"feature":     "Added",
+	"enhancement": "Added",
+	"added":       "Added",
+	"changed":     "Changed",
+	"deprecated":  "Deprecated",
+	"deprecation": "Deprecated",
+	"removed":     "Removed",
+	"removal":     "Removed",
+	"bug":         "Fixed",
+	"bugfix":      "Fixed",
+	"fix":         "Fixed",
+	"fixed":       "Fixed",
+	"security":    "Security",
+}
+
+var changelogSkipLabels = []string{"skip-changelog", "no-changelog"}
+
+// conventionalSectionNames lists the sections of a changelog grouped by
+// Conventional Commits types in the order in which they appear.
+var conventionalSectionNames = []string{
+	"Breaking Changes",
+	"Features",
+	"Bug Fixes",
+	"Performance",
+	"Reverts",
+	"Documentation",
+	"Refactoring",
+	"Styles",
+	"Tests",
+	"Build System",
+	"Continuous Integration",
+	"Chores",
+	"Other Changes",
+}
+
+var conventionalTypeSections = map[string]string{
+	"feat":     "Features",
+	"fix":      "Bug Fixes",
+	"perf":     "Performance",
+	"revert":   "Reverts",
+	"docs":     "Documentation",
+	"refactor": "Refactoring",
+	"style":    "Styles",
+	"test":     "Tests",
+	"build":    "Build System",
+	"ci":       "Continuous Integration",
+	"chore":    "Chores",
+}
+
+var conventionalBreakingLabels = []string{"breaking", "breaking-change", "breaking change"}
+
+var conventionalTitleRegexp = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s+(.+)$`)
+
+// the number of commits to look up pull requests for in a single GraphQL query
+const changelogBatchSize = 50
+
+type changelogPullRequest struct {
+	Number int
+	Title  string
+	URL    string
+	Merged bool
+	Author struct {
+		Login string
+	}
+	Labels struct {
+		Nodes []struct {
+			Name string
+		}
+	}
+}
+
+type changelogCommit struct {
+	Oid             string
+	MessageHeadline string
+	Parents         struct {
+		TotalCount int
+	}
+	AssociatedPullRequests struct {
+		Nodes []changelogPullRequest
+	}
+}
+
+type changelogEntry struct {
+	Title    string   `json:"title"`
+	Number   int      `json:"number,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Author   string   `json:"author,omitempty"`
+	Labels   []string `json:"labels,omitempty"`
+	Commit   string   `json:"commit,omitempty"`
+	Type     string   `json:"type,omitempty"`
+	Scope    string   `json:"scope,omitempty"`
+	Breaking bool     `json:"breaking,omitempty"`
+}
+
+type changelogSection struct {
+	Name    string           `json:"name"`
+	Entries []changelogEntry `json:"entries"`
+}
+
+type changelogDocument struct {
+	Version  string             `json:"version"`
+	Date     string             `json:"date,omitempty"`
+	Sections []changelogSection `json:"sections"`
+}
+
+func changelog(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	from, to, err := parseChangelogRange(args.GetParam(0))
+	utils.Check(err)
+
+	format := "md"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+	if format != "md" && format != "json" {
+		utils.Check(fmt.Errorf("error: invalid format '%s' (expected md or json)", format))
+	}
+
+	doc := changelogDocument{Version: "Unreleased"}
+	if to != "HEAD" {
+		doc.Version = to
+		commitTime, err := git.CommitTime(to)
+		utils.Check(err)
+		doc.Date = commitTime.Format("2006-01-02")
+	}
+
+	shas, err := git.RevList(from, to)
+	utils.Check(err)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would generate changelog for %d commits in %s..%s\n", len(shas), from, to)
+		return
+	}
+
+	// process commits in the order in which they were made
+	for i, j := 0, len(shas)-1; i < j; i, j = i+1, j-1 {
+		shas[i], shas[j] = shas[j], shas[i]
+	}
+
+	commits, err := fetchChangelogCommits(gh, project, shas)
+	utils.Check(err)
+
+	doc.Sections = groupChangelogEntries(commits, args.Flag.Bool("--conventional"))
+
+	if format == "json" {
+		out, err := json.MarshalIndent(doc, "", "  ")
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+
+	ui.Print(formatChangelog(doc))
+}
+
+// parseChangelogRange splits "FROM..TO" into its parts, defaulting TO to HEAD.
+func parseChangelogRange(spec string) (from, to string, err error) {
+	parts := strings.SplitN(spec, "..", 2)
+	from = parts[0]
+	to = "HEAD"
+	if len(parts) == 2 && parts[1] != "" {
+		to = parts[1]
+	}
+	if from == "" || strings.HasPrefix(to, ".") {
+		err = fmt.Errorf("error: invalid range '%s' (expected <FROM>..<TO>)", spec)
+	}
+	return
+}
+
+// fetchChangelogCommits looks up commits along with their pull requests,
+// keeping the order of shas.
+func fetchChangelogCommits(gh *github.Client, project *github.Project, shas []string) ([]changelogCommit, error) {
+	commits := []changelogCommit{}
+	for start := 0; start < len(shas); start += changelogBatchSize {
+		end := start + changelogBatchSize
+		if end > len(shas) {
+			end = len(shas)
+		}
+		batch, err := fetchChangelogCommitBatch(gh, project, shas[start:end])
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, batch...)
+	}
+	return commits, nil
+}
+
+func fetchChangelogCommitBatch(gh *github.Client, project *github.Project, shas []string) ([]changelogCommit, error) {
+	fields := []string{}
+	for i, sha := range shas {
+		fields = append(fields, fmt.Sprintf("c%d: object(oid: %q) { ...changelogCommit }", i, sha))
+	}
+
+	response := struct {
+		Repository map[string]*changelogCommit
+	}{}
+	err := gh.GraphQL(fmt.Sprintf(`
+	query($owner: String!, $repo: String!) {
+		repository(owner: $owner, name: $repo) {
+			%s
+		}
+	}
+	fragment changelogCommit on Commit {
+		oid
+		messageHeadline
+		parents {
+			totalCount
+		}
+		associatedPullRequests(first: 5) {
+			nodes {
+				number
+				title
+				url
+				merged
+				author {
+					login
+				}
+				labels(first: 50) {
+					nodes {
+						name
+					}
+				}
+			}
+		}
+	}`, strings.Join(fields, "\n")), map[string]interface{}{
+		"owner": project.Owner,
+		"repo":  project.Name,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []changelogCommit{}
+	for i, sha := range shas {
+		commit := response.Repository[fmt.Sprintf("c%d", i)]
+		if commit == nil {
+			return nil, fmt.Errorf("error: commit %s was not found in %s; has it been pushed?", sha, project)
+		}
+		commits = append(commits, *commit)
+	}
+	return commits, nil
+}
+
+// groupChangelogEntries lists each merged pull request once, along with
+// commits that were pushed without a pull request, in the section matching
+// its labels, or its Conventional Commits type if conventional is set.
+// Sections without entries are omitted.
+func groupChangelogEntries(commits []changelogCommit, conventional bool) []changelogSection {
+	entries := map[string][]changelogEntry{}
+	seen := map[int]bool{}
+	sectionNames := changelogSectionNames
+	if conventional {
+		sectionNames = conventionalSectionNames
+	}
+
+	for _, commit := range commits {
+		var pr *changelogPullRequest
+		for i := range commit.AssociatedPullRequests.Nodes {
+			if commit.AssociatedPullRequests.Nodes[i].Merged {
+				pr = &commit.AssociatedPullRequests.Nodes[i]
+				break
+			}
+		}
+
+		if pr == nil {
+			if commit.Parents.TotalCount > 1 {
+				continue
+			}
+			entry := changelogEntry{
+				Title:  commit.MessageHeadline,
+				Commit: commit.Oid,
+			}
+			section := "Changed"
+			if conventional {
+				section = conventionalSection(&entry)
+			}
+			entries[section] = append(entries[section], entry)
+			continue
+		}
+
+		if seen[pr.Number] {
+			continue
+		}
+		seen[pr.Number] = true
+
+		labels := []string{}
+		for _, label := range pr.Labels.Nodes {
+			labels = append(labels, label.Name)
+		}
+		section, skip := changelogSectionForLabels(labels)
+		if skip {
+			continue
+		}
+		entry := changelogEntry{
+			Title:  pr.Title,
+			Number: pr.Number,
+			URL:    pr.URL,
+			Author: pr.Author.Login,
+			Labels: labels,
+		}
+		if conventional {
+			section = conventionalSection(&entry)
+		}
+		entries[section] = append(entries[section], entry)
+	}
+
+	sections := []changelogSection{}
+	for _, name := range sectionNames {
+		if len(entries[name]) > 0 {
+			sections = append(sections, changelogSection{Name: name, Entries: entries[name]})
+		}
+	}
+	return sections
+}
+
+// changelogSectionForLabels picks the changelog section for a pull request
+// and reports whether the pull request should be left out of the changelog.
+func changelogSectionForLabels(labels []string) (section string, skip bool) {
+	rank := -1
+	for _, label := range labels {
+		name := strings.ToLower(strings.TrimSpace(label))
+		for _, skipLabel := range changelogSkipLabels {
+			if name == skipLabel {
+				return "", true
+			}
+		}
+		if i := strings.LastIndexAny(name, ":/"); i >= 0 {
+			name = strings.TrimSpace(name[i+1:])
+		}
+
+		s, ok := changelogLabelSections[name]
+		if !ok {
+			continue
+		}
+		for i, n := range changelogSectionNames {
+			if n == s && i > rank {
+				section = s
+				rank = i
+			}
+		}
+	}
+	if section == "" {
+		section = "Changed"
+	}
+	return
+}
+
+// conventionalSection parses the Conventional Commits prefix off the title of
+// entry and returns the section to list it in.
+func conventionalSection(entry *changelogEntry) string {
+	for _, label := range entry.Labels {
+		for _, breakingLabel := range conventionalBreakingLabels {
+			if strings.EqualFold(strings.TrimSpace(label), breakingLabel) {
+				entry.Breaking = true
+			}
+		}
+	}
+
+	match := conventionalTitleRegexp.FindStringSubmatch(entry.Title)
+	if match == nil {
+		if entry.Breaking {
+			return "Breaking Changes"
+		}
+		return "Other Changes"
+	}
+	entry.Type = strings.ToLower(match[1])
+	entry.Scope = match[2]
+	entry.Breaking = entry.Breaking || match[3] == "!"
+	entry.Title = match[4]
+
+	if entry.Breaking {
+		return "Breaking Changes"
+	}
+	if section, ok := conventionalTypeSections[entry.Type]; ok {
+		return section
+	}
+	return "Other Changes"
+}
+
+func formatChangelog(doc changelogDocument) string {
+	var out strings.Builder
+	if doc.Date == "" {
+		fmt.Fprintf(&out, "## [%s]\n", doc.Version)
+	} else {
+		fmt.Fprintf(&out, "## [%s] - %s\n", doc.Version, doc.Date)
+	}
+
+	for _, section := range doc.Sections {
+		fmt.Fprintf(&out, "\n### %s\n\n", section.Name)
+		for _, entry := range section.Entries {
+			title := entry.Title
+			if entry.Scope != "" {
+				title = fmt.Sprintf("**%s:** %s", entry.Scope, title)
+			}
+			if entry.Number > 0 {
+				fmt.Fprintf(&out, "- %s (#%d)\n", title, entry.Number)
+			} else {
+				fmt.Fprintf(&out, "- %s (%s)\n", title, entry.Commit[:7])
+			}
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestParseChangelogRange(t *testing.T) {
+	from, to, err := parseChangelogRange("v1.0..v1.1")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "v1.0", from)
+	assert.Equal(t, "v1.1", to)
+
+	from, to, err = parseChangelogRange("v1.0..")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "v1.0", from)
+	assert.Equal(t, "HEAD", to)
+
+	_, to, err = parseChangelogRange("v1.0")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "HEAD", to)
+
+	_, _, err = parseChangelogRange("v1.0...v1.1")
+	assert.Equal(t, "error: invalid range 'v1.0...v1.1' (expected <FROM>..<TO>)", err.Error())
+
+	_, _, err = parseChangelogRange("..v1.1")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestChangelogSectionForLabels(t *testing.T) {
+	tests := []struct {
+		labels  []string
+		section string
+		skip    bool
+	}{
+		{[]string{}, "Changed", false},
+		{[]string{"documentation"}, "Changed", false},
+		{[]string{"enhancement"}, "Added", false},
+		{[]string{"Type: Bug"}, "Fixed", false},
+		{[]string{"kind/feature"}, "Added", false},
+		{[]string{"bug", "security"}, "Security", false},
+		{[]string{"security", "enhancement"}, "Security", false},
+		{[]string{"bug", "skip-changelog"}, "", true},
+	}
+	for _, test := range tests {
+		section, skip := changelogSectionForLabels(test.labels)
+		assert.Equal(t, test.section, section)
+		assert.Equal(t, test.skip, skip)
+	}
+}
+
+func TestFormatChangelog(t *testing.T) {
+	doc := changelogDocument{
+		Version: "v1.1",
+		Date:    "2020-01-29",
+		Sections: []changelogSection{
+			{Name: "Added", Entries: []changelogEntry{{Title: "Add thing", Number: 12}}},
+			{Name: "Changed", Entries: []changelogEntry{{Title: "Bump version", Commit: "9b5a719a3d76ac9dc2fa635d9b1f34fd73994c06"}}},
+		},
+	}
+
+	assert.Equal(t, `## [v1.1] - 2020-01-29
+
+### Added
+
+- Add thing (#12)
+
+### Changed
+
+- Bump version (9b5a719)
+`, formatChangelog(doc))
+
+	assert.Equal(t, "## [Unreleased]\n", formatChangelog(changelogDocument{Version: "Unreleased"}))
+}
+
+func TestConventionalSection(t *testing.T) {
+	tests := []struct {
+		title   string
+		labels  []string
+		section string
+		entry   changelogEntry
+	}{
+		{"feat: add thing", nil, "Features", changelogEntry{Title: "add thing", Type: "feat"}},
+		{"Fix(api): handle nil", nil, "Bug Fixes", changelogEntry{Title: "handle nil", Type: "fix", Scope: "api"}},
+		{"chore!: drop Go 1.11", nil, "Breaking Changes", changelogEntry{Title: "drop Go 1.11", Type: "chore", Breaking: true}},
+		{"feat: new flag", []string{"Breaking"}, "Breaking Changes", changelogEntry{Title: "new flag", Type: "feat", Breaking: true}},
+		{"wip: experiment", nil, "Other Changes", changelogEntry{Title: "experiment", Type: "wip"}},
+		{"Bump version", nil, "Other Changes", changelogEntry{Title: "Bump version"}},
+	}
+	for _, test := range tests {
+		entry := changelogEntry{Title: test.title, Labels: test.labels}
+		assert.Equal(t, test.section, conventionalSection(&entry))
+		test.entry.Labels = test.labels
+		assert.Equal(t, test.entry, entry)
+	}
+}
+
+func TestFormatConventionalChangelog(t *testing.T) {
+	doc := changelogDocument{
+		Version: "v1.1",
+		Sections: []changelogSection{
+			{Name: "Breaking Changes", Entries: []changelogEntry{{Title: "drop flag", Scope: "cli", Number: 12}}},
+		},
+	}
+
+	assert.Equal(t, `## [v1.1]
+
+### Breaking Changes
+
+- **cli:** drop flag (#12)
+`, formatChangelog(doc))
+}
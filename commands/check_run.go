@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdCheckRun = &Command{
+		Run: printHelp,
+		Usage: `
+check-run create -n <NAME> [-s <STATUS>] [-c <CONCLUSION>] [-t <TITLE>] [-F <FILE>] [-u <URL>] [<COMMIT>]
+`,
+		Long: `Publish check runs for commits in the current repository.
+
+## Commands:
+
+	* _create_:
+		Create a check run for <COMMIT> (default: HEAD) and print its URL.
+
+## Options:
+	-n, --name <NAME>
+		The name of the check, e.g. "build" or "lint".
+
+	-s, --status <STATUS>
+		One of "queued" (default), "in_progress", or "completed".
+
+	-c, --conclusion <CONCLUSION>
+		The outcome of a completed check: "success", "failure", "neutral",
+		"cancelled", "skipped", "timed_out", or "action_required". Implies
+		''--status=completed''.
+
+	-t, --title <TITLE>
+		The title of the check run output (default: <NAME>).
+
+	-F, --output-file <FILE>
+		Read the Markdown summary of the check run output from <FILE>. Pass "-" to
+		read from standard input.
+
+	-u, --details-url <URL>
+		The URL of the CI system page with full details of the check.
+
+	--external-id <ID>
+		A reference for the check run on the CI system.
+
+## Examples:
+		$ hub check-run create -n build -c success -F report.md
+		$ hub check-run create -n lint -s in_progress -u "$BUILD_URL" "$GIT_COMMIT"
+
+## Notes:
+
+GitHub only allows GitHub Apps to create check runs. Run hub with
+''GITHUB_TOKEN'' set to an installation access token of the GitHub App that
+owns the checks.
+
+## See also:
+
+hub-ci-status(1), hub(1)
+`,
+	}
+
+	cmdCreateCheckRun = &Command{
+		Key: "create",
+		Run: createCheckRun,
+		KnownFlags: `
+		-n, --name NAME
+		-s, --status STATUS
+		-c, --conclusion CONCLUSION
+		-t, --title TITLE
+		-F, --output-file FILE
+		-u, --details-url URL
+		--external-id ID
+`,
+	}
+)
+
+func init() {
+	cmdCheckRun.Use(cmdCreateCheckRun)
+	CmdRunner.Use(cmdCheckRun)
+}
+
+func createCheckRun(cmd *Command, args *Args) {
+	name := args.Flag.Value("--name")
+	if name == "" {
+		utils.Check(cmd.UsageError("missing --name"))
+	}
+
+	ref := "HEAD"
+	if !args.IsParamsEmpty() {
+		ref = args.GetParam(0)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	sha, err := git.Ref(ref)
+	if err != nil {
+		err = fmt.Errorf("Aborted: no revision could be determined from '%s'", ref)
+	}
+	utils.Check(err)
+
+	params, err := checkRunParams(args, time.Now())
+	utils.Check(err)
+	params["name"] = name
+	params["head_sha"] = sha
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create check run `%s' for %s\n", name, sha)
+		return
+	}
+
+	checkRun, err := gh.CreateCheckRun(project, params)
+	utils.Check(err)
+
+	ui.Println(checkRun.HTMLURL)
+}
+
+func checkRunParams(args *Args, now time.Time) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+
+	status := args.Flag.Value("--status")
+	if conclusion := args.Flag.Value("--conclusion"); conclusion != "" {
+		if status != "" && status != "completed" {
+			return nil, fmt.Errorf("error: --conclusion requires the status to be 'completed'")
+		}
+		status = "completed"
+		params["conclusion"] = conclusion
+		params["completed_at"] = now.UTC().Format(time.RFC3339)
+	}
+	if status != "" {
+		params["status"] = status
+	}
+
+	if args.Flag.HasReceived("--details-url") {
+		params["details_url"] = args.Flag.Value("--details-url")
+	}
+	if args.Flag.HasReceived("--external-id") {
+		params["external_id"] = args.Flag.Value("--external-id")
+	}
+
+	if args.Flag.HasReceived("--output-file") {
+		summary, err := msgFromFile(args.Flag.Value("--output-file"))
+		if err != nil {
+			return nil, err
+		}
+		title := args.Flag.Value("--title")
+		if title == "" {
+			title = args.Flag.Value("--name")
+		}
+		params["output"] = map[string]interface{}{
+			"title":   title,
+			"summary": summary,
+		}
+	}
+
+	return params, nil
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestCheckRunParams(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	args := NewArgs([]string{"create", "-n", "build", "-c", "success", "-u", "https://ci.example.com/1"})
+	assert.Equal(t, nil, cmdCreateCheckRun.parseArguments(args))
+
+	params, err := checkRunParams(args, now)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{
+		"status":       "completed",
+		"conclusion":   "success",
+		"completed_at": "2026-10-01T10:00:00Z",
+		"details_url":  "https://ci.example.com/1",
+	}, params)
+}
+
+func TestCheckRunParams_ConclusionWithStatus(t *testing.T) {
+	args := NewArgs([]string{"create", "-n", "build", "-s", "in_progress", "-c", "failure"})
+	assert.Equal(t, nil, cmdCreateCheckRun.parseArguments(args))
+
+	_, err := checkRunParams(args, time.Now())
+	assert.Equal(t, "error: --conclusion requires the status to be 'completed'", err.Error())
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
 )
 
@@ -20,6 +21,13 @@ var cmdCheckout = &Command{
 		> git fetch origin pull/73/head:jingweno-feature
 		> git checkout jingweno-feature
 
+## Notes:
+
+The head branch of a closed pull request may have been deleted. For merged
+pull requests, the merge commit is checked out instead; for pull requests that
+were closed without merging, the last commit of the pull request is. Unless
+<BRANCH> is given, the commit is checked out as a detached HEAD.
+
 ## See also:
 
 hub-merge(1), hub-am(1), hub(1), git-checkout(1)
@@ -84,6 +92,11 @@ func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranc
 		return
 	}
 
+	if pullRequest.State == "closed" {
+		newArgs = transformClosedCheckoutArgs(args, pullRequest, baseRemote, newBranchName)
+		return
+	}
+
 	var headRemote *github.Remote
 	if pullRequest.IsSameRepo() {
 		headRemote = baseRemote
@@ -147,6 +160,37 @@ func transformCheckoutArgs(args *Args, pullRequest *github.PullRequest, newBranc
 	return
 }
 
+// transformClosedCheckoutArgs checks out a pull request whose head branch may
+// no longer exist: the merge commit if it was merged, or the last commit of
+// its head otherwise. Unless a branch name is given, the commit is checked out
+// as a detached HEAD.
+func transformClosedCheckoutArgs(args *Args, pullRequest *github.PullRequest, baseRemote *github.Remote, newBranchName string) []string {
+	commit := "FETCH_HEAD"
+	if !pullRequest.MergedAt.IsZero() && pullRequest.MergeCommitSha != "" {
+		commit = pullRequest.MergeCommitSha
+		ui.Errorf("Pull request #%d was merged; checking out its merge commit %s\n", pullRequest.Number, shortSha(commit))
+		args.Before("git", "fetch", baseRemote.Name, commit)
+	} else {
+		if pullRequest.Head != nil && pullRequest.Head.Sha != "" {
+			commit = pullRequest.Head.Sha
+		}
+		ui.Errorf("Pull request #%d is closed; checking out its last commit %s\n", pullRequest.Number, shortSha(commit))
+		args.Before("git", "fetch", baseRemote.Name, fmt.Sprintf("refs/pull/%d/head", pullRequest.Number))
+	}
+
+	if newBranchName != "" {
+		return []string{"-b", newBranchName, commit}
+	}
+	return []string{commit}
+}
+
+func shortSha(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
 func sanitizeCheckoutFlags(args *Args) error {
 	if i := args.IndexOfParam("-b"); i != -1 {
 		return fmt.Errorf("Unsupported flag -b when checking out pull request")
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
@@ -13,13 +14,18 @@ import (
 
 var cmdCiStatus = &Command{
 	Run:   ciStatus,
-	Usage: "ci-status [-v] [<COMMIT>]",
+	Usage: "ci-status [-v] [-w] [<COMMIT>]",
 	Long: `Display status of GitHub checks for a commit.
 
 ## Options:
 	-v, --verbose
 		Print detailed report of all status checks and their URLs.
 
+	-w, --wait
+		Wait until no status checks are pending before reporting. Checks are polled
+		with conditional requests, so waiting uses up very little of the API rate
+		limit.
+
 	-f, --format <FORMAT>
 		Pretty print all status checks using <FORMAT> (implies ''--verbose''). See the
 		"PRETTY FORMATS" section of git-log(1) for some additional details on how
@@ -54,6 +60,10 @@ hub-pull-request(1), hub(1)
 
 var severityList []string
 
+// ciStatusPollInterval is how often `ci-status --wait` checks for updates
+// unless the API asks for a longer interval.
+const ciStatusPollInterval = 10 * time.Second
+
 func init() {
 	CmdRunner.Use(cmdCiStatus)
 
@@ -91,6 +101,10 @@ func ciStatus(cmd *Command, args *Args) {
 	utils.Check(err)
 
 	sha, err := git.Ref(ref)
+	if _, dirErr := git.Dir(); err != nil && dirErr != nil && ref != "HEAD" {
+		// outside of a git repository, let the API resolve the branch or SHA
+		sha, err = ref, nil
+	}
 	if err != nil {
 		err = fmt.Errorf("Aborted: no revision could be determined from '%s'", ref)
 	}
@@ -100,17 +114,17 @@ func ciStatus(cmd *Command, args *Args) {
 		ui.Printf("Would request CI status for %s\n", sha)
 	} else {
 		gh := github.NewClient(project.Host)
-		response, err := gh.FetchCIStatus(project, sha)
+		var response *github.CIStatusResponse
+		if args.Flag.Bool("--wait") {
+			response, err = gh.WaitForCIStatus(project, sha, ciStatusPollInterval, func(response *github.CIStatusResponse) bool {
+				return ciState(response.Statuses) != "pending"
+			})
+		} else {
+			response, err = gh.FetchCIStatus(project, sha)
+		}
 		utils.Check(err)
 
-		state := ""
-		if len(response.Statuses) > 0 {
-			for _, status := range response.Statuses {
-				if checkSeverity(status.State) > checkSeverity(state) {
-					state = status.State
-				}
-			}
-		}
+		state := ciState(response.Statuses)
 
 		var exitCode int
 		switch state {
@@ -140,6 +154,17 @@ func ciStatus(cmd *Command, args *Args) {
 	}
 }
 
+// ciState is the state of the most severe status check.
+func ciState(statuses []github.CIStatus) string {
+	state := ""
+	for _, status := range statuses {
+		if checkSeverity(status.State) > checkSeverity(state) {
+			state = status.State
+		}
+	}
+	return state
+}
+
 func ciVerboseFormat(statuses []github.CIStatus, formatString string, colorize bool) {
 	contextWidth := 0
 	for _, status := range statuses {
@@ -1,11 +1,14 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
 	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
 )
 
@@ -32,13 +35,18 @@ protocol will be used for private repositories and those that you have push
 access to. Alternatively, hub can be configured to use HTTPS protocol for
 everything. See "HTTPS instead of git protocol" and "HUB_PROTOCOL" of hub(1).
 
+## Invitations
+
+If a repository can't be found but you were invited to collaborate on it, hub
+offers to accept the pending invitation and then proceeds with cloning.
+
 ## Examples:
 		$ hub clone rtomayko/ronn
 		> git clone git://github.com/rtomayko/ronn.git
 
 ## See also:
 
-hub-fork(1), hub(1), git-clone(1)
+hub-fork(1), hub-invitation(1), hub(1), git-clone(1)
 `,
 }
 
@@ -129,12 +137,14 @@ func getCloneURL(nameWithOwner string, isSSH, allowSSH bool) string {
 	project := github.NewProject(owner, name, hostStr)
 	gh := github.NewClient(project.Host)
 	repo, err := gh.Repository(project)
-	if err != nil {
-		if strings.Contains(err.Error(), "HTTP 404") {
+	if err != nil && strings.Contains(err.Error(), "HTTP 404") {
+		if invitationAccepted(gh, project) {
+			repo, err = gh.Repository(project)
+		} else {
 			err = fmt.Errorf("Error: repository %s/%s doesn't exist", project.Owner, project.Name)
 		}
-		utils.Check(err)
 	}
+	utils.Check(err)
 
 	owner = repo.Owner.Login
 	name = repo.Name
@@ -154,3 +164,37 @@ func getCloneURL(nameWithOwner string, isSSH, allowSSH bool) string {
 
 	return project.GitURL(name, owner, isSSH)
 }
+
+// invitationAccepted checks whether the user was invited to collaborate on a
+// repository that they can't see yet, and offers to accept the invitation.
+func invitationAccepted(gh *github.Client, project *github.Project) bool {
+	invitations, err := gh.FetchRepositoryInvitations()
+	if err != nil {
+		return false
+	}
+	id, err := findInvitation(invitations, project.String())
+	if err != nil {
+		return false
+	}
+
+	inviter := ""
+	for _, inv := range invitations {
+		if inv.ID == id && inv.Inviter != nil {
+			inviter = " from " + inv.Inviter.Login
+		}
+	}
+	ui.Errorf("You have a pending invitation to collaborate on %s%s.\n", project, inviter)
+	ui.Errorf("Accept it and continue cloning (y/N)? ")
+
+	answer := ""
+	scanner := bufio.NewScanner(os.Stdin)
+	if scanner.Scan() {
+		answer = strings.ToLower(strings.TrimSpace(scanner.Text()))
+	}
+	if answer != "y" && answer != "yes" {
+		utils.Check(fmt.Errorf("Error: accept the invitation with `hub invitation accept %s` before cloning", project))
+	}
+
+	utils.Check(gh.AcceptRepositoryInvitation(id))
+	return true
+}
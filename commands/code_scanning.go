@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdCodeScanning = &Command{
+		Run: printHelp,
+		Usage: `
+code-scanning list [-s <STATE>] [--tool <TOOL>] [-r <REF>] [-f <FORMAT>] [--color]
+code-scanning dismiss -R <REASON> [-m <COMMENT>] <NUMBER>
+`,
+		Long: `Triage code scanning alerts for the current repository.
+
+## Commands:
+
+	* _list_:
+		List code scanning alerts.
+
+	* _dismiss_:
+		Dismiss the code scanning alert <NUMBER>.
+
+## Options:
+	-s, --state <STATE>
+		Filter alerts by state: "open" (default), "closed", "dismissed", "fixed",
+		or "all".
+
+	--tool <TOOL>
+		Only list alerts reported by the code scanning tool <TOOL>, e.g. "CodeQL".
+
+	-r, --ref <REF>
+		Only list alerts for the branch or pull request <REF>, e.g.
+		"refs/heads/main" or "refs/pull/42/merge".
+
+	-R, --reason <REASON>
+		Why the alert is dismissed: "false-positive", "wont-fix", or
+		"used-in-tests".
+
+	-m, --message <COMMENT>
+		Explain why the alert is dismissed.
+
+	-f, --format <FORMAT>
+		Pretty print the list of alerts using format <FORMAT> (default:
+		"%sC%>(6)%I%Creset  %<(8)%sV  %l  %t%n"). See the "PRETTY FORMATS" section
+		of git-log(1) for some additional details on how placeholders are used in
+		format. The available placeholders are:
+
+		%I: alert number
+
+		%U: the URL of this alert
+
+		%S: state (i.e. "open", "dismissed", "fixed")
+
+		%sV: severity
+
+		%sC: set color to red, yellow, or default depending on severity
+
+		%t: rule description
+
+		%R: rule ID
+
+		%T: name of the tool that reported the alert
+
+		%l: location of the alert, as "PATH:LINE"
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+## Examples:
+		$ hub code-scanning list --tool CodeQL
+		$ hub code-scanning dismiss -R used-in-tests 42
+
+## See also:
+
+hub-secret-scanning(1), hub-security(1), hub(1)
+`,
+	}
+
+	cmdListCodeScanningAlerts = &Command{
+		Key: "list",
+		Run: listCodeScanningAlerts,
+		KnownFlags: `
+		-s, --state STATE
+		--tool TOOL
+		-r, --ref REF
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdDismissCodeScanningAlert = &Command{
+		Key: "dismiss",
+		Run: dismissCodeScanningAlert,
+		KnownFlags: `
+		-R, --reason REASON
+		-m, --message COMMENT
+`,
+	}
+
+	codeScanningDismissReasons = map[string]string{
+		"false-positive": "false positive",
+		"wont-fix":       "won't fix",
+		"used-in-tests":  "used in tests",
+	}
+)
+
+func init() {
+	cmdCodeScanning.Use(cmdListCodeScanningAlerts)
+	cmdCodeScanning.Use(cmdDismissCodeScanningAlert)
+	CmdRunner.Use(cmdCodeScanning)
+}
+
+func listCodeScanningAlerts(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of code scanning alerts for %s\n", project)
+		return
+	}
+
+	filters := map[string]interface{}{}
+	state := "open"
+	if args.Flag.HasReceived("--state") {
+		state = args.Flag.Value("--state")
+	}
+	if state != "all" {
+		filters["state"] = state
+	}
+	if args.Flag.HasReceived("--tool") {
+		filters["tool_name"] = args.Flag.Value("--tool")
+	}
+	if args.Flag.HasReceived("--ref") {
+		filters["ref"] = args.Flag.Value("--ref")
+	}
+
+	alerts, err := gh.FetchCodeScanningAlerts(project, filters)
+	utils.Check(err)
+
+	flagAlertFormat := "%sC%>(6)%I%Creset  %<(8)%sV  %l  %t%n"
+	if args.Flag.HasReceived("--format") {
+		flagAlertFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, alert := range alerts {
+		ui.Print(formatCodeScanningAlert(alert, flagAlertFormat, colorize))
+	}
+}
+
+func formatCodeScanningAlert(alert github.CodeScanningAlert, format string, colorize bool) string {
+	severity := alert.Rule.SecuritySeverityLevel
+	if severity == "" {
+		severity = alert.Rule.Severity
+	}
+
+	var severityColorSwitch string
+	if colorize {
+		if color := severityColor(severity); color > 0 {
+			severityColorSwitch = fmt.Sprintf("\033[%dm", color)
+		}
+	}
+
+	location := alert.MostRecentInstance.Location.Path
+	if line := alert.MostRecentInstance.Location.StartLine; line > 0 {
+		location = fmt.Sprintf("%s:%d", location, line)
+	}
+
+	var createdDate, createdAtISO8601, createdAtRelative string
+	if !alert.CreatedAt.IsZero() {
+		createdDate = alert.CreatedAt.Format("02 Jan 2006")
+		createdAtISO8601 = alert.CreatedAt.Format(time.RFC3339)
+		createdAtRelative = utils.TimeAgo(alert.CreatedAt)
+	}
+
+	placeholders := map[string]string{
+		"I":  strconv.Itoa(alert.Number),
+		"U":  alert.HTMLURL,
+		"S":  alert.State,
+		"sV": severity,
+		"sC": severityColorSwitch,
+		"t":  alert.Rule.Description,
+		"R":  alert.Rule.ID,
+		"T":  alert.Tool.Name,
+		"l":  location,
+		"cD": createdDate,
+		"cI": createdAtISO8601,
+		"cr": createdAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+// alertReason normalizes a user-supplied dismissal reason to one of the keys
+// of reasons, accepting spaces or underscores in place of dashes.
+func alertReason(reason string, reasons map[string]string) (string, error) {
+	key := strings.ToLower(strings.NewReplacer(" ", "-", "_", "-", "'", "").Replace(reason))
+	if value, ok := reasons[key]; ok {
+		return value, nil
+	}
+	return "", fmt.Errorf("error: invalid reason '%s'", reason)
+}
+
+func dismissCodeScanningAlert(cmd *Command, args *Args) {
+	alertNumber := 0
+	if args.ParamsSize() > 0 {
+		alertNumber, _ = strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	}
+	if alertNumber == 0 || !args.Flag.HasReceived("--reason") {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	reason, err := alertReason(args.Flag.Value("--reason"), codeScanningDismissReasons)
+	utils.Check(err)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would dismiss code scanning alert %d in %s\n", alertNumber, project)
+		return
+	}
+
+	params := map[string]interface{}{
+		"state":            "dismissed",
+		"dismissed_reason": reason,
+	}
+	if args.Flag.HasReceived("--message") {
+		params["dismissed_comment"] = args.Flag.Value("--message")
+	}
+
+	utils.Check(gh.UpdateCodeScanningAlert(project, alertNumber, params))
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatCodeScanningAlert(t *testing.T) {
+	alert := github.CodeScanningAlert{Number: 42, State: "open"}
+	alert.Rule.ID = "js/sql-injection"
+	alert.Rule.Severity = "error"
+	alert.Rule.Description = "Database query built from user-controlled sources"
+	alert.Tool.Name = "CodeQL"
+	alert.MostRecentInstance.Location.Path = "lib/db.js"
+	alert.MostRecentInstance.Location.StartLine = 12
+
+	assert.Equal(t, "    42  error     lib/db.js:12  Database query built from user-controlled sources\n",
+		formatCodeScanningAlert(alert, "%sC%>(6)%I%Creset  %<(8)%sV  %l  %t%n", false))
+
+	alert.Rule.SecuritySeverityLevel = "critical"
+	assert.Equal(t, "critical CodeQL js/sql-injection", formatCodeScanningAlert(alert, "%sV %T %R", false))
+}
+
+func TestAlertReason(t *testing.T) {
+	reason, err := alertReason("won't fix", codeScanningDismissReasons)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "won't fix", reason)
+
+	reason, err = alertReason("False_Positive", secretScanningResolutions)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "false_positive", reason)
+
+	_, err = alertReason("revoked", codeScanningDismissReasons)
+	assert.Equal(t, "error: invalid reason 'revoked'", err.Error())
+}
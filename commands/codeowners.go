@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdCodeowners = &Command{
+		Run: printHelp,
+		Usage: `
+codeowners check
+codeowners who <PATH>...
+`,
+		Long: `Validate the CODEOWNERS file and look up owners of files.
+
+## Commands:
+
+	* _check_:
+		Report syntax errors in the CODEOWNERS file of the current repository, as
+		well as users and teams that do not exist on GitHub. Exits with a non-zero
+		status if any problems were found.
+
+	* _who_:
+		Print the owners of each <PATH> according to the CODEOWNERS file.
+
+## Examples:
+		$ hub codeowners check
+		.github/CODEOWNERS:4: unknown user or team '@octocta'
+
+		$ hub codeowners who README.md lib/
+		README.md  @github/docs
+		lib/       @mislav @github/hub-maintainers
+
+## Notes:
+
+The CODEOWNERS file is looked up in the ''.github/'' directory, the top-level
+directory, and the ''docs/'' directory of the repository, in that order.
+
+When ''pull-request'' opens a text editor, it suggests values for ''--reviewer''
+based on the owners of the files changed in the pull request.
+
+## See also:
+
+hub-pull-request(1), hub(1)
+`,
+	}
+
+	cmdCheckCodeowners = &Command{
+		Key: "check",
+		Run: checkCodeowners,
+	}
+
+	cmdWhoCodeowners = &Command{
+		Key: "who",
+		Run: whoCodeowners,
+	}
+)
+
+func init() {
+	cmdCodeowners.Use(cmdCheckCodeowners)
+	cmdCodeowners.Use(cmdWhoCodeowners)
+	CmdRunner.Use(cmdCodeowners)
+}
+
+func readCodeowners() (workdir string, codeowners *github.Codeowners, err error) {
+	workdir, err = git.WorkdirName()
+	if err != nil {
+		return
+	}
+
+	codeowners, err = github.ReadCodeowners(workdir)
+	if err == nil && codeowners == nil {
+		err = fmt.Errorf("error: no CODEOWNERS file found in %s", strings.Join(github.CodeownersPaths, ", "))
+	}
+	return
+}
+
+func checkCodeowners(cmd *Command, args *Args) {
+	_, codeowners, err := readCodeowners()
+	utils.Check(err)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would check %s\n", codeowners.Path)
+		return
+	}
+
+	problems := codeowners.Errors
+	checked := map[string]bool{}
+	for _, rule := range codeowners.Rules {
+		for _, owner := range rule.Owners {
+			if !strings.HasPrefix(owner, "@") || checked[owner] {
+				continue
+			}
+			checked[owner] = true
+
+			exists, err := gh.OwnerExists(strings.TrimPrefix(owner, "@"))
+			utils.Check(err)
+			if !exists {
+				problems = append(problems, github.CodeownersError{
+					Line:    rule.Line,
+					Message: fmt.Sprintf("unknown user or team '%s'", owner),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	for _, problem := range problems {
+		ui.Printf("%s:%d: %s\n", codeowners.Path, problem.Line, problem.Message)
+	}
+
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+func whoCodeowners(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	workdir, codeowners, err := readCodeowners()
+	utils.Check(err)
+
+	args.NoForward()
+
+	width := 0
+	for _, path := range args.Params {
+		if len(path) > width {
+			width = len(path)
+		}
+	}
+
+	for _, path := range args.Params {
+		relPath, err := repoRelativePath(workdir, path)
+		utils.Check(err)
+
+		owners := strings.Join(codeowners.Owners(relPath), " ")
+		if owners == "" {
+			owners = "(no owners)"
+		}
+		ui.Printf("%-*s  %s\n", width, path, owners)
+	}
+}
+
+// repoRelativePath converts a path given relative to the current directory to
+// a slash-separated path relative to the top-level directory of the repository.
+func repoRelativePath(workdir, path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(workdir); err == nil {
+		workdir = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(filepath.Dir(absPath)); err == nil {
+		absPath = filepath.Join(resolved, filepath.Base(absPath))
+	}
+
+	relPath, err := filepath.Rel(workdir, absPath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return "", fmt.Errorf("error: %s is outside of the repository", path)
+	}
+	return filepath.ToSlash(relPath), nil
+}
+
+// codeownersReviewers collects owners of the changed files that a review can
+// be requested from, in the format accepted by the --reviewer flag of
+// pull-request.
+func codeownersReviewers(codeowners *github.Codeowners, files []string, author string) []string {
+	reviewers := []string{}
+	seen := map[string]bool{}
+	for _, file := range files {
+		for _, owner := range codeowners.Owners(file) {
+			reviewer := strings.TrimPrefix(owner, "@")
+			if reviewer == owner || seen[reviewer] || strings.EqualFold(reviewer, author) {
+				continue
+			}
+			seen[reviewer] = true
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	return reviewers
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestCodeownersReviewers(t *testing.T) {
+	codeowners := github.ParseCodeowners("CODEOWNERS", `*      @mislav
+*.go   @github/go-reviewers @octocat docs@example.com
+docs/  docs@example.com
+`)
+
+	files := []string{"main.go", "README.md", "docs/index.md", "lib/util.go"}
+	assert.Equal(t, []string{"github/go-reviewers", "octocat"}, codeownersReviewers(codeowners, files, "Mislav"))
+	assert.Equal(t, []string{}, codeownersReviewers(codeowners, []string{"docs/index.md"}, "mislav"))
+}
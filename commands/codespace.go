@@ -0,0 +1,375 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdCodespace = &Command{
+		Run: printHelp,
+		Usage: `
+codespace list [-a] [-f <FORMAT>] [--color]
+codespace create [-b <BRANCH>] [-m <MACHINE>] [--idle-timeout <MINUTES>] [--ssh]
+codespace ssh [<NAME>]
+codespace stop [<NAME>]
+`,
+		Long: `Manage GitHub Codespaces for the current repository.
+
+## Commands:
+
+	* _list_:
+		List your codespaces for the current repository.
+
+	* _create_:
+		Create a codespace for the current branch and print its name.
+
+	* _ssh_:
+		Open an SSH session to the codespace <NAME>. Without <NAME>, the codespace
+		for the current repository and branch is used if there is only one.
+
+	* _stop_:
+		Stop the codespace <NAME>. Without <NAME>, the codespace is picked like for
+		''ssh''.
+
+## Options:
+	-a, --all
+		List codespaces for all repositories instead of only the current one.
+
+	-f, --format <FORMAT>
+		Pretty print the list of codespaces using format <FORMAT> (default:
+		"%<(32)%I  %<(10)%S  %R@%b%n"). See the "PRETTY FORMATS" section of
+		git-log(1) for some additional details on how placeholders are used in
+		format. The available placeholders are:
+
+		%I: codespace name
+
+		%d: display name
+
+		%R: repository name with owner
+
+		%b: branch checked out in the codespace
+
+		%S: state (e.g. "Available", "Shutdown")
+
+		%sC: set color to green or yellow, depending on whether the codespace
+		is available
+
+		%M: machine type
+
+		%U: the URL of this codespace in the browser
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%uD: last used date-only (no time of day)
+
+		%ur: last used date, relative
+
+		%uI: last used date, ISO 8601 format
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+	-b, --branch <BRANCH>
+		The branch to check out in the new codespace (default: the current branch).
+		The branch must already exist on GitHub.
+
+	-m, --machine <MACHINE>
+		The machine type to provision, such as "basicLinux32gb".
+
+	--idle-timeout <MINUTES>
+		Stop the codespace after it has been inactive for <MINUTES>.
+
+	--ssh
+		Open an SSH session to the codespace once it has been created.
+
+## Examples:
+		$ hub codespace create --ssh
+		$ hub codespace list
+		$ hub codespace stop
+
+## Notes:
+
+SSH sessions are tunneled through the GitHub CLI, so ''ssh'' and
+''create --ssh'' require the ''gh'' executable to be installed.
+
+## See also:
+
+hub(1), gh-codespace(1)
+`,
+	}
+
+	cmdListCodespaces = &Command{
+		Key: "list",
+		Run: listCodespaces,
+		KnownFlags: `
+		-a, --all
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdCreateCodespace = &Command{
+		Key: "create",
+		Run: createCodespace,
+		KnownFlags: `
+		-b, --branch BRANCH
+		-m, --machine MACHINE
+		--idle-timeout MINUTES
+		--ssh
+`,
+	}
+
+	cmdSSHCodespace = &Command{
+		Key: "ssh",
+		Run: sshCodespace,
+	}
+
+	cmdStopCodespace = &Command{
+		Key: "stop",
+		Run: stopCodespace,
+	}
+)
+
+func init() {
+	cmdCodespace.Use(cmdListCodespaces)
+	cmdCodespace.Use(cmdCreateCodespace)
+	cmdCodespace.Use(cmdSSHCodespace)
+	cmdCodespace.Use(cmdStopCodespace)
+	CmdRunner.Use(cmdCodespace)
+}
+
+func listCodespaces(cmd *Command, args *Args) {
+	var project *github.Project
+	var host string
+	if args.Flag.Bool("--all") {
+		defHost, err := github.CurrentConfig().DefaultHostNoPrompt()
+		utils.Check(err)
+		host = defHost.Host
+	} else {
+		localRepo, err := github.LocalRepo()
+		utils.Check(err)
+
+		project, err = localRepo.MainProject()
+		utils.Check(err)
+		host = project.Host
+	}
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		if project != nil {
+			ui.Printf("Would request list of codespaces for %s\n", project)
+		} else {
+			ui.Printf("Would request list of codespaces\n")
+		}
+		return
+	}
+
+	codespaces, err := gh.FetchCodespaces(project)
+	utils.Check(err)
+
+	flagCodespaceFormat := "%<(32)%I  %<(10)%S  %R@%b%n"
+	if args.Flag.HasReceived("--format") {
+		flagCodespaceFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, codespace := range codespaces {
+		ui.Print(formatCodespace(codespace, flagCodespaceFormat, colorize))
+	}
+}
+
+func formatCodespace(codespace github.Codespace, format string, colorize bool) string {
+	var stateColorSwitch string
+	if colorize {
+		color := 33
+		if codespace.State == "Available" {
+			color = 32
+		}
+		stateColorSwitch = fmt.Sprintf("\033[%dm", color)
+	}
+
+	var createdDate, createdAtISO8601, createdAtRelative,
+		usedDate, usedAtISO8601, usedAtRelative string
+	if !codespace.CreatedAt.IsZero() {
+		createdDate = codespace.CreatedAt.Format("02 Jan 2006")
+		createdAtISO8601 = codespace.CreatedAt.Format(time.RFC3339)
+		createdAtRelative = utils.TimeAgo(codespace.CreatedAt)
+	}
+	if !codespace.LastUsedAt.IsZero() {
+		usedDate = codespace.LastUsedAt.Format("02 Jan 2006")
+		usedAtISO8601 = codespace.LastUsedAt.Format(time.RFC3339)
+		usedAtRelative = utils.TimeAgo(codespace.LastUsedAt)
+	}
+
+	placeholders := map[string]string{
+		"I":  codespace.Name,
+		"d":  codespace.DisplayName,
+		"R":  codespace.Repository.FullName,
+		"b":  codespace.GitStatus.Ref,
+		"S":  codespace.State,
+		"sC": stateColorSwitch,
+		"M":  codespace.Machine.Name,
+		"U":  codespace.WebURL,
+		"cD": createdDate,
+		"cI": createdAtISO8601,
+		"cr": createdAtRelative,
+		"uD": usedDate,
+		"uI": usedAtISO8601,
+		"ur": usedAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func createCodespace(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	branch := args.Flag.Value("--branch")
+	if branch == "" {
+		currentBranch, err := localRepo.CurrentBranch()
+		utils.Check(err)
+		branch = currentBranch.ShortName()
+	}
+
+	params := map[string]interface{}{
+		"ref": branch,
+	}
+	if machine := args.Flag.Value("--machine"); machine != "" {
+		params["machine"] = machine
+	}
+	if args.Flag.HasReceived("--idle-timeout") {
+		params["idle_timeout_minutes"] = args.Flag.Int("--idle-timeout")
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create codespace for %s on branch %s\n", project, branch)
+		return
+	}
+
+	codespace, err := gh.CreateCodespace(project, params)
+	utils.Check(err)
+
+	if args.Flag.Bool("--ssh") {
+		utils.Check(openCodespaceSSH(args, codespace.Name))
+		return
+	}
+
+	ui.Println(codespace.Name)
+}
+
+func sshCodespace(cmd *Command, args *Args) {
+	name, err := codespaceNameFromArgs(args)
+	utils.Check(err)
+
+	utils.Check(openCodespaceSSH(args, name))
+}
+
+// openCodespaceSSH replaces the current command with an SSH session through
+// the GitHub CLI, which knows how to tunnel into a codespace.
+func openCodespaceSSH(args *Args, name string) error {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return fmt.Errorf("error: opening an SSH session to a codespace requires the GitHub CLI (gh)")
+	}
+
+	args.Replace("gh", "codespace", "ssh", "--codespace", name)
+	return nil
+}
+
+func stopCodespace(cmd *Command, args *Args) {
+	name, err := codespaceNameFromArgs(args)
+	utils.Check(err)
+
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+
+	gh := github.NewClient(host.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would stop codespace %s\n", name)
+		return
+	}
+
+	utils.Check(gh.StopCodespace(name))
+}
+
+// codespaceNameFromArgs returns the codespace name given as the first
+// parameter, or looks up the codespace for the current branch.
+func codespaceNameFromArgs(args *Args) (string, error) {
+	if args.ParamsSize() > 0 {
+		return args.GetParam(0), nil
+	}
+
+	localRepo, err := github.LocalRepo()
+	if err != nil {
+		return "", err
+	}
+
+	project, err := localRepo.MainProject()
+	if err != nil {
+		return "", err
+	}
+
+	currentBranch, err := localRepo.CurrentBranch()
+	if err != nil {
+		return "", err
+	}
+
+	gh := github.NewClient(project.Host)
+	codespaces, err := gh.FetchCodespaces(project)
+	if err != nil {
+		return "", err
+	}
+
+	codespace, err := selectCodespace(codespaces, currentBranch.ShortName())
+	if err != nil {
+		return "", err
+	}
+	return codespace.Name, nil
+}
+
+// selectCodespace picks the only codespace that has branch checked out.
+func selectCodespace(codespaces []github.Codespace, branch string) (*github.Codespace, error) {
+	matches := []string{}
+	var match *github.Codespace
+	for i, codespace := range codespaces {
+		if codespace.GitStatus.Ref == branch {
+			match = &codespaces[i]
+			matches = append(matches, codespace.Name)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("error: no codespace found for branch %s\n(use `hub codespace create` to create one)", branch)
+	case 1:
+		return match, nil
+	default:
+		return nil, fmt.Errorf("error: %d codespaces found for branch %s; pass one of their names:\n%s", len(matches), branch, strings.Join(matches, "\n"))
+	}
+}
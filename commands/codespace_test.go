@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestSelectCodespace(t *testing.T) {
+	codespaces := make([]github.Codespace, 3)
+	codespaces[0].Name = "mislav-hub-a1"
+	codespaces[0].GitStatus.Ref = "main"
+	codespaces[1].Name = "mislav-hub-b2"
+	codespaces[1].GitStatus.Ref = "feature"
+	codespaces[2].Name = "mislav-hub-c3"
+	codespaces[2].GitStatus.Ref = "feature"
+
+	codespace, err := selectCodespace(codespaces, "main")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "mislav-hub-a1", codespace.Name)
+
+	_, err = selectCodespace(codespaces, "feature")
+	assert.Equal(t, "error: 2 codespaces found for branch feature; pass one of their names:\nmislav-hub-b2\nmislav-hub-c3", err.Error())
+
+	_, err = selectCodespace(codespaces, "topic")
+	assert.Equal(t, "error: no codespace found for branch topic\n(use `hub codespace create` to create one)", err.Error())
+}
+
+func TestFormatCodespace(t *testing.T) {
+	codespace := github.Codespace{Name: "mislav-hub-a1", State: "Available"}
+	codespace.Repository.FullName = "github/hub"
+	codespace.GitStatus.Ref = "main"
+
+	assert.Equal(t, "mislav-hub-a1                     Available   github/hub@main\n", formatCodespace(codespace, "%<(32)%I  %<(10)%S  %R@%b%n", false))
+}
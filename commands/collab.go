@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdCollab = &Command{
+		Run: printHelp,
+		Usage: `
+collab list
+collab add [-p <PERMISSION>] <USER>
+collab remove <USER>
+`,
+		Long: `Manage collaborators of the current repository.
+
+## Commands:
+
+	* _list_:
+		List collaborators along with their role in the repository.
+
+	* _add_:
+		Invite <USER> to collaborate on the repository, or change the permission of
+		an existing collaborator.
+
+	* _remove_:
+		Revoke the access of <USER> to the repository.
+
+## Options:
+	-p, --permission <PERMISSION>
+		The permission to grant: "pull", "triage", "push" (default), "maintain", or
+		"admin".
+
+## Examples:
+		$ hub collab add -p maintain octocat
+		$ hub collab list
+		mislav    admin
+		octocat   maintain
+
+## See also:
+
+hub-invitation(1), hub(1)
+`,
+	}
+
+	cmdListCollaborators = &Command{
+		Key: "list",
+		Run: listCollaborators,
+	}
+
+	cmdAddCollaborator = &Command{
+		Key: "add",
+		Run: addCollaborator,
+		KnownFlags: `
+		-p, --permission PERMISSION
+`,
+	}
+
+	cmdRemoveCollaborator = &Command{
+		Key: "remove",
+		Run: removeCollaborator,
+	}
+)
+
+func init() {
+	cmdCollab.Use(cmdListCollaborators)
+	cmdCollab.Use(cmdAddCollaborator)
+	cmdCollab.Use(cmdRemoveCollaborator)
+	CmdRunner.Use(cmdCollab)
+}
+
+func listCollaborators(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of collaborators for %s\n", project)
+		return
+	}
+
+	collaborators, err := gh.FetchCollaborators(project)
+	utils.Check(err)
+
+	width := 0
+	for _, c := range collaborators {
+		if len(c.Login) > width {
+			width = len(c.Login)
+		}
+	}
+	for _, c := range collaborators {
+		ui.Printf("%-*s  %s\n", width, c.Login, collaboratorRole(c))
+	}
+}
+
+// collaboratorRole falls back to deriving the role from permission flags for
+// servers that don't report role names.
+func collaboratorRole(c github.Collaborator) string {
+	if c.RoleName != "" {
+		return c.RoleName
+	}
+	switch {
+	case c.Permissions == nil:
+		return ""
+	case c.Permissions.Admin:
+		return "admin"
+	case c.Permissions.Push:
+		return "write"
+	default:
+		return "read"
+	}
+}
+
+func addCollaborator(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	login := strings.TrimPrefix(args.GetParam(0), "@")
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would add %s as a collaborator to %s\n", login, project)
+		return
+	}
+
+	invited, err := gh.AddCollaborator(project, login, args.Flag.Value("--permission"))
+	utils.Check(err)
+
+	if invited {
+		ui.Printf("Invited %s to collaborate on %s.\n", login, project)
+	} else {
+		ui.Printf("Updated permission of %s on %s.\n", login, project)
+	}
+}
+
+func removeCollaborator(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	login := strings.TrimPrefix(args.GetParam(0), "@")
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove %s as a collaborator from %s\n", login, project)
+		return
+	}
+
+	err = gh.RemoveCollaborator(project, login)
+	utils.Check(err)
+
+	ui.Printf("Removed %s from %s.\n", login, project)
+}
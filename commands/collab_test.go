@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestCollaboratorRole(t *testing.T) {
+	assert.Equal(t, "maintain", collaboratorRole(github.Collaborator{RoleName: "maintain"}))
+	assert.Equal(t, "admin", collaboratorRole(github.Collaborator{Permissions: &github.RepositoryPermissions{Admin: true, Push: true, Pull: true}}))
+	assert.Equal(t, "write", collaboratorRole(github.Collaborator{Permissions: &github.RepositoryPermissions{Push: true, Pull: true}}))
+	assert.Equal(t, "read", collaboratorRole(github.Collaborator{Permissions: &github.RepositoryPermissions{Pull: true}}))
+	assert.Equal(t, "", collaboratorRole(github.Collaborator{}))
+}
+
+func TestFindInvitation(t *testing.T) {
+	invitations := []github.RepositoryInvitation{
+		{ID: 12, Repository: &github.Repository{FullName: "mislav/dotfiles"}},
+		{ID: 34, Repository: &github.Repository{FullName: "github/hub"}},
+	}
+
+	id, err := findInvitation(invitations, "GitHub/Hub")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 34, id)
+
+	id, err = findInvitation(nil, "56")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 56, id)
+
+	_, err = findInvitation(invitations, "octocat/hello")
+	assert.Equal(t, "error: no pending invitation found for 'octocat/hello'", err.Error())
+}
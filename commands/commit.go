@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdCommit = &Command{
+	Run:          commitView,
+	GitExtension: true,
+	Usage:        "commit view [<COMMIT>]",
+	Long: `Show a commit together with its pull requests and checks on GitHub.
+
+## Commands:
+
+	* _view_:
+		Show the message and author of <COMMIT> (default: HEAD) as recorded on
+		GitHub, followed by the pull requests that contain the commit and the
+		status of its CI checks.
+
+## Examples:
+		$ hub commit view 8f3a2c1
+		commit 8f3a2c1d9e...
+		Author: Mislav Marohnić <mislav@github.com>
+		Date:   Thu, 01 Oct 2026 12:00:00 +0000
+
+		    Fix typo in README
+
+		Pull requests:
+		  #1234  Fix typo (merged)
+
+		Checks:
+		✔︎  build  https://github.com/github/hub/runs/4
+
+## See also:
+
+hub-ci-status(1), hub-search(1), hub(1), git-commit(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdCommit)
+}
+
+func commitView(command *Command, args *Args) {
+	if args.IsParamsEmpty() || args.FirstParam() != "view" {
+		return
+	}
+	args.RemoveParam(0)
+
+	ref := "HEAD"
+	if !args.IsParamsEmpty() {
+		ref = args.RemoveParam(0)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	sha := ref
+	if resolved, err := git.Ref(ref); err == nil {
+		sha = resolved
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would show commit %s\n", sha)
+		return
+	}
+
+	commit, err := gh.FetchCommit(project, sha)
+	utils.Check(err)
+
+	pulls, err := gh.FetchCommitPullRequests(project, commit.SHA)
+	utils.Check(err)
+
+	ciStatus, err := gh.FetchCIStatus(project, commit.SHA)
+	utils.Check(err)
+
+	ui.Print(formatCommitView(commit, pulls))
+
+	if len(ciStatus.Statuses) > 0 {
+		ui.Printf("\nChecks:\n")
+		colorize := colorizeOutput(false, "")
+		ciVerboseFormat(ciStatus.Statuses, "", colorize)
+	}
+}
+
+func formatCommitView(commit *github.Commit, pulls []github.PullRequest) string {
+	author := commit.Commit.Author.Name
+	if commit.Commit.Author.Email != "" {
+		author += fmt.Sprintf(" <%s>", commit.Commit.Author.Email)
+	}
+
+	out := fmt.Sprintf("commit %s\n", commit.SHA)
+	out += fmt.Sprintf("Author: %s\n", author)
+	if !commit.Commit.Author.Date.IsZero() {
+		out += fmt.Sprintf("Date:   %s\n", commit.Commit.Author.Date.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	}
+	out += "\n"
+	for _, line := range strings.Split(strings.TrimRight(commit.Commit.Message, "\n"), "\n") {
+		if line == "" {
+			out += "\n"
+		} else {
+			out += fmt.Sprintf("    %s\n", line)
+		}
+	}
+
+	if commit.Stats != nil && len(commit.Files) > 0 {
+		out += fmt.Sprintf("\n %d %s changed, +%d -%d\n", len(commit.Files), pluralize(len(commit.Files), "file"), commit.Stats.Additions, commit.Stats.Deletions)
+	}
+
+	if len(pulls) > 0 {
+		out += "\nPull requests:\n"
+		for _, pr := range pulls {
+			state := pr.State
+			if !pr.MergedAt.IsZero() {
+				state = "merged"
+			}
+			out += fmt.Sprintf("  %6s  %s (%s)\n", fmt.Sprintf("#%d", pr.Number), pr.Title, state)
+		}
+	}
+
+	return out
+}
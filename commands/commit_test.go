@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatCommitView(t *testing.T) {
+	commit := &github.Commit{SHA: "8f3a2c1d9e"}
+	commit.Commit.Message = "Fix typo\n\nIn the README."
+	commit.Commit.Author.Name = "Mislav"
+	commit.Commit.Author.Email = "mislav@example.com"
+	commit.Commit.Author.Date = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	pulls := []github.PullRequest{
+		{Number: 1234, Title: "Fix typo", State: "closed", MergedAt: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)},
+		{Number: 99, Title: "Backport", State: "open"},
+	}
+
+	assert.Equal(t, `commit 8f3a2c1d9e
+Author: Mislav <mislav@example.com>
+Date:   Thu, 01 Oct 2026 12:00:00 +0000
+
+    Fix typo
+
+    In the README.
+
+Pull requests:
+   #1234  Fix typo (merged)
+     #99  Backport (open)
+`, formatCommitView(commit, pulls))
+}
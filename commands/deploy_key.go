@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdDeployKey = &Command{
+		Run: printHelp,
+		Usage: `
+deploy-key list [-f <FORMAT>]
+deploy-key add [--read-only] [-t <TITLE>] <KEY-FILE>
+deploy-key remove <ID>
+`,
+		Long: `Manage deploy keys for the current repository.
+
+## Commands:
+
+	* _list_:
+		List deploy keys that have access to the current repository.
+
+	* _add_:
+		Add the SSH public key read from <KEY-FILE> as a deploy key and print its
+		ID. Pass "-" to read the key from standard input.
+
+	* _remove_:
+		Remove the deploy key with the given <ID>.
+
+## Options:
+	-f, --format <FORMAT>
+		Pretty print the list of deploy keys using format <FORMAT> (default:
+		"%>(10)%I  %t (%R)%n"). See the "PRETTY FORMATS" section of git-log(1) for
+		some additional details on how placeholders are used in format. The
+		available placeholders are:
+
+		%I: deploy key ID
+
+		%t: title
+
+		%K: the public key
+
+		%R: access level (i.e. "read-only", "read-write")
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%n: newline
+
+		%%: a literal %
+
+	--read-only
+		Only allow the key to read from the repository. By default, deploy keys
+		have write access.
+
+	-t, --title <TITLE>
+		The title of the deploy key (default: the comment of the public key or the
+		file name of <KEY-FILE>).
+
+## Examples:
+		$ hub deploy-key add --read-only -t ci ~/.ssh/ci_deploy.pub
+		$ hub deploy-key list
+		$ hub deploy-key remove 123456
+
+## See also:
+
+hub(1), ssh-keygen(1)
+`,
+	}
+
+	cmdListDeployKeys = &Command{
+		Key: "list",
+		Run: listDeployKeys,
+		KnownFlags: `
+		-f, --format FMT
+`,
+	}
+
+	cmdAddDeployKey = &Command{
+		Key: "add",
+		Run: addDeployKey,
+		KnownFlags: `
+		--read-only
+		-t, --title TITLE
+`,
+	}
+
+	cmdRemoveDeployKey = &Command{
+		Key: "remove",
+		Run: removeDeployKey,
+	}
+)
+
+func init() {
+	cmdDeployKey.Use(cmdListDeployKeys)
+	cmdDeployKey.Use(cmdAddDeployKey)
+	cmdDeployKey.Use(cmdRemoveDeployKey)
+	CmdRunner.Use(cmdDeployKey)
+}
+
+func listDeployKeys(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of deploy keys for %s\n", project)
+		return
+	}
+
+	keys, err := gh.FetchDeployKeys(project)
+	utils.Check(err)
+
+	flagDeployKeyFormat := "%>(10)%I  %t (%R)%n"
+	if args.Flag.HasReceived("--format") {
+		flagDeployKeyFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(false, "")
+	for _, key := range keys {
+		ui.Print(formatDeployKey(key, flagDeployKeyFormat, colorize))
+	}
+}
+
+func formatDeployKey(key github.DeployKey, format string, colorize bool) string {
+	access := "read-write"
+	if key.ReadOnly {
+		access = "read-only"
+	}
+
+	var createdDate, createdAtISO8601, createdAtRelative string
+	if !key.CreatedAt.IsZero() {
+		createdDate = key.CreatedAt.Format("02 Jan 2006")
+		createdAtISO8601 = key.CreatedAt.Format(time.RFC3339)
+		createdAtRelative = utils.TimeAgo(key.CreatedAt)
+	}
+
+	placeholders := map[string]string{
+		"I":  strconv.Itoa(key.ID),
+		"t":  key.Title,
+		"K":  key.Key,
+		"R":  access,
+		"cD": createdDate,
+		"cI": createdAtISO8601,
+		"cr": createdAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func addDeployKey(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	keyFile := args.GetParam(0)
+
+	content, err := msgFromFile(keyFile)
+	utils.Check(err)
+	publicKey := strings.TrimSpace(content)
+	if publicKey == "" {
+		utils.Check(fmt.Errorf("error: no public key found in %s", keyFile))
+	}
+
+	title := args.Flag.Value("--title")
+	if title == "" {
+		title = deployKeyTitle(publicKey, keyFile)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would add deploy key `%s' to %s\n", title, project)
+		return
+	}
+
+	key, err := gh.CreateDeployKey(project, map[string]interface{}{
+		"title":     title,
+		"key":       publicKey,
+		"read_only": args.Flag.Bool("--read-only"),
+	})
+	utils.Check(err)
+
+	ui.Println(key.ID)
+}
+
+// deployKeyTitle picks the comment field of an OpenSSH public key, falling
+// back to the name of the file the key was read from.
+func deployKeyTitle(publicKey, keyFile string) string {
+	if fields := strings.Fields(publicKey); len(fields) > 2 {
+		return strings.Join(fields[2:], " ")
+	}
+	if keyFile != "-" {
+		return strings.TrimSuffix(filepath.Base(keyFile), ".pub")
+	}
+	return "hub"
+}
+
+func removeDeployKey(cmd *Command, args *Args) {
+	keyID := 0
+	if args.ParamsSize() > 0 {
+		keyID, _ = strconv.Atoi(args.GetParam(0))
+	}
+	if keyID == 0 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove deploy key %d from %s\n", keyID, project)
+		return
+	}
+
+	utils.Check(gh.DeleteDeployKey(project, keyID))
+}
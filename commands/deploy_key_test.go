@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestDeployKeyTitle(t *testing.T) {
+	assert.Equal(t, "ci@example.com", deployKeyTitle("ssh-ed25519 AAAAC3Nza ci@example.com", "/home/ci/.ssh/id_ed25519.pub"))
+	assert.Equal(t, "deploy key 2", deployKeyTitle("ssh-rsa AAAAB3Nza deploy key 2", "-"))
+	assert.Equal(t, "id_ed25519", deployKeyTitle("ssh-ed25519 AAAAC3Nza", "/home/ci/.ssh/id_ed25519.pub"))
+	assert.Equal(t, "hub", deployKeyTitle("ssh-ed25519 AAAAC3Nza", "-"))
+}
@@ -0,0 +1,351 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdDeployment = &Command{
+		Run: printHelp,
+		Usage: `
+deployment list [-e <ENVIRONMENT>] [-r <REF>] [-L <LIMIT>] [-f <FORMAT>] [--color]
+deployment create [-e <ENVIRONMENT>] [-r <REF>] [-d <DESCRIPTION>] [--auto-merge] [--skip-checks]
+deployment status [-d <DESCRIPTION>] [-u <URL>] [--environment-url <URL>] <ID> [<STATE>]
+`,
+		Long: `Manage deployments of the current repository.
+
+## Commands:
+
+	* _list_:
+		List deployments, most recent first.
+
+	* _create_:
+		Create a deployment for <REF> and print its ID.
+
+	* _status_:
+		Report the <STATE> of deployment <ID>. Without <STATE>, show the statuses
+		reported for the deployment so far. <STATE> is one of "queued", "pending",
+		"in_progress", "success", "failure", "error", or "inactive".
+
+## Options:
+	-e, --env <ENVIRONMENT>
+		The name of the target environment (default for creating: "production").
+
+	-r, --ref <REF>
+		The branch, tag, or commit SHA to deploy (default for creating: the commit
+		at HEAD).
+
+	-d, --description <DESCRIPTION>
+		A short description of the deployment or of its status.
+
+	--auto-merge
+		Merge the default branch into <REF> before deploying if it is behind.
+
+	--skip-checks
+		Create the deployment even if commit statuses for <REF> are not passing.
+
+	-u, --log-url <URL>
+		The URL of the deployment output.
+
+	--environment-url <URL>
+		The URL at which the deployed environment can be accessed.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> deployments.
+
+	-f, --format <FORMAT>
+		Pretty print the list of deployments using format <FORMAT> (default:
+		"%>(10)%I  %<(12)%e  %sh  %cr%n"). See the "PRETTY FORMATS" section of
+		git-log(1) for some additional details on how placeholders are used in
+		format. The available placeholders are:
+
+		%I: deployment ID
+
+		%e: environment
+
+		%r: the ref that was deployed
+
+		%sH: commit SHA
+
+		%sh: abbreviated commit SHA
+
+		%d: description
+
+		%au: login name of the creator
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+## Examples:
+		$ id="$(hub deployment create -e staging -r v2.1.0)"
+		$ hub deployment status "$id" in_progress
+		$ hub deployment status -u "$BUILD_URL" "$id" success
+
+## See also:
+
+hub-ci-status(1), hub(1)
+`,
+	}
+
+	cmdListDeployments = &Command{
+		Key: "list",
+		Run: listDeployments,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		-r, --ref REF
+		-L, --limit N
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdCreateDeployment = &Command{
+		Key: "create",
+		Run: createDeployment,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		-r, --ref REF
+		-d, --description DESCRIPTION
+		--auto-merge
+		--skip-checks
+`,
+	}
+
+	cmdDeploymentStatus = &Command{
+		Key: "status",
+		Run: deploymentStatus,
+		KnownFlags: `
+		-d, --description DESCRIPTION
+		-u, --log-url URL
+		--environment-url URL
+`,
+	}
+
+	deploymentStates = []string{
+		"queued",
+		"pending",
+		"in_progress",
+		"success",
+		"failure",
+		"error",
+		"inactive",
+	}
+)
+
+func init() {
+	cmdDeployment.Use(cmdListDeployments)
+	cmdDeployment.Use(cmdCreateDeployment)
+	cmdDeployment.Use(cmdDeploymentStatus)
+	CmdRunner.Use(cmdDeployment)
+}
+
+func listDeployments(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of deployments for %s\n", project)
+		return
+	}
+
+	filters := map[string]interface{}{}
+	if args.Flag.HasReceived("--env") {
+		filters["environment"] = args.Flag.Value("--env")
+	}
+	if args.Flag.HasReceived("--ref") {
+		filters["ref"] = args.Flag.Value("--ref")
+	}
+
+	deployments, err := gh.FetchDeployments(project, filters, args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	flagDeploymentFormat := "%>(10)%I  %<(12)%e  %sh  %cr%n"
+	if args.Flag.HasReceived("--format") {
+		flagDeploymentFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, deployment := range deployments {
+		ui.Print(formatDeployment(deployment, flagDeploymentFormat, colorize))
+	}
+}
+
+func formatDeployment(deployment github.Deployment, format string, colorize bool) string {
+	shortSHA := deployment.SHA
+	if len(shortSHA) > 7 {
+		shortSHA = shortSHA[:7]
+	}
+
+	creator := ""
+	if deployment.Creator != nil {
+		creator = deployment.Creator.Login
+	}
+
+	var createdDate, createdAtISO8601, createdAtRelative string
+	if !deployment.CreatedAt.IsZero() {
+		createdDate = deployment.CreatedAt.Format("02 Jan 2006")
+		createdAtISO8601 = deployment.CreatedAt.Format(time.RFC3339)
+		createdAtRelative = utils.TimeAgo(deployment.CreatedAt)
+	}
+
+	placeholders := map[string]string{
+		"I":  strconv.Itoa(deployment.ID),
+		"e":  deployment.Environment,
+		"r":  deployment.Ref,
+		"sH": deployment.SHA,
+		"sh": shortSHA,
+		"d":  deployment.Description,
+		"au": creator,
+		"cD": createdDate,
+		"cI": createdAtISO8601,
+		"cr": createdAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func createDeployment(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	ref := args.Flag.Value("--ref")
+	if ref == "" {
+		ref, err = git.Ref("HEAD")
+		if err != nil {
+			err = fmt.Errorf("Aborted: no revision could be determined from 'HEAD'")
+		}
+		utils.Check(err)
+	}
+
+	environment := "production"
+	if args.Flag.HasReceived("--env") {
+		environment = args.Flag.Value("--env")
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create deployment of %s to %s\n", ref, environment)
+		return
+	}
+
+	params := map[string]interface{}{
+		"ref":         ref,
+		"environment": environment,
+		"auto_merge":  args.Flag.Bool("--auto-merge"),
+	}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+	if args.Flag.Bool("--skip-checks") {
+		params["required_contexts"] = []string{}
+	}
+
+	deployment, err := gh.CreateDeployment(project, params)
+	utils.Check(err)
+
+	ui.Println(deployment.ID)
+}
+
+func deploymentStatus(cmd *Command, args *Args) {
+	deploymentID := 0
+	if args.ParamsSize() > 0 {
+		deploymentID, _ = strconv.Atoi(args.GetParam(0))
+	}
+	if deploymentID == 0 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	state := ""
+	if args.ParamsSize() > 1 {
+		state = args.GetParam(1)
+		valid := false
+		for _, s := range deploymentStates {
+			if s == state {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			utils.Check(fmt.Errorf("error: invalid deployment state '%s'", state))
+		}
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+
+	if state == "" {
+		if args.Noop {
+			ui.Printf("Would request statuses of deployment %d\n", deploymentID)
+			return
+		}
+
+		statuses, err := gh.FetchDeploymentStatuses(project, deploymentID)
+		utils.Check(err)
+
+		for _, status := range statuses {
+			line := fmt.Sprintf("%-12s %s", status.State, utils.TimeAgo(status.CreatedAt))
+			if status.Description != "" {
+				line += "  " + status.Description
+			}
+			ui.Println(line)
+		}
+		return
+	}
+
+	if args.Noop {
+		ui.Printf("Would set status of deployment %d to %s\n", deploymentID, state)
+		return
+	}
+
+	params := map[string]interface{}{
+		"state": state,
+	}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+	if args.Flag.HasReceived("--log-url") {
+		params["log_url"] = args.Flag.Value("--log-url")
+	}
+	if args.Flag.HasReceived("--environment-url") {
+		params["environment_url"] = args.Flag.Value("--environment-url")
+	}
+
+	_, err = gh.CreateDeploymentStatus(project, deploymentID, params)
+	utils.Check(err)
+}
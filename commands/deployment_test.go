@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatDeployment(t *testing.T) {
+	deployment := github.Deployment{
+		ID:          42,
+		SHA:         "a5f3ff1b8c4d1f5b2e0ad4c7b3e8f0a1b2c3d4e5",
+		Ref:         "v2.1.0",
+		Environment: "staging",
+		Creator:     &github.User{Login: "mislav"},
+		CreatedAt:   time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, "42 staging v2.1.0 a5f3ff1 mislav 01 Oct 2026\n",
+		formatDeployment(deployment, "%I %e %r %sh %au %cD%n", false))
+	assert.Equal(t, "        42  staging       a5f3ff1",
+		formatDeployment(deployment, "%>(10)%I  %<(12)%e  %sh", false))
+}
@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdDigest = &Command{
+	Run:   digest,
+	Usage: "digest [--org <ORG>] [--stale-days <DAYS>]",
+	Long: `Print a report of what needs your attention on GitHub.
+
+The report lists open pull requests awaiting your review, your own open pull
+requests whose checks are failing, and your branches in the current repository
+that haven't seen a commit in a while. It is plain text meant to be read in a
+terminal or piped from a cron job into an email or chat message.
+
+## Options:
+	--org <ORG>
+		Only include pull requests from repositories owned by <ORG>.
+
+	--stale-days <DAYS>
+		Report branches whose last commit is older than <DAYS> days (default: 30).
+		Stale branches are only looked up when run inside a git repository.
+
+## Examples:
+		$ hub digest --org github
+		Digest for mislav
+
+		Awaiting your review (1):
+		  github/hub#1234  Add digest command (octocat, 2d)
+
+		Your pull requests with failing checks (0)
+
+		Stale branches in github/hub (1):
+		  old-experiment (45d)
+
+		$ hub digest | mail -s "GitHub digest" me@example.com
+
+## See also:
+
+hub-status(1), hub-pr(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdDigest)
+}
+
+// digestPullRequest is a pull request as returned by the searches in digest.
+type digestPullRequest struct {
+	Number     int
+	Title      string
+	CreatedAt  time.Time
+	Author     struct{ Login string }
+	Repository struct{ NameWithOwner string }
+}
+
+// digestBranch is a branch as returned by the query in digest.
+type digestBranch struct {
+	Name   string
+	Target struct {
+		CommittedDate time.Time
+		Author        struct {
+			User *struct{ Login string }
+		}
+	}
+}
+
+// digestReport holds the sections of the report printed by digest.
+type digestReport struct {
+	Login          string
+	ReviewRequests []digestPullRequest
+	FailingPulls   []digestPullRequest
+	Project        *github.Project
+	StaleBranches  []digestBranch
+}
+
+func digest(cmd *Command, args *Args) {
+	staleDays := 30
+	if args.Flag.HasReceived("--stale-days") {
+		var err error
+		staleDays, err = strconv.Atoi(args.Flag.Value("--stale-days"))
+		if err != nil || staleDays < 1 {
+			utils.Check(fmt.Errorf("error: invalid number of days '%s'", args.Flag.Value("--stale-days")))
+		}
+	}
+
+	project, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would compile a digest from %s\n", host)
+		return
+	}
+
+	filter := "is:pr is:open archived:false"
+	if org := args.Flag.Value("--org"); org != "" {
+		filter += " org:" + org
+	}
+
+	response := struct {
+		Viewer         struct{ Login string }
+		ReviewRequests struct{ Nodes []digestPullRequest }
+		FailingPulls   struct{ Nodes []digestPullRequest }
+	}{}
+	err := gh.GraphQL(`
+	query($reviewQuery: String!, $failingQuery: String!) {
+		viewer {
+			login
+		}
+		reviewRequests: search(query: $reviewQuery, type: ISSUE, first: 100) {
+			nodes {
+				...digestPullRequest
+			}
+		}
+		failingPulls: search(query: $failingQuery, type: ISSUE, first: 100) {
+			nodes {
+				...digestPullRequest
+			}
+		}
+	}
+	fragment digestPullRequest on PullRequest {
+		number
+		title
+		createdAt
+		author {
+			login
+		}
+		repository {
+			nameWithOwner
+		}
+	}`, map[string]interface{}{
+		"reviewQuery":  filter + " review-requested:@me",
+		"failingQuery": filter + " author:@me status:failure",
+	}, &response)
+	utils.Check(err)
+
+	report := digestReport{
+		Login:          response.Viewer.Login,
+		ReviewRequests: response.ReviewRequests.Nodes,
+		FailingPulls:   response.FailingPulls.Nodes,
+	}
+
+	if project != nil {
+		branches := struct {
+			Repository struct {
+				DefaultBranchRef struct{ Name string }
+				Refs             struct{ Nodes []digestBranch }
+			}
+		}{}
+		err = gh.GraphQL(`
+		query($owner: String!, $repo: String!) {
+			repository(owner: $owner, name: $repo) {
+				defaultBranchRef {
+					name
+				}
+				refs(refPrefix: "refs/heads/", first: 100) {
+					nodes {
+						name
+						target {
+							... on Commit {
+								committedDate
+								author {
+									user {
+										login
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}`, map[string]interface{}{
+			"owner": project.Owner,
+			"repo":  project.Name,
+		}, &branches)
+		utils.Check(err)
+
+		report.Project = project
+		cutoff := time.Now().AddDate(0, 0, -staleDays)
+		for _, branch := range branches.Repository.Refs.Nodes {
+			author := branch.Target.Author.User
+			if branch.Name == branches.Repository.DefaultBranchRef.Name || author == nil || !strings.EqualFold(author.Login, report.Login) {
+				continue
+			}
+			if branch.Target.CommittedDate.Before(cutoff) {
+				report.StaleBranches = append(report.StaleBranches, branch)
+			}
+		}
+	}
+
+	ui.Print(formatDigest(report, time.Now()))
+}
+
+// formatDigest renders the report with the age of each item relative to now.
+func formatDigest(report digestReport, now time.Time) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "Digest for %s\n", report.Login)
+
+	pullsSection := func(title string, pulls []digestPullRequest) {
+		fmt.Fprintf(&out, "\n%s (%d)", title, len(pulls))
+		if len(pulls) == 0 {
+			out.WriteString("\n")
+			return
+		}
+		out.WriteString(":\n")
+		for _, pr := range pulls {
+			fmt.Fprintf(&out, "  %s#%d  %s (%s, %s)\n", pr.Repository.NameWithOwner, pr.Number, pr.Title, pr.Author.Login, digestAge(now, pr.CreatedAt))
+		}
+	}
+	pullsSection("Awaiting your review", report.ReviewRequests)
+	pullsSection("Your pull requests with failing checks", report.FailingPulls)
+
+	if report.Project != nil {
+		fmt.Fprintf(&out, "\nStale branches in %s (%d)", report.Project, len(report.StaleBranches))
+		if len(report.StaleBranches) == 0 {
+			out.WriteString("\n")
+		} else {
+			out.WriteString(":\n")
+			for _, branch := range report.StaleBranches {
+				fmt.Fprintf(&out, "  %s (%s)\n", branch.Name, digestAge(now, branch.Target.CommittedDate))
+			}
+		}
+	}
+
+	return out.String()
+}
+
+// digestAge renders the time elapsed since t in whole days, or in hours for
+// the first day.
+func digestAge(now, t time.Time) string {
+	hours := int(now.Sub(t).Hours())
+	if hours < 24 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dd", hours/24)
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatDigest(t *testing.T) {
+	now := time.Date(2020, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	pr := digestPullRequest{Number: 12, Title: "Add feature", CreatedAt: now.Add(-50 * time.Hour)}
+	pr.Author.Login = "octocat"
+	pr.Repository.NameWithOwner = "github/hub"
+
+	branch := digestBranch{Name: "old-experiment"}
+	branch.Target.CommittedDate = now.AddDate(0, 0, -45)
+
+	report := digestReport{
+		Login:          "mislav",
+		ReviewRequests: []digestPullRequest{pr},
+		Project:        github.NewProject("github", "hub", "github.com"),
+		StaleBranches:  []digestBranch{branch},
+	}
+	assert.Equal(t, `Digest for mislav
+
+Awaiting your review (1):
+  github/hub#12  Add feature (octocat, 2d)
+
+Your pull requests with failing checks (0)
+
+Stale branches in github/hub (1):
+  old-experiment (45d)
+`, formatDigest(report, now))
+
+	report.Project = nil
+	report.ReviewRequests = nil
+	pr.CreatedAt = now.Add(-5 * time.Hour)
+	report.FailingPulls = []digestPullRequest{pr}
+	assert.Equal(t, `Digest for mislav
+
+Awaiting your review (0)
+
+Your pull requests with failing checks (1):
+  github/hub#12  Add feature (octocat, 5h)
+`, formatDigest(report, now))
+}
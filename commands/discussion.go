@@ -0,0 +1,671 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdDiscussion = &Command{
+		Run: printHelp,
+		Usage: `
+discussion list [--category <CATEGORY>] [-L <LIMIT>] [-f <FORMAT>] [--color]
+discussion view <NUMBER>
+discussion create --category <CATEGORY> [-m <MESSAGE>|-F <FILE>] [--edit] [-o] [-c]
+discussion comment [-m <MESSAGE>|-F <FILE>] [--edit] <NUMBER>
+`,
+		Long: `Manage GitHub Discussions for the current repository.
+
+## Commands:
+
+	* _list_:
+		List the most recently updated discussions of the current repository.
+
+	* _view_:
+		Show the title, body, and comments of the discussion <NUMBER>.
+
+	* _create_:
+		Open a new discussion in <CATEGORY> and print its URL.
+
+	* _comment_:
+		Add a comment to the discussion <NUMBER> and print the URL of the comment.
+
+## Options:
+	--category <CATEGORY>
+		The name of a discussion category, such as "Q&A" or "Ideas". Only list
+		discussions in <CATEGORY>, or open the new discussion in <CATEGORY>.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> discussions, up to 100 (default: 30).
+
+	-f, --format <FORMAT>
+		Pretty print the list of discussions using format <FORMAT> (default:
+		"%sC%>(8)%i%Creset  %t  (%C)%n"). See the "PRETTY FORMATS" section of
+		git-log(1) for some additional details on how placeholders are used in
+		format. The available placeholders are:
+
+		%I: discussion number
+
+		%i: discussion number prefixed with "#"
+
+		%U: the URL of this discussion
+
+		%S: state (i.e. "open", "answered", "closed")
+
+		%sC: set color to green, magenta, or red, depending on state
+
+		%t: title
+
+		%C: category name
+
+		%b: body
+
+		%au: login name of author
+
+		%NC: number of comments
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%uD: last updated date-only (no time of day)
+
+		%ur: last updated date, relative
+
+		%uI: last updated date, ISO 8601 format
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+	-m, --message <MESSAGE>
+		The text up to the first blank line in <MESSAGE> is treated as the
+		discussion title, and the rest is used as the body. For ''comment'', the
+		whole <MESSAGE> is the body of the comment.
+
+		When multiple ''--message'' are passed, their values are concatenated with a
+		blank line in-between.
+
+		When neither ''--message'' nor ''--file'' were supplied, a text editor will
+		open to author the text.
+
+	-F, --file <FILE>
+		Read the text from <FILE>. Pass "-" to read from standard input instead.
+		See ''--message'' for the formatting rules.
+
+	-e, --edit
+		Open the text in a text editor before submitting. This can be used in
+		combination with ''--message'' or ''--file''.
+
+	-o, --browse
+		Open the new discussion in a web browser.
+
+	-c, --copy
+		Put the URL of the new discussion to clipboard instead of printing it.
+
+## Examples:
+		$ hub discussion list --category "Q&A"
+		     #42  How do I configure hub for GitHub Enterprise?  (Q&A)
+
+		$ hub discussion create --category Ideas -m "Support for GitLab"
+		$ hub discussion comment -m "Have you tried ''hub api''?" 42
+
+## See also:
+
+hub-issue(1), hub(1)
+`,
+	}
+
+	cmdListDiscussions = &Command{
+		Key: "list",
+		Run: listDiscussions,
+		KnownFlags: `
+		--category CATEGORY
+		-L, --limit N
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdViewDiscussion = &Command{
+		Key: "view",
+		Run: viewDiscussion,
+	}
+
+	cmdCreateDiscussion = &Command{
+		Key: "create",
+		Run: createDiscussion,
+		KnownFlags: `
+		--category CATEGORY
+		-m, --message MSG
+		-F, --file FILE
+		-e, --edit
+		-o, --browse
+		-c, --copy
+`,
+	}
+
+	cmdCommentDiscussion = &Command{
+		Key: "comment",
+		Run: commentDiscussion,
+		KnownFlags: `
+		-m, --message MSG
+		-F, --file FILE
+		-e, --edit
+`,
+	}
+)
+
+func init() {
+	cmdDiscussion.Use(cmdListDiscussions)
+	cmdDiscussion.Use(cmdViewDiscussion)
+	cmdDiscussion.Use(cmdCreateDiscussion)
+	cmdDiscussion.Use(cmdCommentDiscussion)
+	CmdRunner.Use(cmdDiscussion)
+}
+
+type discussionCategory struct {
+	ID   string
+	Name string
+	Slug string
+}
+
+type discussion struct {
+	ID         string
+	Number     int
+	Title      string
+	Body       string
+	URL        string
+	Closed     bool
+	IsAnswered bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Author     struct {
+		Login string
+	}
+	Category struct {
+		Name string
+	}
+	Comments struct {
+		TotalCount int
+	}
+}
+
+type discussionComment struct {
+	Body      string
+	CreatedAt time.Time
+	Author    struct {
+		Login string
+	}
+}
+
+func (d discussion) state() string {
+	if d.Closed {
+		return "closed"
+	} else if d.IsAnswered {
+		return "answered"
+	}
+	return "open"
+}
+
+// fetchDiscussionCategories returns the GraphQL node ID of the repository
+// along with the discussion categories defined in it.
+func fetchDiscussionCategories(gh *github.Client, project *github.Project) (repoID string, categories []discussionCategory, err error) {
+	response := struct {
+		Repository struct {
+			ID                   string
+			DiscussionCategories struct {
+				Nodes []discussionCategory
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!, $repo: String!) {
+		repository(owner: $owner, name: $repo) {
+			id
+			discussionCategories(first: 100) {
+				nodes {
+					id
+					name
+					slug
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner": project.Owner,
+		"repo":  project.Name,
+	}, &response)
+	if err != nil {
+		return
+	}
+
+	return response.Repository.ID, response.Repository.DiscussionCategories.Nodes, nil
+}
+
+// findDiscussionCategory looks up a category by its name or slug, ignoring
+// case.
+func findDiscussionCategory(categories []discussionCategory, name string) (*discussionCategory, error) {
+	names := []string{}
+	for i, c := range categories {
+		if strings.EqualFold(c.Name, name) || strings.EqualFold(c.Slug, name) {
+			return &categories[i], nil
+		}
+		names = append(names, c.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("error: discussions are not enabled for this repository")
+	}
+	return nil, fmt.Errorf("error: no discussion category named '%s'\nAvailable categories: %s", name, strings.Join(names, ", "))
+}
+
+func listDiscussions(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of discussions for %s\n", project)
+		return
+	}
+
+	limit := 30
+	if args.Flag.HasReceived("--limit") {
+		limit = args.Flag.Int("--limit")
+	}
+	if limit < 1 || limit > 100 {
+		limit = 100
+	}
+
+	var categoryID interface{}
+	if args.Flag.HasReceived("--category") {
+		_, categories, err := fetchDiscussionCategories(gh, project)
+		utils.Check(err)
+		category, err := findDiscussionCategory(categories, args.Flag.Value("--category"))
+		utils.Check(err)
+		categoryID = category.ID
+	}
+
+	response := struct {
+		Repository struct {
+			Discussions struct {
+				Nodes []discussion
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!, $repo: String!, $limit: Int!, $category: ID) {
+		repository(owner: $owner, name: $repo) {
+			discussions(first: $limit, categoryId: $category, orderBy: {field: UPDATED_AT, direction: DESC}) {
+				nodes {
+					number
+					title
+					body
+					url
+					closed
+					isAnswered
+					createdAt
+					updatedAt
+					author {
+						login
+					}
+					category {
+						name
+					}
+					comments {
+						totalCount
+					}
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner":    project.Owner,
+		"repo":     project.Name,
+		"limit":    limit,
+		"category": categoryID,
+	}, &response)
+	utils.Check(err)
+
+	flagDiscussionFormat := "%sC%>(8)%i%Creset  %t  (%C)%n"
+	if args.Flag.HasReceived("--format") {
+		flagDiscussionFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, d := range response.Repository.Discussions.Nodes {
+		ui.Print(formatDiscussion(d, flagDiscussionFormat, colorize))
+	}
+}
+
+func formatDiscussion(d discussion, format string, colorize bool) string {
+	state := d.state()
+
+	var stateColorSwitch string
+	if colorize {
+		color := 32
+		switch state {
+		case "closed":
+			color = 31
+		case "answered":
+			color = 35
+		}
+		stateColorSwitch = fmt.Sprintf("\033[%dm", color)
+	}
+
+	var createdDate, createdAtISO8601, createdAtRelative,
+		updatedDate, updatedAtISO8601, updatedAtRelative string
+	if !d.CreatedAt.IsZero() {
+		createdDate = d.CreatedAt.Format("02 Jan 2006")
+		createdAtISO8601 = d.CreatedAt.Format(time.RFC3339)
+		createdAtRelative = utils.TimeAgo(d.CreatedAt)
+	}
+	if !d.UpdatedAt.IsZero() {
+		updatedDate = d.UpdatedAt.Format("02 Jan 2006")
+		updatedAtISO8601 = d.UpdatedAt.Format(time.RFC3339)
+		updatedAtRelative = utils.TimeAgo(d.UpdatedAt)
+	}
+
+	placeholders := map[string]string{
+		"I":  strconv.Itoa(d.Number),
+		"i":  fmt.Sprintf("#%d", d.Number),
+		"U":  d.URL,
+		"S":  state,
+		"sC": stateColorSwitch,
+		"t":  d.Title,
+		"C":  d.Category.Name,
+		"b":  d.Body,
+		"au": d.Author.Login,
+		"NC": strconv.Itoa(d.Comments.TotalCount),
+		"cD": createdDate,
+		"cI": createdAtISO8601,
+		"cr": createdAtRelative,
+		"uD": updatedDate,
+		"uI": updatedAtISO8601,
+		"ur": updatedAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func fetchDiscussion(gh *github.Client, project *github.Project, number int, withComments bool) (*discussion, []discussionComment, error) {
+	response := struct {
+		Repository struct {
+			Discussion *struct {
+				discussion
+				CommentList struct {
+					Nodes []discussionComment
+				}
+			}
+		}
+	}{}
+	err := gh.GraphQL(`
+	query($owner: String!, $repo: String!, $number: Int!, $comments: Int!) {
+		repository(owner: $owner, name: $repo) {
+			discussion(number: $number) {
+				id
+				number
+				title
+				body
+				url
+				closed
+				isAnswered
+				createdAt
+				author {
+					login
+				}
+				category {
+					name
+				}
+				comments {
+					totalCount
+				}
+				commentList: comments(first: $comments) {
+					nodes {
+						body
+						createdAt
+						author {
+							login
+						}
+					}
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner":    project.Owner,
+		"repo":     project.Name,
+		"number":   number,
+		"comments": map[bool]int{true: 100, false: 0}[withComments],
+	}, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := response.Repository.Discussion
+	if d == nil {
+		return nil, nil, fmt.Errorf("error: no discussion found with number %d in %s", number, project)
+	}
+	return &d.discussion, d.CommentList.Nodes, nil
+}
+
+func discussionNumberParam(cmd *Command, args *Args) int {
+	number := 0
+	if args.ParamsSize() > 0 {
+		number, _ = strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	}
+	if number == 0 {
+		utils.Check(cmd.UsageError(""))
+	}
+	return number
+}
+
+func viewDiscussion(cmd *Command, args *Args) {
+	number := discussionNumberParam(cmd, args)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would display discussion #%d for %s\n", number, project)
+		return
+	}
+
+	d, comments, err := fetchDiscussion(gh, project, number, true)
+	utils.Check(err)
+
+	state := ""
+	if d.Closed {
+		state = "[CLOSED] "
+	} else if d.IsAnswered {
+		state = "[ANSWERED] "
+	}
+	emoji := func(text string) string { return text }
+	if ui.IsTerminal(os.Stdout) {
+		emoji = emojiExpander(gh)
+	}
+
+	ui.Printf("# %s%s\n\n", state, emoji(d.Title))
+	ui.Printf("* created by @%s on %s\n", d.Author.Login, d.CreatedAt.String())
+	ui.Printf("* category: %s\n", d.Category.Name)
+	ui.Printf("* %s\n", d.URL)
+
+	ui.Printf("\n%s\n", emoji(d.Body))
+
+	if len(comments) > 0 {
+		ui.Printf("\n## Comments:\n")
+		for _, comment := range comments {
+			ui.Printf("\n### comment by @%s on %s\n\n%s\n", comment.Author.Login, comment.CreatedAt.String(), emoji(comment.Body))
+		}
+	}
+}
+
+func createDiscussion(cmd *Command, args *Args) {
+	if !args.Flag.HasReceived("--category") {
+		utils.Check(cmd.UsageError("missing --category"))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	messageBuilder := &github.MessageBuilder{
+		Filename: "DISCUSSION_EDITMSG",
+		Title:    "discussion",
+	}
+
+	messageBuilder.AddCommentedSection(fmt.Sprintf(`Creating a discussion for %s
+
+Write a message for this discussion. The first block of
+text is the title and the rest is the description.`, project))
+
+	utils.Check(discussionMessageFromArgs(messageBuilder, args))
+
+	title, body, err := messageBuilder.Extract()
+	utils.Check(err)
+
+	if title == "" {
+		utils.Check(fmt.Errorf("Aborting creation due to empty discussion title"))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create discussion `%s' for %s\n", title, project)
+		return
+	}
+
+	repoID, categories, err := fetchDiscussionCategories(gh, project)
+	utils.Check(err)
+	category, err := findDiscussionCategory(categories, args.Flag.Value("--category"))
+	utils.Check(err)
+
+	response := struct {
+		CreateDiscussion struct {
+			Discussion struct {
+				URL string
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	mutation($input: CreateDiscussionInput!) {
+		createDiscussion(input: $input) {
+			discussion {
+				url
+			}
+		}
+	}`, map[string]interface{}{
+		"input": map[string]interface{}{
+			"repositoryId": repoID,
+			"categoryId":   category.ID,
+			"title":        title,
+			"body":         body,
+		},
+	}, &response)
+	utils.Check(err)
+
+	printBrowseOrCopy(args, response.CreateDiscussion.Discussion.URL, args.Flag.Bool("--browse"), args.Flag.Bool("--copy"))
+
+	messageBuilder.Cleanup()
+}
+
+// discussionMessageFromArgs fills in the message from the --message or --file
+// flags, falling back to opening a text editor.
+func discussionMessageFromArgs(messageBuilder *github.MessageBuilder, args *Args) (err error) {
+	flagMessage := args.Flag.AllValues("--message")
+	if len(flagMessage) > 0 {
+		messageBuilder.Message = strings.Join(flagMessage, "\n\n")
+		messageBuilder.Edit = args.Flag.Bool("--edit")
+	} else if args.Flag.HasReceived("--file") {
+		messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+		messageBuilder.Edit = args.Flag.Bool("--edit")
+	} else {
+		messageBuilder.Edit = true
+	}
+	return
+}
+
+func commentDiscussion(cmd *Command, args *Args) {
+	number := discussionNumberParam(cmd, args)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	messageBuilder := &github.MessageBuilder{
+		Filename: "DISCUSSION_COMMENT_EDITMSG",
+		Title:    "comment",
+	}
+
+	messageBuilder.AddCommentedSection(fmt.Sprintf(`Commenting on discussion #%d in %s`, number, project))
+
+	utils.Check(discussionMessageFromArgs(messageBuilder, args))
+
+	body, err := messageBuilder.ExtractBody()
+	utils.Check(err)
+
+	if body == "" {
+		utils.Check(fmt.Errorf("Aborting due to empty comment"))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would comment on discussion #%d for %s\n", number, project)
+		return
+	}
+
+	d, _, err := fetchDiscussion(gh, project, number, false)
+	utils.Check(err)
+
+	response := struct {
+		AddDiscussionComment struct {
+			Comment struct {
+				URL string
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	mutation($discussion: ID!, $body: String!) {
+		addDiscussionComment(input: {discussionId: $discussion, body: $body}) {
+			comment {
+				url
+			}
+		}
+	}`, map[string]interface{}{
+		"discussion": d.ID,
+		"body":       body,
+	}, &response)
+	utils.Check(err)
+
+	ui.Println(response.AddDiscussionComment.Comment.URL)
+
+	messageBuilder.Cleanup()
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFindDiscussionCategory(t *testing.T) {
+	categories := []discussionCategory{
+		{ID: "C1", Name: "General", Slug: "general"},
+		{ID: "C2", Name: "Q&A", Slug: "q-a"},
+	}
+
+	category, err := findDiscussionCategory(categories, "q&a")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "C2", category.ID)
+
+	category, err = findDiscussionCategory(categories, "q-a")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "C2", category.ID)
+
+	_, err = findDiscussionCategory(categories, "Ideas")
+	assert.Equal(t, "error: no discussion category named 'Ideas'\nAvailable categories: General, Q&A", err.Error())
+
+	_, err = findDiscussionCategory(nil, "Ideas")
+	assert.Equal(t, "error: discussions are not enabled for this repository", err.Error())
+}
+
+func TestFormatDiscussion(t *testing.T) {
+	d := discussion{Number: 42, Title: "How do I?", IsAnswered: true}
+	d.Category.Name = "Q&A"
+	d.Author.Login = "mislav"
+	d.Comments.TotalCount = 3
+
+	assert.Equal(t, "     #42  How do I?  (Q&A)\n", formatDiscussion(d, "%sC%>(8)%i%Creset  %t  (%C)%n", false))
+	assert.Equal(t, "42 answered mislav 3", formatDiscussion(d, "%I %S %au %NC", false))
+
+	d.Closed = true
+	assert.Equal(t, "closed", d.state())
+}
@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdEnvironment = &Command{
+		Run: printHelp,
+		Usage: `
+env list
+env create [-r <REVIEWERS>] [-w <MINUTES>] [--protected-branches | -b <PATTERN>...] <NAME>
+env edit [-r <REVIEWERS>] [-w <MINUTES>] [--protected-branches | -b <PATTERN>... | --all-branches] <NAME>
+`,
+		Long: `Manage deployment environments of the current repository.
+
+## Commands:
+
+	* _list_:
+		List environments along with their protection rules.
+
+	* _create_:
+		Create the environment <NAME>.
+
+	* _edit_:
+		Change the protection rules of the environment <NAME>. Rules that aren't
+		given as options are left unchanged.
+
+## Options:
+	-r, --reviewer <REVIEWERS>
+		A comma-separated list of up to 6 users or "<ORG>/<TEAM>" teams who must
+		approve deployments to the environment. Use "@me" for yourself. With
+		''edit'', replaces the current reviewers; pass "" to remove them all.
+
+	-w, --wait-timer <MINUTES>
+		Delay deployments to the environment by <MINUTES> (0 to 43200).
+
+	--protected-branches
+		Only allow deployments from protected branches.
+
+	-b, --branch <PATTERN>
+		Only allow deployments from branches that match the name pattern
+		<PATTERN>, such as "releases/*". Can be repeated. With ''edit'', the
+		patterns are added to those already allowed.
+
+	--all-branches
+		For ''edit'', allow deployments from any branch again.
+
+## Examples:
+		$ hub env create -r mislav,github/ops -w 30 --protected-branches production
+		$ hub env list
+		production  reviewers: mislav, github/ops; wait timer: 30 minutes; branches: protected
+		staging     no protection rules
+
+## See also:
+
+hub-run(1), hub(1)
+`,
+	}
+
+	cmdListEnvironments = &Command{
+		Key:        "list",
+		Run:        listEnvironments,
+		KnownFlags: "\n",
+	}
+
+	cmdCreateEnvironment = &Command{
+		Key: "create",
+		Run: createEnvironment,
+		KnownFlags: `
+		-r, --reviewer USERS
+		-w, --wait-timer MINUTES
+		--protected-branches
+		-b, --branch PATTERN
+`,
+	}
+
+	cmdEditEnvironment = &Command{
+		Key: "edit",
+		Run: editEnvironment,
+		KnownFlags: `
+		-r, --reviewer USERS
+		-w, --wait-timer MINUTES
+		--protected-branches
+		-b, --branch PATTERN
+		--all-branches
+`,
+	}
+)
+
+func init() {
+	cmdEnvironment.Use(cmdListEnvironments)
+	cmdEnvironment.Use(cmdCreateEnvironment)
+	cmdEnvironment.Use(cmdEditEnvironment)
+	CmdRunner.Use(cmdEnvironment)
+}
+
+func listEnvironments(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of environments for %s\n", project)
+		return
+	}
+
+	environments, err := gh.FetchEnvironments(project)
+	utils.Check(err)
+
+	ui.Print(formatEnvironments(environments, project.Owner))
+}
+
+// formatEnvironments prints one environment per line, with a summary of its
+// protection rules aligned in a column after the longest name.
+func formatEnvironments(environments []github.Environment, owner string) string {
+	width := 0
+	for _, env := range environments {
+		if len(env.Name) > width {
+			width = len(env.Name)
+		}
+	}
+
+	out := ""
+	for _, env := range environments {
+		rules := []string{}
+		if reviewers := environmentReviewerNames(env, owner); len(reviewers) > 0 {
+			rules = append(rules, "reviewers: "+strings.Join(reviewers, ", "))
+		}
+		if wait := environmentWaitTimer(env); wait > 0 {
+			rules = append(rules, fmt.Sprintf("wait timer: %d minutes", wait))
+		}
+		if policy := env.DeploymentBranchPolicy; policy != nil {
+			if policy.ProtectedBranches {
+				rules = append(rules, "branches: protected")
+			} else if policy.CustomBranchPolicies {
+				rules = append(rules, "branches: custom")
+			}
+		}
+		if len(rules) == 0 {
+			rules = append(rules, "no protection rules")
+		}
+		out += fmt.Sprintf("%-*s  %s\n", width, env.Name, strings.Join(rules, "; "))
+	}
+	return out
+}
+
+func environmentReviewerNames(env github.Environment, owner string) []string {
+	names := []string{}
+	for _, rule := range env.ProtectionRules {
+		for _, r := range rule.Reviewers {
+			if r.Type == "Team" {
+				names = append(names, owner+"/"+r.Reviewer.Slug)
+			} else {
+				names = append(names, r.Reviewer.Login)
+			}
+		}
+	}
+	return names
+}
+
+func environmentWaitTimer(env github.Environment) int {
+	for _, rule := range env.ProtectionRules {
+		if rule.Type == "wait_timer" {
+			return rule.WaitTimer
+		}
+	}
+	return 0
+}
+
+func createEnvironment(cmd *Command, args *Args) {
+	updateEnvironment(cmd, args, false)
+}
+
+func editEnvironment(cmd *Command, args *Args) {
+	updateEnvironment(cmd, args, true)
+}
+
+func updateEnvironment(cmd *Command, args *Args, edit bool) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		if edit {
+			ui.Printf("Would update environment %s in %s\n", name, project)
+		} else {
+			ui.Printf("Would create environment %s in %s\n", name, project)
+		}
+		return
+	}
+
+	existing, err := gh.FetchEnvironment(project, name)
+	utils.Check(err)
+	if edit && existing == nil {
+		utils.Check(fmt.Errorf("error: no environment named '%s' in %s", name, project))
+	} else if !edit && existing != nil {
+		utils.Check(fmt.Errorf("error: environment '%s' already exists in %s; use `hub env edit` to change it", name, project))
+	}
+
+	var reviewers []map[string]interface{}
+	if args.Flag.HasReceived("--reviewer") {
+		reviewers, err = resolveEnvironmentReviewers(gh, args)
+		utils.Check(err)
+	}
+
+	params, err := environmentParams(args, existing, reviewers)
+	utils.Check(err)
+
+	_, err = gh.UpdateEnvironment(project, name, params)
+	utils.Check(err)
+
+	for _, pattern := range args.Flag.AllValues("--branch") {
+		utils.Check(gh.CreateDeploymentBranchPolicy(project, name, pattern))
+	}
+}
+
+// resolveEnvironmentReviewers looks up the IDs of the users and teams given
+// with `--reviewer`, which the environments API requires.
+func resolveEnvironmentReviewers(gh *github.Client, args *Args) ([]map[string]interface{}, error) {
+	handles, err := newUserResolver(gh, args.Noop).reviewers(commaSeparated(args.Flag.AllValues("--reviewer")))
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := []map[string]interface{}{}
+	for _, handle := range handles {
+		if parts := strings.SplitN(handle, "/", 2); len(parts) == 2 {
+			team, err := gh.FetchTeam(parts[0], parts[1])
+			if err != nil {
+				return nil, err
+			}
+			reviewers = append(reviewers, map[string]interface{}{"type": "Team", "id": team.ID})
+		} else {
+			user, err := gh.FetchUser(handle)
+			if err != nil {
+				return nil, err
+			}
+			reviewers = append(reviewers, map[string]interface{}{"type": "User", "id": user.ID})
+		}
+	}
+	return reviewers, nil
+}
+
+// environmentParams builds the request to create or replace an environment.
+// Protection rules that aren't given as flags are carried over from existing,
+// and reviewers is used only if `--reviewer` was given.
+func environmentParams(args *Args, existing *github.Environment, reviewers []map[string]interface{}) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+
+	if args.Flag.HasReceived("--wait-timer") {
+		wait, err := strconv.Atoi(args.Flag.Value("--wait-timer"))
+		if err != nil || wait < 0 || wait > 43200 {
+			return nil, fmt.Errorf("error: invalid wait timer '%s'; expected a number of minutes from 0 to 43200", args.Flag.Value("--wait-timer"))
+		}
+		params["wait_timer"] = wait
+	} else if existing != nil {
+		params["wait_timer"] = environmentWaitTimer(*existing)
+	}
+
+	if args.Flag.HasReceived("--reviewer") {
+		params["reviewers"] = reviewers
+	} else if existing != nil {
+		kept := []map[string]interface{}{}
+		for _, rule := range existing.ProtectionRules {
+			for _, r := range rule.Reviewers {
+				kept = append(kept, map[string]interface{}{"type": r.Type, "id": r.Reviewer.ID})
+			}
+		}
+		params["reviewers"] = kept
+	}
+
+	protected := args.Flag.Bool("--protected-branches")
+	custom := len(args.Flag.AllValues("--branch")) > 0
+	all := args.Flag.Bool("--all-branches")
+	switch {
+	case (protected && custom) || (protected && all) || (custom && all):
+		return nil, fmt.Errorf("error: --protected-branches, --branch, and --all-branches are mutually exclusive")
+	case protected || custom:
+		params["deployment_branch_policy"] = map[string]interface{}{
+			"protected_branches":     protected,
+			"custom_branch_policies": custom,
+		}
+	case all:
+		params["deployment_branch_policy"] = nil
+	case existing != nil && existing.DeploymentBranchPolicy != nil:
+		params["deployment_branch_policy"] = map[string]interface{}{
+			"protected_branches":     existing.DeploymentBranchPolicy.ProtectedBranches,
+			"custom_branch_policies": existing.DeploymentBranchPolicy.CustomBranchPolicies,
+		}
+	}
+
+	return params, nil
+}
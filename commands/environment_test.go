@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func parseEnvironment(t *testing.T, data string) github.Environment {
+	var env github.Environment
+	assert.Equal(t, nil, json.Unmarshal([]byte(data), &env))
+	return env
+}
+
+func TestFormatEnvironments(t *testing.T) {
+	production := parseEnvironment(t, `{
+		"name": "production",
+		"protection_rules": [
+			{"type": "required_reviewers", "reviewers": [
+				{"type": "User", "reviewer": {"id": 1, "login": "mislav"}},
+				{"type": "Team", "reviewer": {"id": 2, "slug": "ops"}}
+			]},
+			{"type": "wait_timer", "wait_timer": 30}
+		],
+		"deployment_branch_policy": {"protected_branches": true, "custom_branch_policies": false}
+	}`)
+	staging := parseEnvironment(t, `{"name": "staging", "protection_rules": [], "deployment_branch_policy": null}`)
+
+	assert.Equal(t, `production  reviewers: mislav, github/ops; wait timer: 30 minutes; branches: protected
+staging     no protection rules
+`, formatEnvironments([]github.Environment{production, staging}, "github"))
+}
+
+func TestEnvironmentParams(t *testing.T) {
+	existing := parseEnvironment(t, `{
+		"name": "production",
+		"protection_rules": [
+			{"type": "required_reviewers", "reviewers": [{"type": "User", "reviewer": {"id": 1, "login": "mislav"}}]},
+			{"type": "wait_timer", "wait_timer": 30}
+		],
+		"deployment_branch_policy": {"protected_branches": false, "custom_branch_policies": true}
+	}`)
+
+	args := NewArgs([]string{"env", "edit", "-w", "10", "production"})
+	cmdEditEnvironment.parseArguments(args)
+	params, err := environmentParams(args, &existing, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{
+		"wait_timer": 10,
+		"reviewers":  []map[string]interface{}{{"type": "User", "id": int64(1)}},
+		"deployment_branch_policy": map[string]interface{}{
+			"protected_branches":     false,
+			"custom_branch_policies": true,
+		},
+	}, params)
+
+	args = NewArgs([]string{"env", "edit", "-r", "", "--all-branches", "production"})
+	cmdEditEnvironment.parseArguments(args)
+	params, err = environmentParams(args, &existing, []map[string]interface{}{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{
+		"wait_timer":               30,
+		"reviewers":                []map[string]interface{}{},
+		"deployment_branch_policy": nil,
+	}, params)
+
+	args = NewArgs([]string{"env", "create", "--protected-branches", "production"})
+	cmdCreateEnvironment.parseArguments(args)
+	params, err = environmentParams(args, nil, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{
+		"deployment_branch_policy": map[string]interface{}{
+			"protected_branches":     true,
+			"custom_branch_policies": false,
+		},
+	}, params)
+
+	args = NewArgs([]string{"env", "create", "--protected-branches", "-b", "releases/*", "production"})
+	cmdCreateEnvironment.parseArguments(args)
+	_, err = environmentParams(args, nil, nil)
+	assert.Equal(t, "error: --protected-branches, --branch, and --all-branches are mutually exclusive", err.Error())
+
+	args = NewArgs([]string{"env", "create", "-w", "soon", "production"})
+	cmdCreateEnvironment.parseArguments(args)
+	_, err = environmentParams(args, nil, nil)
+	assert.Equal(t, "error: invalid wait timer 'soon'; expected a number of minutes from 0 to 43200", err.Error())
+}
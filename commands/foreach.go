@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdForeach = &Command{
+	Run:   foreach,
+	Usage: "foreach --org <ORG> [-t <TOPIC>] [--clone] [-j <JOBS>] [--include-archived] -- <COMMAND>...",
+	Long: `Run a hub command against each repository of an organization.
+
+The repositories of <ORG> are listed, filtered, and then <COMMAND> is run as
+''hub --repo <OWNER>/<REPO> <COMMAND>...'' for each of them. The output of every
+run is printed as a block once the run finishes, followed by a summary of which
+repositories the command failed in.
+
+## Options:
+	--org <ORG>
+		The organization whose repositories to run <COMMAND> against.
+
+	-t, --topic <TOPIC>
+		Only include repositories tagged with <TOPIC>. Can be specified multiple
+		times; repositories then need to have all of the topics.
+
+	--clone
+		Clone each repository into a temporary directory and run <COMMAND> there,
+		for commands that need a working copy. The directory is removed afterwards.
+
+	-j, --jobs <JOBS>
+		Run <COMMAND> in up to <JOBS> repositories at a time (default: 4).
+
+	--include-archived
+		Include archived repositories, which are skipped by default.
+
+	<COMMAND>
+		The hub command and its arguments. It must follow a ''--'' argument so its
+		flags aren't mistaken for flags of ''foreach''.
+
+## Examples:
+		$ hub foreach --org acme -t service -- label copy acme/templates
+		==> acme/billing
+		...
+		2 succeeded, 1 failed: acme/legacy
+
+		$ hub foreach --org acme --clone -j 8 -- sync
+
+## See also:
+
+hub-org(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdForeach)
+}
+
+// foreachResult is the outcome of running the command in one repository.
+type foreachResult struct {
+	Repo   string
+	Output []byte
+	Err    error
+}
+
+func foreach(cmd *Command, args *Args) {
+	org := args.Flag.Value("--org")
+	if org == "" {
+		utils.Check(cmd.UsageError("missing --org"))
+	}
+	if !args.Terminator || args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("missing command after '--'"))
+	}
+	command := args.Params
+
+	jobs := 4
+	if args.Flag.HasReceived("--jobs") {
+		var err error
+		jobs, err = strconv.Atoi(args.Flag.Value("--jobs"))
+		if err != nil || jobs < 1 {
+			utils.Check(fmt.Errorf("error: invalid number of jobs '%s'", args.Flag.Value("--jobs")))
+		}
+	}
+
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+
+	allRepos, err := gh.FetchOrgRepositories(org)
+	utils.Check(err)
+	repos := filterForeachRepos(allRepos, args.Flag.AllValues("--topic"), args.Flag.Bool("--include-archived"))
+	if len(repos) == 0 {
+		ui.Errorf("No matching repositories in %s\n", org)
+		return
+	}
+
+	if args.Noop {
+		for _, repo := range repos {
+			ui.Printf("Would run `hub %s` in %s\n", strings.Join(command, " "), repo.FullName)
+		}
+		return
+	}
+
+	executable, err := os.Executable()
+	utils.Check(err)
+	clone := args.Flag.Bool("--clone")
+
+	queue := make(chan github.Repository, len(repos))
+	for _, repo := range repos {
+		queue <- repo
+	}
+	close(queue)
+
+	results := make(chan foreachResult)
+	for i := 0; i < jobs && i < len(repos); i++ {
+		go func() {
+			for repo := range queue {
+				project := github.NewProject(repo.Owner.Login, repo.Name, host)
+				output, err := runForeachCommand(executable, project, command, clone)
+				results <- foreachResult{Repo: repo.FullName, Output: output, Err: err}
+			}
+		}()
+	}
+	finished := []foreachResult{}
+	for range repos {
+		result := <-results
+		ui.Printf("==> %s\n", result.Repo)
+		ui.Print(string(result.Output))
+		if result.Err != nil {
+			ui.Errorf("%s\n", result.Err)
+		}
+		finished = append(finished, result)
+	}
+
+	summary, failed := foreachSummary(finished)
+	ui.Printf("\n%s\n", summary)
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// runForeachCommand runs a hub command against project and captures its
+// combined output.
+func runForeachCommand(executable string, project *github.Project, command []string, clone bool) ([]byte, error) {
+	var output bytes.Buffer
+	hubArgs := append([]string{"--repo", fmt.Sprintf("%s/%s/%s", project.Host, project.Owner, project.Name)}, command...)
+	run := exec.Command(executable, hubArgs...)
+	run.Stdout = &output
+	run.Stderr = &output
+
+	if clone {
+		dir, err := ioutil.TempDir("", "hub-foreach")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+
+		workdir := filepath.Join(dir, project.Name)
+		gitClone := exec.Command("git", "clone", "--quiet", project.GitURL("", "", true), workdir)
+		gitClone.Stdout = &output
+		gitClone.Stderr = &output
+		if err := gitClone.Run(); err != nil {
+			return output.Bytes(), fmt.Errorf("error cloning %s: %s", project, err)
+		}
+		run.Dir = workdir
+	}
+
+	err := run.Run()
+	return output.Bytes(), err
+}
+
+// filterForeachRepos keeps the repositories that have all of topics, sorted by
+// name. Archived repositories are dropped unless includeArchived is set.
+func filterForeachRepos(repos []github.Repository, topics []string, includeArchived bool) []github.Repository {
+	matching := []github.Repository{}
+	for _, repo := range repos {
+		if repo.Archived && !includeArchived {
+			continue
+		}
+		repoTopics := map[string]bool{}
+		for _, topic := range repo.Topics {
+			repoTopics[strings.ToLower(topic)] = true
+		}
+		hasTopics := true
+		for _, topic := range topics {
+			if !repoTopics[strings.ToLower(topic)] {
+				hasTopics = false
+			}
+		}
+		if hasTopics {
+			matching = append(matching, repo)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return strings.ToLower(matching[i].FullName) < strings.ToLower(matching[j].FullName)
+	})
+	return matching
+}
+
+// foreachSummary reports how many runs succeeded and which ones failed.
+func foreachSummary(results []foreachResult) (string, bool) {
+	failed := []string{}
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Repo)
+		}
+	}
+	sort.Strings(failed)
+
+	summary := fmt.Sprintf("%d succeeded", len(results)-len(failed))
+	if len(failed) > 0 {
+		summary += fmt.Sprintf(", %d failed: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return summary, len(failed) > 0
+}
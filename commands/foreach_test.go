@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFilterForeachRepos(t *testing.T) {
+	repos := []github.Repository{
+		{FullName: "acme/web", Topics: []string{"service", "go"}},
+		{FullName: "acme/Docs"},
+		{FullName: "acme/api", Topics: []string{"Service"}},
+		{FullName: "acme/old", Topics: []string{"service"}, Archived: true},
+	}
+
+	names := func(repos []github.Repository) []string {
+		result := []string{}
+		for _, repo := range repos {
+			result = append(result, repo.FullName)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"acme/api", "acme/Docs", "acme/web"}, names(filterForeachRepos(repos, nil, false)))
+	assert.Equal(t, []string{"acme/api", "acme/old", "acme/web"}, names(filterForeachRepos(repos, []string{"service"}, true)))
+	assert.Equal(t, []string{"acme/web"}, names(filterForeachRepos(repos, []string{"service", "go"}, false)))
+}
+
+func TestForeachSummary(t *testing.T) {
+	summary, failed := foreachSummary([]foreachResult{
+		{Repo: "acme/web"},
+		{Repo: "acme/api"},
+	})
+	assert.Equal(t, "2 succeeded", summary)
+	assert.Equal(t, false, failed)
+
+	summary, failed = foreachSummary([]foreachResult{
+		{Repo: "acme/web", Err: fmt.Errorf("exit status 1")},
+		{Repo: "acme/docs"},
+		{Repo: "acme/api", Err: fmt.Errorf("exit status 1")},
+	})
+	assert.Equal(t, "1 succeeded, 2 failed: acme/api, acme/web", summary)
+	assert.Equal(t, true, failed)
+}
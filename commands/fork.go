@@ -3,15 +3,32 @@ package commands
 import (
 	"fmt"
 
+	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
 	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
 )
 
-var cmdFork = &Command{
-	Run:   fork,
-	Usage: "fork [--no-remote] [--remote-name <REMOTE>] [--org <ORGANIZATION>]",
-	Long: `Fork the current repository on GitHub and add a git remote for it.
+var (
+	cmdFork = &Command{
+		Run: fork,
+		Usage: `
+fork [--no-remote] [--remote-name <REMOTE>] [--org <ORGANIZATION>]
+fork sync [<BRANCH>]
+`,
+		Long: `Fork the current repository on GitHub and add a git remote for it.
+
+## Commands:
+
+	* _sync_:
+		Bring <BRANCH> of your fork up to date with the repository it was forked
+		from (default: the default branch of the fork). The branch is updated on
+		GitHub, so no local clone is needed. If the GitHub server doesn't support
+		syncing forks, the branch is fetched from the remote of the original
+		repository and pushed to the remote of the fork instead.
+
+		The fork is the current repository if it is a fork, and otherwise your
+		fork of it.
 
 ## Options:
 	--no-remote
@@ -32,13 +49,23 @@ var cmdFork = &Command{
 		[ repo forked on GitHub into the ORGANIZATION organization]
 		> git remote add -f ORGANIZATION git@github.com:ORGANIZATION/REPO.git
 
+		$ hub fork sync
+		Synced mislav/hub:master with github/hub (fast-forward)
+
 ## See also:
 
-hub-clone(1), hub(1)
+hub-clone(1), hub-sync(1), hub(1)
 `,
-}
+	}
+
+	cmdForkSync = &Command{
+		Key: "sync",
+		Run: forkSync,
+	}
+)
 
 func init() {
+	cmdFork.Use(cmdForkSync)
 	CmdRunner.Use(cmdFork)
 }
 
@@ -130,3 +157,67 @@ func fork(cmd *Command, args *Args) {
 		})
 	}
 }
+
+func forkSync(cmd *Command, args *Args) {
+	if args.ParamsSize() > 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	client := github.NewClient(project.Host)
+	repo, err := client.Repository(project)
+	utils.Check(err)
+
+	forkProject := project
+	forkRepo := repo
+	if repo.Parent == nil {
+		host, err := github.CurrentConfig().PromptForHost(project.Host)
+		utils.Check(github.FormatError("syncing fork", err))
+		forkProject = github.NewProject(host.User, project.Name, project.Host)
+		forkRepo, err = client.Repository(forkProject)
+		if err != nil || forkRepo.Parent == nil {
+			utils.Check(fmt.Errorf("Error syncing fork: %s has no fork owned by %s", project, host.User))
+		}
+	}
+	upstreamProject, err := github.NewProjectFromRepo(forkRepo.Parent)
+	utils.Check(err)
+
+	branch := forkRepo.DefaultBranch
+	if !args.IsParamsEmpty() {
+		branch = args.GetParam(0)
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would sync %s:%s with %s\n", forkProject, branch, upstreamProject)
+		return
+	}
+
+	result, err := client.MergeUpstream(forkProject, branch)
+	utils.Check(err)
+	if result != nil {
+		if result.MergeType == "none" {
+			ui.Printf("%s:%s is already up to date with %s\n", forkProject, branch, upstreamProject)
+		} else {
+			ui.Printf("Synced %s:%s with %s (%s)\n", forkProject, branch, upstreamProject, result.MergeType)
+		}
+		return
+	}
+
+	// the server is too old to sync forks, so go through the local clone
+	upstreamRemote, err := localRepo.RemoteForProject(upstreamProject)
+	if err != nil {
+		utils.Check(fmt.Errorf("Error syncing fork: %s doesn't support syncing forks and no git remote points to %s", project.Host, upstreamProject))
+	}
+	forkRemote, err := localRepo.RemoteForProject(forkProject)
+	if err != nil {
+		utils.Check(fmt.Errorf("Error syncing fork: %s doesn't support syncing forks and no git remote points to %s", project.Host, forkProject))
+	}
+	utils.Check(git.Spawn("fetch", "--quiet", upstreamRemote.Name, branch))
+	utils.Check(git.Spawn("push", "--quiet", forkRemote.Name, "FETCH_HEAD:refs/heads/"+branch))
+	ui.Printf("Synced %s:%s with %s by pushing from %s to %s\n", forkProject, branch, upstreamProject, upstreamRemote.Name, forkRemote.Name)
+}
@@ -1,10 +1,16 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/atotto/clipboard"
 	"github.com/github/hub/v2/github"
 	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
@@ -14,7 +20,7 @@ var (
 	cmdGist = &Command{
 		Run: printGistHelp,
 		Usage: `
-gist create [-oc] [--public] [<FILES>...]
+gist create [-oc] [--public] [-n <FILENAME>] [--from-clipboard | <FILES>...]
 gist show <ID> [<FILENAME>]
 `,
 		Long: `Create and print GitHub Gists
@@ -23,7 +29,7 @@ gist show <ID> [<FILENAME>]
 
 	* _create_:
 		Create a new gist. If no <FILES> are specified, the content is read from
-		standard input.
+		standard input, or from the clipboard with ''--from-clipboard''.
 
 	* _show_:
 		Print the contents of a gist. If the gist contains multiple files, the
@@ -34,6 +40,17 @@ gist show <ID> [<FILENAME>]
 	--public
 		Make the new gist public (default: false).
 
+	--from-clipboard
+		Read the content of the new gist from the clipboard.
+
+	-n, --filename <FILENAME>
+		The name of the file in the gist when its content comes from standard
+		input or the clipboard. If <FILENAME> starts with ".", it is only used as
+		the extension of the default name "gistfile1".
+
+		Without this option, the extension is guessed from the content, e.g. from
+		a "#!" line or valid JSON, and falls back to ".txt".
+
 	-o, --browse
 		Open the new gist in a web browser.
 
@@ -44,6 +61,9 @@ gist show <ID> [<FILENAME>]
 
     $ echo hello | hub gist create --public
 
+    # share a snippet from the clipboard with syntax highlighting:
+    $ hub gist create --from-clipboard -n .go
+
     $ hub gist create file1.txt file2.txt
 
     # print a specific file within a gist:
@@ -65,6 +85,8 @@ hub(1), hub-api(1)
 		Run: createGist,
 		KnownFlags: `
 		--public
+		--from-clipboard
+		-n, --filename FILENAME
 		-o, --browse
 		-c, --copy
 `,
@@ -118,11 +140,38 @@ func createGist(cmd *Command, args *Args) {
 	utils.Check(err)
 	gh := github.NewClient(host.Host)
 
-	filenames := []string{}
-	if args.IsParamsEmpty() {
-		filenames = append(filenames, "-")
+	flagGistFromClipboard := args.Flag.Bool("--from-clipboard")
+	if flagGistFromClipboard && !args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError("--from-clipboard can't be combined with files"))
+	}
+
+	files := map[string]github.GistFile{}
+	if flagGistFromClipboard || args.IsParamsEmpty() {
+		var content string
+		if flagGistFromClipboard {
+			content, err = clipboard.ReadAll()
+		} else {
+			var data []byte
+			data, err = ioutil.ReadAll(os.Stdin)
+			content = string(data)
+		}
+		utils.Check(err)
+		if strings.TrimSpace(content) == "" {
+			utils.Check(fmt.Errorf("Aborting creation due to empty gist content"))
+		}
+		files[gistFilename(args.Flag.Value("--filename"), content)] = github.GistFile{Content: content}
 	} else {
-		filenames = args.Params
+		for _, file := range args.Params {
+			var data []byte
+			if file == "-" {
+				data, err = ioutil.ReadAll(os.Stdin)
+				file = gistFilename(args.Flag.Value("--filename"), string(data))
+			} else {
+				data, err = ioutil.ReadFile(file)
+			}
+			utils.Check(err)
+			files[path.Base(file)] = github.GistFile{Content: string(data)}
+		}
 	}
 
 	var gist *github.Gist
@@ -132,7 +181,7 @@ func createGist(cmd *Command, args *Args) {
 			HTMLURL: fmt.Sprintf("https://gist.%s/%s", gh.Host.Host, "ID"),
 		}
 	} else {
-		gist, err = gh.CreateGist(filenames, args.Flag.Bool("--public"))
+		gist, err = gh.CreateGist(files, args.Flag.Bool("--public"))
 		utils.Check(err)
 	}
 
@@ -141,6 +190,65 @@ func createGist(cmd *Command, args *Args) {
 	printBrowseOrCopy(args, gist.HTMLURL, flagIssueBrowse, flagIssueCopy)
 }
 
+var gistShebangRegexp = regexp.MustCompile(`^#!\s*(?:\S*/)?([\w.]+)(?:\s+([\w.]+))?`)
+
+var gistInterpreterExtensions = map[string]string{
+	"sh":     ".sh",
+	"bash":   ".sh",
+	"zsh":    ".sh",
+	"python": ".py",
+	"ruby":   ".rb",
+	"node":   ".js",
+	"perl":   ".pl",
+	"php":    ".php",
+}
+
+// gistFilename names a gist file whose content doesn't come from a file on
+// disk. A name starting with "." only sets the extension.
+func gistFilename(name, content string) string {
+	if name != "" && !strings.HasPrefix(name, ".") {
+		return name
+	}
+	ext := name
+	if ext == "" {
+		ext = inferGistExtension(content)
+	}
+	return "gistfile1" + ext
+}
+
+// inferGistExtension guesses a file extension from content so that the gist
+// gets syntax highlighting.
+func inferGistExtension(content string) string {
+	trimmed := strings.TrimSpace(content)
+
+	if m := gistShebangRegexp.FindStringSubmatch(trimmed); m != nil {
+		interpreter := m[1]
+		if interpreter == "env" {
+			interpreter = m[2]
+		}
+		interpreter = strings.TrimRight(interpreter, "0123456789.")
+		if ext, ok := gistInterpreterExtensions[interpreter]; ok {
+			return ext
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(trimmed, "<?php"):
+		return ".php"
+	case strings.HasPrefix(trimmed, "<?xml"):
+		return ".xml"
+	case strings.HasPrefix(strings.ToLower(trimmed), "<!doctype html"), strings.HasPrefix(strings.ToLower(trimmed), "<html"):
+		return ".html"
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		if json.Valid([]byte(trimmed)) {
+			return ".json"
+		}
+	case strings.HasPrefix(trimmed, "diff --git "):
+		return ".diff"
+	}
+	return ".txt"
+}
+
 func showGist(cmd *Command, args *Args) {
 	args.NoForward()
 
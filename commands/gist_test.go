@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestGistFilename(t *testing.T) {
+	assert.Equal(t, "snippet.go", gistFilename("snippet.go", "package main\n"))
+	assert.Equal(t, "gistfile1.go", gistFilename(".go", "package main\n"))
+	assert.Equal(t, "gistfile1.txt", gistFilename("", "hello\n"))
+	assert.Equal(t, "gistfile1.json", gistFilename("", "{\"a\": [1, 2]}\n"))
+}
+
+func TestInferGistExtension(t *testing.T) {
+	assert.Equal(t, ".sh", inferGistExtension("#!/bin/bash\necho hi\n"))
+	assert.Equal(t, ".py", inferGistExtension("#!/usr/bin/env python3\nprint(1)\n"))
+	assert.Equal(t, ".rb", inferGistExtension("#! /usr/bin/ruby -w\nputs 1\n"))
+	assert.Equal(t, ".txt", inferGistExtension("#!/usr/bin/env unknown\n"))
+	assert.Equal(t, ".txt", inferGistExtension("{not json\n"))
+	assert.Equal(t, ".html", inferGistExtension("<!DOCTYPE html>\n<p>hi</p>\n"))
+	assert.Equal(t, ".diff", inferGistExtension("diff --git a/x b/x\n"))
+}
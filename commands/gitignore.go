@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdGitignore = &Command{
+		Run: printHelp,
+		Usage: `
+gitignore list
+gitignore view <TEMPLATE>
+gitignore apply [-f] <TEMPLATE>
+`,
+		Long: `Add ignore rules for a language or framework to the current project.
+
+## Commands:
+
+	* _list_:
+		List the names of available ''.gitignore'' templates.
+
+	* _view_:
+		Print the contents of <TEMPLATE>.
+
+	* _apply_:
+		Append the rules from <TEMPLATE> to the ''.gitignore'' file at the top level
+		of the current repository, creating the file if necessary.
+
+## Options:
+	-f, --force
+		Overwrite an existing ''.gitignore'' file instead of appending to it.
+
+	<TEMPLATE>
+		The name of a template as shown by ''gitignore list'', e.g. "Go" or "Node".
+
+## Examples:
+		$ hub gitignore apply Go
+
+## See also:
+
+hub-license(1), gitignore(5), hub(1)
+`,
+	}
+
+	cmdListGitignores = &Command{
+		Key: "list",
+		Run: listGitignores,
+	}
+
+	cmdViewGitignore = &Command{
+		Key: "view",
+		Run: viewGitignore,
+	}
+
+	cmdApplyGitignore = &Command{
+		Key: "apply",
+		Run: applyGitignore,
+		KnownFlags: `
+		-f, --force
+`,
+	}
+)
+
+func init() {
+	cmdGitignore.Use(cmdListGitignores)
+	cmdGitignore.Use(cmdViewGitignore)
+	cmdGitignore.Use(cmdApplyGitignore)
+	CmdRunner.Use(cmdGitignore)
+}
+
+func listGitignores(cmd *Command, args *Args) {
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of gitignore templates\n")
+		return
+	}
+
+	names, err := gh.FetchGitignoreTemplates()
+	utils.Check(err)
+
+	for _, name := range names {
+		ui.Println(name)
+	}
+}
+
+func viewGitignore(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would display gitignore template %s\n", name)
+		return
+	}
+
+	source, err := gh.FetchGitignoreTemplate(name)
+	utils.Check(err)
+
+	ui.Print(source)
+}
+
+func applyGitignore(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	filename := topLevelPath(".gitignore")
+
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would add gitignore template %s to %s\n", name, filename)
+		return
+	}
+
+	source, err := gh.FetchGitignoreTemplate(name)
+	utils.Check(err)
+
+	existing := ""
+	if !args.Flag.Bool("--force") {
+		if content, err := ioutil.ReadFile(filename); err == nil {
+			existing = string(content)
+		} else if !os.IsNotExist(err) {
+			utils.Check(err)
+		}
+	}
+
+	utils.Check(ioutil.WriteFile(filename, []byte(appendGitignore(existing, source)), 0644))
+}
+
+// appendGitignore adds the rules from template after the existing contents of
+// a .gitignore file, separated by a blank line.
+func appendGitignore(existing, template string) string {
+	if existing == "" {
+		return template
+	}
+	if !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	return fmt.Sprintf("%s\n%s", existing, template)
+}
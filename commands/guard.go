@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdGuard = &Command{
+	Run:   guard,
+	Usage: "guard [--checks] [--reviews] [--up-to-date] [<PR-NUMBER>]",
+	Long: `Check whether a pull request satisfies the branch protection requirements.
+
+Exits with a zero status only if every requested requirement is met. This is
+meant for scripts, e.g. to verify that a pull request may be deployed, without
+having to duplicate the rules of the protected branch. When no requirement is
+specified, all of them are checked.
+
+## Options:
+	--checks
+		Require the status checks that the branch protection rule of the base
+		branch marks as required to have passed. Without a rule, all checks of
+		the head commit must have passed.
+
+	--reviews
+		Require the review decision of the pull request to be "approved", unless
+		no review is required.
+
+	--up-to-date
+		Require the head branch to be up to date with the base branch.
+
+	<PR-NUMBER>
+		The pull request to check (default: the pull request for the current
+		branch).
+
+## Examples:
+		$ hub guard --checks --reviews 1234 && ./deploy.sh
+		✔ checks: 2 of 2 required checks passed
+		✔ reviews: approved
+
+## See also:
+
+hub-ci-status(1), hub-pr(1), hub-protection(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdGuard)
+}
+
+// guardPullRequest is a pull request as returned by the query in guard.
+type guardPullRequest struct {
+	Number           int
+	ReviewDecision   string
+	MergeStateStatus string
+	BaseRef          *struct {
+		BranchProtectionRule *struct {
+			RequiredStatusCheckContexts []string
+		}
+	}
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					Contexts struct {
+						Nodes []struct {
+							Name       string
+							Conclusion string
+							Context    string
+							State      string
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// guardResult is the outcome of checking one requirement.
+type guardResult struct {
+	Name    string
+	OK      bool
+	Message string
+}
+
+func guard(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+
+	prNumber := 0
+	if words := args.Words(); len(words) > 1 {
+		utils.Check(cmd.UsageError(""))
+	} else if len(words) == 1 {
+		prNumber, err = strconv.Atoi(words[0])
+		utils.Check(err)
+	} else if args.Noop {
+		ui.Printf("Would check the pull request for the current branch in %s\n", project)
+		return
+	} else {
+		pr, err := findCurrentPullRequest(localRepo, gh, project, "")
+		utils.Check(err)
+		prNumber = pr.Number
+	}
+
+	if args.Noop {
+		ui.Printf("Would check pull request #%d in %s\n", prNumber, project)
+		return
+	}
+
+	response := struct {
+		Repository struct {
+			PullRequest guardPullRequest
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $number) {
+				number
+				reviewDecision
+				mergeStateStatus
+				baseRef {
+					branchProtectionRule {
+						requiredStatusCheckContexts
+					}
+				}
+				commits(last: 1) {
+					nodes {
+						commit {
+							statusCheckRollup {
+								contexts(first: 100) {
+									nodes {
+										... on CheckRun {
+											name
+											conclusion
+										}
+										... on StatusContext {
+											context
+											state
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner":  project.Owner,
+		"repo":   project.Name,
+		"number": prNumber,
+	}, &response)
+	utils.Check(err)
+
+	checks := args.Flag.Bool("--checks")
+	reviews := args.Flag.Bool("--reviews")
+	upToDate := args.Flag.Bool("--up-to-date")
+	if !checks && !reviews && !upToDate {
+		checks, reviews, upToDate = true, true, true
+	}
+
+	failed := []string{}
+	for _, result := range evaluateGuard(response.Repository.PullRequest, checks, reviews, upToDate) {
+		mark := "✔"
+		if !result.OK {
+			mark = "✖"
+			failed = append(failed, result.Name)
+		}
+		ui.Printf("%s %s: %s\n", mark, result.Name, result.Message)
+	}
+
+	if len(failed) > 0 {
+		utils.Check(fmt.Errorf("Aborted: pull request #%d does not satisfy: %s", prNumber, strings.Join(failed, ", ")))
+	}
+}
+
+// evaluateGuard checks a pull request against the requested requirements.
+func evaluateGuard(pr guardPullRequest, checks, reviews, upToDate bool) []guardResult {
+	results := []guardResult{}
+
+	if checks {
+		passed := map[string]bool{}
+		names := []string{}
+		if len(pr.Commits.Nodes) > 0 && pr.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+			for _, context := range pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+				if context.Name != "" {
+					names = append(names, context.Name)
+					passed[context.Name] = context.Conclusion == "SUCCESS" || context.Conclusion == "NEUTRAL" || context.Conclusion == "SKIPPED"
+				} else {
+					names = append(names, context.Context)
+					passed[context.Context] = context.State == "SUCCESS"
+				}
+			}
+		}
+
+		label := "checks"
+		if pr.BaseRef != nil && pr.BaseRef.BranchProtectionRule != nil {
+			names = pr.BaseRef.BranchProtectionRule.RequiredStatusCheckContexts
+			label = "required checks"
+		}
+		failing := []string{}
+		for _, name := range names {
+			if !passed[name] {
+				failing = append(failing, name)
+			}
+		}
+
+		result := guardResult{Name: "checks", OK: len(failing) == 0}
+		result.Message = fmt.Sprintf("%d of %d %s passed", len(names)-len(failing), len(names), label)
+		if len(failing) > 0 {
+			result.Message += fmt.Sprintf(" (not passing: %s)", strings.Join(failing, ", "))
+		}
+		results = append(results, result)
+	}
+
+	if reviews {
+		result := guardResult{Name: "reviews", OK: true, Message: "no review required"}
+		switch pr.ReviewDecision {
+		case "APPROVED":
+			result.Message = "approved"
+		case "CHANGES_REQUESTED":
+			result.OK, result.Message = false, "changes requested"
+		case "REVIEW_REQUIRED":
+			result.OK, result.Message = false, "review required"
+		}
+		results = append(results, result)
+	}
+
+	if upToDate {
+		result := guardResult{Name: "up-to-date", OK: true, Message: "head branch is up to date"}
+		if pr.MergeStateStatus == "BEHIND" {
+			result.OK, result.Message = false, "head branch is behind the base branch"
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestEvaluateGuard(t *testing.T) {
+	var pr guardPullRequest
+	err := json.Unmarshal([]byte(`{
+		"reviewDecision": "APPROVED",
+		"mergeStateStatus": "BEHIND",
+		"baseRef": {"branchProtectionRule": {"requiredStatusCheckContexts": ["build", "ci/lint"]}},
+		"commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {"nodes": [
+			{"name": "build", "conclusion": "SUCCESS"},
+			{"context": "ci/lint", "state": "PENDING"},
+			{"name": "optional", "conclusion": "FAILURE"}
+		]}}}}]}
+	}`), &pr)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []guardResult{
+		{Name: "checks", OK: false, Message: "1 of 2 required checks passed (not passing: ci/lint)"},
+		{Name: "reviews", OK: true, Message: "approved"},
+		{Name: "up-to-date", OK: false, Message: "head branch is behind the base branch"},
+	}, evaluateGuard(pr, true, true, true))
+
+	pr.BaseRef = nil
+	assert.Equal(t, []guardResult{
+		{Name: "checks", OK: false, Message: "1 of 3 checks passed (not passing: ci/lint, optional)"},
+	}, evaluateGuard(pr, true, false, false))
+
+	assert.Equal(t, []guardResult{
+		{Name: "reviews", OK: true, Message: "no review required"},
+	}, evaluateGuard(guardPullRequest{}, false, true, false))
+}
@@ -213,17 +213,54 @@ func customCommands() []string {
 var helpText = `
 These GitHub commands are provided by hub:
 
-   api            Low-level GitHub API request interface
-   browse         Open a GitHub page in the default browser
-   ci-status      Show the status of GitHub checks for a commit
-   compare        Open a compare page on GitHub
-   create         Create this repository on GitHub and add GitHub as origin
-   delete         Delete a repository on GitHub
-   fork           Make a fork of a remote repository on GitHub and add as remote
-   gist           Make a gist
-   issue          List or create GitHub issues
-   pr             Manage GitHub pull requests
-   pull-request   Open a pull request on GitHub
-   release        List or create GitHub releases
-   sync           Fetch git objects from upstream and update branches
+   api              Low-level GitHub API request interface
+   auth             Refresh the stored GitHub access token
+   bisect-ci        Find the commit that broke CI using recorded statuses
+   blame-pr         Find the pull request that last changed a line
+   branch           Report on remote branches and delete stale ones
+   browse           Open a GitHub page in the default browser
+   changelog        Generate a changelog from merged pull requests
+   check-run        Publish check runs from external CI systems
+   ci-status        Show the status of GitHub checks for a commit
+   code-scanning    Triage code scanning alerts
+   codeowners       Validate CODEOWNERS and look up file owners
+   codespace        Create and connect to GitHub Codespaces
+   collab           Manage collaborators of a repository
+   compare          Open a compare page on GitHub
+   create           Create this repository on GitHub and add GitHub as origin
+   delete           Delete a repository on GitHub
+   deploy-key       Manage deploy keys of a repository
+   deployment       Create deployments and report their status
+   digest           Report pull requests and branches that need attention
+   discussion       List, view, or start GitHub discussions
+   foreach          Run a hub command in each repository of an organization
+   fork             Make a fork of a remote repository on GitHub and add as remote
+   gist             Make a gist
+   gitignore        Add a .gitignore template to this repository
+   guard            Check that a pull request meets branch protection rules
+   invitation       Accept or decline repository invitations
+   issue            List or create GitHub issues
+   label            List or copy GitHub issue labels
+   license          Add an open source license to this repository
+   markdown         Preview Markdown rendered by GitHub
+   milestone        Manage GitHub milestones
+   open             Open the GitHub page for a shorthand reference
+   org              Export the audit log of an organization
+   package          List or prune GitHub Packages
+   pr               Manage GitHub pull requests
+   project          Manage GitHub projects
+   protection       Manage branch protection rules
+   pull-request     Open a pull request on GitHub
+   queue            Show the merge queue
+   release          List or create GitHub releases
+   repo             Show information about a repository
+   search           Search GitHub for code, repositories, users, or commits
+   secret           Manage GitHub Actions secrets
+   secret-scanning  Triage secret scanning alerts
+   security         List Dependabot security alerts
+   sync             Fetch git objects from upstream and update branches
+   tag-protection   Manage tag protection rules
+   team             Manage teams of an organization
+   variable         Manage GitHub Actions variables
+   whoami           Show the authenticated GitHub user
 `
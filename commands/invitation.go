@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdInvitation = &Command{
+		Run: printHelp,
+		Usage: `
+invitation list
+invitation accept <INVITATION>
+invitation decline <INVITATION>
+`,
+		Long: `Manage invitations to collaborate on repositories.
+
+## Commands:
+
+	* _list_:
+		List pending repository invitations for the authenticated user.
+
+	* _accept_:
+		Accept an invitation and gain access to the repository.
+
+	* _decline_:
+		Decline an invitation.
+
+## Options:
+	<INVITATION>
+		The ID of an invitation or the "OWNER/REPO" name of the repository that the
+		invitation is for.
+
+## Examples:
+		$ hub invitation list
+		  123456  mislav/dotfiles (write) from mislav
+		$ hub invitation accept mislav/dotfiles
+
+## See also:
+
+hub-collab(1), hub(1)
+`,
+	}
+
+	cmdListInvitations = &Command{
+		Key: "list",
+		Run: listInvitations,
+	}
+
+	cmdAcceptInvitation = &Command{
+		Key: "accept",
+		Run: acceptInvitation,
+	}
+
+	cmdDeclineInvitation = &Command{
+		Key: "decline",
+		Run: declineInvitation,
+	}
+)
+
+func init() {
+	cmdInvitation.Use(cmdListInvitations)
+	cmdInvitation.Use(cmdAcceptInvitation)
+	cmdInvitation.Use(cmdDeclineInvitation)
+	CmdRunner.Use(cmdInvitation)
+}
+
+func listInvitations(cmd *Command, args *Args) {
+	args.NoForward()
+
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	gh := github.NewClient(host.Host)
+
+	if args.Noop {
+		ui.Println("Would request list of repository invitations")
+		return
+	}
+
+	invitations, err := gh.FetchRepositoryInvitations()
+	utils.Check(err)
+
+	for _, inv := range invitations {
+		ui.Println(formatInvitation(inv))
+	}
+}
+
+func formatInvitation(inv github.RepositoryInvitation) string {
+	repo := ""
+	if inv.Repository != nil {
+		repo = inv.Repository.FullName
+	}
+	line := fmt.Sprintf("%8d  %s (%s)", inv.ID, repo, inv.Permissions)
+	if inv.Inviter != nil {
+		line += " from " + inv.Inviter.Login
+	}
+	return line
+}
+
+// findInvitation resolves an invitation ID or repository name to the ID of a
+// pending invitation.
+func findInvitation(invitations []github.RepositoryInvitation, name string) (int, error) {
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+	for _, inv := range invitations {
+		if inv.Repository != nil && strings.EqualFold(inv.Repository.FullName, name) {
+			return inv.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("error: no pending invitation found for '%s'", name)
+}
+
+func respondToInvitation(cmd *Command, args *Args, accept bool) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	args.NoForward()
+
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	gh := github.NewClient(host.Host)
+
+	action := "decline"
+	if accept {
+		action = "accept"
+	}
+	if args.Noop {
+		ui.Printf("Would %s invitation %s\n", action, name)
+		return
+	}
+
+	var invitations []github.RepositoryInvitation
+	if _, err := strconv.Atoi(name); err != nil {
+		invitations, err = gh.FetchRepositoryInvitations()
+		utils.Check(err)
+	}
+
+	id, err := findInvitation(invitations, name)
+	utils.Check(err)
+
+	if accept {
+		err = gh.AcceptRepositoryInvitation(id)
+	} else {
+		err = gh.DeclineRepositoryInvitation(id)
+	}
+	utils.Check(err)
+}
+
+func acceptInvitation(cmd *Command, args *Args) {
+	respondToInvitation(cmd, args, true)
+}
+
+func declineInvitation(cmd *Command, args *Args) {
+	respondToInvitation(cmd, args, false)
+}
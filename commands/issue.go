@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -17,12 +20,18 @@ var (
 	cmdIssue = &Command{
 		Run: listIssues,
 		Usage: `
-issue [-a <ASSIGNEE>] [-c <CREATOR>] [-@ <USER>] [-s <STATE>] [-f <FORMAT>] [-M <MILESTONE>] [-l <LABELS>] [-d <DATE>] [-o <SORT_KEY> [-^]] [-L <LIMIT>]
+issue [-a <ASSIGNEE>] [-c <CREATOR>] [-@ <USER>] [-s <STATE>] [-f <FORMAT>] [-M <MILESTONE>] [-l <LABELS>] [-d <DATE>] [--until <DATE>] [-o <SORT_KEY> [-^]] [-L <LIMIT>] [--reactions] [--filter <NAME>]
+issue list [<OPTIONS>]
+issue list --org <ORG> [<OPTIONS>]
 issue show [-f <FORMAT>] <NUMBER>
-issue create [-oc] [-m <MESSAGE>|-F <FILE>] [--edit] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>]
+issue create [-oc] [-m <MESSAGE>|-F <FILE>|--recover] [--edit] [--emoji] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>] [--project <PROJECT>]
 issue update <NUMBER> [-m <MESSAGE>|-F <FILE>] [--edit] [-a <USERS>] [-M <MILESTONE>] [-l <LABELS>] [-s <STATE>]
+issue close [--reason <REASON>] <NUMBER>
+issue reopen <NUMBER>
 issue labels [--color]
 issue transfer <NUMBER> <REPO>
+issue links <NUMBER>
+issue import --file <FILE> [--map <MAPPING>] [-l <LABELS>] [--dry-run]
 `,
 		Long: `Manage GitHub Issues for the current repository.
 
@@ -30,8 +39,16 @@ issue transfer <NUMBER> <REPO>
 
 With no arguments, show a list of open issues.
 
+	* _list_:
+		Show a list of issues, like ''hub issue'' does without a command.
+
+		With ''--org'', list the issues of all repositories in <ORG> instead,
+		grouped by repository. This uses the GitHub Search API, so it works
+		outside of a git repository and accepts the same filters, except that a
+		milestone is matched by its title.
+
 	* _show_:
-		Show an existing issue specified by <NUMBER>.
+		Show an existing issue specified by <NUMBER> along with its comments.
 
 	* _create_:
 		Open an issue in the current repository.
@@ -40,24 +57,47 @@ With no arguments, show a list of open issues.
 		Update fields of an existing issue specified by <NUMBER>. Use ''--edit''
 		to edit the title and message interactively in the text editor.
 
+	* _close_:
+		Close an existing issue specified by <NUMBER>.
+
+	* _reopen_:
+		Reopen a closed issue specified by <NUMBER>.
+
 	* _labels_:
 		List the labels available in this repository.
 
 	* _transfer_:
 		Transfer an issue to another repository.
 
+	* _links_:
+		Show a tree of the issues and pull requests that are linked to the issue
+		or pull request <NUMBER>: pull requests that close it or that it closes,
+		issues it tracks in a task list or that track it, duplicates, and other
+		issues and pull requests that reference it. Each item is listed once,
+		under the first of these relations that applies.
+
+	* _import_:
+		Create issues from the rows of a CSV file or the objects of a JSON array,
+		such as an export from another issue tracker. Issues are created one per
+		second, and hub pauses when GitHub reports that the rate limit was hit.
+
 ## Options:
 	-a, --assignee <ASSIGNEE>
-		In list mode, display only issues assigned to <ASSIGNEE>.
+		In list mode, display only issues assigned to <ASSIGNEE>. Use "@me" for
+		the authenticated user.
 
 	-a, --assign <USERS>
 		A comma-separated list of GitHub handles to assign to the created issue.
+		"@me" stands for the authenticated user, and "@<ORG>/<TEAM>" assigns
+		every member of a team.
 
 	-c, --creator <CREATOR>
-		Display only issues created by <CREATOR>.
+		Display only issues created by <CREATOR>. Use "@me" for the
+		authenticated user.
 
 	-@, --mentioned <USER>
-		Display only issues mentioning <USER>.
+		Display only issues mentioning <USER>. Use "@me" for the authenticated
+		user.
 
 	-s, --state <STATE>
 		Display issues with state <STATE> (default: "open").
@@ -98,6 +138,10 @@ With no arguments, show a list of open issues.
 
 		%Nc: number of comments wrapped in parentheses, or blank string if zero.
 
+		%Ru: number of 👍 reactions, with ''--reactions''
+
+		%Rd: number of 👎 reactions, with ''--reactions''
+
 		%cD: created date-only (no time of day)
 
 		%cr: created date, relative
@@ -130,7 +174,8 @@ With no arguments, show a list of open issues.
 		blank line in-between.
 
 		When neither ''--message'' nor ''--file'' were supplied to ''issue create'', a
-		text editor will open to author the title and description in.
+		text editor will open to author the title and description in. See "Editor
+		templates" in hub(1) for customizing its initial contents.
 
 	-F, --file <FILE>
 		Read the issue title and description from <FILE>. Pass "-" to read from
@@ -140,6 +185,14 @@ With no arguments, show a list of open issues.
 		Open the issue title and description in a text editor before submitting.
 		This can be used in combination with ''--message'' or ''--file''.
 
+	--emoji
+		Replace emoji shortcodes such as ":tada:" in the title and description
+		with their Unicode characters before submitting.
+
+	--recover
+		Reuse the title and description from a previous ''issue create'' that failed
+		after the text editor was used, without opening the editor again.
+
 	-o, --browse
 		Open the new issue in a web browser.
 
@@ -156,12 +209,27 @@ With no arguments, show a list of open issues.
 		Display only issues with certain labels.
 
 		When opening an issue, add a comma-separated list of labels to this issue.
+		For ''import'', ''--label'' is accepted as an alias.
+
+	--project <PROJECT>
+		When opening an issue, add it to the GitHub project with the number
+		<PROJECT>, owned by the owner of the current repository. Pass multiple
+		numbers separated by comma to add the issue to several projects.
 
 	-d, --since <DATE>
-		Display only issues updated on or after <DATE> in ISO 8601 format.
+		Display only issues updated on or after <DATE>, which is a date such as
+		"2021-03-15", a timestamp in ISO 8601 format, or a duration such as
+		"3d" or "2w" counted back from now.
+
+	--until <DATE>
+		Display only issues updated on or before <DATE>, in the same formats as
+		''--since''. A date includes the whole day.
 
 	-o, --sort <KEY>
-		Sort displayed issues by "created" (default), "updated" or "comments".
+		Sort displayed issues by "created" (default), "updated", "comments", or
+		"reactions". Sorting by "reactions" puts the issues with the most 👍
+		reactions first, which requires fetching all matching issues before any
+		are displayed.
 
 	-^ --sort-ascending
 		Sort by ascending dates instead of descending.
@@ -172,9 +240,58 @@ With no arguments, show a list of open issues.
 	--include-pulls
 		Include pull requests as well as issues.
 
+	--reactions
+		Include the number of 👍 and 👎 reactions to each issue in the output.
+
+	--org <ORG>
+		List issues across all repositories of the organization <ORG>.
+
+	--filter <NAME>
+		List issues using the named filter <NAME>, which is defined in git config
+		as ''hub.filter.<NAME>''. A filter is a space-separated list of
+		"<QUALIFIER>:<VALUE>" terms; values with spaces must be in double quotes.
+		The supported qualifiers are "state", "label", "assignee", "author",
+		"mentions", "milestone", "since", "until", "sort", "order" ("asc" or "desc"), and
+		"limit". Options passed on the command line take precedence over the
+		filter.
+
 	--color
 		Enable colored output for labels list.
 
+	--reason <REASON>
+		For ''close'', record why the issue was closed: "completed" (default) or
+		"not-planned".
+
+	--file <FILE>
+		For ''import'', the CSV or JSON file to read issues from. A file is read as
+		JSON if its name ends in ".json". The first row of a CSV file must name
+		its columns.
+
+	--map <MAPPING>
+		For ''import'', a comma-separated list of "<FIELD>=<COLUMN>" pairs that
+		name the column to read each issue field from. Supported fields are
+		"title", "body", "labels", "assignees", and "milestone". Fields that
+		aren't mapped are read from the column of the same name, if any. Labels
+		and assignees are separated by comma within a column.
+
+	--dry-run
+		For ''import'', preview the issues that would be created without creating
+		them.
+
+## Examples:
+		$ git config --global hub.filter.bugs "label:bug state:open sort:updated"
+		$ hub issue list --filter bugs
+
+		$ hub issue list --org acme --assignee @me
+
+		$ hub issue links 123
+		#123 Crash on empty remote [open]
+		├── closed by
+		│   └── #130 Handle empty remotes [merged]
+		└── referenced by
+		    ├── #128 Remote detection is slow [open]
+		    └── acme/deploy#5 Pin hub to v2.14 [closed]
+
 ## See also:
 
 hub-pr(1), hub(1)
@@ -188,14 +305,24 @@ hub-pr(1), hub(1)
 		-@, --mentioned USER
 		-l, --labels LIST
 		-d, --since DATE
+		--until DATE
 		-o, --sort KEY
 		-^, --sort-ascending
 		--include-pulls
+		--reactions
+		--filter NAME
+		--org ORG
 		-L, --limit N
 		--color
 `,
 	}
 
+	cmdListIssues = &Command{
+		Key:        "list",
+		Run:        listIssues,
+		KnownFlags: cmdIssue.KnownFlags,
+	}
+
 	cmdCreateIssue = &Command{
 		Key: "create",
 		Run: createIssue,
@@ -208,6 +335,8 @@ hub-pr(1), hub(1)
 		-o, --browse
 		-c, --copy
 		-e, --edit
+		--recover
+		--project PROJECT
 `,
 	}
 
@@ -220,6 +349,19 @@ hub-pr(1), hub(1)
 `,
 	}
 
+	cmdCloseIssue = &Command{
+		Key: "close",
+		Run: closeIssue,
+		KnownFlags: `
+		--reason REASON
+`,
+	}
+
+	cmdReopenIssue = &Command{
+		Key: "reopen",
+		Run: reopenIssue,
+	}
+
 	cmdLabel = &Command{
 		Key: "labels",
 		Run: listLabels,
@@ -233,6 +375,23 @@ hub-pr(1), hub(1)
 		Run: transferIssue,
 	}
 
+	cmdIssueLinks = &Command{
+		Key: "links",
+		Run: issueLinks,
+	}
+
+	cmdImportIssues = &Command{
+		Key: "import",
+		Run: importIssues,
+		KnownFlags: `
+		--file FILE
+		--map MAPPING
+		-l, --labels LIST
+		--label LIST
+		--dry-run
+`,
+	}
+
 	cmdUpdate = &Command{
 		Key: "update",
 		Run: updateIssue,
@@ -249,15 +408,26 @@ hub-pr(1), hub(1)
 )
 
 func init() {
+	cmdIssue.Use(cmdListIssues)
 	cmdIssue.Use(cmdShowIssue)
 	cmdIssue.Use(cmdCreateIssue)
 	cmdIssue.Use(cmdLabel)
 	cmdIssue.Use(cmdTransfer)
+	cmdIssue.Use(cmdIssueLinks)
 	cmdIssue.Use(cmdUpdate)
+	cmdIssue.Use(cmdCloseIssue)
+	cmdIssue.Use(cmdReopenIssue)
+	cmdIssue.Use(cmdImportIssues)
 	CmdRunner.Use(cmdIssue)
 }
 
 func listIssues(cmd *Command, args *Args) {
+	utils.Check(applyIssueFilter(args))
+	if args.Flag.HasReceived("--org") {
+		listOrgIssues(args)
+		return
+	}
+
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
 
@@ -273,32 +443,9 @@ func listIssues(cmd *Command, args *Args) {
 		if args.Flag.HasReceived("--state") {
 			filters["state"] = args.Flag.Value("--state")
 		}
-		if args.Flag.HasReceived("--assignee") {
-			filters["assignee"] = args.Flag.Value("--assignee")
-		}
-		if args.Flag.HasReceived("--milestone") {
-			milestoneValue := args.Flag.Value("--milestone")
-			if milestoneValue == "none" {
-				filters["milestone"] = milestoneValue
-			} else {
-				milestoneNumber, err := milestoneValueToNumber(milestoneValue, gh, project)
-				utils.Check(err)
-				if milestoneNumber > 0 {
-					filters["milestone"] = milestoneNumber
-				}
-			}
-		}
-		if args.Flag.HasReceived("--creator") {
-			filters["creator"] = args.Flag.Value("--creator")
-		}
-		if args.Flag.HasReceived("--mentioned") {
-			filters["mentioned"] = args.Flag.Value("--mentioned")
-		}
-		if args.Flag.HasReceived("--labels") {
-			labels := commaSeparated(args.Flag.AllValues("--labels"))
-			filters["labels"] = strings.Join(labels, ",")
-		}
-		if args.Flag.HasReceived("--sort") {
+		utils.Check(addIssueFilters(filters, args, gh, project))
+		sortByReactionCount := args.Flag.Value("--sort") == "reactions"
+		if args.Flag.HasReceived("--sort") && !sortByReactionCount {
 			filters["sort"] = args.Flag.Value("--sort")
 		}
 
@@ -308,43 +455,65 @@ func listIssues(cmd *Command, args *Args) {
 			filters["direction"] = "desc"
 		}
 
-		if args.Flag.HasReceived("--since") {
-			flagIssueSince := args.Flag.Value("--since")
-			if sinceTime, err := time.ParseInLocation("2006-01-02", flagIssueSince, time.Local); err == nil {
-				filters["since"] = sinceTime.Format(time.RFC3339)
-			} else {
-				filters["since"] = flagIssueSince
-			}
+		updated, err := timeRangeFromArgs(args, time.Now())
+		utils.Check(err)
+		if !updated.Since.IsZero() {
+			filters["since"] = updated.Since.Format(time.RFC3339)
 		}
 
 		flagIssueLimit := args.Flag.Int("--limit")
 		flagIssueIncludePulls := args.Flag.Bool("--include-pulls")
-		flagIssueFormat := "%sC%>(8)%i%Creset  %t%  l%n"
-		if args.Flag.HasReceived("--format") {
-			flagIssueFormat = args.Flag.Value("--format")
-		}
-
-		issues, err := gh.FetchIssues(project, filters, flagIssueLimit, func(issue *github.Issue) bool {
-			return issue.PullRequest == nil || flagIssueIncludePulls
-		})
-		utils.Check(err)
-
-		maxNumWidth := 0
-		for _, issue := range issues {
-			if numWidth := len(strconv.Itoa(issue.Number)); numWidth > maxNumWidth {
-				maxNumWidth = numWidth
-			}
-		}
+		flagIssueReactions := args.Flag.Bool("--reactions")
+		flagIssueFormat := issueListFormat(args)
 
 		colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
-		for _, issue := range issues {
-			ui.Print(formatIssue(issue, flagIssueFormat, colorize))
+		filter := func(issue *github.Issue) bool {
+			return (issue.PullRequest == nil || flagIssueIncludePulls) && updated.Includes(issue.UpdatedAt)
+		}
+		if flagIssueReactions || sortByReactionCount {
+			fetchLimit := flagIssueLimit
+			if sortByReactionCount {
+				fetchLimit = 0
+			}
+			issues := []*github.Issue{}
+			err = gh.EachIssue(project, filters, fetchLimit, filter, func(issue *github.Issue) error {
+				issueCopy := *issue
+				issues = append(issues, &issueCopy)
+				return nil
+			})
+			utils.Check(err)
+			utils.Check(fetchMissingReactions(gh, project, issues))
+			if sortByReactionCount {
+				sortByReactions(issues, args.Flag.Bool("--sort-ascending"))
+				if flagIssueLimit > 0 && len(issues) > flagIssueLimit {
+					issues = issues[:flagIssueLimit]
+				}
+			}
+			for _, issue := range issues {
+				ui.Print(formatIssue(*issue, flagIssueFormat, colorize))
+			}
+		} else {
+			err = gh.EachIssue(project, filters, flagIssueLimit, filter, func(issue *github.Issue) error {
+				ui.Print(formatIssue(*issue, flagIssueFormat, colorize))
+				return nil
+			})
+			utils.Check(err)
 		}
 	}
 
 	args.NoForward()
 }
 
+// issueListFormat is the format for each issue in a listing.
+func issueListFormat(args *Args) string {
+	if args.Flag.HasReceived("--format") {
+		return args.Flag.Value("--format")
+	} else if args.Flag.Bool("--reactions") {
+		return reactionsListFormat("%sC%>(8)%i%Creset  %t%  l")
+	}
+	return "%sC%>(8)%i%Creset  %t%  l%n"
+}
+
 func formatIssuePlaceholders(issue github.Issue, colorize bool) map[string]string {
 	var stateColorSwitch string
 	if colorize {
@@ -385,6 +554,12 @@ func formatIssuePlaceholders(issue github.Issue, colorize bool) map[string]strin
 		numCommentsWrapped = fmt.Sprintf("(%d)", issue.Comments)
 	}
 
+	var reactionsUp, reactionsDown string
+	if issue.Reactions != nil {
+		reactionsUp = fmt.Sprintf("%d", issue.Reactions.PlusOne)
+		reactionsDown = fmt.Sprintf("%d", issue.Reactions.MinusOne)
+	}
+
 	var createdDate, createdAtISO8601, createdAtUnix, createdAtRelative,
 		updatedDate, updatedAtISO8601, updatedAtUnix, updatedAtRelative string
 	if !issue.CreatedAt.IsZero() {
@@ -416,6 +591,8 @@ func formatIssuePlaceholders(issue github.Issue, colorize bool) map[string]strin
 		"Mt": milestoneTitle,
 		"NC": numComments,
 		"Nc": numCommentsWrapped,
+		"Ru": reactionsUp,
+		"Rd": reactionsDown,
 		"cD": createdDate,
 		"cI": createdAtISO8601,
 		"ct": createdAtUnix,
@@ -532,7 +709,12 @@ func showIssue(cmd *Command, args *Args) {
 	commentsList, err := gh.FetchComments(project, issueNumber)
 	utils.Check(err)
 
-	ui.Printf("# %s%s\n\n", closed, issue.Title)
+	emoji := func(text string) string { return text }
+	if ui.IsTerminal(os.Stdout) {
+		emoji = emojiExpander(gh)
+	}
+
+	ui.Printf("# %s%s\n\n", closed, emoji(issue.Title))
 	ui.Printf("* created by @%s on %s\n", issue.User.Login, issue.CreatedAt.String())
 
 	if len(issue.Assignees) > 0 {
@@ -543,12 +725,12 @@ func showIssue(cmd *Command, args *Args) {
 		ui.Printf("* assignees: %s\n", strings.Join(assignees, ", "))
 	}
 
-	ui.Printf("\n%s\n", issue.Body)
+	ui.Printf("\n%s\n", emoji(issue.Body))
 
 	if issue.Comments > 0 {
 		ui.Printf("\n## Comments:\n")
 		for _, comment := range commentsList {
-			ui.Printf("\n### comment by @%s on %s\n\n%s\n", comment.User.Login, comment.CreatedAt.String(), comment.Body)
+			ui.Printf("\n### comment by @%s on %s\n\n%s\n", comment.User.Login, comment.CreatedAt.String(), emoji(comment.Body))
 		}
 	}
 }
@@ -567,14 +749,21 @@ func createIssue(cmd *Command, args *Args) {
 		Title:    "issue",
 	}
 
+	milestoneNumber := prefetchMilestoneNumber(args, gh, project)
+	assignees := prefetchUsers(args, gh, "--assign", (*userResolver).logins)
+	emoji := prefetchEmojiExpander(gh, args.Flag.Bool("--emoji") && !args.Noop)
+
 	messageBuilder.AddCommentedSection(fmt.Sprintf(`Creating an issue for %s
 
 Write a message for this issue. The first block of
 text is the title and the rest is the description.`, project))
 
+	var templateLabels []string
 	flagIssueEdit := args.Flag.Bool("--edit")
 	flagIssueMessage := args.Flag.AllValues("--message")
-	if len(flagIssueMessage) > 0 {
+	if args.Flag.Bool("--recover") {
+		messageBuilder.Recover = true
+	} else if len(flagIssueMessage) > 0 {
 		messageBuilder.Message = strings.Join(flagIssueMessage, "\n\n")
 		messageBuilder.Edit = flagIssueEdit
 	} else if args.Flag.HasReceived("--file") {
@@ -584,12 +773,30 @@ text is the title and the rest is the description.`, project))
 	} else {
 		messageBuilder.Edit = true
 
+		branch := ""
+		if currentBranch, err := localRepo.CurrentBranch(); err == nil {
+			branch = currentBranch.ShortName()
+		}
+
 		workdir, _ := git.WorkdirName()
-		if workdir != "" {
-			template, err := github.ReadTemplate(github.IssueTemplate, workdir)
-			utils.Check(err)
-			if template != "" {
-				messageBuilder.Message = template
+		if template, found := editorTemplate(github.HubIssueTemplate, map[string]string{
+			"repo":   project.String(),
+			"branch": branch,
+		}); found {
+			messageBuilder.Message = template
+		} else if workdir != "" {
+			if choice, ok := chooseTemplate(github.IssueTemplate, workdir, "Blank issue"); ok {
+				messageBuilder.Message = choice.Body
+				if choice.Title != "" {
+					messageBuilder.Message = choice.Title + "\n\n" + choice.Body
+				}
+				templateLabels = choice.Labels
+			} else {
+				template, err := github.ReadTemplate(github.IssueTemplate, workdir)
+				utils.Check(err)
+				if template != "" {
+					messageBuilder.Message = template
+				}
 			}
 		}
 
@@ -602,23 +809,42 @@ text is the title and the rest is the description.`, project))
 		utils.Check(fmt.Errorf("Aborting creation due to empty issue title"))
 	}
 
+	if !args.Noop {
+		title, body, err = expandReferences(gh, project, title, body)
+		utils.Check(messageBuilder.RecoverableError(err))
+
+		if args.Flag.Bool("--emoji") {
+			expand := emoji()
+			title, body = expand(title), expand(body)
+		}
+	}
+
 	params := map[string]interface{}{
 		"title": title,
 		"body":  body,
 	}
 
 	setLabelsFromArgs(params, args)
+	if len(templateLabels) > 0 {
+		labels, _ := params["labels"].([]string)
+		params["labels"] = append(templateLabels, labels...)
+	}
 
-	setAssigneesFromArgs(params, args)
+	setAssigneesFromArgs(params, args, assignees)
 
-	setMilestoneFromArgs(params, args, gh, project)
+	setMilestoneFromArgs(params, args, milestoneNumber)
 
 	args.NoForward()
 	if args.Noop {
 		ui.Printf("Would create issue `%s' for %s\n", params["title"], project)
 	} else {
 		issue, err := gh.CreateIssue(project, params)
-		utils.Check(err)
+		utils.Check(messageBuilder.RecoverableError(err))
+
+		if flagIssueProjects := commaSeparated(args.Flag.AllValues("--project")); len(flagIssueProjects) > 0 {
+			_, err = addToProjectsV2(gh, project.Owner, flagIssueProjects, issue.NodeID)
+			utils.Check(err)
+		}
 
 		flagIssueBrowse := args.Flag.Bool("--browse")
 		flagIssueCopy := args.Flag.Bool("--copy")
@@ -650,8 +876,8 @@ func updateIssue(cmd *Command, args *Args) {
 
 	params := map[string]interface{}{}
 	setLabelsFromArgs(params, args)
-	setAssigneesFromArgs(params, args)
-	setMilestoneFromArgs(params, args, gh, project)
+	setAssigneesFromArgs(params, args, prefetchUsers(args, gh, "--assign", (*userResolver).logins))
+	setMilestoneFromArgs(params, args, prefetchMilestoneNumber(args, gh, project))
 
 	if args.Flag.HasReceived("--state") {
 		params["state"] = args.Flag.Value("--state")
@@ -701,6 +927,51 @@ text is the title and the rest is the description.`, issueNumber, project))
 	}
 }
 
+func closeIssue(cmd *Command, args *Args) {
+	params := map[string]interface{}{"state": "closed"}
+	if args.Flag.HasReceived("--reason") {
+		switch reason := args.Flag.Value("--reason"); reason {
+		case "completed":
+			params["state_reason"] = "completed"
+		case "not-planned", "not_planned":
+			params["state_reason"] = "not_planned"
+		default:
+			utils.Check(fmt.Errorf("invalid close reason: '%s'; expected \"completed\" or \"not-planned\"", reason))
+		}
+	}
+	setIssueState(cmd, args, "close", params)
+}
+
+func reopenIssue(cmd *Command, args *Args) {
+	setIssueState(cmd, args, "reopen", map[string]interface{}{"state": "open"})
+}
+
+func setIssueState(cmd *Command, args *Args, action string, params map[string]interface{}) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	issueNumber, err := strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	if err != nil {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would %s issue #%d for %s\n", action, issueNumber, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	err = gh.UpdateIssue(project, issueNumber, params)
+	utils.Check(err)
+}
+
 func listLabels(cmd *Command, args *Args) {
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
@@ -742,23 +1013,55 @@ func setLabelsFromArgs(params map[string]interface{}, args *Args) {
 	params["labels"] = commaSeparated(args.Flag.AllValues("--labels"))
 }
 
-func setAssigneesFromArgs(params map[string]interface{}, args *Args) {
+func setAssigneesFromArgs(params map[string]interface{}, args *Args, assignees func() ([]string, error)) {
 	if !args.Flag.HasReceived("--assign") {
 		return
 	}
-	params["assignees"] = commaSeparated(args.Flag.AllValues("--assign"))
+	logins, err := assignees()
+	utils.Check(err)
+	params["assignees"] = logins
 }
 
-func setMilestoneFromArgs(params map[string]interface{}, args *Args, gh *github.Client, project *github.Project) {
+func setMilestoneFromArgs(params map[string]interface{}, args *Args, milestoneNumber func() (int, error)) {
 	if !args.Flag.HasReceived("--milestone") {
 		return
 	}
-	milestoneNumber, err := milestoneValueToNumber(args.Flag.Value("--milestone"), gh, project)
+	number, err := milestoneNumber()
 	utils.Check(err)
-	if milestoneNumber == 0 {
+	if number == 0 {
 		params["milestone"] = nil
 	} else {
-		params["milestone"] = milestoneNumber
+		params["milestone"] = number
+	}
+}
+
+// prefetchMilestoneNumber starts resolving the value of `--milestone` in
+// the background, so that looking up a milestone by name doesn't keep the user
+// waiting once they close their editor.
+func prefetchMilestoneNumber(args *Args, gh *github.Client, project *github.Project) func() (int, error) {
+	var number int
+	wait := prefetch(gh, func() (err error) {
+		number, err = milestoneValueToNumber(args.Flag.Value("--milestone"), gh, project)
+		return
+	})
+	return func() (int, error) {
+		err := wait()
+		return number, err
+	}
+}
+
+// prefetchUsers starts resolving the handles given with flag in the
+// background, like prefetchMilestoneNumber, since expanding "@me" or a team
+// takes a request of its own.
+func prefetchUsers(args *Args, gh *github.Client, flag string, resolve func(*userResolver, []string) ([]string, error)) func() ([]string, error) {
+	var users []string
+	wait := prefetch(gh, func() (err error) {
+		users, err = resolve(newUserResolver(gh, args.Noop), commaSeparated(args.Flag.AllValues(flag)))
+		return
+	})
+	return func() ([]string, error) {
+		err := wait()
+		return users, err
 	}
 }
 
@@ -826,6 +1129,46 @@ func pickHighContrastTextColor(color *utils.Color) *utils.Color {
 	return utils.Black
 }
 
+// addIssueFilters maps the --assignee, --milestone, --creator, --mentioned,
+// and --labels flags onto query parameters of the issues API.
+func addIssueFilters(filters map[string]interface{}, args *Args, gh *github.Client, project *github.Project) error {
+	users := newUserResolver(gh, args.Noop)
+	for _, flag := range []string{"--assignee", "--creator", "--mentioned"} {
+		if !args.Flag.HasReceived(flag) {
+			continue
+		}
+		value := args.Flag.Value(flag)
+		if flag == "--assignee" && (value == "none" || value == "*") {
+			filters["assignee"] = value
+			continue
+		}
+		login, err := users.login(value)
+		if err != nil {
+			return err
+		}
+		filters[strings.TrimPrefix(flag, "--")] = login
+	}
+	if args.Flag.HasReceived("--milestone") {
+		milestoneValue := args.Flag.Value("--milestone")
+		if milestoneValue == "none" || milestoneValue == "*" {
+			filters["milestone"] = milestoneValue
+		} else {
+			milestoneNumber, err := milestoneValueToNumber(milestoneValue, gh, project)
+			if err != nil {
+				return err
+			}
+			if milestoneNumber > 0 {
+				filters["milestone"] = milestoneNumber
+			}
+		}
+	}
+	if args.Flag.HasReceived("--labels") {
+		labels := commaSeparated(args.Flag.AllValues("--labels"))
+		filters["labels"] = strings.Join(labels, ",")
+	}
+	return nil
+}
+
 func milestoneValueToNumber(value string, client *github.Client, project *github.Project) (int, error) {
 	if value == "" {
 		return 0, nil
@@ -835,7 +1178,7 @@ func milestoneValueToNumber(value string, client *github.Client, project *github
 		return milestoneNumber, nil
 	}
 
-	milestones, err := client.FetchMilestones(project)
+	milestones, err := client.FetchMilestones(project, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -848,6 +1191,197 @@ func milestoneValueToNumber(value string, client *github.Client, project *github
 	return 0, fmt.Errorf("error: no milestone found with name '%s'", value)
 }
 
+// issueImportDelay is the pause between creating issues during import, as
+// recommended by GitHub for requests that create content.
+var issueImportDelay = time.Second
+
+var issueImportFields = []string{"title", "body", "labels", "assignees", "milestone"}
+
+func importIssues(cmd *Command, args *Args) {
+	if !args.Flag.HasReceived("--file") {
+		utils.Check(cmd.UsageError("--file is required"))
+	}
+
+	mapping, err := parseIssueImportMapping(args.Flag.Value("--map"))
+	utils.Check(err)
+
+	filename := args.Flag.Value("--file")
+	file, err := os.Open(filename)
+	utils.Check(err)
+	defer file.Close()
+
+	var records []map[string]string
+	if strings.HasSuffix(strings.ToLower(filename), ".json") {
+		records, err = readJSONRecords(file)
+	} else {
+		records, err = readCSVRecords(file)
+	}
+	utils.Check(err)
+
+	extraLabels := commaSeparated(append(args.Flag.AllValues("--labels"), args.Flag.AllValues("--label")...))
+	issues := []map[string]interface{}{}
+	for i, record := range records {
+		params, err := issueImportParams(record, mapping, extraLabels)
+		if err != nil {
+			utils.Check(fmt.Errorf("error in record %d of %s: %s", i+1, filename, err))
+		}
+		issues = append(issues, params)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop || args.Flag.Bool("--dry-run") {
+		for _, params := range issues {
+			ui.Printf("Would create issue `%s'", params["title"])
+			if labels, ok := params["labels"].([]string); ok {
+				ui.Printf(" with labels %s", strings.Join(labels, ", "))
+			}
+			ui.Println()
+		}
+		ui.Printf("Would import %d %s into %s\n", len(issues), pluralize(len(issues), "issue"), project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	milestones := map[string]int{}
+	for i, params := range issues {
+		if name, ok := params["milestone"].(string); ok {
+			if _, found := milestones[name]; !found {
+				milestones[name], err = milestoneValueToNumber(name, gh, project)
+				utils.Check(err)
+			}
+			params["milestone"] = milestones[name]
+		}
+
+		if i > 0 {
+			time.Sleep(issueImportDelay)
+		}
+		for {
+			issue, err := gh.CreateIssue(project, params)
+			if rateLimited, ok := err.(*github.RateLimitedError); ok {
+				ui.Errorf("API rate limit exceeded; pausing for %s ...\n", rateLimited.RetryAfter.Round(time.Second))
+				time.Sleep(rateLimited.RetryAfter)
+				continue
+			}
+			utils.Check(err)
+			ui.Println(issue.HTMLURL)
+			break
+		}
+	}
+}
+
+// parseIssueImportMapping parses "FIELD=COLUMN" pairs into a map of issue
+// fields to column names.
+func parseIssueImportMapping(value string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, field := range issueImportFields {
+		mapping[field] = field
+	}
+
+	for _, pair := range splitTrimmed(value) {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("error: invalid mapping '%s'; expected FIELD=COLUMN", pair)
+		}
+		if _, ok := mapping[kv[0]]; !ok {
+			return nil, fmt.Errorf("error: unknown issue field '%s'; supported fields are: %s", kv[0], strings.Join(issueImportFields, ", "))
+		}
+		mapping[kv[0]] = kv[1]
+	}
+	return mapping, nil
+}
+
+// issueImportParams builds the parameters to create an issue from a record.
+func issueImportParams(record map[string]string, mapping map[string]string, extraLabels []string) (map[string]interface{}, error) {
+	title := strings.TrimSpace(record[mapping["title"]])
+	if title == "" {
+		return nil, fmt.Errorf("missing title in column '%s'", mapping["title"])
+	}
+	params := map[string]interface{}{"title": title}
+
+	if body := record[mapping["body"]]; body != "" {
+		params["body"] = body
+	}
+	labels := append(splitTrimmed(record[mapping["labels"]]), extraLabels...)
+	if len(labels) > 0 {
+		params["labels"] = labels
+	}
+	if assignees := splitTrimmed(record[mapping["assignees"]]); len(assignees) > 0 {
+		params["assignees"] = assignees
+	}
+	if milestone := strings.TrimSpace(record[mapping["milestone"]]); milestone != "" {
+		params["milestone"] = milestone
+	}
+	return params, nil
+}
+
+// splitTrimmed splits a comma-separated list, dropping blank items.
+func splitTrimmed(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func readCSVRecords(r io.Reader) ([]map[string]string, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []map[string]string{}
+	if len(rows) == 0 {
+		return records, nil
+	}
+	header := rows[0]
+	for _, row := range rows[1:] {
+		record := map[string]string{}
+		for i, value := range row {
+			if i < len(header) {
+				record[header[i]] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func readJSONRecords(r io.Reader) ([]map[string]string, error) {
+	objects := []map[string]interface{}{}
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, err
+	}
+
+	records := []map[string]string{}
+	for _, object := range objects {
+		record := map[string]string{}
+		for key, value := range object {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				record[key] = v
+			case []interface{}:
+				values := []string{}
+				for _, item := range v {
+					values = append(values, fmt.Sprint(item))
+				}
+				record[key] = strings.Join(values, ",")
+			default:
+				record[key] = fmt.Sprint(v)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
 func transferIssue(cmd *Command, args *Args) {
 	if args.ParamsSize() < 2 {
 		utils.Check(cmd.UsageError(""))
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/hub/v2/git"
+)
+
+// issueFilterFlags maps the qualifiers of a named filter to the listing flags
+// of the issue command.
+var issueFilterFlags = map[string]string{
+	"state":     "--state",
+	"label":     "--labels",
+	"labels":    "--labels",
+	"assignee":  "--assignee",
+	"author":    "--creator",
+	"creator":   "--creator",
+	"mentions":  "--mentioned",
+	"mentioned": "--mentioned",
+	"milestone": "--milestone",
+	"since":     "--since",
+	"until":     "--until",
+	"sort":      "--sort",
+	"order":     "--sort-ascending",
+	"limit":     "--limit",
+}
+
+// applyIssueFilter sets the listing flags from the filter named by
+// `--filter`, which is read from the `hub.filter.<NAME>` git config. Flags
+// passed on the command line take precedence over the filter.
+func applyIssueFilter(args *Args) error {
+	if !args.Flag.HasReceived("--filter") {
+		return nil
+	}
+	name := args.Flag.Value("--filter")
+	query, err := git.Config("hub.filter." + name)
+	if err != nil || query == "" {
+		return fmt.Errorf("error: no filter named '%s'; define one with `git config hub.filter.%s \"label:bug state:open\"`", name, name)
+	}
+
+	flags, err := parseIssueFilter(query)
+	if err != nil {
+		return fmt.Errorf("error: invalid filter '%s': %s", name, err)
+	}
+	for _, flag := range flags {
+		if err := args.Flag.SetDefault(flag[0], flag[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseIssueFilter turns a query such as `label:bug label:"help wanted"
+// sort:updated` into pairs of flags and values. Repeated labels are combined.
+func parseIssueFilter(query string) ([][2]string, error) {
+	flags := [][2]string{}
+	labels := []string{}
+	for _, term := range splitFilterTerms(query) {
+		parts := strings.SplitN(term, ":", 2)
+		flag, known := issueFilterFlags[strings.ToLower(parts[0])]
+		if len(parts) != 2 || !known {
+			return nil, fmt.Errorf("unsupported term '%s'", term)
+		}
+		value := strings.Trim(parts[1], `"`)
+
+		switch flag {
+		case "--labels":
+			labels = append(labels, value)
+		case "--sort-ascending":
+			if value != "asc" && value != "desc" {
+				return nil, fmt.Errorf("order must be 'asc' or 'desc', got '%s'", value)
+			}
+			if value == "asc" {
+				flags = append(flags, [2]string{flag, ""})
+			}
+		default:
+			flags = append(flags, [2]string{flag, value})
+		}
+	}
+	if len(labels) > 0 {
+		flags = append(flags, [2]string{"--labels", strings.Join(labels, ",")})
+	}
+	return flags, nil
+}
+
+// splitFilterTerms splits query on whitespace outside of double quotes.
+func splitFilterTerms(query string) []string {
+	terms := []string{}
+	term := strings.Builder{}
+	quoted := false
+	for _, c := range query {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			term.WriteRune(c)
+		case (c == ' ' || c == '\t') && !quoted:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(c)
+		}
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
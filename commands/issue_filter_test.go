@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestParseIssueFilter(t *testing.T) {
+	flags, err := parseIssueFilter(`label:bug  state:open label:"help wanted" sort:updated order:asc`)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][2]string{
+		{"--state", "open"},
+		{"--sort", "updated"},
+		{"--sort-ascending", ""},
+		{"--labels", "bug,help wanted"},
+	}, flags)
+
+	flags, err = parseIssueFilter("Author:octocat order:desc")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, [][2]string{{"--creator", "octocat"}}, flags)
+
+	_, err = parseIssueFilter("label:bug is:open")
+	assert.Equal(t, "unsupported term 'is:open'", err.Error())
+
+	_, err = parseIssueFilter("bug")
+	assert.Equal(t, "unsupported term 'bug'", err.Error())
+
+	_, err = parseIssueFilter("order:newest")
+	assert.Equal(t, "order must be 'asc' or 'desc', got 'newest'", err.Error())
+}
@@ -0,0 +1,265 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+// issueLinkRelations lists the ways in which items can be linked, in the
+// order in which they are shown.
+var issueLinkRelations = []string{
+	"closes",
+	"closed by",
+	"tracks",
+	"tracked by",
+	"duplicate of",
+	"duplicates",
+	"referenced by",
+}
+
+type linkedItem struct {
+	Number     int
+	Title      string
+	State      string
+	URL        string
+	Repository struct {
+		NameWithOwner string
+	}
+}
+
+type issueLink struct {
+	Relation string
+	Item     linkedItem
+}
+
+type linkedItemConnection struct {
+	Nodes []linkedItem
+}
+
+type issueLinksTimeline struct {
+	Nodes []struct {
+		WillCloseTarget bool
+		Source          *linkedItem
+		Canonical       *linkedItem
+		Duplicate       *linkedItem
+	}
+}
+
+func issueLinks(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	if err != nil {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would show the items linked to #%d in %s\n", number, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	subject, links, err := fetchIssueLinks(gh, project, number)
+	utils.Check(err)
+
+	ui.Print(formatIssueLinks(subject, links, project.String()))
+}
+
+// fetchIssueLinks looks up an issue or a pull request along with the items
+// that are linked to it.
+func fetchIssueLinks(gh *github.Client, project *github.Project, number int) (linkedItem, []issueLink, error) {
+	response := struct {
+		Repository struct {
+			IssueOrPullRequest *struct {
+				linkedItem
+				ClosedByPullRequestsReferences linkedItemConnection
+				ClosingIssuesReferences        linkedItemConnection
+				TrackedIssues                  linkedItemConnection
+				TrackedInIssues                linkedItemConnection
+				IssueTimeline                  issueLinksTimeline
+				PullRequestTimeline            issueLinksTimeline
+			}
+		}
+	}{}
+	err := gh.GraphQL(`
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			issueOrPullRequest(number: $number) {
+				...linkedItem
+				... on Issue {
+					closedByPullRequestsReferences(first: 50, includeClosedPrs: true) {
+						nodes { ...linkedItem }
+					}
+					trackedIssues(first: 50) {
+						nodes { ...linkedItem }
+					}
+					trackedInIssues(first: 50) {
+						nodes { ...linkedItem }
+					}
+					issueTimeline: timelineItems(first: 100, itemTypes: [CROSS_REFERENCED_EVENT, MARKED_AS_DUPLICATE_EVENT]) {
+						nodes {
+							... on CrossReferencedEvent {
+								willCloseTarget
+								source { ...linkedItem }
+							}
+							... on MarkedAsDuplicateEvent {
+								canonical { ...linkedItem }
+								duplicate { ...linkedItem }
+							}
+						}
+					}
+				}
+				... on PullRequest {
+					closingIssuesReferences(first: 50) {
+						nodes { ...linkedItem }
+					}
+					pullRequestTimeline: timelineItems(first: 100, itemTypes: [CROSS_REFERENCED_EVENT, MARKED_AS_DUPLICATE_EVENT]) {
+						nodes {
+							... on CrossReferencedEvent {
+								willCloseTarget
+								source { ...linkedItem }
+							}
+							... on MarkedAsDuplicateEvent {
+								canonical { ...linkedItem }
+								duplicate { ...linkedItem }
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	fragment linkedItem on IssueOrPullRequest {
+		... on Issue {
+			number
+			title
+			state
+			url
+			repository { nameWithOwner }
+		}
+		... on PullRequest {
+			number
+			title
+			state
+			url
+			repository { nameWithOwner }
+		}
+	}`, map[string]interface{}{
+		"owner":  project.Owner,
+		"repo":   project.Name,
+		"number": number,
+	}, &response)
+	if err != nil {
+		return linkedItem{}, nil, err
+	}
+
+	item := response.Repository.IssueOrPullRequest
+	if item == nil {
+		return linkedItem{}, nil, fmt.Errorf("Error: no issue or pull request #%d in %s", number, project)
+	}
+
+	links := []issueLink{}
+	add := func(relation string, nodes []linkedItem) {
+		for _, node := range nodes {
+			links = append(links, issueLink{Relation: relation, Item: node})
+		}
+	}
+	add("closes", item.ClosingIssuesReferences.Nodes)
+	add("closed by", item.ClosedByPullRequestsReferences.Nodes)
+	add("tracks", item.TrackedIssues.Nodes)
+	add("tracked by", item.TrackedInIssues.Nodes)
+	events := append(item.IssueTimeline.Nodes, item.PullRequestTimeline.Nodes...)
+	for _, event := range events {
+		switch {
+		case event.Source != nil && event.WillCloseTarget:
+			links = append(links, issueLink{Relation: "closed by", Item: *event.Source})
+		case event.Source != nil:
+			links = append(links, issueLink{Relation: "referenced by", Item: *event.Source})
+		case event.Canonical != nil && event.Duplicate != nil:
+			if sameLinkedItem(*event.Duplicate, item.linkedItem) {
+				links = append(links, issueLink{Relation: "duplicate of", Item: *event.Canonical})
+			} else {
+				links = append(links, issueLink{Relation: "duplicates", Item: *event.Duplicate})
+			}
+		}
+	}
+
+	return item.linkedItem, links, nil
+}
+
+func sameLinkedItem(a, b linkedItem) bool {
+	return a.Number == b.Number && strings.EqualFold(a.Repository.NameWithOwner, b.Repository.NameWithOwner)
+}
+
+// formatIssueLinks renders the links of subject as a tree grouped by
+// relation. Items are listed once, under the first relation that applies,
+// and those from other repositories than repo are shown with their full name.
+func formatIssueLinks(subject linkedItem, links []issueLink, repo string) string {
+	describe := func(item linkedItem) string {
+		ref := fmt.Sprintf("#%d", item.Number)
+		if item.Repository.NameWithOwner != "" && !strings.EqualFold(item.Repository.NameWithOwner, repo) {
+			ref = item.Repository.NameWithOwner + ref
+		}
+		return fmt.Sprintf("%s %s [%s]", ref, item.Title, strings.ToLower(item.State))
+	}
+
+	groups := map[string][]linkedItem{}
+	seen := []linkedItem{subject}
+	for _, relation := range issueLinkRelations {
+	links:
+		for _, link := range links {
+			if link.Relation != relation {
+				continue
+			}
+			for _, item := range seen {
+				if sameLinkedItem(item, link.Item) {
+					continue links
+				}
+			}
+			seen = append(seen, link.Item)
+			groups[relation] = append(groups[relation], link.Item)
+		}
+	}
+
+	relations := []string{}
+	for _, relation := range issueLinkRelations {
+		if len(groups[relation]) > 0 {
+			relations = append(relations, relation)
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s\n", describe(subject))
+	if len(relations) == 0 {
+		out.WriteString("└── no linked issues or pull requests\n")
+	}
+	for i, relation := range relations {
+		branch, indent := "├── ", "│   "
+		if i == len(relations)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(&out, "%s%s\n", branch, relation)
+		items := groups[relation]
+		for j, item := range items {
+			leaf := "├── "
+			if j == len(items)-1 {
+				leaf = "└── "
+			}
+			fmt.Fprintf(&out, "%s%s%s\n", indent, leaf, describe(item))
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatIssueLinks(t *testing.T) {
+	item := func(repo string, number int, title, state string) linkedItem {
+		i := linkedItem{Number: number, Title: title, State: state}
+		i.Repository.NameWithOwner = repo
+		return i
+	}
+	subject := item("github/hub", 123, "Crash on empty remote", "OPEN")
+	links := []issueLink{
+		{"closed by", item("github/hub", 130, "Handle empty remotes", "MERGED")},
+		{"referenced by", item("github/hub", 130, "Handle empty remotes", "MERGED")},
+		{"referenced by", item("github/hub", 128, "Remote detection is slow", "OPEN")},
+		{"referenced by", item("acme/deploy", 5, "Pin hub to v2.14", "CLOSED")},
+		{"referenced by", item("github/hub", 123, "Crash on empty remote", "OPEN")},
+	}
+
+	assert.Equal(t, `#123 Crash on empty remote [open]
+├── closed by
+│   └── #130 Handle empty remotes [merged]
+└── referenced by
+    ├── #128 Remote detection is slow [open]
+    └── acme/deploy#5 Pin hub to v2.14 [closed]
+`, formatIssueLinks(subject, links, "github/hub"))
+
+	assert.Equal(t, `#123 Crash on empty remote [open]
+└── no linked issues or pull requests
+`, formatIssueLinks(subject, nil, "GitHub/Hub"))
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+// listOrgIssues lists the issues of all repositories of an organization with
+// the Search API, grouped by repository.
+func listOrgIssues(args *Args) {
+	org := args.Flag.Value("--org")
+	_, host := projectOrDefaultHost()
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would search issues in the %s organization\n", org)
+		return
+	}
+
+	gh := github.NewClient(host)
+	params := orgIssueSearchParams(args)
+	issues, err := gh.SearchIssues(params, args.Flag.Int("--limit"))
+	utils.Check(err)
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	ui.Print(formatOrgIssues(issues, issueListFormat(args), colorize))
+}
+
+// orgIssueSearchParams translates the listing flags to a query of the Search
+// API.
+func orgIssueSearchParams(args *Args) map[string]interface{} {
+	terms := []string{"org:" + args.Flag.Value("--org")}
+	if !args.Flag.Bool("--include-pulls") {
+		terms = append(terms, "is:issue")
+	}
+	switch state := args.Flag.Value("--state"); state {
+	case "", "open":
+		terms = append(terms, "is:open")
+	case "closed":
+		terms = append(terms, "is:closed")
+	}
+
+	quote := func(value string) string {
+		if strings.ContainsAny(value, " \t") {
+			return fmt.Sprintf("%q", value)
+		}
+		return value
+	}
+	if assignee := args.Flag.Value("--assignee"); assignee != "" {
+		terms = append(terms, "assignee:"+assignee)
+	}
+	if creator := args.Flag.Value("--creator"); creator != "" {
+		terms = append(terms, "author:"+creator)
+	}
+	if mentioned := args.Flag.Value("--mentioned"); mentioned != "" {
+		terms = append(terms, "mentions:"+mentioned)
+	}
+	if milestone := args.Flag.Value("--milestone"); milestone == "none" {
+		terms = append(terms, "no:milestone")
+	} else if milestone != "" {
+		terms = append(terms, "milestone:"+quote(milestone))
+	}
+	for _, label := range commaSeparated(args.Flag.AllValues("--labels")) {
+		terms = append(terms, "label:"+quote(label))
+	}
+	updated, err := timeRangeFromArgs(args, time.Now())
+	utils.Check(err)
+	if !updated.Since.IsZero() {
+		terms = append(terms, "updated:>="+updated.Since.UTC().Format(time.RFC3339))
+	}
+	if !updated.Until.IsZero() {
+		terms = append(terms, "updated:<="+updated.Until.UTC().Format(time.RFC3339))
+	}
+
+	params := map[string]interface{}{"q": strings.Join(terms, " ")}
+	if sortKey := args.Flag.Value("--sort"); sortKey == "reactions" {
+		params["sort"] = "reactions-+1"
+	} else if sortKey != "" {
+		params["sort"] = sortKey
+	}
+	if args.Flag.Bool("--sort-ascending") {
+		params["order"] = "asc"
+	} else {
+		params["order"] = "desc"
+	}
+	return params
+}
+
+// formatOrgIssues renders issues under a heading for each repository, with
+// repositories in alphabetical order.
+func formatOrgIssues(issues []github.Issue, format string, colorize bool) string {
+	groups := map[string][]github.Issue{}
+	repos := []string{}
+	for _, issue := range issues {
+		repo := issue.RepositoryURL
+		if i := strings.Index(repo, "/repos/"); i >= 0 {
+			repo = repo[i+len("/repos/"):]
+		}
+		if _, seen := groups[repo]; !seen {
+			repos = append(repos, repo)
+		}
+		groups[repo] = append(groups[repo], issue)
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		return strings.ToLower(repos[i]) < strings.ToLower(repos[j])
+	})
+
+	var out strings.Builder
+	for i, repo := range repos {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "%s\n", repo)
+		for _, issue := range groups[repo] {
+			out.WriteString(formatIssue(issue, format, colorize))
+		}
+	}
+	return out.String()
+}
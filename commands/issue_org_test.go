@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestOrgIssueSearchParams(t *testing.T) {
+	args := NewArgs([]string{"issue", "--org", "acme", "-a", "@me", "-l", "bug,help wanted", "-s", "all", "-o", "reactions"})
+	assert.Equal(t, nil, cmdIssue.parseArguments(args))
+	assert.Equal(t, map[string]interface{}{
+		"q":     `org:acme is:issue assignee:@me label:bug label:"help wanted"`,
+		"sort":  "reactions-+1",
+		"order": "desc",
+	}, orgIssueSearchParams(args))
+
+	args = NewArgs([]string{"issue", "--org", "acme", "--include-pulls", "-M", "none", "-^", "-d", "2016-08-18T09:11:32Z", "--until", "2016-09-01T00:00:00Z"})
+	assert.Equal(t, nil, cmdIssue.parseArguments(args))
+	assert.Equal(t, map[string]interface{}{
+		"q":     "org:acme is:open no:milestone updated:>=2016-08-18T09:11:32Z updated:<=2016-09-01T00:00:00Z",
+		"order": "asc",
+	}, orgIssueSearchParams(args))
+}
+
+func TestFormatOrgIssues(t *testing.T) {
+	author := &github.User{Login: "octocat"}
+	issues := []github.Issue{
+		{Number: 12, Title: "Fix login", User: author, RepositoryURL: "https://api.github.com/repos/acme/web"},
+		{Number: 3, Title: "Crash on start", User: author, RepositoryURL: "https://api.github.com/repos/acme/api"},
+		{Number: 7, Title: "Slow page", User: author, RepositoryURL: "https://api.github.com/repos/acme/web"},
+	}
+	assert.Equal(t, `acme/api
+#3 Crash on start
+
+acme/web
+#12 Fix login
+#7 Slow page
+`, formatOrgIssues(issues, "%i %t%n", false))
+}
@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
 )
 
 type formatIssueTest struct {
@@ -308,3 +310,43 @@ func TestFormatIssue_customFormatString(t *testing.T) {
 		},
 	})
 }
+
+func TestIssueImportParams(t *testing.T) {
+	mapping, err := parseIssueImportMapping("title=Summary, labels=Tags")
+	assert.Equal(t, nil, err)
+
+	records, err := readCSVRecords(strings.NewReader("Summary,body,Tags,Sprint\nLogin fails,Steps to reproduce,\"bug, auth\",\n"))
+	assert.Equal(t, nil, err)
+
+	params, err := issueImportParams(records[0], mapping, []string{"imported"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{
+		"title":  "Login fails",
+		"body":   "Steps to reproduce",
+		"labels": []string{"bug", "auth", "imported"},
+	}, params)
+
+	_, err = parseIssueImportMapping("summary=Title")
+	assert.Equal(t, "error: unknown issue field 'summary'; supported fields are: title, body, labels, assignees, milestone", err.Error())
+}
+
+func TestReadJSONRecords(t *testing.T) {
+	records, err := readJSONRecords(strings.NewReader(`[{"title": "Crash", "labels": ["bug", "p1"], "estimate": 3, "body": null}]`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []map[string]string{{"title": "Crash", "labels": "bug,p1", "estimate": "3"}}, records)
+}
+
+func TestPrefetchUsers(t *testing.T) {
+	gh := &github.Client{Host: &github.Host{AccessToken: "OTOKEN"}}
+	args := NewArgs([]string{"issue", "create", "-a", "@mislav,octocat", "-a", "github/hubbers"})
+	assert.Equal(t, nil, cmdCreateIssue.parseArguments(args))
+	args.Noop = true
+
+	assignees, err := prefetchUsers(args, gh, "--assign", (*userResolver).logins)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"mislav", "octocat", "github/hubbers"}, assignees)
+
+	reviewers, err := prefetchUsers(args, gh, "--reviewer", (*userResolver).reviewers)()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{}, reviewers)
+}
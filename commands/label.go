@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdLabels = &Command{
+		Run: listLabels,
+		Usage: `
+label [--color]
+label clone [--prune] [<OWNER>/]<REPO>
+`,
+		Long: `Manage issue labels for the current repository.
+
+## Commands:
+
+With no arguments, show a list of labels available in this repository.
+
+	* _clone_:
+		Copy all labels from the <OWNER>/<REPO> repository into the current one.
+		Labels that already exist are updated to match the color and description
+		of the source label; others are created.
+
+## Options:
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+	--prune
+		Delete labels from the current repository that do not exist in the source
+		repository.
+
+	[<OWNER>/]<REPO>
+		The repository to copy labels from. <OWNER> defaults to the owner of the
+		current repository.
+
+## Examples:
+		$ hub label clone myorg/label-templates
+		$ hub label clone --prune myorg/label-templates
+
+## See also:
+
+hub-issue(1), hub(1)
+`,
+		KnownFlags: `
+		--color
+`,
+	}
+
+	cmdCloneLabels = &Command{
+		Key: "clone",
+		Run: cloneLabels,
+		KnownFlags: `
+		--prune
+`,
+	}
+)
+
+func init() {
+	cmdLabels.Use(cmdCloneLabels)
+	CmdRunner.Use(cmdLabels)
+}
+
+func cloneLabels(cmd *Command, args *Args) {
+	sourceName := ""
+	if !args.IsParamsEmpty() {
+		sourceName = args.FirstParam()
+	}
+	if !regexp.MustCompile(NameWithOwnerRe).MatchString(sourceName) {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	sourceOwner := project.Owner
+	if strings.Contains(sourceName, "/") {
+		split := strings.SplitN(sourceName, "/", 2)
+		sourceOwner, sourceName = split[0], split[1]
+	}
+	source := github.NewProject(sourceOwner, sourceName, project.Host)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+
+	sourceLabels, err := gh.FetchLabels(source)
+	utils.Check(err)
+
+	targetLabels, err := gh.FetchLabels(project)
+	utils.Check(err)
+
+	toCreate, toUpdate, toDelete := planLabelClone(sourceLabels, targetLabels, args.Flag.Bool("--prune"))
+
+	for _, label := range toCreate {
+		if args.Noop {
+			ui.Printf("Would create label '%s'.\n", label.Name)
+		} else {
+			utils.Check(gh.CreateLabel(project, label))
+			ui.Printf("Created label '%s'.\n", label.Name)
+		}
+	}
+
+	for _, update := range toUpdate {
+		if args.Noop {
+			ui.Printf("Would update label '%s'.\n", update.label.Name)
+		} else {
+			utils.Check(gh.UpdateLabel(project, update.name, update.label))
+			ui.Printf("Updated label '%s'.\n", update.label.Name)
+		}
+	}
+
+	for _, label := range toDelete {
+		if args.Noop {
+			ui.Printf("Would delete label '%s'.\n", label.Name)
+		} else {
+			utils.Check(gh.DeleteLabel(project, label.Name))
+			ui.Printf("Deleted label '%s'.\n", label.Name)
+		}
+	}
+}
+
+type labelUpdate struct {
+	name  string
+	label github.IssueLabel
+}
+
+// planLabelClone compares source labels against the labels of the target
+// repository. Label names are matched case-insensitively.
+func planLabelClone(source, target []github.IssueLabel, prune bool) (toCreate []github.IssueLabel, toUpdate []labelUpdate, toDelete []github.IssueLabel) {
+	existing := map[string]github.IssueLabel{}
+	for _, label := range target {
+		existing[strings.ToLower(label.Name)] = label
+	}
+
+	wanted := map[string]bool{}
+	for _, label := range source {
+		key := strings.ToLower(label.Name)
+		wanted[key] = true
+		if current, ok := existing[key]; !ok {
+			toCreate = append(toCreate, label)
+		} else if current != label {
+			toUpdate = append(toUpdate, labelUpdate{name: current.Name, label: label})
+		}
+	}
+
+	if prune {
+		for _, label := range target {
+			if !wanted[strings.ToLower(label.Name)] {
+				toDelete = append(toDelete, label)
+			}
+		}
+	}
+
+	return
+}
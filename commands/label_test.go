@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestPlanLabelClone(t *testing.T) {
+	source := []github.IssueLabel{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "Feature", Color: "a2eeef"},
+		{Name: "help wanted", Color: "008672"},
+	}
+	target := []github.IssueLabel{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "feature", Color: "ffffff"},
+		{Name: "wontfix", Color: "ffffff"},
+	}
+
+	toCreate, toUpdate, toDelete := planLabelClone(source, target, false)
+	assert.Equal(t, []github.IssueLabel{{Name: "help wanted", Color: "008672"}}, toCreate)
+	assert.Equal(t, []labelUpdate{{name: "feature", label: github.IssueLabel{Name: "Feature", Color: "a2eeef"}}}, toUpdate)
+	assert.Equal(t, 0, len(toDelete))
+
+	_, _, toDelete = planLabelClone(source, target, true)
+	assert.Equal(t, []github.IssueLabel{{Name: "wontfix", Color: "ffffff"}}, toDelete)
+}
@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdLicense = &Command{
+		Run: printHelp,
+		Usage: `
+license list
+license view <LICENSE>
+license apply [--author <NAME>] [--year <YEAR>] [-f] <LICENSE>
+`,
+		Long: `Add an open source license to the current project.
+
+## Commands:
+
+	* _list_:
+		List the keys and names of commonly used licenses.
+
+	* _view_:
+		Show the description, permissions, conditions, and limitations of
+		<LICENSE> followed by its text.
+
+	* _apply_:
+		Write the text of <LICENSE> to a ''LICENSE'' file at the top level of the
+		current repository, filling in the year and the copyright holder.
+
+## Options:
+	--author <NAME>
+		The copyright holder (default: the value of "user.name" git config).
+
+	--year <YEAR>
+		The copyright year (default: the current year).
+
+	-f, --force
+		Overwrite an existing ''LICENSE'' file.
+
+	<LICENSE>
+		The key of a license as shown by ''license list'', e.g. "mit" or
+		"apache-2.0".
+
+## Examples:
+		$ hub license list
+		agpl-3.0      GNU Affero General Public License v3.0
+		apache-2.0    Apache License 2.0
+		mit           MIT License
+
+		$ hub license apply mit
+
+## See also:
+
+hub-gitignore(1), hub(1)
+`,
+	}
+
+	cmdListLicenses = &Command{
+		Key: "list",
+		Run: listLicenses,
+	}
+
+	cmdViewLicense = &Command{
+		Key: "view",
+		Run: viewLicense,
+	}
+
+	cmdApplyLicense = &Command{
+		Key: "apply",
+		Run: applyLicense,
+		KnownFlags: `
+		--author NAME
+		--year YEAR
+		-f, --force
+`,
+	}
+)
+
+func init() {
+	cmdLicense.Use(cmdListLicenses)
+	cmdLicense.Use(cmdViewLicense)
+	cmdLicense.Use(cmdApplyLicense)
+	CmdRunner.Use(cmdLicense)
+}
+
+func listLicenses(cmd *Command, args *Args) {
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of licenses\n")
+		return
+	}
+
+	licenses, err := gh.FetchLicenses()
+	utils.Check(err)
+
+	width := 0
+	for _, l := range licenses {
+		if len(l.Key) > width {
+			width = len(l.Key)
+		}
+	}
+	for _, l := range licenses {
+		ui.Printf("%-*s  %s\n", width, l.Key, l.Name)
+	}
+}
+
+func viewLicense(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	key := args.GetParam(0)
+
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would display license %s\n", key)
+		return
+	}
+
+	license, err := gh.FetchLicense(key)
+	utils.Check(err)
+
+	ui.Printf("# %s\n\n", license.Name)
+	ui.Printf("%s\n\n", license.Description)
+	ui.Printf("* permissions: %s\n", strings.Join(license.Permissions, ", "))
+	ui.Printf("* conditions: %s\n", strings.Join(license.Conditions, ", "))
+	ui.Printf("* limitations: %s\n", strings.Join(license.Limitations, ", "))
+	ui.Printf("\n%s", license.Body)
+}
+
+func applyLicense(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	key := args.GetParam(0)
+
+	filename := topLevelPath("LICENSE")
+	if !args.Flag.Bool("--force") {
+		if _, err := os.Stat(filename); err == nil {
+			utils.Check(fmt.Errorf("error: %s already exists (use --force to overwrite)", filename))
+		}
+	}
+
+	values := licenseValues{
+		Year:   args.Flag.Value("--year"),
+		Author: args.Flag.Value("--author"),
+	}
+	if values.Year == "" {
+		values.Year = strconv.Itoa(time.Now().Year())
+	}
+	if values.Author == "" {
+		values.Author, _ = git.Config("user.name")
+	}
+	values.Email, _ = git.Config("user.email")
+	if workdir, err := git.WorkdirName(); err == nil {
+		values.Project = filepath.Base(workdir)
+	}
+
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would write license %s to %s\n", key, filename)
+		return
+	}
+
+	license, err := gh.FetchLicense(key)
+	utils.Check(err)
+
+	content := fillLicense(license.Body, values)
+	utils.Check(ioutil.WriteFile(filename, []byte(content), 0644))
+}
+
+// topLevelPath resolves filename relative to the top-level directory of the
+// current repository, or the current directory when outside of a repository.
+func topLevelPath(filename string) string {
+	if workdir, err := git.WorkdirName(); err == nil {
+		return filepath.Join(workdir, filename)
+	}
+	return filename
+}
+
+type licenseValues struct {
+	Year    string
+	Author  string
+	Email   string
+	Project string
+}
+
+// fillLicense substitutes the placeholders that license templates use for
+// the copyright year and holder. Placeholders without a known value are left
+// in place so that they can be filled in by hand.
+func fillLicense(body string, values licenseValues) string {
+	placeholders := []struct {
+		value string
+		keys  []string
+	}{
+		{values.Year, []string{"[year]", "[yyyy]", "<year>"}},
+		{values.Author, []string{"[fullname]", "[name of copyright owner]", "<name of author>"}},
+		{values.Email, []string{"[email]"}},
+		{values.Project, []string{"[project]"}},
+	}
+
+	replacements := []string{}
+	for _, p := range placeholders {
+		if p.value == "" {
+			continue
+		}
+		for _, key := range p.keys {
+			replacements = append(replacements, key, p.value)
+		}
+	}
+
+	return strings.NewReplacer(replacements...).Replace(body)
+}
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFillLicense(t *testing.T) {
+	body := "Copyright (c) [year] [fullname]\nCopyright [yyyy] [name of copyright owner] <[email]>\n[project] [description]"
+	values := licenseValues{
+		Year:    "2026",
+		Author:  "Mona Lisa",
+		Project: "hub",
+	}
+
+	assert.Equal(t, "Copyright (c) 2026 Mona Lisa\nCopyright 2026 Mona Lisa <[email]>\nhub [description]", fillLicense(body, values))
+}
+
+func TestAppendGitignore(t *testing.T) {
+	assert.Equal(t, "*.o\n", appendGitignore("", "*.o\n"))
+	assert.Equal(t, "/bin\n\n*.o\n", appendGitignore("/bin", "*.o\n"))
+	assert.Equal(t, "/bin\n\n*.o\n", appendGitignore("/bin\n", "*.o\n"))
+}
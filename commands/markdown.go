@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdMarkdown = &Command{
+		Run: printHelp,
+		Usage: `
+markdown preview [--context <OWNER/REPO>] [-u] <FILE>
+`,
+		Long: `Render Markdown the way GitHub displays it.
+
+## Commands:
+
+	* _preview_:
+		Render <FILE> as GitHub-flavored Markdown through the GitHub API, save the
+		result as an HTML page in a temporary directory, and open it in a web
+		browser. Pass "-" to read Markdown from standard input.
+
+## Options:
+	--context <OWNER/REPO>
+		Link references such as "#123" or commit SHAs to the issues, pull requests,
+		and commits of <OWNER/REPO> (default: the current repository).
+
+	-u, --url
+		Print the path of the rendered HTML page instead of opening it.
+
+## Examples:
+		$ hub markdown preview README.md
+		$ git log -1 --format=%b | hub markdown preview -
+
+## See also:
+
+hub-browse(1), hub(1)
+`,
+	}
+
+	cmdPreviewMarkdown = &Command{
+		Key: "preview",
+		Run: previewMarkdown,
+		KnownFlags: `
+		--context OWNER/REPO
+		-u, --url
+`,
+	}
+)
+
+func init() {
+	cmdMarkdown.Use(cmdPreviewMarkdown)
+	CmdRunner.Use(cmdMarkdown)
+}
+
+func previewMarkdown(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	filename := args.GetParam(0)
+
+	project, host := projectOrDefaultHost()
+
+	context := args.Flag.Value("--context")
+	if context == "" && project != nil {
+		context = fmt.Sprintf("%s/%s", project.Owner, project.Name)
+	}
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would render %s as Markdown\n", filename)
+		return
+	}
+
+	text, err := msgFromFile(filename)
+	utils.Check(err)
+
+	rendered, err := gh.RenderMarkdown(text, context)
+	utils.Check(err)
+
+	title := filepath.Base(filename)
+	if filename == "-" {
+		title = "Markdown preview"
+	}
+
+	page, err := ioutil.TempFile(os.TempDir(), "hub-markdown-*.html")
+	utils.Check(err)
+	_, err = page.WriteString(markdownPage(title, rendered))
+	page.Close()
+	utils.Check(err)
+
+	flagMarkdownURLPrint := args.Flag.Bool("--url")
+	printBrowseOrCopy(args, page.Name(), !flagMarkdownURLPrint, false)
+}
+
+// markdownPage wraps HTML rendered by the GitHub API in a standalone page
+// that approximates the styling of rendered Markdown on GitHub.
+func markdownPage(title, body string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { max-width: 980px; margin: 0 auto; padding: 45px; color: #24292f; font: 16px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; }
+h1, h2 { padding-bottom: .3em; border-bottom: 1px solid #d0d7de; }
+a { color: #0969da; text-decoration: none; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 85%%; background: #f6f8fa; border-radius: 6px; }
+code { padding: .2em .4em; }
+pre { padding: 16px; overflow: auto; }
+pre code { padding: 0; background: none; }
+blockquote { margin: 0; padding: 0 1em; color: #57606a; border-left: .25em solid #d0d7de; }
+table { border-collapse: collapse; }
+td, th { padding: 6px 13px; border: 1px solid #d0d7de; }
+img { max-width: 100%%; }
+</style>
+</head>
+<body>
+%s
+</body>
+</html>
+`, html.EscapeString(title), body)
+}
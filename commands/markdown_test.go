@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestMarkdownPage(t *testing.T) {
+	page := markdownPage("<notes>.md", "<p>Hello</p>")
+
+	assert.T(t, strings.Contains(page, "<title>&lt;notes&gt;.md</title>"))
+	assert.T(t, strings.Contains(page, "<body>\n<p>Hello</p>\n</body>"))
+	assert.T(t, strings.Contains(page, "max-width: 100%;"))
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+)
+
+// mergeMessageData exposes the fields of a pull request to the
+// `hub.mergeMessageFormat` template.
+type mergeMessageData struct {
+	Number int
+	Title  string
+	Body   string
+	Author string
+	Head   string
+	Base   string
+	Labels []string
+	URL    string
+	Method string
+}
+
+// HasLabel reports whether the pull request is labeled with name, so that a
+// template can pick a commit type such as `{{if .HasLabel "bug"}}fix{{end}}`.
+func (d mergeMessageData) HasLabel(name string) bool {
+	for _, label := range d.Labels {
+		if strings.EqualFold(label, name) {
+			return true
+		}
+	}
+	return false
+}
+
+var mergeMessageFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"join":  strings.Join,
+}
+
+// mergeMessageTemplate parses the `hub.mergeMessageFormat` git config. It
+// returns nil when no format is configured.
+func mergeMessageTemplate() (*template.Template, error) {
+	format, err := git.Config("hub.mergeMessageFormat")
+	if err != nil || format == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("hub.mergeMessageFormat").Funcs(mergeMessageFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("error: invalid hub.mergeMessageFormat: %s", err)
+	}
+	return tmpl, nil
+}
+
+// renderMergeMessage returns the commit title and body for merging pr with
+// method. The first paragraph of the rendered template becomes the title.
+func renderMergeMessage(tmpl *template.Template, pr *github.PullRequest, method string) (title, body string, err error) {
+	data := mergeMessageData{
+		Number: pr.Number,
+		Title:  pr.Title,
+		Body:   pr.Body,
+		URL:    pr.HTMLURL,
+		Method: method,
+	}
+	if pr.User != nil {
+		data.Author = pr.User.Login
+	}
+	if pr.Head != nil {
+		data.Head = pr.Head.Ref
+	}
+	if pr.Base != nil {
+		data.Base = pr.Base.Ref
+	}
+	for _, label := range pr.Labels {
+		data.Labels = append(data.Labels, label.Name)
+	}
+
+	var out strings.Builder
+	if err = tmpl.Execute(&out, data); err != nil {
+		return "", "", fmt.Errorf("error: invalid hub.mergeMessageFormat: %s", err)
+	}
+	title, body = github.SplitTitleBody(strings.TrimSpace(out.String()))
+	if title == "" {
+		err = fmt.Errorf("error: hub.mergeMessageFormat produced an empty commit title for pull request #%d", pr.Number)
+	}
+	return
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestRenderMergeMessage(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(mergeMessageFuncs).Parse(
+		`{{if .HasLabel "bug"}}fix{{else}}feat{{end}}: {{lower .Title}} (#{{.Number}})
+
+{{.Body}}
+
+Merged by {{.Method}} from {{.Head}} into {{.Base}}`))
+	pr := &github.PullRequest{
+		Number: 12,
+		Title:  "Handle Empty Remotes",
+		Body:   "Fixes a crash.",
+		Labels: []github.IssueLabel{{Name: "Bug"}},
+		Head:   &github.PullRequestSpec{Ref: "fix-remotes"},
+		Base:   &github.PullRequestSpec{Ref: "main"},
+	}
+
+	title, body, err := renderMergeMessage(tmpl, pr, "squash")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "fix: handle empty remotes (#12)", title)
+	assert.Equal(t, "Fixes a crash.\n\nMerged by squash from fix-remotes into main", body)
+
+	pr.Labels = nil
+	title, _, err = renderMergeMessage(tmpl, pr, "merge")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "feat: handle empty remotes (#12)", title)
+
+	empty := template.Must(template.New("").Parse(`{{if .HasLabel "wip"}}WIP{{end}}`))
+	_, _, err = renderMergeMessage(empty, pr, "merge")
+	assert.Equal(t, "error: hub.mergeMessageFormat produced an empty commit title for pull request #12", err.Error())
+}
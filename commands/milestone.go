@@ -0,0 +1,456 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdMilestone = &Command{
+		Run: listMilestones,
+		Usage: `
+milestone [list] [-s <STATE>] [-f <FORMAT>] [--color]
+milestone create [-m <MESSAGE>|-F <FILE>] [--edit] [-D <DATE>]
+milestone edit <MILESTONE> [-m <MESSAGE>|-F <FILE>] [--edit] [-D <DATE>] [-s <STATE>]
+milestone close <MILESTONE>
+`,
+		Long: `Manage GitHub Milestones for the current repository.
+
+## Commands:
+
+With no arguments, show a list of open milestones together with their
+progress.
+
+	* _list_:
+		Same as running ''milestone'' with no arguments.
+
+	* _create_:
+		Create a new milestone in the current repository.
+
+	* _edit_:
+		Update fields of an existing milestone. Use ''--edit'' to edit the title
+		and description interactively in the text editor.
+
+	* _close_:
+		Close an existing milestone.
+
+## Options:
+	-s, --state <STATE>
+		In list mode, display milestones with state <STATE>: "open" (default),
+		"closed", or "all".
+
+		When editing, set the state of the milestone to "open" or "closed".
+
+	-f, --format <FORMAT>
+		Pretty print the list of milestones using format <FORMAT> (default:
+		"%sC%>(4)%I%Creset  %pb %>(4)%pc  %<(11)%dD  %t%n"). See the "PRETTY
+		FORMATS" section of git-log(1) for some additional details on how
+		placeholders are used in format. The available placeholders are:
+
+		%I: milestone number
+
+		%U: the URL of this milestone
+
+		%S: state (i.e. "open", "closed")
+
+		%sC: set color to red or green, depending on milestone state.
+
+		%t: title
+
+		%b: description
+
+		%NO: number of open issues
+
+		%NC: number of closed issues
+
+		%NT: total number of issues
+
+		%pc: percentage of closed issues, e.g. "40%"
+
+		%pb: progress bar of closed issues, e.g. "[####------]"
+
+		%dD: due date-only (no time of day)
+
+		%dI: due date, ISO 8601 format
+
+		%dt: due date, UNIX timestamp
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+	-m, --message <MESSAGE>
+		The text up to the first blank line in <MESSAGE> is treated as the
+		milestone title, and the rest is used as milestone description.
+
+		When multiple ''--message'' are passed, their values are concatenated with a
+		blank line in-between.
+
+		When neither ''--message'' nor ''--file'' were supplied to ''milestone create'', a
+		text editor will open to author the title and description in.
+
+	-F, --file <FILE>
+		Read the milestone title and description from <FILE>. Pass "-" to read from
+		standard input instead. See ''--message'' for the formatting rules.
+
+	-e, --edit
+		Open the milestone title and description in a text editor before
+		submitting. This can be used in combination with ''--message'' or ''--file''.
+
+	-D, --due <DATE>
+		Set the due date of the milestone in "YYYY-MM-DD" or ISO 8601 format. Pass
+		an empty string to remove the due date.
+
+	<MILESTONE>
+		The number or the title of a milestone.
+
+## Examples:
+		$ hub milestone
+		   3  [####------]  40%  31 Oct 2026  v2.0
+
+		$ hub milestone create -m "v2.1" --due 2026-12-01
+		$ hub milestone close v2.0
+
+## See also:
+
+hub-issue(1), hub(1)
+`,
+		KnownFlags: `
+		-s, --state STATE
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdListMilestones = &Command{
+		Key: "list",
+		Run: listMilestones,
+		KnownFlags: `
+		-s, --state STATE
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdCreateMilestone = &Command{
+		Key: "create",
+		Run: createMilestone,
+		KnownFlags: `
+		-m, --message MSG
+		-F, --file FILE
+		-e, --edit
+		-D, --due DATE
+`,
+	}
+
+	cmdEditMilestone = &Command{
+		Key: "edit",
+		Run: editMilestone,
+		KnownFlags: `
+		-m, --message MSG
+		-F, --file FILE
+		-e, --edit
+		-D, --due DATE
+		-s, --state STATE
+`,
+	}
+
+	cmdCloseMilestone = &Command{
+		Key: "close",
+		Run: closeMilestone,
+	}
+)
+
+func init() {
+	cmdMilestone.Use(cmdListMilestones)
+	cmdMilestone.Use(cmdCreateMilestone)
+	cmdMilestone.Use(cmdEditMilestone)
+	cmdMilestone.Use(cmdCloseMilestone)
+	CmdRunner.Use(cmdMilestone)
+}
+
+func listMilestones(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of milestones for %s\n", project)
+		return
+	}
+
+	filters := map[string]interface{}{}
+	if args.Flag.HasReceived("--state") {
+		filters["state"] = args.Flag.Value("--state")
+	}
+
+	milestones, err := gh.FetchMilestones(project, filters)
+	utils.Check(err)
+
+	flagMilestoneFormat := "%sC%>(4)%I%Creset  %pb %>(4)%pc  %<(11)%dD  %t%n"
+	if args.Flag.HasReceived("--format") {
+		flagMilestoneFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, milestone := range milestones {
+		ui.Print(formatMilestone(milestone, flagMilestoneFormat, colorize))
+	}
+}
+
+func formatMilestone(milestone github.Milestone, format string, colorize bool) string {
+	var stateColorSwitch string
+	if colorize {
+		milestoneColor := 32
+		if milestone.State == "closed" {
+			milestoneColor = 31
+		}
+		stateColorSwitch = fmt.Sprintf("\033[%dm", milestoneColor)
+	}
+
+	total := milestone.OpenIssues + milestone.ClosedIssues
+	percent := 0
+	if total > 0 {
+		percent = milestone.ClosedIssues * 100 / total
+	}
+
+	var dueDate, dueAtISO8601, dueAtUnix string
+	if !milestone.DueOn.IsZero() {
+		dueDate = milestone.DueOn.Format("02 Jan 2006")
+		dueAtISO8601 = milestone.DueOn.Format(time.RFC3339)
+		dueAtUnix = fmt.Sprintf("%d", milestone.DueOn.Unix())
+	}
+
+	placeholders := map[string]string{
+		"I":  fmt.Sprintf("%d", milestone.Number),
+		"U":  milestone.HTMLURL,
+		"S":  milestone.State,
+		"sC": stateColorSwitch,
+		"t":  milestone.Title,
+		"b":  milestone.Description,
+		"NO": fmt.Sprintf("%d", milestone.OpenIssues),
+		"NC": fmt.Sprintf("%d", milestone.ClosedIssues),
+		"NT": fmt.Sprintf("%d", total),
+		"pc": fmt.Sprintf("%d%%", percent),
+		"pb": progressBar(percent, 10),
+		"dD": dueDate,
+		"dI": dueAtISO8601,
+		"dt": dueAtUnix,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func progressBar(percent, width int) string {
+	filled := percent * width / 100
+	return fmt.Sprintf("[%s%s]", strings.Repeat("#", filled), strings.Repeat("-", width-filled))
+}
+
+func createMilestone(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	messageBuilder := &github.MessageBuilder{
+		Filename: "MILESTONE_EDITMSG",
+		Title:    "milestone",
+	}
+
+	messageBuilder.AddCommentedSection(fmt.Sprintf(`Creating a milestone for %s
+
+Write a message for this milestone. The first block of
+text is the title and the rest is the description.`, project))
+
+	flagMilestoneEdit := args.Flag.Bool("--edit")
+	flagMilestoneMessage := args.Flag.AllValues("--message")
+	if len(flagMilestoneMessage) > 0 {
+		messageBuilder.Message = strings.Join(flagMilestoneMessage, "\n\n")
+		messageBuilder.Edit = flagMilestoneEdit
+	} else if args.Flag.HasReceived("--file") {
+		messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+		utils.Check(err)
+		messageBuilder.Edit = flagMilestoneEdit
+	} else {
+		messageBuilder.Edit = true
+	}
+
+	title, body, err := messageBuilder.Extract()
+	utils.Check(err)
+
+	if title == "" {
+		utils.Check(fmt.Errorf("Aborting creation due to empty milestone title"))
+	}
+
+	params := map[string]interface{}{
+		"title":       title,
+		"description": body,
+	}
+	utils.Check(setMilestoneDueFromArgs(params, args))
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create milestone `%s' for %s\n", title, project)
+	} else {
+		milestone, err := gh.CreateMilestone(project, params)
+		utils.Check(err)
+		ui.Println(milestone.HTMLURL)
+	}
+
+	messageBuilder.Cleanup()
+}
+
+func editMilestone(cmd *Command, args *Args) {
+	milestoneValue := ""
+	if args.ParamsSize() > 0 {
+		milestoneValue = args.GetParam(0)
+	}
+	if milestoneValue == "" {
+		utils.Check(cmd.UsageError(""))
+	}
+	if !hasField(args, "--message", "--file", "--edit", "--due", "--state") {
+		utils.Check(cmd.UsageError("please specify fields to update"))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	milestone, err := findMilestone(gh, project, milestoneValue)
+	utils.Check(err)
+
+	params := map[string]interface{}{}
+	if args.Flag.HasReceived("--state") {
+		params["state"] = args.Flag.Value("--state")
+	}
+	utils.Check(setMilestoneDueFromArgs(params, args))
+
+	if hasField(args, "--message", "--file", "--edit") {
+		messageBuilder := &github.MessageBuilder{
+			Filename: "MILESTONE_EDITMSG",
+			Title:    "milestone",
+		}
+
+		messageBuilder.AddCommentedSection(fmt.Sprintf(`Editing milestone %d for %s
+
+Update the message for this milestone. The first block of
+text is the title and the rest is the description.`, milestone.Number, project))
+
+		messageBuilder.Edit = args.Flag.Bool("--edit")
+		flagMilestoneMessage := args.Flag.AllValues("--message")
+		if len(flagMilestoneMessage) > 0 {
+			messageBuilder.Message = strings.Join(flagMilestoneMessage, "\n\n")
+		} else if args.Flag.HasReceived("--file") {
+			messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
+			utils.Check(err)
+		} else {
+			existingMessage := fmt.Sprintf("%s\n\n%s", milestone.Title, milestone.Description)
+			messageBuilder.Message = strings.Replace(existingMessage, "\r\n", "\n", -1)
+		}
+
+		title, body, err := messageBuilder.Extract()
+		utils.Check(err)
+		if title == "" {
+			utils.Check(fmt.Errorf("Aborting editing due to empty milestone title"))
+		}
+		params["title"] = title
+		params["description"] = body
+		defer messageBuilder.Cleanup()
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would update milestone `%s' for %s\n", milestone.Title, project)
+	} else {
+		_, err := gh.UpdateMilestone(project, milestone.Number, params)
+		utils.Check(err)
+	}
+}
+
+func closeMilestone(cmd *Command, args *Args) {
+	milestoneValue := ""
+	if args.ParamsSize() > 0 {
+		milestoneValue = args.GetParam(0)
+	}
+	if milestoneValue == "" {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	milestone, err := findMilestone(gh, project, milestoneValue)
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would close milestone `%s' for %s\n", milestone.Title, project)
+	} else {
+		_, err := gh.UpdateMilestone(project, milestone.Number, map[string]interface{}{
+			"state": "closed",
+		})
+		utils.Check(err)
+	}
+}
+
+func findMilestone(client *github.Client, project *github.Project, value string) (*github.Milestone, error) {
+	milestones, err := client.FetchMilestones(project, map[string]interface{}{"state": "all"})
+	if err != nil {
+		return nil, err
+	}
+
+	number, _ := strconv.Atoi(value)
+	for _, milestone := range milestones {
+		if milestone.Number == number || strings.EqualFold(milestone.Title, value) {
+			return &milestone, nil
+		}
+	}
+
+	return nil, fmt.Errorf("error: no milestone found with name '%s'", value)
+}
+
+func setMilestoneDueFromArgs(params map[string]interface{}, args *Args) error {
+	if !args.Flag.HasReceived("--due") {
+		return nil
+	}
+
+	due := args.Flag.Value("--due")
+	if due == "" {
+		params["due_on"] = nil
+	} else if dueDate, err := time.Parse("2006-01-02", due); err == nil {
+		params["due_on"] = dueDate.Format(time.RFC3339)
+	} else if dueTime, err := time.Parse(time.RFC3339, due); err == nil {
+		params["due_on"] = dueTime.UTC().Format(time.RFC3339)
+	} else {
+		return fmt.Errorf("invalid due date: %s", due)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatMilestone(t *testing.T) {
+	milestone := github.Milestone{
+		Number:       3,
+		Title:        "v2.0",
+		State:        "open",
+		OpenIssues:   6,
+		ClosedIssues: 4,
+		DueOn:        time.Date(2026, time.October, 31, 7, 0, 0, 0, time.UTC),
+	}
+
+	format := "%sC%>(4)%I%Creset  %pb %>(4)%pc  %<(11)%dD  %t%n"
+	assert.Equal(t, "   3  [####------]  40%  31 Oct 2026  v2.0\n", formatMilestone(milestone, format, false))
+
+	milestone.DueOn = time.Time{}
+	milestone.OpenIssues = 0
+	milestone.ClosedIssues = 0
+	assert.Equal(t, "\033[32m   3\033[m  [----------]   0%               v2.0\n", formatMilestone(milestone, format, true))
+
+	assert.Equal(t, "0/0\n", formatMilestone(milestone, "%NO/%NT%n", false))
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdOpen = &Command{
+	Run:   open,
+	Usage: "open [-uc] <REFERENCE>",
+	Long: `Open the GitHub page that a shorthand reference points to.
+
+## Options:
+	-u, --url
+		Print the URL instead of opening it.
+
+	-c, --copy
+		Put the URL in clipboard instead of opening it.
+
+	<REFERENCE>
+		One of:
+
+		* ''#<NUMBER>'' or ''<OWNER>/<REPO>#<NUMBER>'': an issue or pull request;
+
+		* ''<SHA>'' or ''<OWNER>/<REPO>@<SHA>'': a commit;
+
+		* ''<OWNER>/<REPO>'': a repository;
+
+		* ''@<USER>'': a user or organization profile;
+
+		* a URL, which is opened as is.
+
+		References that don't name a repository are resolved against the
+		repository in the current working directory.
+
+## Examples:
+		$ hub open '#123'
+		> open https://github.com/REPO/issues/123
+
+		$ hub open github/hub#2000
+		> open https://github.com/github/hub/issues/2000
+
+		$ hub open github/hub@4dd71c9
+		> open https://github.com/github/hub/commit/4dd71c9
+
+		$ hub open @mislav
+		> open https://github.com/mislav
+
+## See also:
+
+hub-browse(1), hub(1)
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdOpen)
+}
+
+var (
+	openIssueRegexp  = regexp.MustCompile(`^(?:([\w.-]+)/([\w.-]+))?#(\d+)$`)
+	openCommitRegexp = regexp.MustCompile(`^(?:([\w.-]+)/([\w.-]+)@)?([0-9a-fA-F]{7,40})$`)
+	openRepoRegexp   = regexp.MustCompile(`^([\w.-]+)/([\w.-]+)$`)
+	openUserRegexp   = regexp.MustCompile(`^@([\w-]+)$`)
+	openURLRegexp    = regexp.MustCompile(`^https?://`)
+)
+
+func open(command *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(command.UsageError(""))
+	}
+	reference := args.GetParam(0)
+
+	var project *github.Project
+	if localRepo, err := github.LocalRepo(); err == nil {
+		project, _ = localRepo.MainProject()
+	}
+
+	pageURL, err := resolveReferenceURL(reference, project)
+	utils.Check(err)
+
+	args.NoForward()
+	flagOpenURLPrint := args.Flag.Bool("--url")
+	flagOpenURLCopy := args.Flag.Bool("--copy")
+	printBrowseOrCopy(args, pageURL, !flagOpenURLPrint && !flagOpenURLCopy, flagOpenURLCopy)
+}
+
+// resolveReferenceURL returns the web URL for a shorthand reference. Issue
+// numbers and commit SHAs without a repository are looked up in project,
+// which may be nil if there is no current repository.
+func resolveReferenceURL(reference string, project *github.Project) (string, error) {
+	host := ""
+	if project != nil {
+		host = project.Host
+	}
+
+	inProject := func(owner, name string) (*github.Project, error) {
+		if owner != "" {
+			return github.NewProject(owner, name, host), nil
+		}
+		if project == nil {
+			return nil, fmt.Errorf("Aborted: could not find any git remote pointing to a GitHub repository to resolve '%s'", reference)
+		}
+		return project, nil
+	}
+
+	if openURLRegexp.MatchString(reference) {
+		return reference, nil
+	} else if m := openUserRegexp.FindStringSubmatch(reference); m != nil {
+		p := github.NewProject(m[1], "", host)
+		return fmt.Sprintf("%s://%s/%s", p.Protocol, p.Host, m[1]), nil
+	} else if m := openIssueRegexp.FindStringSubmatch(reference); m != nil {
+		p, err := inProject(m[1], m[2])
+		if err != nil {
+			return "", err
+		}
+		return p.WebURL("", "", "issues/"+m[3]), nil
+	} else if m := openCommitRegexp.FindStringSubmatch(reference); m != nil {
+		p, err := inProject(m[1], m[2])
+		if err != nil {
+			return "", err
+		}
+		return p.WebURL("", "", "commit/"+strings.ToLower(m[3])), nil
+	} else if m := openRepoRegexp.FindStringSubmatch(reference); m != nil {
+		return github.NewProject(m[1], m[2], host).WebURL("", "", ""), nil
+	}
+
+	return "", fmt.Errorf("Aborted: '%s' is not a recognized reference", reference)
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestResolveReferenceURL(t *testing.T) {
+	project := &github.Project{Owner: "github", Name: "hub", Host: "github.com", Protocol: "https"}
+
+	tests := []struct {
+		reference string
+		want      string
+	}{
+		{"#123", "https://github.com/github/hub/issues/123"},
+		{"mislav/dotfiles#4", "https://github.com/mislav/dotfiles/issues/4"},
+		{"4DD71C9", "https://github.com/github/hub/commit/4dd71c9"},
+		{"mislav/dotfiles@4dd71c9", "https://github.com/mislav/dotfiles/commit/4dd71c9"},
+		{"mislav/dotfiles", "https://github.com/mislav/dotfiles"},
+		{"@mislav", "https://github.com/mislav"},
+		{"https://example.com/path", "https://example.com/path"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			got, err := resolveReferenceURL(test.reference, project)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestResolveReferenceURL_Errors(t *testing.T) {
+	_, err := resolveReferenceURL("#123", nil)
+	assert.Equal(t, "Aborted: could not find any git remote pointing to a GitHub repository to resolve '#123'", err.Error())
+
+	_, err = resolveReferenceURL("not a reference", nil)
+	assert.Equal(t, "Aborted: 'not a reference' is not a recognized reference", err.Error())
+}
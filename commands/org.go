@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdOrg = &Command{
+		Run: printHelp,
+		Usage: `
+org audit-log [--phrase <QUERY>] [--since <DATE>] [--json] [<ORG>]
+`,
+		Long: `Administer a GitHub organization.
+
+## Commands:
+
+	* _audit-log_:
+		Print the audit log of <ORG> (default: the owner of the current
+		repository), newest entries first. Entries are printed as each page is
+		received, so large logs can be piped to other tools while they are being
+		fetched. Requires an access token of an organization owner with the
+		"read:audit_log" scope.
+
+## Options:
+	--phrase <QUERY>
+		Only show entries matching the audit log search <QUERY>, for example
+		"action:repo.create actor:mislav".
+
+	--since <DATE>
+		Only show entries created on or after <DATE>, given in the "YYYY-MM-DD"
+		format or as an ISO 8601 timestamp.
+
+	--json
+		Print each entry as received from the API as a JSON object on its own
+		line.
+
+## Examples:
+		$ hub org audit-log --since 2020-01-01 github
+		2020-01-29T13:12:33Z  repo.create  mislav  github/hub
+
+		$ hub org audit-log --phrase action:team --json github > audit.jsonl
+
+## Notes:
+
+Audit logs are fetched from the GitHub host of the current repository. Use the
+''GITHUB_HOST'' environment variable to export the audit log of an organization
+on GitHub Enterprise from outside of a repository.
+
+## See also:
+
+hub-team(1), hub(1)
+`,
+	}
+
+	cmdOrgAuditLog = &Command{
+		Key: "audit-log",
+		Run: orgAuditLog,
+		KnownFlags: `
+		--phrase QUERY
+		--since DATE
+		--json
+`,
+	}
+)
+
+func init() {
+	cmdOrg.Use(cmdOrgAuditLog)
+	CmdRunner.Use(cmdOrg)
+}
+
+func orgAuditLog(cmd *Command, args *Args) {
+	project, host := projectOrDefaultHost()
+
+	org := ""
+	if !args.IsParamsEmpty() {
+		org = args.FirstParam()
+	} else if project != nil {
+		org = project.Owner
+	} else {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	phrase, err := auditLogPhrase(args.Flag.Value("--phrase"), args.Flag.Value("--since"))
+	utils.Check(err)
+
+	params := map[string]interface{}{}
+	if phrase != "" {
+		params["phrase"] = phrase
+	}
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request audit log for %s\n", org)
+		return
+	}
+
+	flagAuditLogJSON := args.Flag.Bool("--json")
+	err = gh.FetchAuditLog(org, params, func(entry *github.AuditLogEntry) error {
+		if flagAuditLogJSON {
+			var out bytes.Buffer
+			if err := json.Compact(&out, entry.Raw); err != nil {
+				return err
+			}
+			ui.Println(out.String())
+		} else {
+			ui.Println(formatAuditLogEntry(entry))
+		}
+		return nil
+	})
+	utils.Check(err)
+}
+
+// auditLogPhrase combines a search phrase with a filter for entries created
+// on or after the date since.
+func auditLogPhrase(phrase, since string) (string, error) {
+	if since == "" {
+		return phrase, nil
+	}
+
+	if _, err := time.Parse("2006-01-02", since); err != nil {
+		if _, err := time.Parse(time.RFC3339, since); err != nil {
+			return "", fmt.Errorf("error: invalid date '%s'; expected YYYY-MM-DD", since)
+		}
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s created:>=%s", phrase, since)), nil
+}
+
+func formatAuditLogEntry(entry *github.AuditLogEntry) string {
+	fields := []string{entry.CreatedAt().Format(time.RFC3339), entry.Action, entry.Actor}
+	if entry.Repo != "" {
+		fields = append(fields, entry.Repo)
+	} else if entry.User != "" {
+		fields = append(fields, entry.User)
+	}
+	return strings.Join(fields, "  ")
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestAuditLogPhrase(t *testing.T) {
+	phrase, err := auditLogPhrase("action:repo.create", "2020-01-29")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "action:repo.create created:>=2020-01-29", phrase)
+
+	phrase, err = auditLogPhrase("", "2020-01-29T13:12:33Z")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "created:>=2020-01-29T13:12:33Z", phrase)
+
+	phrase, err = auditLogPhrase("actor:mislav", "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "actor:mislav", phrase)
+
+	_, err = auditLogPhrase("", "yesterday")
+	assert.Equal(t, "error: invalid date 'yesterday'; expected YYYY-MM-DD", err.Error())
+}
+
+func TestFormatAuditLogEntry(t *testing.T) {
+	entry := &github.AuditLogEntry{
+		Timestamp: 1580303553000,
+		Action:    "repo.create",
+		Actor:     "mislav",
+		Repo:      "github/hub",
+	}
+	assert.Equal(t, "2020-01-29T13:12:33Z  repo.create  mislav  github/hub", formatAuditLogEntry(entry))
+
+	entry = &github.AuditLogEntry{
+		Timestamp: 1580303553000,
+		Action:    "org.add_member",
+		Actor:     "mislav",
+		User:      "octocat",
+	}
+	assert.Equal(t, "2020-01-29T13:12:33Z  org.add_member  mislav  octocat", formatAuditLogEntry(entry))
+}
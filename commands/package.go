@@ -0,0 +1,332 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdPackage = &Command{
+		Run: printHelp,
+		Usage: `
+package list [-t <TYPE>] [--org] [-f <FORMAT>]
+package versions [-t <TYPE>] [--org] [-f <FORMAT>] <NAME>
+package delete-version [-t <TYPE>] [--org] <NAME> <VERSION-ID>...
+package delete-version [-t <TYPE>] [--org] --untagged <NAME>
+`,
+		Long: `Manage GitHub Packages of the authenticated user or an organization.
+
+## Commands:
+
+	* _list_:
+		List packages of the given type.
+
+	* _versions_:
+		List versions of the package <NAME>, most recent first.
+
+	* _delete-version_:
+		Delete one or more versions of the package <NAME>.
+
+## Options:
+	-t, --type <TYPE>
+		The package registry: "container" (default), "npm", "maven", "rubygems",
+		"docker", or "nuget".
+
+	--org
+		Manage packages of the organization that owns the current repository
+		instead of packages of the authenticated user.
+
+	--untagged
+		Delete all versions of a container package that have no tags.
+
+	-f, --format <FORMAT>
+		Pretty print packages or versions using format <FORMAT> (default for
+		''list'': "%<(40)%N  %v%n"; for ''versions'': "%>(12)%I  %<(16)%cr  %T%n").
+		See the "PRETTY FORMATS" section of git-log(1) for some additional details
+		on how placeholders are used in format. The available placeholders for
+		packages are:
+
+		%N: package name
+
+		%T: package type
+
+		%v: visibility (i.e. "public", "private", "internal")
+
+		%C: number of versions
+
+		%R: repository name with owner
+
+		%U: the URL of this package
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%uD: updated date-only (no time of day)
+
+		%ur: updated date, relative
+
+		%uI: updated date, ISO 8601 format
+
+		The available placeholders for versions are:
+
+		%I: version ID
+
+		%V: version name (the image digest for containers)
+
+		%T: comma-separated list of tags
+
+		%U: the URL of this version
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%uD: updated date-only (no time of day)
+
+		%ur: updated date, relative
+
+		%uI: updated date, ISO 8601 format
+
+## Examples:
+		$ hub package list --org
+		$ hub package versions hub
+		    81234567  2 hours ago       latest, v2.14.2
+		    80123456  3 weeks ago
+		$ hub package delete-version --untagged hub
+
+## Notes:
+
+The access token used by hub must include the "read:packages" scope, and
+"delete:packages" for deleting versions.
+
+## See also:
+
+hub(1)
+`,
+	}
+
+	cmdListPackages = &Command{
+		Key: "list",
+		Run: listPackages,
+		KnownFlags: `
+		-t, --type TYPE
+		--org
+		-f, --format FMT
+`,
+	}
+
+	cmdListPackageVersions = &Command{
+		Key: "versions",
+		Run: listPackageVersions,
+		KnownFlags: `
+		-t, --type TYPE
+		--org
+		-f, --format FMT
+`,
+	}
+
+	cmdDeletePackageVersion = &Command{
+		Key: "delete-version",
+		Run: deletePackageVersion,
+		KnownFlags: `
+		-t, --type TYPE
+		--org
+		--untagged
+`,
+	}
+)
+
+func init() {
+	cmdPackage.Use(cmdListPackages)
+	cmdPackage.Use(cmdListPackageVersions)
+	cmdPackage.Use(cmdDeletePackageVersion)
+	CmdRunner.Use(cmdPackage)
+}
+
+// packagesPrefix returns the API path under which packages are managed, along
+// with the host to talk to and a human-readable description of the owner.
+func packagesPrefix(args *Args) (prefix, host, scope string) {
+	project, host := projectOrDefaultHost()
+
+	if args.Flag.Bool("--org") {
+		if project == nil {
+			utils.Check(fmt.Errorf("error: --org requires being in a repository owned by an organization"))
+		}
+		return fmt.Sprintf("orgs/%s", project.Owner), host, project.Owner
+	}
+	return "user", host, "you"
+}
+
+func packageType(args *Args) string {
+	if t := args.Flag.Value("--type"); t != "" {
+		return t
+	}
+	return "container"
+}
+
+func listPackages(cmd *Command, args *Args) {
+	prefix, host, scope := packagesPrefix(args)
+	kind := packageType(args)
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of %s packages for %s\n", kind, scope)
+		return
+	}
+
+	packages, err := gh.FetchPackages(prefix, kind)
+	utils.Check(err)
+
+	flagPackageFormat := "%<(40)%N  %v%n"
+	if args.Flag.HasReceived("--format") {
+		flagPackageFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(false, "")
+	for _, p := range packages {
+		ui.Print(formatPackage(p, flagPackageFormat, colorize))
+	}
+}
+
+func formatPackage(p github.Package, format string, colorize bool) string {
+	repository := ""
+	if p.Repository != nil {
+		repository = p.Repository.FullName
+	}
+
+	placeholders := map[string]string{
+		"N": p.Name,
+		"T": p.PackageType,
+		"v": p.Visibility,
+		"C": strconv.Itoa(p.VersionCount),
+		"R": repository,
+		"U": p.HTMLURL,
+	}
+	addTimePlaceholders(placeholders, p.CreatedAt, p.UpdatedAt)
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+// addTimePlaceholders fills in the "c" (created) and "u" (updated) families of
+// date placeholders.
+func addTimePlaceholders(placeholders map[string]string, createdAt, updatedAt time.Time) {
+	for prefix, t := range map[string]time.Time{"c": createdAt, "u": updatedAt} {
+		placeholders[prefix+"D"], placeholders[prefix+"I"], placeholders[prefix+"r"] = "", "", ""
+		if !t.IsZero() {
+			placeholders[prefix+"D"] = t.Format("02 Jan 2006")
+			placeholders[prefix+"I"] = t.Format(time.RFC3339)
+			placeholders[prefix+"r"] = utils.TimeAgo(t)
+		}
+	}
+}
+
+func listPackageVersions(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	prefix, host, scope := packagesPrefix(args)
+	kind := packageType(args)
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of versions of %s package %s for %s\n", kind, name, scope)
+		return
+	}
+
+	versions, err := gh.FetchPackageVersions(prefix, kind, name)
+	utils.Check(err)
+
+	flagVersionFormat := "%>(12)%I  %<(16)%cr  %T%n"
+	if args.Flag.HasReceived("--format") {
+		flagVersionFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(false, "")
+	for _, v := range versions {
+		ui.Print(formatPackageVersion(v, flagVersionFormat, colorize))
+	}
+}
+
+func formatPackageVersion(v github.PackageVersion, format string, colorize bool) string {
+	placeholders := map[string]string{
+		"I": strconv.Itoa(v.ID),
+		"V": v.Name,
+		"T": strings.Join(v.Metadata.Container.Tags, ", "),
+		"U": v.HTMLURL,
+	}
+	addTimePlaceholders(placeholders, v.CreatedAt, v.UpdatedAt)
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+// untaggedVersionIDs returns the IDs of container versions without any tags.
+func untaggedVersionIDs(versions []github.PackageVersion) []int {
+	ids := []int{}
+	for _, v := range versions {
+		if len(v.Metadata.Container.Tags) == 0 {
+			ids = append(ids, v.ID)
+		}
+	}
+	return ids
+}
+
+func deletePackageVersion(cmd *Command, args *Args) {
+	untagged := args.Flag.Bool("--untagged")
+	if args.ParamsSize() < 1 || (!untagged && args.ParamsSize() < 2) {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	versionIDs := []int{}
+	for _, param := range args.Params[1:] {
+		id, err := strconv.Atoi(param)
+		if err != nil {
+			utils.Check(fmt.Errorf("invalid version ID: %s", param))
+		}
+		versionIDs = append(versionIDs, id)
+	}
+
+	prefix, host, scope := packagesPrefix(args)
+	kind := packageType(args)
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		if untagged {
+			ui.Printf("Would delete untagged versions of %s package %s for %s\n", kind, name, scope)
+		}
+		for _, id := range versionIDs {
+			ui.Printf("Would delete version %d of %s package %s for %s\n", id, kind, name, scope)
+		}
+		return
+	}
+
+	if untagged {
+		versions, err := gh.FetchPackageVersions(prefix, kind, name)
+		utils.Check(err)
+		versionIDs = append(versionIDs, untaggedVersionIDs(versions)...)
+	}
+
+	for _, id := range versionIDs {
+		utils.Check(gh.DeletePackageVersion(prefix, kind, name, id))
+		ui.Printf("Deleted version %d of package '%s'.\n", id, name)
+	}
+}
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestUntaggedVersionIDs(t *testing.T) {
+	versions := make([]github.PackageVersion, 3)
+	versions[0].ID = 1
+	versions[0].Metadata.Container.Tags = []string{"latest"}
+	versions[1].ID = 2
+	versions[2].ID = 3
+	versions[2].Metadata.Container.Tags = []string{}
+
+	assert.Equal(t, []int{2, 3}, untaggedVersionIDs(versions))
+}
+
+func TestFormatPackageVersion(t *testing.T) {
+	version := github.PackageVersion{ID: 81234567, Name: "sha256:abc"}
+	version.Metadata.Container.Tags = []string{"latest", "v2.14.2"}
+
+	assert.Equal(t, "81234567 sha256:abc latest, v2.14.2 ", formatPackageVersion(version, "%I %V %T %cr", false))
+}
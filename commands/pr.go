@@ -2,8 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
@@ -15,11 +18,18 @@ var (
 	cmdPr = &Command{
 		Run: printHelp,
 		Usage: `
-pr list [-s <STATE>] [-h <HEAD>] [-b <BASE>] [-o <SORT_KEY> [-^]] [-f <FORMAT>] [-L <LIMIT>]
-pr checkout <PR-NUMBER> [<BRANCH>]
+pr list [-s <STATE>] [-h <HEAD>] [-b <BASE>] [-l <LABELS>] [-a <ASSIGNEE>] [-M <MILESTONE>] [--creator <USER>] [-@ <USER>] [-o <SORT_KEY> [-^]] [-f <FORMAT>] [-L <LIMIT>] [--since <DATE>] [--until <DATE>] [--reactions]
+pr status [-L <LIMIT>] [--color]
+pr stat [--threshold <LINES>] [--color] <PR-NUMBER>
+pr todo <PR-NUMBER>
+pr stack [-p] [--draft] [-b <BASE>] <BRANCH>...
+pr retarget <PR-NUMBER> <BASE>
+pr checkout <PR-NUMBER>|<PR-URL> [<BRANCH>]
 pr show [-uc] [-f <FORMAT>] [-h <HEAD>]
 pr show [-uc] [-f <FORMAT>] <PR-NUMBER>
+pr checks [--annotations] [--color] [<PR-NUMBER>]
 pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--head-sha <COMMIT-SHA>]
+pr merge --queue <PR-NUMBER> [--head-sha <COMMIT-SHA>]
 `,
 		Long: `Manage GitHub Pull Requests for the current repository.
 
@@ -28,8 +38,41 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 	* _list_:
 		List pull requests in the current repository.
 
+	* _status_:
+		Show the pull request for the current branch, open pull requests created
+		by you, and open pull requests requesting your review, along with the
+		state of their checks, reviews, and mergeability. Everything is fetched in
+		a single API request.
+
+	* _stat_:
+		Show the files changed by a pull request along with the number of added
+		and deleted lines and a histogram, like ''git diff --stat''.
+
+	* _todo_:
+		Print a checklist of the feedback left on a pull request: review
+		comment threads that haven't been resolved yet, with the file and line
+		they refer to, followed by unchecked task list items in the pull request
+		description.
+
+	* _stack_:
+		Open or update one pull request per <BRANCH> for a stack of branches
+		that build on each other, listed from the bottom of the stack to the top.
+		The first pull request is based on <BASE> and each of the others on the
+		branch before it. All branches must be pushed to the current repository.
+
+		Run it again after a pull request lower in the stack was merged to
+		retarget the pull request above it onto the next unmerged branch. The
+		description of each pull request links to the others in the stack.
+
+	* _retarget_:
+		Change the base branch of a pull request to <BASE>. See also
+		''hub sync --retarget'', which does this for every pull request whose base
+		branch no longer exists.
+
 	* _checkout_:
-		Check out the head of a pull request in a new branch.
+		Check out the head of a pull request in a new branch. The pull request
+		may also be given by its URL, such as the one printed by
+		hub-pull-request(1).
 
 		To update the pull request with new commits, use ''git push''.
 
@@ -39,10 +82,25 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 		the current branch name. With ''--format'', print information about the
 		pull request instead of opening it.
 
+	* _checks_:
+		Show the status checks for the head commit of pull request <PR-NUMBER>,
+		or of the pull request for the current branch. Exits like
+		hub-ci-status(1): 0 if checks passed, 1 if any failed, and 2 if any are
+		still pending.
+
+		With ''--annotations'', also print the annotations of each failed check
+		run, such as compiler errors or test failures, along with the file and
+		line they refer to.
+
 	* _merge_:
 		Merge a pull request in the current repository remotely. Select an
 		alternate merge method with ''--squash'' or ''--rebase''. Change the
-		commit subject and body with ''--message'' or ''--file''.
+		commit subject and body with ''--message'' or ''--file'', or configure a
+		template for them with ''hub.mergeMessageFormat''.
+
+		With ''--queue'', add the pull request to the merge queue of its base
+		branch instead. The merge method is then determined by the queue
+		settings. See hub-queue(1) for viewing the queue.
 
 ## Options:
 
@@ -55,7 +113,32 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 		"OWNER:BRANCH" format must be used for pull requests from forks.
 
 	-b, --base <BRANCH>
-		Show pull requests based off the specified <BRANCH>.
+		Show pull requests based off the specified <BRANCH>. For ''stack'', the
+		branch that the bottom of the stack is based on (default: the default
+		branch of the repository).
+
+	-l, --labels <LABELS>
+		Show pull requests labeled with all of the comma-separated <LABELS>.
+
+	-a, --assignee <ASSIGNEE>
+		Show pull requests assigned to <ASSIGNEE>. Use "@me" for yourself, "none"
+		for pull requests without assignees, or "*" for those with any.
+
+	-M, --milestone <MILESTONE>
+		Show pull requests in <MILESTONE>, given by its number or title. Use
+		"none" for pull requests without a milestone, or "*" for those with any.
+
+	--creator <USER>
+		Show pull requests opened by <USER>. Use "@me" for yourself.
+
+	-@, --mentioned <USER>
+		Show pull requests mentioning <USER>. Use "@me" for yourself.
+
+		Pull requests are listed through the issues API when any of ''--labels'',
+		''--assignee'', ''--milestone'', ''--creator'', or ''--mentioned'' are
+		given. That API doesn't include branches or commits, so these filters
+		can't be combined with ''--head'' or ''--base'', and the %B, %H, %sB, %sH,
+		and %sm placeholders are empty.
 
 	-f, --format <FORMAT>
 		Pretty print the list of pull requests using format <FORMAT> (default:
@@ -105,6 +188,10 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 
 		%Mt: milestone title
 
+		%Ru: number of 👍 reactions, with ''--reactions''
+
+		%Rd: number of 👎 reactions, with ''--reactions''
+
 		%cD: created date-only (no time of day)
 
 		%cr: created date, relative
@@ -137,14 +224,37 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for ''--color''), "never", or "auto" (default).
 
+	--annotations
+		With ''checks'', print the annotations of failed check runs.
+
 	-o, --sort <KEY>
-		Sort displayed pull requests by "created" (default), "updated", "popularity", or "long-running".
+		Sort displayed pull requests by "created" (default), "updated", "popularity", "long-running", or "reactions".
+		Sorting by "reactions" puts the pull requests with the most 👍 reactions
+		first, which requires fetching all matching pull requests before any are
+		displayed.
+
+	--reactions
+		Include the number of 👍 and 👎 reactions to each pull request in the output.
 
 	-^, --sort-ascending
 		Sort by ascending dates instead of descending.
 
 	-L, --limit <LIMIT>
-		Display only the first <LIMIT> pull requests.
+		Display only the first <LIMIT> pull requests. For ''status'', this applies
+		to each section separately (default: 10).
+
+	--since <DATE>
+		Display only pull requests updated on or after <DATE>, which is a date
+		such as "2021-03-15", a timestamp in ISO 8601 format, or a duration such
+		as "3d" or "2w" counted back from now.
+
+	--until <DATE>
+		Display only pull requests updated on or before <DATE>, in the same
+		formats as ''--since''. A date includes the whole day.
+
+	--threshold <LINES>
+		Exit with a non-zero status if the pull request changes more than <LINES>
+		lines in total. Useful for enforcing a size limit on pull requests.
 
 	-u, --url
 		Print the pull request URL instead of opening it.
@@ -175,12 +285,75 @@ pr merge [-d] [--squash | --rebase] <PR-NUMBER> [-m <MESSAGE> | -F <FILE>] [--he
 	-d, --delete-branch
 		Delete the head branch after successfully merging a pull request.
 
+	--queue
+		Add the pull request to the merge queue instead of merging it right away.
+
+	-p, --push
+		Push each branch of the stack before opening or updating pull requests.
+
+	--draft
+		Open new pull requests in the stack as drafts.
+
+## Configuration:
+
+	* ''hub.mergeMessageFormat'':
+		A Go template for the commit message of ''pr merge'' when neither
+		''--message'' nor ''--file'' is given and the merge method is not
+		''--rebase''. The first paragraph of the result is the commit title and
+		the rest is the body. The template receives the fields ".Number",
+		".Title", ".Body", ".Author", ".Head", ".Base", ".Labels", ".URL", and
+		".Method", the method ".HasLabel NAME", and the functions "lower",
+		"upper", "trim", and "join". For example:
+
+			git config hub.mergeMessageFormat \
+			  '{{if .HasLabel "bug"}}fix{{else}}feat{{end}}: {{.Title}} (#{{.Number}})'
+
 ## See also:
 
-hub-issue(1), hub-pull-request(1), hub(1)
+hub-issue(1), hub-pull-request(1), hub-queue(1), hub(1)
 `,
 	}
 
+	cmdStatusPr = &Command{
+		Key: "status",
+		Run: statusPr,
+		KnownFlags: `
+		-L, --limit N
+		--color
+		`,
+	}
+
+	cmdStatPr = &Command{
+		Key: "stat",
+		Run: statPr,
+		KnownFlags: `
+		--threshold LINES
+		--color
+		`,
+	}
+
+	cmdTodoPr = &Command{
+		Key:        "todo",
+		Run:        todoPr,
+		KnownFlags: "\n",
+	}
+
+	cmdStackPr = &Command{
+		Key: "stack",
+		Run: stackPr,
+		KnownFlags: `
+		-b, --base BASE
+		-p, --push
+		--draft
+		`,
+	}
+
+	cmdRetargetPr = &Command{
+		Key:        "retarget",
+		Run:        retargetPr,
+		KnownFlags: "\n",
+	}
+
 	cmdCheckoutPr = &Command{
 		Key:        "checkout",
 		Run:        checkoutPr,
@@ -193,6 +366,15 @@ hub-issue(1), hub-pull-request(1), hub(1)
 		Long: cmdPr.Long,
 	}
 
+	cmdChecksPr = &Command{
+		Key: "checks",
+		Run: checksPr,
+		KnownFlags: `
+		--annotations
+		--color
+		`,
+	}
+
 	cmdShowPr = &Command{
 		Key: "show",
 		Run: showPr,
@@ -215,14 +397,21 @@ hub-issue(1), hub-pull-request(1), hub(1)
 		--squash
 		--rebase
 		-d, --delete-branch
+		--queue
 		`,
 	}
 )
 
 func init() {
 	cmdPr.Use(cmdListPulls)
+	cmdPr.Use(cmdStatusPr)
+	cmdPr.Use(cmdStatPr)
+	cmdPr.Use(cmdTodoPr)
+	cmdPr.Use(cmdStackPr)
+	cmdPr.Use(cmdRetargetPr)
 	cmdPr.Use(cmdCheckoutPr)
 	cmdPr.Use(cmdShowPr)
+	cmdPr.Use(cmdChecksPr)
 	cmdPr.Use(cmdMergePr)
 	CmdRunner.Use(cmdPr)
 }
@@ -250,7 +439,8 @@ func listPulls(cmd *Command, args *Args) {
 	if args.Flag.HasReceived("--state") {
 		filters["state"] = args.Flag.Value("--state")
 	}
-	if args.Flag.HasReceived("--sort") {
+	sortByReactionCount := args.Flag.Value("--sort") == "reactions"
+	if args.Flag.HasReceived("--sort") && !sortByReactionCount {
 		filters["sort"] = args.Flag.Value("--sort")
 	}
 	if args.Flag.HasReceived("--base") {
@@ -277,20 +467,546 @@ func listPulls(cmd *Command, args *Args) {
 	}
 
 	flagPullRequestLimit := args.Flag.Int("--limit")
+	flagPullRequestReactions := args.Flag.Bool("--reactions")
 	flagPullRequestFormat := args.Flag.Value("--format")
 	if !args.Flag.HasReceived("--format") {
 		flagPullRequestFormat = "%pC%>(8)%i%Creset  %t%  l%n"
+		if flagPullRequestReactions {
+			flagPullRequestFormat = reactionsListFormat("%pC%>(8)%i%Creset  %t%  l")
+		}
 	}
 
-	pulls, err := gh.FetchPullRequests(project, filters, flagPullRequestLimit, func(pr *github.PullRequest) bool {
-		return !(onlyMerged && pr.MergedAt.IsZero())
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	updated, err := timeRangeFromArgs(args, time.Now())
+	utils.Check(err)
+
+	// The pull requests API can't filter by these fields, but the issues API
+	// can, and it lists pull requests as well.
+	eachPull := func(limit int, each func(*github.PullRequest) error) error {
+		return gh.EachPullRequest(project, filters, limit, func(pr *github.PullRequest) bool {
+			return !(onlyMerged && pr.MergedAt.IsZero()) && updated.Includes(pr.UpdatedAt)
+		}, each)
+	}
+	if hasField(args, "--labels", "--assignee", "--milestone", "--creator", "--mentioned") {
+		if hasField(args, "--head", "--base") {
+			utils.Check(fmt.Errorf("error: --head and --base can't be combined with --labels, --assignee, --milestone, --creator, or --mentioned"))
+		}
+		delete(filters, "sort")
+		if sort := args.Flag.Value("--sort"); sort == "created" || sort == "updated" {
+			filters["sort"] = sort
+		} else if sort == "popularity" {
+			filters["sort"] = "comments"
+		}
+		if !updated.Since.IsZero() {
+			filters["since"] = updated.Since.Format(time.RFC3339)
+		}
+		utils.Check(addIssueFilters(filters, args, gh, project))
+		eachPull = func(limit int, each func(*github.PullRequest) error) error {
+			return gh.EachIssue(project, filters, limit, func(issue *github.Issue) bool {
+				return issue.PullRequest != nil && !(onlyMerged && issue.PullRequest.MergedAt.IsZero()) && updated.Includes(issue.UpdatedAt)
+			}, func(issue *github.Issue) error {
+				return each(pullRequestFromIssue(issue))
+			})
+		}
+	}
+
+	if !flagPullRequestReactions && !sortByReactionCount {
+		err = eachPull(flagPullRequestLimit, func(pr *github.PullRequest) error {
+			ui.Print(formatPullRequest(*pr, flagPullRequestFormat, colorize))
+			return nil
+		})
+		utils.Check(err)
+		return
+	}
+
+	fetchLimit := flagPullRequestLimit
+	if sortByReactionCount {
+		fetchLimit = 0
+	}
+	pulls := []*github.Issue{}
+	err = eachPull(fetchLimit, func(pr *github.PullRequest) error {
+		issue := github.Issue(*pr)
+		pulls = append(pulls, &issue)
+		return nil
 	})
 	utils.Check(err)
+	utils.Check(fetchMissingReactions(gh, project, pulls))
+	if sortByReactionCount {
+		sortByReactions(pulls, args.Flag.Bool("--sort-ascending"))
+		if flagPullRequestLimit > 0 && len(pulls) > flagPullRequestLimit {
+			pulls = pulls[:flagPullRequestLimit]
+		}
+	}
+	for _, pr := range pulls {
+		ui.Print(formatPullRequest(github.PullRequest(*pr), flagPullRequestFormat, colorize))
+	}
+}
+
+// pullRequestFromIssue converts a pull request as listed by the issues API.
+// Its branches aren't part of that API, so they are left empty.
+func pullRequestFromIssue(issue *github.Issue) *github.PullRequest {
+	pr := github.PullRequest(*issue)
+	pr.MergedAt = issue.PullRequest.MergedAt
+	pr.PullRequest = nil
+	pr.Head = &github.PullRequestSpec{}
+	pr.Base = &github.PullRequestSpec{}
+	return &pr
+}
+
+// prStatusItem is a pull request as returned by the query in statusPr.
+type prStatusItem struct {
+	Number         int
+	Title          string
+	IsDraft        bool
+	Mergeable      string
+	ReviewDecision string
+	Labels         struct {
+		Nodes []struct {
+			Name  string
+			Color string
+		}
+	}
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string
+				}
+			}
+		}
+	}
+}
+
+type prStatusSearch struct {
+	Nodes []prStatusItem
+}
+
+func statusPr(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request pull request status for %s\n", project)
+		return
+	}
+
+	limit := 10
+	if args.Flag.HasReceived("--limit") {
+		limit = args.Flag.Int("--limit")
+	}
+	if limit < 1 || limit > 100 {
+		limit = 100
+	}
+
+	branchName := ""
+	if currentBranch, err := localRepo.CurrentBranch(); err == nil {
+		branchName = currentBranch.ShortName()
+	}
+
+	gh := github.NewClient(project.Host)
+	response := struct {
+		Repository struct {
+			PullRequests prStatusSearch
+		}
+		ViewerCreated   prStatusSearch
+		ReviewRequested prStatusSearch
+	}{}
+	err = gh.GraphQL(`
+	fragment pr on PullRequest {
+		number
+		title
+		isDraft
+		mergeable
+		reviewDecision
+		labels(first: 10) {
+			nodes {
+				name
+				color
+			}
+		}
+		commits(last: 1) {
+			nodes {
+				commit {
+					statusCheckRollup {
+						state
+					}
+				}
+			}
+		}
+	}
+	query($owner: String!, $repo: String!, $branch: String!, $created: String!, $reviewRequested: String!, $limit: Int!, $onBranch: Boolean!) {
+		repository(owner: $owner, name: $repo) @include(if: $onBranch) {
+			pullRequests(headRefName: $branch, states: OPEN, first: 1) {
+				nodes {
+					...pr
+				}
+			}
+		}
+		viewerCreated: search(query: $created, type: ISSUE, first: $limit) {
+			nodes {
+				...pr
+			}
+		}
+		reviewRequested: search(query: $reviewRequested, type: ISSUE, first: $limit) {
+			nodes {
+				...pr
+			}
+		}
+	}`, map[string]interface{}{
+		"owner":           project.Owner,
+		"repo":            project.Name,
+		"branch":          branchName,
+		"created":         fmt.Sprintf("repo:%s is:pr is:open author:@me", project),
+		"reviewRequested": fmt.Sprintf("repo:%s is:pr is:open review-requested:@me", project),
+		"limit":           limit,
+		"onBranch":        branchName != "",
+	}, &response)
+	utils.Check(err)
 
 	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
-	for _, pr := range pulls {
-		ui.Print(formatPullRequest(pr, flagPullRequestFormat, colorize))
+
+	ui.Println("Current branch")
+	if branchName == "" {
+		ui.Println("  Not on any branch")
+	} else if len(response.Repository.PullRequests.Nodes) == 0 {
+		ui.Printf("  There is no pull request associated with [%s]\n", branchName)
+	} else {
+		printPrStatusItems(response.Repository.PullRequests.Nodes, colorize)
+	}
+
+	ui.Println("\nCreated by you")
+	if len(response.ViewerCreated.Nodes) == 0 {
+		ui.Println("  You have no open pull requests")
+	}
+	printPrStatusItems(response.ViewerCreated.Nodes, colorize)
+
+	ui.Println("\nRequesting a code review from you")
+	if len(response.ReviewRequested.Nodes) == 0 {
+		ui.Println("  You have no pull requests to review")
+	}
+	printPrStatusItems(response.ReviewRequested.Nodes, colorize)
+}
+
+func printPrStatusItems(items []prStatusItem, colorize bool) {
+	for _, item := range items {
+		issue := github.Issue{
+			Number: item.Number,
+			Title:  item.Title,
+			State:  "open",
+			Draft:  item.IsDraft,
+			Head:   &github.PullRequestSpec{},
+			Base:   &github.PullRequestSpec{},
+		}
+		for _, label := range item.Labels.Nodes {
+			issue.Labels = append(issue.Labels, github.IssueLabel{Name: label.Name, Color: label.Color})
+		}
+		ui.Print(formatPullRequest(github.PullRequest(issue), "%pC%>(8)%i%Creset  %t%  l%n", colorize))
+		ui.Printf("          %s\n", strings.Join(prStatusSummary(item, colorize), "  "))
+	}
+}
+
+// prStatusSummary describes the checks, review decision, and mergeability of
+// a pull request.
+func prStatusSummary(item prStatusItem, colorize bool) []string {
+	summary := []string{}
+	add := func(color int, text string) {
+		if colorize {
+			text = fmt.Sprintf("\033[%dm%s\033[0m", color, text)
+		}
+		summary = append(summary, text)
+	}
+
+	checks := ""
+	if len(item.Commits.Nodes) > 0 && item.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		checks = item.Commits.Nodes[0].Commit.StatusCheckRollup.State
+	}
+	switch checks {
+	case "SUCCESS":
+		add(32, "✔︎ Checks passing")
+	case "FAILURE", "ERROR":
+		add(31, "✖︎ Checks failing")
+	case "PENDING", "EXPECTED":
+		add(33, "● Checks pending")
+	default:
+		add(90, "No checks")
+	}
+
+	switch item.ReviewDecision {
+	case "APPROVED":
+		add(32, "✔︎ Approved")
+	case "CHANGES_REQUESTED":
+		add(31, "✖︎ Changes requested")
+	case "REVIEW_REQUIRED":
+		add(33, "● Review required")
+	}
+
+	if item.Mergeable == "CONFLICTING" {
+		add(31, "✖︎ Merge conflicts")
+	}
+
+	return summary
+}
+
+// prStatGraphWidth is the widest histogram that `pr stat` draws.
+const prStatGraphWidth = 40
+
+func statPr(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) != 1 {
+		utils.Check(command.UsageError(""))
+	}
+	prNumber, err := strconv.Atoi(words[0])
+	utils.Check(err)
+
+	threshold := -1
+	if args.Flag.HasReceived("--threshold") {
+		threshold = args.Flag.Int("--threshold")
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request changed files of pull request #%d in %s\n", prNumber, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	files, err := gh.FetchPullRequestFiles(project, prNumber)
+	utils.Check(err)
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	ui.Print(formatDiffStat(files, colorize))
+
+	changes := 0
+	for _, file := range files {
+		changes += file.Additions + file.Deletions
+	}
+	if threshold >= 0 && changes > threshold {
+		utils.Check(fmt.Errorf("Pull request #%d changes %d lines, which exceeds the threshold of %d", prNumber, changes, threshold))
+	}
+}
+
+// formatDiffStat renders changed files the way `git diff --stat` does.
+func formatDiffStat(files []github.PullRequestFile, colorize bool) string {
+	nameWidth, maxChanges, additions, deletions := 0, 0, 0, 0
+	for _, file := range files {
+		if len(file.Filename) > nameWidth {
+			nameWidth = len(file.Filename)
+		}
+		if changes := file.Additions + file.Deletions; changes > maxChanges {
+			maxChanges = changes
+		}
+		additions += file.Additions
+		deletions += file.Deletions
+	}
+	countWidth := len(strconv.Itoa(maxChanges))
+
+	paint := func(color int, text string) string {
+		if colorize && text != "" {
+			return fmt.Sprintf("\033[%dm%s\033[0m", color, text)
+		}
+		return text
+	}
+
+	var out strings.Builder
+	for _, file := range files {
+		plus, minus := file.Additions, file.Deletions
+		if maxChanges > prStatGraphWidth {
+			scale := func(n int) int {
+				scaled := n * prStatGraphWidth / maxChanges
+				if scaled == 0 && n > 0 {
+					scaled = 1
+				}
+				return scaled
+			}
+			plus, minus = scale(plus), scale(minus)
+		}
+		fmt.Fprintf(&out, " %-*s | %*d %s%s\n", nameWidth, file.Filename, countWidth, file.Additions+file.Deletions,
+			paint(32, strings.Repeat("+", plus)), paint(31, strings.Repeat("-", minus)))
+	}
+
+	fmt.Fprintf(&out, " %d %s changed, %d %s(+), %d %s(-)\n",
+		len(files), pluralize(len(files), "file"),
+		additions, pluralize(additions, "insertion"),
+		deletions, pluralize(deletions, "deletion"))
+	return out.String()
+}
+
+// prReviewThread is a review comment thread as returned by the query in
+// todoPr.
+type prReviewThread struct {
+	IsResolved   bool
+	IsOutdated   bool
+	Path         string
+	Line         int
+	OriginalLine int
+	Comments     struct {
+		Nodes []struct {
+			Author struct {
+				Login string
+			}
+			Body string
+		}
+	}
+}
+
+func todoPr(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) != 1 {
+		utils.Check(command.UsageError(""))
+	}
+	prNumber, err := strconv.Atoi(words[0])
+	utils.Check(err)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request review feedback of pull request #%d in %s\n", prNumber, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	response := struct {
+		Repository struct {
+			PullRequest struct {
+				Body          string
+				ReviewThreads struct {
+					Nodes []prReviewThread
+				}
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			pullRequest(number: $number) {
+				body
+				reviewThreads(first: 100) {
+					nodes {
+						isResolved
+						isOutdated
+						path
+						line
+						originalLine
+						comments(first: 1) {
+							nodes {
+								author {
+									login
+								}
+								body
+							}
+						}
+					}
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner":  project.Owner,
+		"repo":   project.Name,
+		"number": prNumber,
+	}, &response)
+	utils.Check(err)
+
+	pr := response.Repository.PullRequest
+	ui.Print(formatPrTodo(pr.ReviewThreads.Nodes, uncheckedTasks(pr.Body)))
+}
+
+// formatPrTodo renders unresolved review threads and unchecked tasks as a
+// Markdown checklist.
+func formatPrTodo(threads []prReviewThread, tasks []string) string {
+	var out strings.Builder
+	for _, thread := range threads {
+		if thread.IsResolved || len(thread.Comments.Nodes) == 0 {
+			continue
+		}
+		comment := thread.Comments.Nodes[0]
+		location := thread.Path
+		if thread.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, thread.Line)
+		} else if thread.OriginalLine > 0 {
+			location = fmt.Sprintf("%s:%d", location, thread.OriginalLine)
+		}
+		if thread.IsOutdated {
+			location += " (outdated)"
+		}
+		summary := strings.TrimSpace(strings.SplitN(strings.TrimSpace(comment.Body), "\n", 2)[0])
+		fmt.Fprintf(&out, "- [ ] %s @%s: %s\n", location, comment.Author.Login, summary)
+	}
+	for _, task := range tasks {
+		fmt.Fprintf(&out, "- [ ] %s\n", task)
+	}
+	return out.String()
+}
+
+var uncheckedTaskRegexp = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[ \]\s+(.+)$`)
+
+// uncheckedTasks returns the text of unchecked task list items in a Markdown
+// body, ignoring ones inside code blocks.
+func uncheckedTasks(body string) []string {
+	tasks := []string{}
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := uncheckedTaskRegexp.FindStringSubmatch(line); m != nil {
+			tasks = append(tasks, strings.TrimSpace(m[1]))
+		}
+	}
+	return tasks
+}
+
+func retargetPr(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) != 2 {
+		utils.Check(command.UsageError(""))
+	}
+	prNumber, err := strconv.Atoi(words[0])
+	utils.Check(err)
+	base := words[1]
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would change the base of pull request #%d in %s to %s\n", prNumber, project, base)
+		return
 	}
+
+	gh := github.NewClient(project.Host)
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+	if pr.Base.Ref == base {
+		ui.Printf("Pull request #%d is already based on %s\n", prNumber, base)
+		return
+	}
+
+	_, err = gh.UpdatePullRequest(project, prNumber, map[string]interface{}{
+		"base": base,
+	})
+	utils.Check(err)
+	ui.Printf("Changed the base of pull request #%d from %s to %s\n", prNumber, pr.Base.Ref, base)
 }
 
 func checkoutPr(command *Command, args *Args) {
@@ -304,14 +1020,19 @@ func checkoutPr(command *Command, args *Args) {
 	}
 
 	prNumberString := words[0]
-	_, err := strconv.Atoi(prNumberString)
-	utils.Check(err)
+	var baseProject *github.Project
+	if _, err := strconv.Atoi(prNumberString); err != nil {
+		baseProject, prNumberString, err = parsePullRequestURL(prNumberString)
+		utils.Check(err)
+	}
 
 	// Figure out the PR URL
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
-	baseProject, err := localRepo.MainProject()
-	utils.Check(err)
+	if baseProject == nil {
+		baseProject, err = localRepo.MainProject()
+		utils.Check(err)
+	}
 	host, err := github.CurrentConfig().PromptForHost(baseProject.Host)
 	utils.Check(err)
 	client := github.NewClientWithHost(host)
@@ -324,6 +1045,21 @@ func checkoutPr(command *Command, args *Args) {
 	args.Replace(args.Executable, "checkout", newArgs...)
 }
 
+// parsePullRequestURL returns the project and the number of the pull request
+// at a URL such as "https://github.com/OWNER/REPO/pull/12".
+func parsePullRequestURL(pullURL string) (*github.Project, string, error) {
+	invalid := fmt.Errorf("invalid pull request number or URL: '%s'", pullURL)
+	url, err := github.ParseURL(pullURL)
+	if err != nil {
+		return nil, "", invalid
+	}
+	match := regexp.MustCompile(`^pull/(\d+)`).FindStringSubmatch(url.ProjectPath())
+	if match == nil {
+		return nil, "", invalid
+	}
+	return url.Project, match[1], nil
+}
+
 func showPr(command *Command, args *Args) {
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
@@ -465,6 +1201,14 @@ func mergePr(command *Command, args *Args) {
 	prNumber, err := strconv.Atoi(words[0])
 	utils.Check(err)
 
+	if args.Flag.Bool("--queue") {
+		if hasField(args, "--squash", "--rebase", "--message", "--file", "--delete-branch") {
+			utils.Check(command.UsageError("--queue can't be combined with merge options"))
+		}
+		enqueuePr(args, prNumber)
+		return
+	}
+
 	params := map[string]interface{}{
 		"merge_method": "merge",
 	}
@@ -475,6 +1219,7 @@ func mergePr(command *Command, args *Args) {
 		params["merge_method"] = "rebase"
 	}
 
+	var messageTemplate *template.Template
 	msgs := args.Flag.AllValues("--message")
 	if len(msgs) > 0 {
 		params["commit_title"] = msgs[0]
@@ -483,6 +1228,9 @@ func mergePr(command *Command, args *Args) {
 		content, err := msgFromFile(args.Flag.Value("--file"))
 		utils.Check(err)
 		params["commit_title"], params["commit_message"] = github.SplitTitleBody(content)
+	} else if params["merge_method"] != "rebase" {
+		messageTemplate, err = mergeMessageTemplate()
+		utils.Check(err)
 	}
 
 	if headSHA := args.Flag.Value("--head-sha"); headSHA != "" {
@@ -502,6 +1250,15 @@ func mergePr(command *Command, args *Args) {
 	}
 
 	gh := github.NewClient(project.Host)
+
+	var pr *github.PullRequest
+	if messageTemplate != nil {
+		pr, err = gh.PullRequest(project, strconv.Itoa(prNumber))
+		utils.Check(err)
+		params["commit_title"], params["commit_message"], err = renderMergeMessage(messageTemplate, pr, params["merge_method"].(string))
+		utils.Check(err)
+	}
+
 	_, err = gh.MergePullRequest(project, prNumber, params)
 	utils.Check(err)
 
@@ -509,8 +1266,10 @@ func mergePr(command *Command, args *Args) {
 		return
 	}
 
-	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
-	utils.Check(err)
+	if pr == nil {
+		pr, err = gh.PullRequest(project, strconv.Itoa(prNumber))
+		utils.Check(err)
+	}
 	if !pr.IsSameRepo() {
 		return
 	}
@@ -520,6 +1279,52 @@ func mergePr(command *Command, args *Args) {
 	utils.Check(err)
 }
 
+// enqueuePr adds a pull request to the merge queue of its base branch.
+func enqueuePr(args *Args, prNumber int) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would add pull request #%d for %s to the merge queue\n", prNumber, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	pr, err := gh.PullRequest(project, strconv.Itoa(prNumber))
+	utils.Check(err)
+
+	input := map[string]interface{}{
+		"pullRequestId": pr.NodeID,
+	}
+	if headSHA := args.Flag.Value("--head-sha"); headSHA != "" {
+		input["expectedHeadOid"] = headSHA
+	}
+
+	response := struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry struct {
+				Position int
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	mutation($input: EnqueuePullRequestInput!) {
+		enqueuePullRequest(input: $input) {
+			mergeQueueEntry {
+				position
+			}
+		}
+	}`, map[string]interface{}{"input": input}, &response)
+	utils.Check(err)
+
+	ui.Printf("Added pull request #%d to the merge queue for %s at position %d\n",
+		prNumber, pr.Base.Ref, response.EnqueuePullRequest.MergeQueueEntry.Position)
+}
+
 func formatPullRequest(pr github.PullRequest, format string, colorize bool) string {
 	placeholders := formatIssuePlaceholders(github.Issue(pr), colorize)
 	delete(placeholders, "NC")
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+func checksPr(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	words := args.Words()
+	if len(words) > 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		if len(words) > 0 {
+			ui.Printf("Would request checks for pull request #%s in %s\n", words[0], project)
+		} else {
+			ui.Printf("Would request checks for the pull request of the current branch in %s\n", project)
+		}
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+
+	var pr *github.PullRequest
+	if len(words) > 0 {
+		if _, err := strconv.Atoi(words[0]); err != nil {
+			utils.Check(fmt.Errorf("invalid pull request number: '%s'", words[0]))
+		}
+		pr, err = gh.PullRequest(project, words[0])
+	} else {
+		pr, err = findCurrentPullRequest(localRepo, gh, project, "")
+	}
+	utils.Check(err)
+
+	response, err := gh.FetchCIStatus(project, pr.Head.Sha)
+	utils.Check(err)
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	if len(response.Statuses) == 0 {
+		ui.Println("no status")
+	} else {
+		ciVerboseFormat(response.Statuses, "", colorize)
+	}
+
+	if args.Flag.Bool("--annotations") {
+		checkRuns, err := gh.FetchCheckRuns(project, pr.Head.Sha)
+		utils.Check(err)
+		for _, checkRun := range checkRuns {
+			if !checkRunFailed(checkRun) || checkRun.Output.AnnotationsCount == 0 {
+				continue
+			}
+			annotations, err := gh.FetchCheckRunAnnotations(project, checkRun.ID)
+			utils.Check(err)
+			ui.Printf("\n%s:\n", checkRun.Name)
+			ui.Print(formatCheckRunAnnotations(annotations))
+		}
+	}
+
+	switch ciState(response.Statuses) {
+	case "success", "neutral":
+		os.Exit(0)
+	case "failure", "error", "action_required", "cancelled", "timed_out":
+		os.Exit(1)
+	case "pending":
+		os.Exit(2)
+	default:
+		os.Exit(3)
+	}
+}
+
+func checkRunFailed(checkRun github.CheckRun) bool {
+	switch checkRun.Conclusion {
+	case "failure", "action_required", "cancelled", "timed_out":
+		return checkRun.Status == "completed"
+	}
+	return false
+}
+
+// formatCheckRunAnnotations lists annotations as "PATH:LINE: LEVEL: MESSAGE",
+// the way compilers report errors, with the lines of multi-line messages
+// indented below.
+func formatCheckRunAnnotations(annotations []github.CheckRunAnnotation) string {
+	var out strings.Builder
+	for _, annotation := range annotations {
+		location := annotation.Path
+		if annotation.StartLine > 0 {
+			location = fmt.Sprintf("%s:%d", location, annotation.StartLine)
+			if annotation.EndLine > annotation.StartLine {
+				location = fmt.Sprintf("%s-%d", location, annotation.EndLine)
+			}
+		}
+
+		message := strings.TrimSpace(annotation.Message)
+		if annotation.Title != "" && !strings.HasPrefix(message, annotation.Title) {
+			message = annotation.Title + "\n" + message
+		}
+		message = strings.Replace(message, "\n", "\n      ", -1)
+
+		fmt.Fprintf(&out, "  %s: %s: %s\n", location, annotation.AnnotationLevel, message)
+	}
+	return out.String()
+}
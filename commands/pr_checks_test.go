@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatCheckRunAnnotations(t *testing.T) {
+	annotations := []github.CheckRunAnnotation{
+		{Path: "commands/pr.go", StartLine: 12, EndLine: 12, AnnotationLevel: "failure", Message: "undefined: bar"},
+		{Path: "commands/pr_test.go", StartLine: 40, EndLine: 42, AnnotationLevel: "failure", Title: "TestChecks", Message: "expected 1\ngot 2"},
+		{Path: ".github", AnnotationLevel: "warning", Message: "Node 12 is deprecated"},
+	}
+	assert.Equal(t, `  commands/pr.go:12: failure: undefined: bar
+  commands/pr_test.go:40-42: failure: TestChecks
+      expected 1
+      got 2
+  .github: warning: Node 12 is deprecated
+`, formatCheckRunAnnotations(annotations))
+}
+
+func TestCheckRunFailed(t *testing.T) {
+	assert.Equal(t, true, checkRunFailed(github.CheckRun{Status: "completed", Conclusion: "failure"}))
+	assert.Equal(t, false, checkRunFailed(github.CheckRun{Status: "completed", Conclusion: "success"}))
+	assert.Equal(t, false, checkRunFailed(github.CheckRun{Status: "in_progress"}))
+}
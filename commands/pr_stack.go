@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+const (
+	prStackStartMarker = "<!-- hub-stack -->"
+	prStackEndMarker   = "<!-- /hub-stack -->"
+)
+
+var prStackSectionRegexp = regexp.MustCompile(`(?s)\n*` + regexp.QuoteMeta(prStackStartMarker) + `.*?` + regexp.QuoteMeta(prStackEndMarker))
+
+// prStackEntry is a branch of a stack along with its pull request, if one
+// exists.
+type prStackEntry struct {
+	Branch string
+	Base   string
+	Pull   *github.PullRequest
+}
+
+func (e *prStackEntry) merged() bool {
+	return e.Pull != nil && !e.Pull.MergedAt.IsZero()
+}
+
+func stackPr(command *Command, args *Args) {
+	branches := args.Words()
+	if len(branches) == 0 {
+		utils.Check(command.UsageError("no branches given"))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+	remote, err := localRepo.RemoteForProject(project)
+	utils.Check(err)
+
+	base := args.Flag.Value("--base")
+	if base == "" {
+		base = localRepo.DefaultBranch(remote).ShortName()
+	}
+
+	args.NoForward()
+	if args.Flag.Bool("--push") {
+		for _, branch := range branches {
+			pushArgs := []string{"push", "--force-with-lease", "--set-upstream", remote.Name, fmt.Sprintf("%s:%s", branch, branch)}
+			if args.Noop {
+				ui.Printf("Would run `git %s`\n", strings.Join(pushArgs, " "))
+			} else {
+				utils.Check(git.Spawn(pushArgs...))
+			}
+		}
+	}
+
+	gh := github.NewClient(project.Host)
+	entries := make([]*prStackEntry, len(branches))
+	for i, branch := range branches {
+		entries[i] = &prStackEntry{Branch: branch}
+		if args.Noop {
+			continue
+		}
+		pulls, err := gh.FetchPullRequests(project, map[string]interface{}{
+			"head":  fmt.Sprintf("%s:%s", project.Owner, branch),
+			"state": "all",
+		}, 0, func(pr *github.PullRequest) bool {
+			return pr.State == "open" || !pr.MergedAt.IsZero()
+		})
+		utils.Check(err)
+		entries[i].Pull = stackPullRequest(pulls)
+	}
+	assignStackBases(entries, base)
+
+	for _, entry := range entries {
+		if entry.merged() {
+			continue
+		}
+		if entry.Pull == nil {
+			if args.Noop {
+				ui.Printf("Would open a pull request for %s onto %s\n", entry.Branch, entry.Base)
+				continue
+			}
+			params := map[string]interface{}{
+				"base":  entry.Base,
+				"head":  fmt.Sprintf("%s:%s", project.Owner, entry.Branch),
+				"title": stackPullRequestTitle(remote, entry),
+			}
+			if args.Flag.Bool("--draft") {
+				params["draft"] = true
+			}
+			entry.Pull, err = gh.CreatePullRequest(project, params)
+			utils.Check(err)
+		} else if entry.Pull.Base.Ref != entry.Base {
+			if args.Noop {
+				ui.Printf("Would change the base of #%d to %s\n", entry.Pull.Number, entry.Base)
+				continue
+			}
+			entry.Pull, err = gh.UpdatePullRequest(project, entry.Pull.Number, map[string]interface{}{
+				"base": entry.Base,
+			})
+			utils.Check(err)
+		}
+	}
+
+	if args.Noop {
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.merged() {
+			continue
+		}
+		body := replaceStackSection(entry.Pull.Body, formatStackSection(entries, entry))
+		if body != entry.Pull.Body {
+			_, err = gh.UpdatePullRequest(project, entry.Pull.Number, map[string]interface{}{
+				"body": body,
+			})
+			utils.Check(err)
+		}
+		ui.Printf("#%d  %s -> %s  %s\n", entry.Pull.Number, entry.Branch, entry.Base, entry.Pull.HTMLURL)
+	}
+}
+
+// stackPullRequest picks the open pull request for a branch, or else the one
+// that was merged.
+func stackPullRequest(pulls []github.PullRequest) *github.PullRequest {
+	for i, pr := range pulls {
+		if pr.State == "open" {
+			return &pulls[i]
+		}
+	}
+	if len(pulls) > 0 {
+		return &pulls[0]
+	}
+	return nil
+}
+
+// assignStackBases bases each branch of a stack on the closest branch below
+// it whose pull request hasn't been merged yet, or on base.
+func assignStackBases(entries []*prStackEntry, base string) {
+	for _, entry := range entries {
+		entry.Base = base
+		if !entry.merged() {
+			base = entry.Branch
+		}
+	}
+}
+
+// stackPullRequestTitle uses the subject of the only commit on a branch as the
+// title of its pull request, or else the branch name.
+func stackPullRequestTitle(remote *github.Remote, entry *prStackEntry) string {
+	commits, _ := git.RefList(fmt.Sprintf("%s/%s", remote.Name, entry.Base), entry.Branch)
+	if len(commits) == 1 {
+		if message, err := git.Show(commits[0]); err == nil {
+			title, _ := github.SplitTitleBody(message)
+			return title
+		}
+	}
+	return entry.Branch
+}
+
+// formatStackSection lists the pull requests of a stack for the description
+// of the pull request of current.
+func formatStackSection(entries []*prStackEntry, current *prStackEntry) string {
+	lines := []string{prStackStartMarker, "**Stack:**", ""}
+	for _, entry := range entries {
+		if entry.Pull == nil {
+			continue
+		}
+		line := fmt.Sprintf("- #%d", entry.Pull.Number)
+		if entry.merged() {
+			line += " (merged)"
+		}
+		if entry == current {
+			line += " ← this pull request"
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, prStackEndMarker)
+	return strings.Join(lines, "\n")
+}
+
+// replaceStackSection replaces the stack section in a pull request description
+// or appends it if there is none yet.
+func replaceStackSection(body, section string) string {
+	if prStackSectionRegexp.MatchString(body) {
+		return strings.TrimLeft(prStackSectionRegexp.ReplaceAllLiteralString(body, "\n\n"+section), "\n")
+	}
+	if strings.TrimSpace(body) == "" {
+		return section
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + section
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestAssignStackBases(t *testing.T) {
+	entries := []*prStackEntry{
+		{Branch: "one", Pull: &github.PullRequest{Number: 1, MergedAt: time.Now()}},
+		{Branch: "two", Pull: &github.PullRequest{Number: 2}},
+		{Branch: "three"},
+	}
+	assignStackBases(entries, "main")
+
+	assert.Equal(t, "main", entries[0].Base)
+	assert.Equal(t, "main", entries[1].Base)
+	assert.Equal(t, "two", entries[2].Base)
+}
+
+func TestStackPullRequest(t *testing.T) {
+	assert.T(t, stackPullRequest(nil) == nil)
+
+	pulls := []github.PullRequest{
+		{Number: 3, State: "closed", MergedAt: time.Now()},
+		{Number: 5, State: "open"},
+	}
+	assert.Equal(t, 5, stackPullRequest(pulls).Number)
+	assert.Equal(t, 3, stackPullRequest(pulls[:1]).Number)
+}
+
+func TestFormatStackSection(t *testing.T) {
+	entries := []*prStackEntry{
+		{Branch: "one", Pull: &github.PullRequest{Number: 1, MergedAt: time.Now()}},
+		{Branch: "two", Pull: &github.PullRequest{Number: 2}},
+		{Branch: "three", Pull: &github.PullRequest{Number: 3}},
+	}
+	assert.Equal(t, `<!-- hub-stack -->
+**Stack:**
+
+- #1 (merged)
+- #2 ← this pull request
+- #3
+<!-- /hub-stack -->`, formatStackSection(entries, entries[1]))
+}
+
+func TestReplaceStackSection(t *testing.T) {
+	section := "<!-- hub-stack -->\nnew\n<!-- /hub-stack -->"
+
+	assert.Equal(t, section, replaceStackSection("", section))
+	assert.Equal(t, "Intro\n\n"+section, replaceStackSection("Intro\n", section))
+	assert.Equal(t, "Intro\n\n"+section+"\n\nOutro", replaceStackSection("Intro\n<!-- hub-stack -->\nold\n<!-- /hub-stack -->\n\nOutro", section))
+	assert.Equal(t, section, replaceStackSection("<!-- hub-stack -->\nold\n<!-- /hub-stack -->", section))
+}
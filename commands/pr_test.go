@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestPrStatusSummary(t *testing.T) {
+	var item prStatusItem
+	err := json.Unmarshal([]byte(`{
+		"number": 12,
+		"mergeable": "CONFLICTING",
+		"reviewDecision": "CHANGES_REQUESTED",
+		"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "SUCCESS"}}}]}
+	}`), &item)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"✔︎ Checks passing", "✖︎ Changes requested", "✖︎ Merge conflicts"}, prStatusSummary(item, false))
+
+	assert.Equal(t, []string{"No checks"}, prStatusSummary(prStatusItem{Mergeable: "MERGEABLE"}, false))
+	assert.Equal(t, []string{"\033[90mNo checks\033[0m"}, prStatusSummary(prStatusItem{}, true))
+}
+
+func TestFormatDiffStat(t *testing.T) {
+	files := []github.PullRequestFile{
+		{Filename: "commands/pr.go", Additions: 3, Deletions: 1},
+		{Filename: "README.md", Additions: 10, Deletions: 0},
+	}
+	assert.Equal(t, ` commands/pr.go |  4 +++-
+ README.md      | 10 ++++++++++
+ 2 files changed, 13 insertions(+), 1 deletion(-)
+`, formatDiffStat(files, false))
+
+	files = []github.PullRequestFile{
+		{Filename: "big.txt", Additions: 300, Deletions: 100},
+		{Filename: "small.txt", Additions: 1, Deletions: 0},
+	}
+	assert.Equal(t, ` big.txt   | 400 ++++++++++++++++++++++++++++++----------
+ small.txt |   1 +
+ 2 files changed, 301 insertions(+), 100 deletions(-)
+`, formatDiffStat(files, false))
+}
+
+func TestUncheckedTasks(t *testing.T) {
+	body := "Fixes #1\r\n\r\n- [x] Write code\r\n- [ ] Update docs\r\n  * [ ]  Add tests \r\n1. [ ] Release\r\n```\n- [ ] not a task\n```\n- [] not a task either"
+	assert.Equal(t, []string{"Update docs", "Add tests", "Release"}, uncheckedTasks(body))
+	assert.Equal(t, []string{}, uncheckedTasks(""))
+}
+
+func TestFormatPrTodo(t *testing.T) {
+	threads := []prReviewThread{}
+	err := json.Unmarshal([]byte(`[
+		{"path": "commands/pr.go", "line": 42, "comments": {"nodes": [{"author": {"login": "mislav"}, "body": "Handle this error\n\nOtherwise it panics"}]}},
+		{"path": "README.md", "line": 3, "isResolved": true, "comments": {"nodes": [{"author": {"login": "mislav"}, "body": "Typo"}]}},
+		{"path": "main.go", "originalLine": 7, "isOutdated": true, "comments": {"nodes": [{"author": {"login": "jdoe"}, "body": "Rename"}]}}
+	]`), &threads)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `- [ ] commands/pr.go:42 @mislav: Handle this error
+- [ ] main.go:7 (outdated) @jdoe: Rename
+- [ ] Update docs
+`, formatPrTodo(threads, []string{"Update docs"}))
+}
+
+func TestPullRequestFromIssue(t *testing.T) {
+	mergedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	issue := &github.Issue{
+		Number:      102,
+		State:       "closed",
+		Title:       "Second",
+		User:        &github.User{Login: "octocat"},
+		PullRequest: &github.PullRequest{MergedAt: mergedAt},
+	}
+
+	pr := pullRequestFromIssue(issue)
+	assert.Equal(t, 102, pr.Number)
+	assert.Equal(t, mergedAt, pr.MergedAt)
+	assert.Equal(t, "    #102  merged  Second  ()\n", formatPullRequest(*pr, "%>(8)%i  %pS  %t  (%H)%n", false))
+}
+
+func TestParsePullRequestURL(t *testing.T) {
+	project, number, err := parsePullRequestURL("https://github.com/mislav/jekyll/pull/77/files")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "mislav/jekyll", project.String())
+	assert.Equal(t, "77", number)
+
+	_, _, err = parsePullRequestURL("https://github.com/mislav/jekyll/issues/77")
+	assert.Equal(t, "invalid pull request number or URL: 'https://github.com/mislav/jekyll/issues/77'", err.Error())
+
+	_, _, err = parsePullRequestURL("fixes")
+	assert.Equal(t, "invalid pull request number or URL: 'fixes'", err.Error())
+}
+
+func TestAddIssueFiltersForPulls(t *testing.T) {
+	args := NewArgs([]string{"pr", "list", "-@", "octocat", "-l", "bug,ready", "-a", "none", "-M", "3", "--creator", "mislav"})
+	assert.Equal(t, nil, cmdListPulls.parseArguments(args))
+
+	filters := map[string]interface{}{}
+	assert.Equal(t, nil, addIssueFilters(filters, args, nil, nil))
+	assert.Equal(t, map[string]interface{}{
+		"mentioned": "octocat",
+		"labels":    "bug,ready",
+		"assignee":  "none",
+		"milestone": 3,
+		"creator":   "mislav",
+	}, filters)
+}
@@ -0,0 +1,466 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdProject = &Command{
+		Run: printHelp,
+		Usage: `
+project list [-c] [--owner <OWNER>] [-f <FORMAT>] [--color]
+project view [--owner <OWNER>] <PROJECT>
+project add-item [--owner <OWNER>] <PROJECT> <NUMBER>
+`,
+		Long: `Manage GitHub Projects for the owner of the current repository.
+
+## Commands:
+
+	* _list_:
+		List projects owned by the user or organization that owns the current
+		repository.
+
+	* _view_:
+		Show the title, description, and items of the project <PROJECT>.
+
+	* _add-item_:
+		Add the issue or pull request <NUMBER> from the current repository to the
+		project <PROJECT> and print the URL of the project.
+
+## Options:
+	-c, --include-closed
+		List closed projects together with open ones.
+
+	--owner <OWNER>
+		The user or organization that owns the project (default: the owner of the
+		current repository).
+
+	-f, --format <FORMAT>
+		Pretty print the list of projects using format <FORMAT> (default:
+		"%>(4)%I  %t%n"). See the "PRETTY FORMATS" section of git-log(1) for some
+		additional details on how placeholders are used in format. The available
+		placeholders are:
+
+		%I: project number
+
+		%U: the URL of this project
+
+		%S: state (i.e. "open", "closed")
+
+		%t: title
+
+		%d: short description
+
+		%Ni: number of items in the project
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+	<PROJECT>
+		The number of a project.
+
+	<NUMBER>
+		The number of an issue or a pull request in the current repository.
+
+## Examples:
+		$ hub project list
+		   1  Roadmap
+		   4  Bug triage
+
+		$ hub project add-item 1 123
+
+## Notes:
+
+Projects are accessed through the GraphQL API; the access token used by hub
+must include the "project" scope (or "read:project" for read-only commands).
+
+## See also:
+
+hub-issue(1), hub-pull-request(1), hub(1)
+`,
+	}
+
+	cmdListProjects = &Command{
+		Key: "list",
+		Run: listProjects,
+		KnownFlags: `
+		-c, --include-closed
+		--owner OWNER
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdViewProject = &Command{
+		Key: "view",
+		Run: viewProject,
+		KnownFlags: `
+		--owner OWNER
+`,
+	}
+
+	cmdAddProjectItem = &Command{
+		Key: "add-item",
+		Run: addProjectItem,
+		KnownFlags: `
+		--owner OWNER
+`,
+	}
+)
+
+func init() {
+	cmdProject.Use(cmdListProjects)
+	cmdProject.Use(cmdViewProject)
+	cmdProject.Use(cmdAddProjectItem)
+	CmdRunner.Use(cmdProject)
+}
+
+type projectV2 struct {
+	ID               string
+	Number           int
+	Title            string
+	ShortDescription string
+	URL              string
+	Closed           bool
+	Items            struct {
+		TotalCount int
+	}
+}
+
+type projectV2Item struct {
+	Type    string
+	Content struct {
+		Number int
+		Title  string
+		State  string
+		URL    string
+	}
+}
+
+func listProjects(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	owner := project.Owner
+	if args.Flag.HasReceived("--owner") {
+		owner = args.Flag.Value("--owner")
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of projects for %s\n", owner)
+		return
+	}
+
+	response := struct {
+		RepositoryOwner struct {
+			ProjectsV2 struct {
+				Nodes []projectV2
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!) {
+		repositoryOwner(login: $owner) {
+			... on ProjectV2Owner {
+				projectsV2(first: 100, orderBy: {field: NUMBER, direction: ASC}) {
+					nodes {
+						number
+						title
+						shortDescription
+						url
+						closed
+						items {
+							totalCount
+						}
+					}
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner": owner,
+	}, &response)
+	utils.Check(err)
+
+	flagProjectFormat := "%>(4)%I  %t%n"
+	if args.Flag.HasReceived("--format") {
+		flagProjectFormat = args.Flag.Value("--format")
+	}
+	includeClosed := args.Flag.Bool("--include-closed")
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, p := range response.RepositoryOwner.ProjectsV2.Nodes {
+		if p.Closed && !includeClosed {
+			continue
+		}
+		ui.Print(formatProjectV2(p, flagProjectFormat, colorize))
+	}
+}
+
+func formatProjectV2(p projectV2, format string, colorize bool) string {
+	state := "open"
+	if p.Closed {
+		state = "closed"
+	}
+
+	placeholders := map[string]string{
+		"I":  strconv.Itoa(p.Number),
+		"U":  p.URL,
+		"S":  state,
+		"t":  p.Title,
+		"d":  p.ShortDescription,
+		"Ni": strconv.Itoa(p.Items.TotalCount),
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func viewProject(cmd *Command, args *Args) {
+	projectNumber := 0
+	if args.ParamsSize() > 0 {
+		projectNumber, _ = strconv.Atoi(args.GetParam(0))
+	}
+	if projectNumber == 0 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	owner := project.Owner
+	if args.Flag.HasReceived("--owner") {
+		owner = args.Flag.Value("--owner")
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would display project %d for %s\n", projectNumber, owner)
+		return
+	}
+
+	response := struct {
+		RepositoryOwner struct {
+			ProjectV2 *struct {
+				projectV2
+				ItemList struct {
+					Nodes []projectV2Item
+				}
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!, $number: Int!) {
+		repositoryOwner(login: $owner) {
+			... on ProjectV2Owner {
+				projectV2(number: $number) {
+					number
+					title
+					shortDescription
+					url
+					closed
+					items {
+						totalCount
+					}
+					itemList: items(first: 100) {
+						nodes {
+							type
+							content {
+								... on DraftIssue {
+									title
+								}
+								... on Issue {
+									number
+									title
+									state
+									url
+								}
+								... on PullRequest {
+									number
+									title
+									state
+									url
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner":  owner,
+		"number": projectNumber,
+	}, &response)
+	utils.Check(err)
+
+	p := response.RepositoryOwner.ProjectV2
+	if p == nil {
+		utils.Check(fmt.Errorf("error: no project found with number %d for %s", projectNumber, owner))
+	}
+
+	closed := ""
+	if p.Closed {
+		closed = "[CLOSED] "
+	}
+	ui.Printf("# %s%s\n\n", closed, p.Title)
+	ui.Printf("* %s\n", p.URL)
+	ui.Printf("* %d %s\n", p.Items.TotalCount, pluralize(p.Items.TotalCount, "item"))
+	if p.ShortDescription != "" {
+		ui.Printf("\n%s\n", p.ShortDescription)
+	}
+
+	if len(p.ItemList.Nodes) > 0 {
+		ui.Printf("\n## Items:\n\n")
+		for _, item := range p.ItemList.Nodes {
+			ui.Println(formatProjectV2Item(item))
+		}
+	}
+}
+
+func formatProjectV2Item(item projectV2Item) string {
+	switch item.Type {
+	case "ISSUE", "PULL_REQUEST":
+		return fmt.Sprintf("%6s  %s (%s)", fmt.Sprintf("#%d", item.Content.Number), item.Content.Title, strings.ToLower(item.Content.State))
+	case "DRAFT_ISSUE":
+		return fmt.Sprintf("%6s  %s (draft)", "", item.Content.Title)
+	default:
+		return fmt.Sprintf("%6s  (%s)", "", strings.ToLower(strings.Replace(item.Type, "_", " ", -1)))
+	}
+}
+
+func addProjectItem(cmd *Command, args *Args) {
+	if args.ParamsSize() < 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	projectNumber, err := strconv.Atoi(args.GetParam(0))
+	utils.Check(err)
+	itemNumber, err := strconv.Atoi(strings.TrimPrefix(args.GetParam(1), "#"))
+	utils.Check(err)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	owner := project.Owner
+	if args.Flag.HasReceived("--owner") {
+		owner = args.Flag.Value("--owner")
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would add #%d to project %d for %s\n", itemNumber, projectNumber, owner)
+		return
+	}
+
+	response := struct {
+		Repository struct {
+			IssueOrPullRequest struct {
+				ID string
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			issueOrPullRequest(number: $number) {
+				... on Issue {
+					id
+				}
+				... on PullRequest {
+					id
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner":  project.Owner,
+		"repo":   project.Name,
+		"number": itemNumber,
+	}, &response)
+	utils.Check(err)
+
+	itemURL, err := addToProjectsV2(gh, owner, []string{strconv.Itoa(projectNumber)}, response.Repository.IssueOrPullRequest.ID)
+	utils.Check(err)
+
+	ui.Println(itemURL)
+}
+
+// addToProjectsV2 adds the issue or pull request identified by its GraphQL
+// node ID to each of the projects of owner. It returns the URL of the last
+// project the item was added to.
+func addToProjectsV2(gh *github.Client, owner string, projectNumbers []string, contentID string) (projectURL string, err error) {
+	for _, value := range projectNumbers {
+		var projectNumber int
+		if projectNumber, err = strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("invalid project number: %s", value)
+		}
+
+		projectResponse := struct {
+			RepositoryOwner struct {
+				ProjectV2 *projectV2
+			}
+		}{}
+		err = gh.GraphQL(`
+		query($owner: String!, $number: Int!) {
+			repositoryOwner(login: $owner) {
+				... on ProjectV2Owner {
+					projectV2(number: $number) {
+						id
+						url
+					}
+				}
+			}
+		}`, map[string]interface{}{
+			"owner":  owner,
+			"number": projectNumber,
+		}, &projectResponse)
+		if err != nil {
+			return
+		}
+		if projectResponse.RepositoryOwner.ProjectV2 == nil {
+			return "", fmt.Errorf("error: no project found with number %d for %s", projectNumber, owner)
+		}
+
+		err = gh.GraphQL(`
+		mutation($project: ID!, $content: ID!) {
+			addProjectV2ItemById(input: {projectId: $project, contentId: $content}) {
+				item {
+					id
+				}
+			}
+		}`, map[string]interface{}{
+			"project": projectResponse.RepositoryOwner.ProjectV2.ID,
+			"content": contentID,
+		}, &struct{}{})
+		if err != nil {
+			return
+		}
+		projectURL = projectResponse.RepositoryOwner.ProjectV2.URL
+	}
+
+	return
+}
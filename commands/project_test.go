@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatProjectV2Item(t *testing.T) {
+	issue := projectV2Item{Type: "ISSUE"}
+	issue.Content.Number = 123
+	issue.Content.Title = "Fix the build"
+	issue.Content.State = "OPEN"
+	assert.Equal(t, "  #123  Fix the build (open)", formatProjectV2Item(issue))
+
+	draft := projectV2Item{Type: "DRAFT_ISSUE"}
+	draft.Content.Title = "Write release notes"
+	assert.Equal(t, "        Write release notes (draft)", formatProjectV2Item(draft))
+
+	redacted := projectV2Item{Type: "REDACTED"}
+	assert.Equal(t, "        (redacted)", formatProjectV2Item(redacted))
+}
@@ -0,0 +1,388 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	cmdProtection = &Command{
+		Run: printHelp,
+		Usage: `
+protection show <BRANCH-PATTERN>
+protection set [-F <FILE>] [-c <CHECK>] [-r <COUNT>] [--enforce-admins] [--linear-history] <BRANCH-PATTERN>
+protection delete <BRANCH-PATTERN>
+`,
+		Long: `Manage branch protection rules for the current repository.
+
+## Commands:
+
+	* _show_:
+		Print the branch protection rule for <BRANCH-PATTERN> in the YAML format
+		accepted by ''protection set --file''.
+
+	* _set_:
+		Create or replace the branch protection rule for <BRANCH-PATTERN>. Settings
+		that are not specified in <FILE> or via flags are turned off.
+
+	* _delete_:
+		Delete the branch protection rule for <BRANCH-PATTERN>.
+
+## Options:
+	-F, --file <FILE>
+		Read the protection settings from a JSON or YAML <FILE>. Pass "-" to read
+		from standard input instead. Flags take precedence over settings in <FILE>.
+		The supported keys are:
+
+		required_status_checks: list of status check names that must pass
+
+		strict_status_checks: require branches to be up to date before merging
+
+		required_approving_reviews: number of approving reviews required
+
+		dismiss_stale_reviews: dismiss approvals when new commits are pushed
+
+		require_code_owner_reviews: require a review from code owners
+
+		enforce_admins: apply the rule to repository administrators as well
+
+		require_linear_history: prevent merge commits from being pushed
+
+	-c, --required-check <CHECK>
+		Require the status check <CHECK> to pass before merging. This flag can be
+		passed multiple times.
+
+	-r, --required-reviews <COUNT>
+		Require <COUNT> approving reviews before merging.
+
+	--enforce-admins
+		Enforce the rule for repository administrators.
+
+	--linear-history
+		Require a linear commit history.
+
+	<BRANCH-PATTERN>
+		A branch name or a pattern such as "release/*".
+
+## Examples:
+		$ hub protection show main > protection.yml
+		$ hub protection set -F protection.yml main
+		$ hub protection set -c ci/build -r 2 --enforce-admins 'release/*'
+
+## See also:
+
+hub(1)
+`,
+	}
+
+	cmdShowProtection = &Command{
+		Key: "show",
+		Run: showProtection,
+	}
+
+	cmdSetProtection = &Command{
+		Key: "set",
+		Run: setProtection,
+		KnownFlags: `
+		-F, --file FILE
+		-c, --required-check CHECK
+		-r, --required-reviews COUNT
+		--enforce-admins
+		--linear-history
+`,
+	}
+
+	cmdDeleteProtection = &Command{
+		Key: "delete",
+		Run: deleteProtection,
+	}
+)
+
+func init() {
+	cmdProtection.Use(cmdShowProtection)
+	cmdProtection.Use(cmdSetProtection)
+	cmdProtection.Use(cmdDeleteProtection)
+	CmdRunner.Use(cmdProtection)
+}
+
+type protectionSpec struct {
+	RequiredStatusChecks     []string `yaml:"required_status_checks" json:"required_status_checks"`
+	StrictStatusChecks       bool     `yaml:"strict_status_checks" json:"strict_status_checks"`
+	RequiredApprovingReviews int      `yaml:"required_approving_reviews" json:"required_approving_reviews"`
+	DismissStaleReviews      bool     `yaml:"dismiss_stale_reviews" json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews  bool     `yaml:"require_code_owner_reviews" json:"require_code_owner_reviews"`
+	EnforceAdmins            bool     `yaml:"enforce_admins" json:"enforce_admins"`
+	RequireLinearHistory     bool     `yaml:"require_linear_history" json:"require_linear_history"`
+}
+
+type branchProtectionRule struct {
+	ID                           string
+	Pattern                      string
+	RequiresStatusChecks         bool
+	RequiredStatusCheckContexts  []string
+	RequiresStrictStatusChecks   bool
+	RequiresApprovingReviews     bool
+	RequiredApprovingReviewCount int
+	DismissesStaleReviews        bool
+	RequiresCodeOwnerReviews     bool
+	IsAdminEnforced              bool
+	RequiresLinearHistory        bool
+}
+
+func (r *branchProtectionRule) spec() protectionSpec {
+	spec := protectionSpec{
+		RequiredStatusChecks:    []string{},
+		DismissStaleReviews:     r.DismissesStaleReviews,
+		RequireCodeOwnerReviews: r.RequiresCodeOwnerReviews,
+		EnforceAdmins:           r.IsAdminEnforced,
+		RequireLinearHistory:    r.RequiresLinearHistory,
+	}
+	if r.RequiresStatusChecks {
+		spec.RequiredStatusChecks = r.RequiredStatusCheckContexts
+		spec.StrictStatusChecks = r.RequiresStrictStatusChecks
+	}
+	if r.RequiresApprovingReviews {
+		spec.RequiredApprovingReviews = r.RequiredApprovingReviewCount
+	}
+	return spec
+}
+
+func (s protectionSpec) mutationInput() map[string]interface{} {
+	checks := s.RequiredStatusChecks
+	if checks == nil {
+		checks = []string{}
+	}
+	return map[string]interface{}{
+		"requiresStatusChecks":         len(checks) > 0,
+		"requiredStatusCheckContexts":  checks,
+		"requiresStrictStatusChecks":   s.StrictStatusChecks,
+		"requiresApprovingReviews":     s.RequiredApprovingReviews > 0,
+		"requiredApprovingReviewCount": s.RequiredApprovingReviews,
+		"dismissesStaleReviews":        s.DismissStaleReviews,
+		"requiresCodeOwnerReviews":     s.RequireCodeOwnerReviews,
+		"isAdminEnforced":              s.EnforceAdmins,
+		"requiresLinearHistory":        s.RequireLinearHistory,
+	}
+}
+
+func parseProtectionSpec(content string) (spec protectionSpec, err error) {
+	if strings.HasPrefix(strings.TrimSpace(content), "{") {
+		err = json.Unmarshal([]byte(content), &spec)
+	} else {
+		err = yaml.UnmarshalStrict([]byte(content), &spec)
+	}
+	if err != nil {
+		err = fmt.Errorf("error parsing protection settings: %s", err)
+	}
+	return
+}
+
+func fetchBranchProtectionRule(gh *github.Client, project *github.Project, pattern string) (repoID string, rule *branchProtectionRule, err error) {
+	repoID, rules, err := fetchBranchProtectionRules(gh, project)
+	if err != nil {
+		return
+	}
+
+	for _, r := range rules {
+		if r.Pattern == pattern {
+			rule = &r
+			break
+		}
+	}
+	return
+}
+
+func fetchBranchProtectionRules(gh *github.Client, project *github.Project) (repoID string, rules []branchProtectionRule, err error) {
+	response := struct {
+		Repository struct {
+			ID                    string
+			BranchProtectionRules struct {
+				Nodes []branchProtectionRule
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!, $repo: String!) {
+		repository(owner: $owner, name: $repo) {
+			id
+			branchProtectionRules(first: 100) {
+				nodes {
+					id
+					pattern
+					requiresStatusChecks
+					requiredStatusCheckContexts
+					requiresStrictStatusChecks
+					requiresApprovingReviews
+					requiredApprovingReviewCount
+					dismissesStaleReviews
+					requiresCodeOwnerReviews
+					isAdminEnforced
+					requiresLinearHistory
+				}
+			}
+		}
+	}`, map[string]interface{}{
+		"owner": project.Owner,
+		"repo":  project.Name,
+	}, &response)
+	if err != nil {
+		return
+	}
+
+	repoID = response.Repository.ID
+	rules = response.Repository.BranchProtectionRules.Nodes
+	return
+}
+
+func showProtection(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	pattern := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would show protection rule for `%s' in %s\n", pattern, project)
+		return
+	}
+
+	_, rule, err := fetchBranchProtectionRule(gh, project, pattern)
+	utils.Check(err)
+	if rule == nil {
+		utils.Check(fmt.Errorf("error: no protection rule found for '%s'", pattern))
+	}
+
+	out, err := yaml.Marshal(rule.spec())
+	utils.Check(err)
+	ui.Print(string(out))
+}
+
+func setProtection(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	pattern := args.GetParam(0)
+
+	spec := protectionSpec{}
+	if args.Flag.HasReceived("--file") {
+		content, err := msgFromFile(args.Flag.Value("--file"))
+		utils.Check(err)
+		spec, err = parseProtectionSpec(content)
+		utils.Check(err)
+	}
+	if args.Flag.HasReceived("--required-check") {
+		spec.RequiredStatusChecks = args.Flag.AllValues("--required-check")
+	}
+	if args.Flag.HasReceived("--required-reviews") {
+		spec.RequiredApprovingReviews = args.Flag.Int("--required-reviews")
+	}
+	if args.Flag.HasReceived("--enforce-admins") {
+		spec.EnforceAdmins = args.Flag.Bool("--enforce-admins")
+	}
+	if args.Flag.HasReceived("--linear-history") {
+		spec.RequireLinearHistory = args.Flag.Bool("--linear-history")
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set protection rule for `%s' in %s\n", pattern, project)
+		return
+	}
+
+	repoID, rule, err := fetchBranchProtectionRule(gh, project, pattern)
+	utils.Check(err)
+
+	err = saveBranchProtectionRule(gh, repoID, rule, pattern, spec)
+	utils.Check(err)
+}
+
+// saveBranchProtectionRule creates a protection rule for pattern, or replaces
+// the settings of rule if it exists.
+func saveBranchProtectionRule(gh *github.Client, repoID string, rule *branchProtectionRule, pattern string, spec protectionSpec) error {
+	input := spec.mutationInput()
+	if rule == nil {
+		input["repositoryId"] = repoID
+		input["pattern"] = pattern
+		return gh.GraphQL(`
+		mutation($input: CreateBranchProtectionRuleInput!) {
+			createBranchProtectionRule(input: $input) {
+				branchProtectionRule {
+					id
+				}
+			}
+		}`, map[string]interface{}{
+			"input": input,
+		}, &struct{}{})
+	}
+
+	input["branchProtectionRuleId"] = rule.ID
+	return gh.GraphQL(`
+	mutation($input: UpdateBranchProtectionRuleInput!) {
+		updateBranchProtectionRule(input: $input) {
+			branchProtectionRule {
+				id
+			}
+		}
+	}`, map[string]interface{}{
+		"input": input,
+	}, &struct{}{})
+}
+
+func deleteProtection(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	pattern := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete protection rule for `%s' in %s\n", pattern, project)
+		return
+	}
+
+	_, rule, err := fetchBranchProtectionRule(gh, project, pattern)
+	utils.Check(err)
+	if rule == nil {
+		utils.Check(fmt.Errorf("error: no protection rule found for '%s'", pattern))
+	}
+
+	err = gh.GraphQL(`
+	mutation($id: ID!) {
+		deleteBranchProtectionRule(input: {branchProtectionRuleId: $id}) {
+			clientMutationId
+		}
+	}`, map[string]interface{}{
+		"id": rule.ID,
+	}, &struct{}{})
+	utils.Check(err)
+}
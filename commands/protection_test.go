@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestParseProtectionSpec(t *testing.T) {
+	want := protectionSpec{
+		RequiredStatusChecks:     []string{"ci/build", "lint"},
+		RequiredApprovingReviews: 2,
+		EnforceAdmins:            true,
+	}
+
+	spec, err := parseProtectionSpec(`
+required_status_checks:
+- ci/build
+- lint
+required_approving_reviews: 2
+enforce_admins: true
+`)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, want, spec)
+
+	spec, err = parseProtectionSpec(`{
+	"required_status_checks": ["ci/build", "lint"],
+	"required_approving_reviews": 2,
+	"enforce_admins": true
+}`)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, want, spec)
+
+	_, err = parseProtectionSpec("enforce_admin: true\n")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBranchProtectionRuleSpec(t *testing.T) {
+	rule := branchProtectionRule{
+		RequiresStatusChecks:         false,
+		RequiredStatusCheckContexts:  []string{"stale"},
+		RequiresApprovingReviews:     true,
+		RequiredApprovingReviewCount: 1,
+		RequiresLinearHistory:        true,
+	}
+
+	out, err := yaml.Marshal(rule.spec())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `required_status_checks: []
+strict_status_checks: false
+required_approving_reviews: 1
+dismiss_stale_reviews: false
+require_code_owner_reviews: false
+enforce_admins: false
+require_linear_history: true
+`, string(out))
+
+	spec, err := parseProtectionSpec(string(out))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rule.spec(), spec)
+}
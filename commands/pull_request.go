@@ -16,10 +16,11 @@ import (
 var cmdPullRequest = &Command{
 	Run: pullRequest,
 	Usage: `
-pull-request [-focpd] [-b <BASE>] [-h <HEAD>] [-r <REVIEWERS> ] [-a <ASSIGNEES>] [-M <MILESTONE>] [-l <LABELS>]
-pull-request -m <MESSAGE> [--edit]
-pull-request -F <FILE> [--edit]
+pull-request [-focpd] [-b <BASE>] [-h <HEAD>] [-r <REVIEWERS> ] [-a <ASSIGNEES>] [-M <MILESTONE>] [-l <LABELS>] [--project <PROJECT>]
+pull-request -m <MESSAGE> [--edit] [--emoji]
+pull-request -F <FILE> [--edit] [--emoji]
 pull-request -i <ISSUE>
+pull-request --recover
 `,
 	Long: `Create a GitHub Pull Request.
 
@@ -36,7 +37,8 @@ pull-request -i <ISSUE>
 		blank line in-between.
 
 		When neither ''--message'' nor ''--file'' were supplied, a text editor will open
-		to author the title and description in.
+		to author the title and description in. See "Editor templates" in hub(1)
+		for customizing its initial contents.
 
 	--no-edit
 		Use the message from the first commit on the branch as pull request title
@@ -50,6 +52,15 @@ pull-request -i <ISSUE>
 		Open the pull request title and description in a text editor before
 		submitting. This can be used in combination with ''--message'' or ''--file''.
 
+	--emoji
+		Replace emoji shortcodes such as ":tada:" in the title and description
+		with their Unicode characters before submitting.
+
+	--recover
+		Reuse the title and description from a previous attempt to create a pull
+		request that failed after the text editor was used, without opening the
+		editor again.
+
 	-i, --issue <ISSUE>
 		Convert <ISSUE> (referenced by its number) to a pull request.
 
@@ -80,11 +91,13 @@ pull-request -i <ISSUE>
 
 	-r, --reviewer <USERS>
 		A comma-separated list (no spaces around the comma) of GitHub handles to
-		request a review from.
+		request a review from. "@me" stands for the authenticated user, and
+		"<ORG>/<TEAM>" or "@<ORG>/<TEAM>" requests a review from a team.
 
 	-a, --assign <USERS>
 		A comma-separated list (no spaces around the comma) of GitHub handles to
-		assign to this pull request.
+		assign to this pull request. "@me" stands for the authenticated user, and
+		"@<ORG>/<TEAM>" assigns every member of a team.
 
 	-M, --milestone <NAME>
 		The milestone name to add to this pull request. Passing the milestone number
@@ -94,6 +107,11 @@ pull-request -i <ISSUE>
 		A comma-separated list (no spaces around the comma) of labels to add to
 		this pull request. Labels will be created if they do not already exist.
 
+	--project <PROJECT>
+		Add this pull request to the GitHub project with the number <PROJECT>,
+		owned by the owner of the base repository. Pass multiple numbers separated
+		by comma to add the pull request to several projects.
+
 	-d, --draft
 		Create the pull request as a draft.
 
@@ -221,6 +239,11 @@ func pullRequest(cmd *Command, args *Args) {
 		}
 	}
 
+	milestoneNumber := prefetchMilestoneNumber(args, client, baseProject)
+	assignees := prefetchUsers(args, client, "--assign", (*userResolver).logins)
+	reviewers := prefetchUsers(args, client, "--reviewer", (*userResolver).reviewers)
+	emoji := prefetchEmojiExpander(client, args.Flag.Bool("--emoji") && !args.Noop)
+
 	messageBuilder := &github.MessageBuilder{
 		Filename: "PULLREQ_EDITMSG",
 		Title:    "pull request",
@@ -256,7 +279,9 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		flagPullRequestIssue = parsePullRequestIssueNumber(args.GetParam(0))
 	}
 
-	if len(flagPullRequestMessage) > 0 {
+	if args.Flag.Bool("--recover") {
+		messageBuilder.Recover = true
+	} else if len(flagPullRequestMessage) > 0 {
 		messageBuilder.Message = strings.Join(flagPullRequestMessage, "\n\n")
 		messageBuilder.Edit = flagPullRequestEdit
 	} else if args.Flag.HasReceived("--file") {
@@ -298,13 +323,34 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		}
 
 		workdir, _ := git.WorkdirName()
-		if workdir != "" {
-			template, _ := github.ReadTemplate(github.PullRequestTemplate, workdir)
-			if template != "" {
+		subjects, _ := git.CommitSubjects(baseTracking, headForMessage)
+		if template, found := editorTemplate(github.HubPullRequestTemplate, map[string]string{
+			"repo":    baseProject.String(),
+			"base":    base,
+			"branch":  head,
+			"commits": formatCommitSubjects(subjects),
+		}); found {
+			message = template
+		} else if workdir != "" {
+			if choice, ok := chooseTemplate(github.PullRequestTemplate, workdir, "No template"); ok {
+				message = message + "\n\n\n" + choice.Body
+			} else if template, _ := github.ReadTemplate(github.PullRequestTemplate, workdir); template != "" {
 				message = message + "\n\n\n" + template
 			}
 		}
 
+		if workdir != "" {
+			if !args.Flag.HasReceived("--reviewer") && len(commits) > 0 {
+				codeowners, _ := github.ReadCodeowners(workdir)
+				files, _ := git.ChangedFiles(baseTracking, headForMessage)
+				if codeowners != nil {
+					if reviewers := codeownersReviewers(codeowners, files, host.User); len(reviewers) > 0 {
+						messageBuilder.AddCommentedSection(fmt.Sprintf("\nSuggested reviewers from %s:\n\n  --reviewer %s", codeowners.Path, strings.Join(reviewers, ",")))
+					}
+				}
+			}
+		}
+
 		messageBuilder.Message = message
 	}
 
@@ -315,6 +361,16 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		utils.Check(fmt.Errorf("Aborting due to empty pull request title"))
 	}
 
+	if !args.Noop {
+		title, body, err = expandReferences(client, baseProject, title, body)
+		utils.Check(messageBuilder.RecoverableError(err))
+
+		if args.Flag.Bool("--emoji") {
+			expand := emoji()
+			title, body = expand(title), expand(body)
+		}
+	}
+
 	if flagPullRequestPush {
 		if args.Noop {
 			args.Before(fmt.Sprintf("Would push to %s/%s", remote.Name, head), "")
@@ -324,7 +380,7 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		}
 	}
 
-	milestoneNumber, err := milestoneValueToNumber(args.Flag.Value("--milestone"), client, baseProject)
+	milestone, err := milestoneNumber()
 	utils.Check(err)
 
 	var pullRequestURL string
@@ -390,7 +446,7 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 			defer messageBuilder.Cleanup()
 		}
 
-		utils.Check(err)
+		utils.Check(messageBuilder.RecoverableError(err))
 
 		pullRequestURL = pr.HTMLURL
 
@@ -399,12 +455,13 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 		if len(flagPullRequestLabels) > 0 {
 			params["labels"] = flagPullRequestLabels
 		}
-		flagPullRequestAssignees := commaSeparated(args.Flag.AllValues("--assign"))
+		flagPullRequestAssignees, err := assignees()
+		utils.Check(err)
 		if len(flagPullRequestAssignees) > 0 {
 			params["assignees"] = flagPullRequestAssignees
 		}
-		if milestoneNumber > 0 {
-			params["milestone"] = milestoneNumber
+		if milestone > 0 {
+			params["milestone"] = milestone
 		}
 
 		if len(params) > 0 {
@@ -412,7 +469,8 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 			utils.Check(err)
 		}
 
-		flagPullRequestReviewers := commaSeparated(args.Flag.AllValues("--reviewer"))
+		flagPullRequestReviewers, err := reviewers()
+		utils.Check(err)
 		if len(flagPullRequestReviewers) > 0 {
 			userReviewers := []string{}
 			teamReviewers := []string{}
@@ -434,6 +492,11 @@ of text is the title and the rest is the description.`, fullBase, fullHead))
 				utils.Check(err)
 			}
 		}
+
+		if flagPullRequestProjects := commaSeparated(args.Flag.AllValues("--project")); len(flagPullRequestProjects) > 0 {
+			_, err = addToProjectsV2(client, baseProject.Owner, flagPullRequestProjects, pr.NodeID)
+			utils.Check(err)
+		}
 	}
 
 	args.NoForward()
@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdQueue = &Command{
+		Run: printHelp,
+		Usage: `
+queue status [-b <BRANCH>] [--color]
+`,
+		Long: `Show the merge queue of the current repository.
+
+## Commands:
+
+	* _status_:
+		List the pull requests in the merge queue in the order they will be
+		merged, along with the state of each entry and the estimated time until
+		it gets merged.
+
+		Use ''hub pr merge --queue'' to add a pull request to the queue.
+
+## Options:
+	-b, --branch <BRANCH>
+		Show the merge queue for <BRANCH> instead of the default branch.
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+## Examples:
+		$ hub queue status
+		  1  #1234  Fix the frobnicator  mergeable  ~5m
+		  2  #1237  Update dependencies  awaiting checks
+
+## See also:
+
+hub-pr(1), hub(1)
+`,
+	}
+
+	cmdStatusQueue = &Command{
+		Key: "status",
+		Run: statusQueue,
+		KnownFlags: `
+		-b, --branch BRANCH
+		--color
+`,
+	}
+)
+
+func init() {
+	cmdQueue.Use(cmdStatusQueue)
+	CmdRunner.Use(cmdQueue)
+}
+
+// mergeQueueEntry is an entry of a merge queue as returned by the query in
+// statusQueue.
+type mergeQueueEntry struct {
+	Position             int
+	State                string
+	EstimatedTimeToMerge int
+	PullRequest          struct {
+		Number int
+		Title  string
+	}
+}
+
+func statusQueue(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request the merge queue for %s\n", project)
+		return
+	}
+
+	variables := map[string]interface{}{
+		"owner": project.Owner,
+		"repo":  project.Name,
+	}
+	if args.Flag.HasReceived("--branch") {
+		variables["branch"] = args.Flag.Value("--branch")
+	}
+
+	gh := github.NewClient(project.Host)
+	response := struct {
+		Repository struct {
+			MergeQueue *struct {
+				Entries struct {
+					Nodes []mergeQueueEntry
+				}
+			}
+		}
+	}{}
+	err = gh.GraphQL(`
+	query($owner: String!, $repo: String!, $branch: String) {
+		repository(owner: $owner, name: $repo) {
+			mergeQueue(branch: $branch) {
+				entries(first: 100) {
+					nodes {
+						position
+						state
+						estimatedTimeToMerge
+						pullRequest {
+							number
+							title
+						}
+					}
+				}
+			}
+		}
+	}`, variables, &response)
+	utils.Check(err)
+
+	queue := response.Repository.MergeQueue
+	if queue == nil {
+		utils.Check(fmt.Errorf("Error: merge queue is not enabled for this branch"))
+	}
+	if len(queue.Entries.Nodes) == 0 {
+		ui.Println("The merge queue is empty")
+		return
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	ui.Print(formatMergeQueue(queue.Entries.Nodes, colorize))
+}
+
+// formatMergeQueue renders merge queue entries as a table.
+func formatMergeQueue(entries []mergeQueueEntry, colorize bool) string {
+	titleWidth := 0
+	for _, entry := range entries {
+		if len(entry.PullRequest.Title) > titleWidth {
+			titleWidth = len(entry.PullRequest.Title)
+		}
+	}
+
+	out := ""
+	for _, entry := range entries {
+		state, color := mergeQueueState(entry.State)
+		if colorize {
+			state = fmt.Sprintf("\033[%dm%s\033[0m", color, state)
+		}
+		line := fmt.Sprintf("%3d  #%-5d %-*s  %s", entry.Position, entry.PullRequest.Number, titleWidth, entry.PullRequest.Title, state)
+		if entry.EstimatedTimeToMerge > 0 {
+			line += "  ~" + formatQueueETA(entry.EstimatedTimeToMerge)
+		}
+		out += line + "\n"
+	}
+	return out
+}
+
+// mergeQueueState describes the state of a merge queue entry and picks a color
+// for it.
+func mergeQueueState(state string) (string, int) {
+	switch state {
+	case "MERGEABLE":
+		return "mergeable", 32
+	case "AWAITING_CHECKS":
+		return "awaiting checks", 33
+	case "QUEUED":
+		return "queued", 33
+	case "LOCKED":
+		return "locked", 90
+	case "UNMERGEABLE":
+		return "unmergeable", 31
+	default:
+		return state, 0
+	}
+}
+
+// formatQueueETA renders a number of seconds in whole minutes, e.g. "1h5m".
+func formatQueueETA(seconds int) string {
+	minutes := (seconds + 59) / 60
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+}
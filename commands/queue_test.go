@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatMergeQueue(t *testing.T) {
+	entries := []mergeQueueEntry{
+		{Position: 1, State: "MERGEABLE", EstimatedTimeToMerge: 290},
+		{Position: 2, State: "AWAITING_CHECKS"},
+	}
+	entries[0].PullRequest.Number = 1234
+	entries[0].PullRequest.Title = "Fix the frobnicator"
+	entries[1].PullRequest.Number = 1237
+	entries[1].PullRequest.Title = "Update deps"
+
+	assert.Equal(t, `  1  #1234  Fix the frobnicator  mergeable  ~5m
+  2  #1237  Update deps          awaiting checks
+`, formatMergeQueue(entries, false))
+
+	assert.Equal(t, "  1  #1234  Fix the frobnicator  \033[32mmergeable\033[0m  ~5m\n", formatMergeQueue(entries[:1], true))
+}
+
+func TestFormatQueueETA(t *testing.T) {
+	assert.Equal(t, "1m", formatQueueETA(1))
+	assert.Equal(t, "59m", formatQueueETA(3540))
+	assert.Equal(t, "1h5m", formatQueueETA(3900))
+}
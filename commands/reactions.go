@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+)
+
+// fetchMissingReactions fills in the reaction counts of issues that the REST
+// API returned without them, such as pull requests, with one GraphQL query
+// per 100 issues.
+func fetchMissingReactions(gh *github.Client, project *github.Project, issues []*github.Issue) error {
+	missing := []*github.Issue{}
+	for _, issue := range issues {
+		if issue.Reactions == nil {
+			missing = append(missing, issue)
+		}
+	}
+
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > 100 {
+			batch = batch[:100]
+		}
+		missing = missing[len(batch):]
+
+		fields := []string{}
+		for _, issue := range batch {
+			fields = append(fields, fmt.Sprintf(`
+				issue%d: issueOrPullRequest(number: %d) {
+					...reactionCounts
+				}`, issue.Number, issue.Number))
+		}
+
+		response := struct {
+			Repository map[string]*struct {
+				ThumbsUp   struct{ TotalCount int }
+				ThumbsDown struct{ TotalCount int }
+			}
+		}{}
+		err := gh.GraphQL(`
+		query($owner: String!, $repo: String!) {
+			repository(owner: $owner, name: $repo) {`+strings.Join(fields, "")+`
+			}
+		}
+		fragment reactionCounts on Reactable {
+			thumbsUp: reactions(content: THUMBS_UP) {
+				totalCount
+			}
+			thumbsDown: reactions(content: THUMBS_DOWN) {
+				totalCount
+			}
+		}`, map[string]interface{}{
+			"owner": project.Owner,
+			"repo":  project.Name,
+		}, &response)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range batch {
+			issue.Reactions = &github.Reactions{}
+			if counts := response.Repository[fmt.Sprintf("issue%d", issue.Number)]; counts != nil {
+				issue.Reactions.PlusOne = counts.ThumbsUp.TotalCount
+				issue.Reactions.MinusOne = counts.ThumbsDown.TotalCount
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortByReactions orders issues by their number of 👍 reactions, most first,
+// breaking ties by the fewest 👎 reactions.
+func sortByReactions(issues []*github.Issue, ascending bool) {
+	score := func(issue *github.Issue) (int, int) {
+		if issue.Reactions == nil {
+			return 0, 0
+		}
+		return issue.Reactions.PlusOne, issue.Reactions.MinusOne
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		upI, downI := score(issues[i])
+		upJ, downJ := score(issues[j])
+		if ascending {
+			upI, downI, upJ, downJ = upJ, downJ, upI, downI
+		}
+		return upI > upJ || (upI == upJ && downI < downJ)
+	})
+}
+
+// reactionsListFormat extends the default format of a listing with reaction
+// counts.
+func reactionsListFormat(format string) string {
+	return format + "  👍 %Ru 👎 %Rd%n"
+}
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestSortByReactions(t *testing.T) {
+	issue := func(number, up, down int) *github.Issue {
+		return &github.Issue{Number: number, Reactions: &github.Reactions{PlusOne: up, MinusOne: down}}
+	}
+	numbers := func(issues []*github.Issue) []int {
+		result := []int{}
+		for _, issue := range issues {
+			result = append(result, issue.Number)
+		}
+		return result
+	}
+
+	issues := []*github.Issue{issue(1, 2, 0), issue(2, 5, 3), {Number: 3}, issue(4, 5, 1), issue(5, 2, 0)}
+	sortByReactions(issues, false)
+	assert.Equal(t, []int{4, 2, 1, 5, 3}, numbers(issues))
+
+	sortByReactions(issues, true)
+	assert.Equal(t, []int{3, 1, 5, 2, 4}, numbers(issues))
+}
@@ -3,11 +3,16 @@ package commands
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/github/hub/v2/cmd"
+	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/github"
 	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
@@ -17,12 +22,15 @@ var (
 	cmdRelease = &Command{
 		Run: listReleases,
 		Usage: `
-release [--include-drafts] [--exclude-prereleases] [-L <LIMIT>] [-f <FORMAT>]
-release show [-f <FORMAT>] <TAG>
-release create [-dpoc] [-a <FILE>] [-m <MESSAGE>|-F <FILE>] [-t <TARGET>] <TAG>
+release [--include-drafts] [--exclude-prereleases] [-L <LIMIT>] [--since <DATE>] [--until <DATE>] [-f <FORMAT>]
+release show [-f <FORMAT>] [--verify-tag] <TAG>
+release create [-dpocs] [-a <FILE>] [--auto-label[=<FORMAT>]] [-m <MESSAGE>|-F <FILE>|--notes-from-changelog|--recover|--no-edit] [-t <TARGET>] <TAG>
 release edit [<options>] <TAG>
-release download <TAG> [-i <PATTERN>]
-release delete <TAG>
+release download <TAG> [-p <GLOB>] [-d <DIR>] [--archive <FORMAT>]
+release delete [--with-tag] <TAG>
+release delete-asset <TAG> <NAME>
+release diff [--markdown] <TAG1> <TAG2>
+release watch [--exec <COMMAND>] [--once] [--interval <SECONDS>] [--prereleases] <OWNER>/<REPO>
 `,
 		Long: `Manage GitHub Releases for the current repository.
 
@@ -35,10 +43,16 @@ With no arguments, shows a list of existing releases.
 
 		With ''--show-downloads'', include the "Downloads" section.
 
+		With ''--verify-tag'', also report whether GitHub could verify the
+		signature of the git tag <TAG>.
+
 	* _create_:
 		Create a GitHub release for the specified <TAG> name. If git tag <TAG>
 		does not exist, it will be created at <TARGET> (default: current branch).
 
+		With ''--sign'', a GPG-signed annotated tag <TAG> is first created
+		locally at <TARGET> and pushed before the release is created for it.
+
 	* _edit_:
 		Edit the GitHub release for the specified <TAG> name. Accepts the same
 		options as _create_ command. Publish a draft with ''--draft=false''.
@@ -48,11 +62,33 @@ With no arguments, shows a list of existing releases.
 		unchanged, pass ''-m ""''.
 
 	* _download_:
-		Download the assets attached to release for the specified <TAG>.
+		Download the assets attached to release for the specified <TAG>. With
+		''--archive'', download the source code archive of <TAG> instead, or in
+		addition to the assets matched by ''--pattern''.
+
+		A progress indicator is shown while downloading if standard error is a
+		terminal.
 
 	* _delete_:
 		Delete the release and associated assets for the specified <TAG>. Note that
-		this does **not** remove the git tag <TAG>.
+		this does **not** remove the git tag <TAG> unless ''--with-tag'' is given.
+
+	* _delete-asset_:
+		Delete the asset <NAME> from the release for the specified <TAG>, such as
+		after a botched upload.
+
+	* _diff_:
+		Summarize what changed between releases <TAG1> and <TAG2>: the number of
+		commits, the pull requests that were merged, commits pushed without a
+		pull request, and everyone who contributed. Tags are compared on GitHub,
+		so they don't need to exist locally.
+
+	* _watch_:
+		Poll the releases of <OWNER>/<REPO> and report every release published
+		from then on, e.g. to update a dependency as soon as it has a new version.
+		Polling uses conditional requests, so an unchanged list of releases
+		doesn't count against the API rate limit. Prereleases and drafts are
+		skipped.
 
 ## Options:
 	-d, --include-drafts
@@ -64,6 +100,16 @@ With no arguments, shows a list of existing releases.
 	-L, --limit
 		Display only the first <LIMIT> releases.
 
+	--since <DATE>
+		Display only releases published on or after <DATE>, which is a date such
+		as "2021-03-15", a timestamp in ISO 8601 format, or a duration such as
+		"3d" or "2w" counted back from now. Drafts are matched by the date they
+		were created.
+
+	--until <DATE>
+		Display only releases published on or before <DATE>, in the same formats
+		as ''--since''. A date includes the whole day.
+
 	-d, --draft
 		Create a draft release.
 
@@ -76,6 +122,31 @@ With no arguments, shows a list of existing releases.
 		If <FILE> is in the "<filename>#<text>" format, the text after the "#"
 		character is taken as asset label.
 
+		If <FILE> is a Git LFS pointer, the file it points to is attached instead.
+		Files larger than 2 GiB can't be attached to releases, so they are skipped
+		with a warning.
+
+		Up to 4 files are uploaded at a time, with a progress bar for each if
+		standard error is a terminal. An upload that fails with a server or
+		network error is retried from the start of the file up to 3 times. A
+		file that can't be attached doesn't stop the others from uploading.
+
+	--auto-label[=<FORMAT>]
+		Label attached files that don't have an explicit label after the
+		operating system and architecture in their name, such as
+		"tool_v1.2.3_linux_amd64.tar.gz", using <FORMAT> (default: "%o %a (%e)").
+		The available placeholders are:
+
+		%o: operating system, e.g. "Linux" or "macOS"
+
+		%a: architecture, e.g. "x86-64" or "ARM64"
+
+		%e: file type, e.g. "tar.gz"
+
+		%f: file name
+
+		Files whose name doesn't mention an operating system are left unlabeled.
+
 	-m, --message <MESSAGE>
 		The text up to the first blank line in <MESSAGE> is treated as the release
 		title, and the rest is used as release description in Markdown format.
@@ -84,16 +155,34 @@ With no arguments, shows a list of existing releases.
 		blank line in-between.
 
 		When neither ''--message'' nor ''--file'' were supplied to ''release create'', a
-		text editor will open to author the title and description in.
+		text editor will open to author the title and description in. See "Editor
+		templates" in hub(1) for customizing its initial contents. The commits
+		made since the previous tag are listed below the message for reference.
+
+	--no-edit
+		Create the release without opening a text editor, using the tag name as
+		the title and a list of commits made since the previous tag as the
+		description, or the message generated from a release template.
 
 	-F, --file <FILE>
 		Read the release title and description from <FILE>. Pass "-" to read from
 		standard input instead. See ''--message'' for the formatting rules.
 
+	--notes-from-changelog
+		Use the section of ''CHANGELOG.md'' in the root of the repository whose
+		heading matches <TAG> as the release description, with <TAG> as the
+		title, without opening a text editor. Both Keep a Changelog headings such
+		as "## [1.2.0] - 2020-01-31" and plain ones such as "## v1.2.0" are
+		recognized, with or without the "v" prefix of <TAG>.
+
 	-e, --edit
 		Open the release title and description in a text editor before submitting.
 		This can be used in combination with ''--message'' or ''--file''.
 
+	--recover
+		Reuse the title and description from a previous ''release create'' that
+		failed after the text editor was used, without opening the editor again.
+
 	-o, --browse
 		Open the new release in a web browser.
 
@@ -104,8 +193,29 @@ With no arguments, shows a list of existing releases.
 		A commit SHA or branch name to attach the release to, only used if <TAG>
 		does not already exist (default: main branch).
 
-	-i, --include <PATTERN>
-		Filter the files in the release to those that match the glob <PATTERN>.
+	-s, --sign
+		Create <TAG> as an annotated tag signed with git-tag(1) ''--sign'', using
+		the release title as the tag message, and push it to the remote for the
+		current repository before creating the release. An existing <TAG> is
+		reused only if its signature is valid. Signs <TARGET> (default: the
+		current ''HEAD'').
+
+	--verify-tag
+		Show whether the signature of the tag for the release was verified by
+		GitHub, along with the reason reported by the API, such as "unsigned"
+		or "unknown_key".
+
+	-p, --pattern <GLOB>
+		Filter the files in the release to those that match <GLOB>. Can be
+		specified multiple times. ''-i, --include'' is accepted as an alias.
+
+	-d, --dir <DIR>
+		Save downloaded files to <DIR> instead of the current directory. The
+		directory is created if it doesn't exist.
+
+	--archive <FORMAT>
+		Download the source code archive of the release, either "tar.gz" or
+		"zip", as "<REPO>-<TAG>.<FORMAT>".
 
 	-f, --format <FORMAT>
 		Pretty print releases using <FORMAT> (default: "%T%n"). See the "PRETTY
@@ -156,6 +266,25 @@ With no arguments, shows a list of existing releases.
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for ''--color''), "never", or "auto" (default).
 
+	--markdown
+		Format the output of _diff_ as Markdown, ready to paste into a release
+		announcement.
+
+	--exec <COMMAND>
+		Instead of printing new releases, run <COMMAND> with sh(1) for each of
+		them. The release is described to <COMMAND> by the ''HUB_RELEASE_TAG'',
+		''HUB_RELEASE_NAME'', and ''HUB_RELEASE_URL'' environment variables.
+
+	--once
+		Stop watching after the first new release. With ''--exec'', exit with the
+		status of <COMMAND>.
+
+	--interval <SECONDS>
+		Poll every <SECONDS> seconds (default: 60).
+
+	--prereleases
+		Also report prereleases.
+
 	<TAG>
 		The git tag name for this release.
 
@@ -167,6 +296,8 @@ hub(1), git-tag(1)
 		-d, --include-drafts
 		-p, --exclude-prereleases
 		-L, --limit N
+		--since DATE
+		--until DATE
 		-f, --format FMT
 		--color
 `,
@@ -179,6 +310,7 @@ hub(1), git-tag(1)
 		-d, --show-downloads
 		-f, --format FMT
 		--color
+		--verify-tag
 `,
 	}
 
@@ -192,9 +324,14 @@ hub(1), git-tag(1)
 		-o, --browse
 		-c, --copy
 		-a, --attach FILE
+		--auto-label[=FORMAT]
 		-m, --message MSG
 		-F, --file FILE
 		-t, --commitish C
+		-s, --sign
+		--recover
+		--no-edit
+		--notes-from-changelog
 `,
 	}
 
@@ -206,6 +343,7 @@ hub(1), git-tag(1)
 		-d, --draft
 		-p, --prerelease
 		-a, --attach FILE
+		--auto-label[=FORMAT]
 		-m, --message MSG
 		-F, --file FILE
 		-t, --commitish C
@@ -217,12 +355,42 @@ hub(1), git-tag(1)
 		Run: downloadRelease,
 		KnownFlags: `
 		-i, --include PATTERN
+		-p, --pattern GLOB
+		-d, --dir DIR
+		--archive FORMAT
 		`,
 	}
 
 	cmdDeleteRelease = &Command{
 		Key: "delete",
 		Run: deleteRelease,
+		KnownFlags: `
+		--with-tag
+`,
+	}
+
+	cmdDeleteAssetRelease = &Command{
+		Key: "delete-asset",
+		Run: deleteAssetRelease,
+	}
+
+	cmdDiffRelease = &Command{
+		Key: "diff",
+		Run: diffRelease,
+		KnownFlags: `
+		--markdown
+`,
+	}
+
+	cmdWatchRelease = &Command{
+		Key: "watch",
+		Run: watchRelease,
+		KnownFlags: `
+		--exec CMD
+		--once
+		--interval N
+		--prereleases
+`,
 	}
 )
 
@@ -232,6 +400,9 @@ func init() {
 	cmdRelease.Use(cmdEditRelease)
 	cmdRelease.Use(cmdDownloadRelease)
 	cmdRelease.Use(cmdDeleteRelease)
+	cmdRelease.Use(cmdDeleteAssetRelease)
+	cmdRelease.Use(cmdDiffRelease)
+	cmdRelease.Use(cmdWatchRelease)
 	CmdRunner.Use(cmdRelease)
 }
 
@@ -247,24 +418,31 @@ func listReleases(cmd *Command, args *Args) {
 	flagReleaseLimit := args.Flag.Int("--limit")
 	flagReleaseIncludeDrafts := args.Flag.Bool("--include-drafts")
 	flagReleaseExcludePrereleases := args.Flag.Bool("--exclude-prereleases")
+	published, err := timeRangeFromArgs(args, time.Now())
+	utils.Check(err)
 
 	if args.Noop {
 		ui.Printf("Would request list of releases for %s\n", project)
 	} else {
-		releases, err := gh.FetchReleases(project, flagReleaseLimit, func(release *github.Release) bool {
-			return (!release.Draft || flagReleaseIncludeDrafts) &&
-				(!release.Prerelease || !flagReleaseExcludePrereleases)
-		})
-		utils.Check(err)
+		flagReleaseFormat := "%T%n"
+		if args.Flag.HasReceived("--format") {
+			flagReleaseFormat = args.Flag.Value("--format")
+		}
 
 		colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
-		for _, release := range releases {
-			flagReleaseFormat := "%T%n"
-			if args.Flag.HasReceived("--format") {
-				flagReleaseFormat = args.Flag.Value("--format")
+		err := gh.EachRelease(project, flagReleaseLimit, func(release *github.Release) bool {
+			publishedAt := release.PublishedAt
+			if publishedAt.IsZero() {
+				publishedAt = release.CreatedAt
 			}
-			ui.Print(formatRelease(release, flagReleaseFormat, colorize))
-		}
+			return (!release.Draft || flagReleaseIncludeDrafts) &&
+				(!release.Prerelease || !flagReleaseExcludePrereleases) &&
+				published.Includes(publishedAt)
+		}, func(release *github.Release) error {
+			ui.Print(formatRelease(*release, flagReleaseFormat, colorize))
+			return nil
+		})
+		utils.Check(err)
 	}
 
 	args.NoForward()
@@ -355,6 +533,9 @@ func showRelease(cmd *Command, args *Args) {
 		colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
 		if flagShowReleaseFormat := args.Flag.Value("--format"); flagShowReleaseFormat != "" {
 			ui.Print(formatRelease(*release, flagShowReleaseFormat, colorize))
+			if args.Flag.Bool("--verify-tag") {
+				showTagVerification(gh, project, release.TagName)
+			}
 			return
 		}
 
@@ -372,6 +553,20 @@ func showRelease(cmd *Command, args *Args) {
 				ui.Println(release.TarballURL)
 			}
 		}
+		if args.Flag.Bool("--verify-tag") {
+			showTagVerification(gh, project, release.TagName)
+		}
+	}
+}
+
+func showTagVerification(gh *github.Client, project *github.Project, tagName string) {
+	verification, err := gh.FetchTagVerification(project, tagName)
+	utils.Check(err)
+
+	if verification.Verified {
+		ui.Printf("\nTag %s: signature verified\n", tagName)
+	} else {
+		ui.Printf("\nTag %s: signature not verified (%s)\n", tagName, verification.Reason)
 	}
 }
 
@@ -384,6 +579,11 @@ func downloadRelease(cmd *Command, args *Args) {
 		utils.Check(cmd.UsageError(""))
 	}
 
+	archive := args.Flag.Value("--archive")
+	if archive != "" && archive != "tar.gz" && archive != "zip" {
+		utils.Check(fmt.Errorf("invalid archive format '%s'; expected \"tar.gz\" or \"zip\"", archive))
+	}
+
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
 
@@ -395,54 +595,172 @@ func downloadRelease(cmd *Command, args *Args) {
 	release, err := gh.FetchRelease(project, tagName)
 	utils.Check(err)
 
-	hasPattern := args.Flag.HasReceived("--include")
+	dir := args.Flag.Value("--dir")
+	if dir != "" {
+		utils.Check(os.MkdirAll(dir, 0755))
+	}
+
+	patternFlag := "--pattern"
+	patterns := args.Flag.AllValues("--pattern")
+	if args.Flag.HasReceived("--include") {
+		patternFlag = "--include"
+		patterns = append(patterns, args.Flag.AllValues("--include")...)
+	}
+
+	if archive != "" {
+		archiveURL := release.TarballURL
+		if archive == "zip" {
+			archiveURL = release.ZipballURL
+		}
+		name := fmt.Sprintf("%s-%s.%s", project.Name, tagName, archive)
+		ui.Printf("Downloading %s ...\n", name)
+		err = downloadReleaseFile(gh, archiveURL, filepath.Join(dir, name), 0)
+		utils.Check(err)
+	}
+
 	found := false
-	for _, asset := range release.Assets {
-		if hasPattern {
-			isMatch, err := filepath.Match(args.Flag.Value("--include"), asset.Name)
+	if archive == "" || len(patterns) > 0 {
+		for _, asset := range release.Assets {
+			isMatch, err := matchesAnyPattern(patterns, asset.Name)
 			utils.Check(err)
 			if !isMatch {
 				continue
 			}
-		}
 
-		found = true
-		ui.Printf("Downloading %s ...\n", asset.Name)
-		err := downloadReleaseAsset(asset, gh)
-		utils.Check(err)
+			found = true
+			ui.Printf("Downloading %s ...\n", asset.Name)
+			err = downloadReleaseFile(gh, asset.APIURL, filepath.Join(dir, asset.Name), asset.Size)
+			utils.Check(err)
+		}
 	}
 
-	if !found && hasPattern {
+	if !found && len(patterns) > 0 {
 		names := []string{}
 		for _, asset := range release.Assets {
 			names = append(names, asset.Name)
 		}
-		utils.Check(fmt.Errorf("the `--include` pattern did not match any available assets:\n%s", strings.Join(names, "\n")))
+		utils.Check(fmt.Errorf("the `%s` pattern did not match any available assets:\n%s", patternFlag, strings.Join(names, "\n")))
 	}
 
 	args.NoForward()
 }
 
-func downloadReleaseAsset(asset github.ReleaseAsset, gh *github.Client) (err error) {
-	assetReader, err := gh.DownloadReleaseAsset(asset.APIURL)
-	if err != nil {
-		return
+// matchesAnyPattern reports whether name matches one of the glob patterns.
+// Every name matches when no patterns are given.
+func matchesAnyPattern(patterns []string, name string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, pattern := range patterns {
+		isMatch, err := filepath.Match(pattern, name)
+		if err != nil || isMatch {
+			return isMatch, err
+		}
+	}
+	return false, nil
+}
+
+// releaseTemplateVars lists the variables available to a release template.
+// The commits are those made since the tag that precedes the release.
+func releaseTemplateVars(localRepo *github.GitHubRepo, project *github.Project, tagName, target string) map[string]string {
+	vars := map[string]string{
+		"repo":         project.String(),
+		"tag":          tagName,
+		"branch":       "",
+		"previous_tag": "",
+		"commits":      "",
+	}
+	if branch, err := localRepo.CurrentBranch(); err == nil {
+		vars["branch"] = branch.ShortName()
+	}
+
+	if previousTag, head := releaseCommitRange(tagName, target); previousTag != "" {
+		vars["previous_tag"] = previousTag
+		subjects, _ := git.CommitSubjects(previousTag, head)
+		vars["commits"] = formatCommitSubjects(subjects)
 	}
-	defer assetReader.Close()
 
-	assetFile, err := os.OpenFile(asset.Name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	return vars
+}
+
+// releaseCommitRange finds the commit that a release will point to and the
+// most recent tag preceding it. The tag is blank if there are no earlier tags.
+func releaseCommitRange(tagName, target string) (previousTag, head string) {
+	head = target
+	if head == "" {
+		head = "HEAD"
+	}
+	searchFrom := head
+	if _, err := git.Ref("refs/tags/" + tagName); err == nil {
+		head = "refs/tags/" + tagName
+		searchFrom = head + "^"
+	}
+
+	previousTag, _ = git.LatestTag(searchFrom)
+	return
+}
+
+func downloadReleaseFile(gh *github.Client, url, filename string, size int64) (err error) {
+	reader, err := gh.DownloadReleaseAsset(url)
 	if err != nil {
 		return
 	}
-	defer assetFile.Close()
+	defer reader.Close()
 
-	_, err = io.Copy(assetFile, assetReader)
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
 		return
 	}
+	defer file.Close()
+
+	var dest io.Writer = file
+	if ui.IsTerminal(os.Stderr) {
+		progress := &downloadProgress{total: size}
+		defer progress.Done()
+		dest = io.MultiWriter(file, progress)
+	}
+
+	_, err = io.Copy(dest, reader)
 	return
 }
 
+// downloadProgress reports on standard error how much of a download has
+// been written so far, as a percentage when the total size is known.
+type downloadProgress struct {
+	total   int64
+	written int64
+}
+
+func (p *downloadProgress) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+	if p.total > 0 {
+		ui.Errorf("\r  %3d%% of %s", p.written*100/p.total, formatByteSize(p.total))
+	} else {
+		ui.Errorf("\r  %s", formatByteSize(p.written))
+	}
+	return len(b), nil
+}
+
+// Done clears the progress line.
+func (p *downloadProgress) Done() {
+	ui.Errorf("\r\033[K")
+}
+
+func formatByteSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	suffixes := []string{"KB", "MB", "GB"}
+	value := float64(size) / unit
+	i := 0
+	for value >= unit && i < len(suffixes)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[i])
+}
+
 func createRelease(cmd *Command, args *Args) {
 	tagName := ""
 	if args.ParamsSize() > 0 {
@@ -456,6 +774,9 @@ func createRelease(cmd *Command, args *Args) {
 	assetsToUpload, close, err := openAssetFiles(args.Flag.AllValues("--attach"))
 	utils.Check(err)
 	defer close()
+	if args.Flag.Bool("--auto-label") {
+		autoLabelAssets(assetsToUpload, args.Flag.Value("--auto-label"))
+	}
 
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
@@ -465,6 +786,17 @@ func createRelease(cmd *Command, args *Args) {
 
 	gh := github.NewClient(project.Host)
 
+	flagReleaseSign := args.Flag.Bool("--sign")
+	signedTagExists := false
+	if flagReleaseSign {
+		if _, err := git.Ref("refs/tags/" + tagName); err == nil {
+			if !git.Quiet("tag", "--verify", tagName) {
+				utils.Check(fmt.Errorf("Aborted: tag %s already exists and has no valid signature", tagName))
+			}
+			signedTagExists = true
+		}
+	}
+
 	messageBuilder := &github.MessageBuilder{
 		Filename: "RELEASE_EDITMSG",
 		Title:    "release",
@@ -476,15 +808,47 @@ Write a message for this release. The first block of
 text is the title and the rest is the description.`, tagName, project))
 
 	flagReleaseMessage := args.Flag.AllValues("--message")
-	if len(flagReleaseMessage) > 0 {
+	if args.Flag.Bool("--recover") {
+		messageBuilder.Recover = true
+	} else if len(flagReleaseMessage) > 0 {
 		messageBuilder.Message = strings.Join(flagReleaseMessage, "\n\n")
 		messageBuilder.Edit = args.Flag.Bool("--edit")
 	} else if args.Flag.HasReceived("--file") {
 		messageBuilder.Message, err = msgFromFile(args.Flag.Value("--file"))
 		utils.Check(err)
 		messageBuilder.Edit = args.Flag.Bool("--edit")
+	} else if args.Flag.Bool("--notes-from-changelog") {
+		workdir, err := git.WorkdirName()
+		utils.Check(err)
+		content, err := ioutil.ReadFile(filepath.Join(workdir, "CHANGELOG.md"))
+		utils.Check(err)
+		notes, found := changelogNotes(string(content), tagName)
+		if !found {
+			utils.Check(fmt.Errorf("Aborted: no section for %s found in CHANGELOG.md", tagName))
+		}
+		messageBuilder.Message = fmt.Sprintf("%s\n\n%s", tagName, notes)
+		messageBuilder.Edit = args.Flag.Bool("--edit")
 	} else {
-		messageBuilder.Edit = true
+		target := args.Flag.Value("--commitish")
+		vars := releaseTemplateVars(localRepo, project, tagName, target)
+		template, found := editorTemplate(github.HubReleaseTemplate, vars)
+		if found {
+			messageBuilder.Message = template
+		}
+
+		if args.Flag.Bool("--no-edit") {
+			if !found {
+				messageBuilder.Message = fmt.Sprintf("%s\n\n%s", tagName, vars["commits"])
+			}
+		} else {
+			messageBuilder.Edit = true
+
+			if previousTag, head := releaseCommitRange(tagName, target); previousTag != "" {
+				if commitLogs, err := git.Log(previousTag, head); err == nil && strings.TrimSpace(commitLogs) != "" {
+					messageBuilder.AddCommentedSection(fmt.Sprintf("\nChanges since %s:\n\n%s", previousTag, strings.TrimSpace(commitLogs)))
+				}
+			}
+		}
 	}
 
 	title, body, err := messageBuilder.Extract()
@@ -494,6 +858,35 @@ text is the title and the rest is the description.`, tagName, project))
 		utils.Check(fmt.Errorf("Aborting release due to empty release title"))
 	}
 
+	if !args.Noop {
+		title, body, err = expandReferences(gh, project, title, body)
+		utils.Check(messageBuilder.RecoverableError(err))
+	}
+
+	if flagReleaseSign {
+		remote, err := localRepo.RemoteForProject(project)
+		utils.Check(err)
+
+		target := args.Flag.Value("--commitish")
+		if target == "" {
+			target = "HEAD"
+		}
+
+		if args.Noop {
+			if !signedTagExists {
+				ui.Printf("Would create signed tag `%s' at %s\n", tagName, target)
+			}
+			ui.Printf("Would push tag `%s' to %s\n", tagName, remote.Name)
+		} else {
+			if !signedTagExists {
+				err = git.Spawn("tag", "--sign", "-m", title, tagName, target)
+				utils.Check(messageBuilder.RecoverableError(err))
+			}
+			err = git.Spawn("push", remote.Name, "refs/tags/"+tagName)
+			utils.Check(messageBuilder.RecoverableError(err))
+		}
+	}
+
 	params := &github.Release{
 		TagName:         tagName,
 		TargetCommitish: args.Flag.Value("--commitish"),
@@ -510,7 +903,7 @@ text is the title and the rest is the description.`, tagName, project))
 		ui.Printf("Would create release `%s' for %s with tag name `%s'\n", title, project, tagName)
 	} else {
 		release, err = gh.CreateRelease(project, params)
-		utils.Check(err)
+		utils.Check(messageBuilder.RecoverableError(err))
 
 		flagReleaseBrowse := args.Flag.Bool("--browse")
 		flagReleaseCopy := args.Flag.Bool("--copy")
@@ -527,10 +920,10 @@ text is the title and the rest is the description.`, tagName, project))
 		ui.Printf("Would attach %d %s\n", numAssets, pluralize(numAssets, "asset"))
 	} else {
 		ui.Errorf("Attaching %d %s...\n", numAssets, pluralize(numAssets, "asset"))
-		uploaded, err := gh.UploadReleaseAssets(release, assetsToUpload)
+		failedAssets, err := uploadReleaseAssets(gh, release, assetsToUpload)
 		if err != nil {
 			failed := []string{}
-			for _, a := range assetsToUpload[len(uploaded):] {
+			for _, a := range failedAssets {
 				failed = append(failed, fmt.Sprintf("-a %s", a.Name))
 			}
 			ui.Errorf("The release was created, but attaching %d %s failed. ", len(failed), pluralize(len(failed), "asset"))
@@ -540,6 +933,48 @@ text is the title and the rest is the description.`, tagName, project))
 	}
 }
 
+var (
+	changelogHeadingRegexp = regexp.MustCompile(`^(#{1,6})\s+\[?([^\]\s(]+)`)
+	changelogLinkRefRegexp = regexp.MustCompile(`^\[[^\]]+\]:\s*\S+`)
+)
+
+// changelogNotes extracts the notes under the heading for tagName from a
+// changelog in Markdown format, up to the next heading of the same or a
+// higher level.
+func changelogNotes(content, tagName string) (string, bool) {
+	version := strings.TrimPrefix(tagName, "v")
+	lines := strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n")
+
+	level := 0
+	section := []string{}
+	for _, line := range lines {
+		match := changelogHeadingRegexp.FindStringSubmatch(line)
+		if level == 0 {
+			if match != nil && strings.TrimPrefix(match[2], "v") == version {
+				level = len(match[1])
+			}
+			continue
+		}
+		if match != nil && len(match[1]) <= level {
+			break
+		}
+		section = append(section, line)
+	}
+	if level == 0 {
+		return "", false
+	}
+
+	// drop the link reference definitions that close a Keep a Changelog file
+	for len(section) > 0 {
+		last := strings.TrimSpace(section[len(section)-1])
+		if last != "" && !changelogLinkRefRegexp.MatchString(last) {
+			break
+		}
+		section = section[:len(section)-1]
+	}
+	return strings.TrimSpace(strings.Join(section, "\n")), true
+}
+
 func editRelease(cmd *Command, args *Args) {
 	tagName := ""
 	if args.ParamsSize() > 0 {
@@ -553,6 +988,9 @@ func editRelease(cmd *Command, args *Args) {
 	assetsToUpload, close, err := openAssetFiles(args.Flag.AllValues("--attach"))
 	utils.Check(err)
 	defer close()
+	if args.Flag.Bool("--auto-label") {
+		autoLabelAssets(assetsToUpload, args.Flag.Value("--auto-label"))
+	}
 
 	localRepo, err := github.LocalRepo()
 	utils.Check(err)
@@ -633,10 +1071,10 @@ text is the title and the rest is the description.`, tagName, project))
 		ui.Printf("Would attach %d %s\n", numAssets, pluralize(numAssets, "asset"))
 	} else {
 		ui.Errorf("Attaching %d %s...\n", numAssets, pluralize(numAssets, "asset"))
-		uploaded, err := gh.UploadReleaseAssets(release, assetsToUpload)
+		failedAssets, err := uploadReleaseAssets(gh, release, assetsToUpload)
 		if err != nil {
 			failed := []string{}
-			for _, a := range assetsToUpload[len(uploaded):] {
+			for _, a := range failedAssets {
 				failed = append(failed, a.Name)
 			}
 			ui.Errorf("Attaching these assets failed:\n%s\n\n", strings.Join(failed, "\n"))
@@ -666,20 +1104,134 @@ func deleteRelease(cmd *Command, args *Args) {
 	release, err := gh.FetchRelease(project, tagName)
 	utils.Check(err)
 
+	withTag := args.Flag.Bool("--with-tag")
 	if args.Noop {
 		message := fmt.Sprintf("Deleting release related to %s...", tagName)
 		ui.Println(message)
+		if withTag {
+			ui.Printf("Would delete tag %s from %s\n", tagName, project)
+		}
 	} else {
 		err = gh.DeleteRelease(release)
 		utils.Check(err)
+		if withTag {
+			utils.Check(gh.DeleteTag(project, tagName))
+		}
 	}
 
 	args.NoForward()
 }
 
+func deleteAssetRelease(cmd *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+	tagName, assetName := args.GetParam(0), args.GetParam(1)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	release, err := gh.FetchRelease(project, tagName)
+	utils.Check(err)
+
+	var asset *github.ReleaseAsset
+	for i := range release.Assets {
+		if release.Assets[i].Name == assetName {
+			asset = &release.Assets[i]
+			break
+		}
+	}
+	if asset == nil {
+		utils.Check(fmt.Errorf("Error: release %s has no asset named '%s'", tagName, assetName))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete asset %s from release %s\n", assetName, tagName)
+		return
+	}
+
+	utils.Check(gh.DeleteReleaseAsset(asset))
+}
+
+func watchRelease(command *Command, args *Args) {
+	words := args.Words()
+	if len(words) != 1 {
+		utils.Check(command.UsageError(""))
+	}
+	parts := strings.Split(words[0], "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		utils.Check(fmt.Errorf("error: expected OWNER/REPO, got '%s'", words[0]))
+	}
+
+	interval := 60
+	if args.Flag.HasReceived("--interval") {
+		var err error
+		interval, err = strconv.Atoi(args.Flag.Value("--interval"))
+		if err != nil || interval < 1 {
+			utils.Check(fmt.Errorf("error: invalid interval '%s'", args.Flag.Value("--interval")))
+		}
+	}
+
+	_, host := projectOrDefaultHost()
+	project := github.NewProject(parts[0], parts[1], host)
+	execCommand := args.Flag.Value("--exec")
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would watch releases of %s\n", project)
+		return
+	}
+
+	once := args.Flag.Bool("--once")
+	prereleases := args.Flag.Bool("--prereleases")
+	var execErr error
+
+	gh := github.NewClient(host)
+	err := gh.WatchReleases(project, time.Duration(interval)*time.Second, func(release *github.Release) bool {
+		if release.Prerelease && !prereleases {
+			return true
+		}
+		if execCommand == "" {
+			ui.Printf("%s  %s\n", release.TagName, release.HTMLURL)
+		} else {
+			os.Setenv("HUB_RELEASE_TAG", release.TagName)
+			os.Setenv("HUB_RELEASE_NAME", release.Name)
+			os.Setenv("HUB_RELEASE_URL", release.HTMLURL)
+			if execErr = cmd.New("sh").WithArgs("-c", execCommand).Spawn(); execErr != nil {
+				ui.Errorf("error running command for %s: %s\n", release.TagName, execErr)
+			}
+		}
+		return !once
+	})
+	utils.Check(err)
+
+	if execErr != nil {
+		os.Exit(1)
+	}
+}
+
+// maxAssetSize is the largest file that can be attached to a release.
+const maxAssetSize = 2 * 1024 * 1024 * 1024
+
 func openAssetFiles(args []string) ([]github.LocalAsset, func(), error) {
 	assets := []github.LocalAsset{}
 	files := []*os.File{}
+	tempDirs := []string{}
+
+	closeAll := func() {
+		for _, f := range files {
+			f.Close()
+		}
+		for _, dir := range tempDirs {
+			os.RemoveAll(dir)
+		}
+	}
 
 	for _, arg := range args {
 		var label string
@@ -691,29 +1243,188 @@ func openAssetFiles(args []string) ([]github.LocalAsset, func(), error) {
 
 		file, err := os.Open(path)
 		if err != nil {
+			closeAll()
 			return nil, nil, err
 		}
+		files = append(files, file)
 		stat, err := file.Stat()
 		if err != nil {
+			closeAll()
 			return nil, nil, err
 		}
-		files = append(files, file)
+
+		size := stat.Size()
+		if lfsSize, ok := lfsPointerSize(file, size); ok {
+			if lfsSize > maxAssetSize {
+				warnAssetTooLarge(path, lfsSize)
+				continue
+			}
+			tempDir, err := ioutil.TempDir("", "hub-lfs")
+			if err != nil {
+				closeAll()
+				return nil, nil, err
+			}
+			tempDirs = append(tempDirs, tempDir)
+
+			path = filepath.Join(tempDir, filepath.Base(path))
+			if file, err = smudgeLFSPointer(file, path); err != nil {
+				closeAll()
+				return nil, nil, err
+			}
+			files = append(files, file)
+			size = lfsSize
+		} else if size > maxAssetSize {
+			warnAssetTooLarge(path, size)
+			continue
+		}
 
 		assets = append(assets, github.LocalAsset{
 			Name:     path,
 			Label:    label,
-			Size:     stat.Size(),
+			Size:     size,
 			Contents: file,
 		})
 	}
 
-	close := func() {
-		for _, f := range files {
-			f.Close()
+	return assets, closeAll, nil
+}
+
+// assetPlatforms and assetArchitectures map the names that build tools use
+// for platforms in file names to human-friendly ones.
+var (
+	assetPlatforms = []struct {
+		pattern *regexp.Regexp
+		name    string
+	}{
+		{regexp.MustCompile(`(?i)(?:^|[_.-])linux(?:$|[_.-])`), "Linux"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])(?:darwin|macos|osx|mac)(?:$|[_.-])`), "macOS"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])(?:windows|win32|win64|win)(?:$|[_.-])`), "Windows"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])freebsd(?:$|[_.-])`), "FreeBSD"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])openbsd(?:$|[_.-])`), "OpenBSD"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])netbsd(?:$|[_.-])`), "NetBSD"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])android(?:$|[_.-])`), "Android"},
+	}
+	assetArchitectures = []struct {
+		pattern *regexp.Regexp
+		name    string
+	}{
+		{regexp.MustCompile(`(?i)(?:^|[_.-])(?:amd64|x86_64|x64)(?:$|[_.-])`), "x86-64"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])(?:arm64|aarch64)(?:$|[_.-])`), "ARM64"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])(?:armv7l?|armv7hf|armhf)(?:$|[_.-])`), "ARMv7"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])(?:armv6l?|arm)(?:$|[_.-])`), "ARM"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])(?:386|i386|i686|x86|win32)(?:$|[_.-])`), "x86"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])ppc64le(?:$|[_.-])`), "PowerPC 64 LE"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])s390x(?:$|[_.-])`), "s390x"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])riscv64(?:$|[_.-])`), "RISC-V 64"},
+		{regexp.MustCompile(`(?i)(?:^|[_.-])universal(?:$|[_.-])`), "Universal"},
+	}
+	assetExtensions = []string{".tar.gz", ".tar.xz", ".tar.bz2", ".tar.zst"}
+)
+
+// autoLabelAssets labels the assets without a label after the platform in
+// their file name.
+func autoLabelAssets(assets []github.LocalAsset, format string) {
+	for i := range assets {
+		if assets[i].Label == "" {
+			assets[i].Label = assetLabel(filepath.Base(assets[i].Name), format)
+		}
+	}
+}
+
+// assetLabel renders format for the platform mentioned in filename, or returns
+// an empty string if filename doesn't mention an operating system.
+func assetLabel(filename, format string) string {
+	if format == "" {
+		format = "%o %a (%e)"
+	}
+
+	values := map[string]string{"f": filename, "a": ""}
+	for _, platform := range assetPlatforms {
+		if platform.pattern.MatchString(filename) {
+			values["o"] = platform.name
+			break
+		}
+	}
+	if values["o"] == "" {
+		return ""
+	}
+	for _, arch := range assetArchitectures {
+		if arch.pattern.MatchString(filename) {
+			values["a"] = arch.name
+			break
+		}
+	}
+
+	values["e"] = "binary"
+	lowerName := strings.ToLower(filename)
+	for _, ext := range assetExtensions {
+		if strings.HasSuffix(lowerName, ext) {
+			values["e"] = ext[1:]
+		}
+	}
+	if values["e"] == "binary" {
+		if ext := filepath.Ext(lowerName); ext != "" && !strings.ContainsAny(ext[1:], "0123456789") {
+			values["e"] = ext[1:]
+		}
+	}
+
+	return strings.Join(strings.Fields(ui.Expand(format, values, false)), " ")
+}
+
+// lfsPointerSize reports whether file is a Git LFS pointer and returns the
+// size of the object it points to. The file is rewound afterwards.
+func lfsPointerSize(file *os.File, size int64) (int64, bool) {
+	if size > 1024 {
+		return 0, false
+	}
+	defer file.Seek(0, io.SeekStart)
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		return 0, false
+	}
+	return parseLFSPointer(string(content))
+}
+
+// parseLFSPointer returns the object size recorded in the contents of a Git
+// LFS pointer file.
+func parseLFSPointer(content string) (int64, bool) {
+	if !strings.HasPrefix(content, "version https://git-lfs.github.com/spec/") {
+		return 0, false
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "size ") {
+			if size, err := strconv.ParseInt(strings.TrimPrefix(line, "size "), 10, 64); err == nil {
+				return size, true
+			}
 		}
 	}
+	return 0, false
+}
+
+// smudgeLFSPointer resolves a Git LFS pointer into the real file at path.
+func smudgeLFSPointer(pointer *os.File, path string) (*os.File, error) {
+	out, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := git.LFSSmudge(pointer.Name(), pointer, out); err != nil {
+		out.Close()
+		return nil, err
+	}
+	if _, err := out.Seek(0, io.SeekStart); err != nil {
+		out.Close()
+		return nil, err
+	}
+	return out, nil
+}
 
-	return assets, close, nil
+// warnAssetTooLarge explains why a file that exceeds the release asset size
+// limit isn't attached.
+func warnAssetTooLarge(path string, size int64) {
+	ui.Errorf("warning: skipping %s: it is %.1f GiB, but release assets can't be larger than 2 GiB\n"+
+		"(split it into smaller parts, e.g. with `split -b 2000m`, or host it elsewhere and link to it from the release notes)\n",
+		path, float64(size)/(1024*1024*1024))
 }
 
 func pluralize(count int, label string) string {
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+// releaseDiff is what changed between two releases.
+type releaseDiff struct {
+	From         string
+	To           string
+	Commits      int
+	Pulls        []changelogPullRequest
+	Direct       []changelogCommit
+	Contributors []string
+}
+
+func diffRelease(cmd *Command, args *Args) {
+	words := args.Words()
+	if len(words) != 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+	from, to := words[0], words[1]
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would compare releases %s and %s of %s\n", from, to, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	commits, err := gh.CompareCommits(project, from, to)
+	utils.Check(err)
+
+	shas := []string{}
+	for _, commit := range commits {
+		shas = append(shas, commit.SHA)
+	}
+	details, err := fetchChangelogCommits(gh, project, shas)
+	utils.Check(err)
+
+	diff := buildReleaseDiff(from, to, commits, details)
+	if args.Flag.Bool("--markdown") {
+		ui.Print(formatReleaseDiffMarkdown(diff))
+	} else {
+		ui.Print(formatReleaseDiff(diff))
+	}
+}
+
+// buildReleaseDiff groups commits by the pull request that merged them and
+// collects everyone who authored a commit or a pull request.
+func buildReleaseDiff(from, to string, commits []github.Commit, details []changelogCommit) releaseDiff {
+	diff := releaseDiff{From: from, To: to, Commits: len(commits)}
+	seenPulls := map[int]bool{}
+	seenAuthors := map[string]bool{}
+	addAuthor := func(name string) {
+		if name != "" && !seenAuthors[strings.ToLower(name)] {
+			seenAuthors[strings.ToLower(name)] = true
+			diff.Contributors = append(diff.Contributors, name)
+		}
+	}
+
+	for i, commit := range commits {
+		if commit.Author != nil && commit.Author.Login != "" {
+			addAuthor("@" + commit.Author.Login)
+		} else {
+			addAuthor(commit.Commit.Author.Name)
+		}
+
+		if i >= len(details) {
+			continue
+		}
+		var pr *changelogPullRequest
+		for j := range details[i].AssociatedPullRequests.Nodes {
+			if details[i].AssociatedPullRequests.Nodes[j].Merged {
+				pr = &details[i].AssociatedPullRequests.Nodes[j]
+				break
+			}
+		}
+		if pr == nil {
+			if details[i].Parents.TotalCount <= 1 {
+				diff.Direct = append(diff.Direct, details[i])
+			}
+		} else if !seenPulls[pr.Number] {
+			seenPulls[pr.Number] = true
+			diff.Pulls = append(diff.Pulls, *pr)
+			if pr.Author.Login != "" {
+				addAuthor("@" + pr.Author.Login)
+			}
+		}
+	}
+
+	sort.Slice(diff.Contributors, func(i, j int) bool {
+		return strings.ToLower(strings.TrimPrefix(diff.Contributors[i], "@")) < strings.ToLower(strings.TrimPrefix(diff.Contributors[j], "@"))
+	})
+	return diff
+}
+
+func formatReleaseDiff(diff releaseDiff) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s...%s: %d %s, %d pull %s, %d %s\n",
+		diff.From, diff.To,
+		diff.Commits, pluralize(diff.Commits, "commit"),
+		len(diff.Pulls), pluralize(len(diff.Pulls), "request"),
+		len(diff.Contributors), pluralize(len(diff.Contributors), "contributor"))
+
+	if len(diff.Pulls) > 0 {
+		out.WriteString("\nPull requests:\n")
+		for _, pr := range diff.Pulls {
+			fmt.Fprintf(&out, "  #%d  %s (%s)\n", pr.Number, pr.Title, pr.Author.Login)
+		}
+	}
+	if len(diff.Direct) > 0 {
+		out.WriteString("\nOther commits:\n")
+		for _, commit := range diff.Direct {
+			fmt.Fprintf(&out, "  %.7s  %s\n", commit.Oid, commit.MessageHeadline)
+		}
+	}
+	if len(diff.Contributors) > 0 {
+		fmt.Fprintf(&out, "\nContributors:\n  %s\n", strings.Join(diff.Contributors, ", "))
+	}
+	return out.String()
+}
+
+func formatReleaseDiffMarkdown(diff releaseDiff) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "## Changes from %s to %s\n", diff.From, diff.To)
+
+	if len(diff.Pulls) > 0 || len(diff.Direct) > 0 {
+		out.WriteString("\n")
+		for _, pr := range diff.Pulls {
+			fmt.Fprintf(&out, "- %s (#%d) by @%s\n", pr.Title, pr.Number, pr.Author.Login)
+		}
+		for _, commit := range diff.Direct {
+			fmt.Fprintf(&out, "- %s (%.7s)\n", commit.MessageHeadline, commit.Oid)
+		}
+	}
+	if len(diff.Contributors) > 0 {
+		fmt.Fprintf(&out, "\n### Contributors\n\nThanks to %s!\n", joinWithAnd(diff.Contributors))
+	}
+	return out.String()
+}
+
+// joinWithAnd lists names in prose, e.g. "a, b, and c".
+func joinWithAnd(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " and " + names[1]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + ", and " + names[len(names)-1]
+	}
+}
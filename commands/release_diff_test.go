@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestReleaseDiff(t *testing.T) {
+	commits := []github.Commit{}
+	err := json.Unmarshal([]byte(`[
+		{"sha": "aaaaaaaaaa", "author": {"login": "mislav"}},
+		{"sha": "bbbbbbbbbb", "author": {"login": "octocat"}},
+		{"sha": "cccccccccc", "commit": {"author": {"name": "Jane Doe"}}},
+		{"sha": "dddddddddd", "author": {"login": "Mislav"}}
+	]`), &commits)
+	assert.Equal(t, nil, err)
+
+	details := []changelogCommit{}
+	err = json.Unmarshal([]byte(`[
+		{"oid": "aaaaaaaaaa", "messageHeadline": "Add feature", "parents": {"totalCount": 1},
+		 "associatedPullRequests": {"nodes": [{"number": 12, "title": "Add feature", "merged": true, "author": {"login": "hubot"}}]}},
+		{"oid": "bbbbbbbbbb", "messageHeadline": "Polish feature", "parents": {"totalCount": 1},
+		 "associatedPullRequests": {"nodes": [{"number": 12, "title": "Add feature", "merged": true, "author": {"login": "hubot"}}]}},
+		{"oid": "cccccccccc", "messageHeadline": "Fix typo", "parents": {"totalCount": 1},
+		 "associatedPullRequests": {"nodes": []}},
+		{"oid": "dddddddddd", "messageHeadline": "Merge branch 'main'", "parents": {"totalCount": 2},
+		 "associatedPullRequests": {"nodes": []}}
+	]`), &details)
+	assert.Equal(t, nil, err)
+
+	diff := buildReleaseDiff("v1.0", "v1.1", commits, details)
+	assert.Equal(t, []string{"@hubot", "Jane Doe", "@mislav", "@octocat"}, diff.Contributors)
+
+	assert.Equal(t, `v1.0...v1.1: 4 commits, 1 pull request, 4 contributors
+
+Pull requests:
+  #12  Add feature (hubot)
+
+Other commits:
+  ccccccc  Fix typo
+
+Contributors:
+  @hubot, Jane Doe, @mislav, @octocat
+`, formatReleaseDiff(diff))
+
+	assert.Equal(t, `## Changes from v1.0 to v1.1
+
+- Add feature (#12) by @hubot
+- Fix typo (ccccccc)
+
+### Contributors
+
+Thanks to @hubot, Jane Doe, @mislav, and @octocat!
+`, formatReleaseDiffMarkdown(diff))
+}
+
+func TestJoinWithAnd(t *testing.T) {
+	assert.Equal(t, "", joinWithAnd(nil))
+	assert.Equal(t, "a", joinWithAnd([]string{"a"}))
+	assert.Equal(t, "a and b", joinWithAnd([]string{"a", "b"}))
+	assert.Equal(t, "a, b, and c", joinWithAnd([]string{"a", "b", "c"}))
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	size, ok := parseLFSPointer("version https://git-lfs.github.com/spec/v1\noid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\nsize 12345\n")
+	assert.T(t, ok)
+	assert.Equal(t, int64(12345), size)
+
+	_, ok = parseLFSPointer("#!/bin/sh\necho hello\n")
+	assert.T(t, !ok)
+}
+
+func TestChangelogNotes(t *testing.T) {
+	keepAChangelog := `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2020-01-31
+### Added
+- Release notes from the changelog
+
+### Fixed
+- Crash on startup
+
+## [1.1.0] - 2019-12-01
+### Added
+- Everything else
+
+[Unreleased]: https://github.com/github/hub/compare/v1.2.0...HEAD
+[1.2.0]: https://github.com/github/hub/compare/v1.1.0...v1.2.0
+`
+	notes, found := changelogNotes(keepAChangelog, "v1.2.0")
+	assert.T(t, found)
+	assert.Equal(t, "### Added\n- Release notes from the changelog\n\n### Fixed\n- Crash on startup", notes)
+
+	notes, found = changelogNotes(keepAChangelog, "1.1.0")
+	assert.T(t, found)
+	assert.Equal(t, "### Added\n- Everything else", notes)
+
+	_, found = changelogNotes(keepAChangelog, "v1.3.0")
+	assert.T(t, !found)
+
+	notes, found = changelogNotes("## v2.0.0\r\nBreaking changes.\r\n\r\n## v1.0.0 (2019-01-01)\r\nFirst release.\r\n", "v1.0.0")
+	assert.T(t, found)
+	assert.Equal(t, "First release.", notes)
+}
+
+func TestAssetLabel(t *testing.T) {
+	tests := []struct {
+		filename string
+		format   string
+		label    string
+	}{
+		{"tool_v1.2.3_linux_amd64.tar.gz", "", "Linux x86-64 (tar.gz)"},
+		{"tool-1.2.3-darwin-arm64.zip", "", "macOS ARM64 (zip)"},
+		{"tool_1.2.3_Windows_x86_64.exe", "", "Windows x86-64 (exe)"},
+		{"tool_v1.2.3_linux_armv7", "", "Linux ARMv7 (binary)"},
+		{"tool_v1.2.3_freebsd.tar.xz", "", "FreeBSD (tar.xz)"},
+		{"tool_v1.2.3_linux_386.deb", "%f for %o/%a", "tool_v1.2.3_linux_386.deb for Linux/x86"},
+		{"tool_v1.2.3_checksums.txt", "", ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.label, assetLabel(test.filename, test.format))
+	}
+}
+
+func TestMatchesAnyPattern(t *testing.T) {
+	isMatch, err := matchesAnyPattern(nil, "hub-linux.tgz")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, isMatch)
+
+	isMatch, _ = matchesAnyPattern([]string{"*.zip", "*linux*"}, "hub-linux.tgz")
+	assert.Equal(t, true, isMatch)
+
+	isMatch, _ = matchesAnyPattern([]string{"*.zip"}, "hub-linux.tgz")
+	assert.Equal(t, false, isMatch)
+
+	_, err = matchesAnyPattern([]string{"[a-"}, "hub-linux.tgz")
+	assert.Equal(t, true, err != nil)
+}
+
+func TestFormatByteSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatByteSize(512))
+	assert.Equal(t, "1.5 KB", formatByteSize(1536))
+	assert.Equal(t, "12.0 MB", formatByteSize(12*1024*1024))
+	assert.Equal(t, "2048.0 GB", formatByteSize(2*1024*1024*1024*1024))
+}
+
+func TestUploadProgressLine(t *testing.T) {
+	assert.Equal(t, "hub.tgz    [#####-----]  50% of 4.0 MB", uploadProgressLine("hub.tgz", 9, 2*1024*1024, 4*1024*1024))
+	assert.Equal(t, "sums.txt  [##########] 100% of 0 B", uploadProgressLine("sums.txt", 8, 0, 0))
+}
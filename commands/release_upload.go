@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+)
+
+// the number of assets that are uploaded at the same time
+const maxConcurrentUploads = 4
+
+// uploadReleaseAssets uploads assets to release in parallel. An asset that
+// fails to upload doesn't stop the others; the assets that failed are
+// returned in their original order along with the first error.
+func uploadReleaseAssets(gh *github.Client, release *github.Release, assets []github.LocalAsset) (failed []github.LocalAsset, err error) {
+	jobs := make(chan int, len(assets))
+	for i := range assets {
+		jobs <- i
+	}
+	close(jobs)
+
+	// workers report progress and results as events, so that only this
+	// goroutine touches the terminal
+	events := make(chan uploadEvent)
+	workers := maxConcurrentUploads
+	if len(assets) < workers {
+		workers = len(assets)
+	}
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				i := i
+				asset := assets[i]
+				asset.Progress = func(sent int64) {
+					events <- uploadEvent{index: i, sent: sent}
+				}
+				_, uploadErr := gh.UploadReleaseAsset(release, asset)
+				events <- uploadEvent{index: i, done: true, err: uploadErr}
+			}
+		}()
+	}
+
+	var progress *uploadProgress
+	if ui.IsTerminal(os.Stderr) {
+		progress = newUploadProgress(assets)
+	}
+
+	errs := make([]error, len(assets))
+	for remaining := len(assets); remaining > 0; {
+		event := <-events
+		if event.done {
+			errs[event.index] = event.err
+			remaining--
+		}
+		if progress != nil {
+			progress.handle(event)
+		}
+	}
+
+	for i, uploadErr := range errs {
+		if uploadErr != nil {
+			failed = append(failed, assets[i])
+			if err == nil {
+				err = uploadErr
+			}
+		}
+	}
+	return
+}
+
+type uploadEvent struct {
+	index int
+	sent  int64
+	done  bool
+	err   error
+}
+
+// uploadProgress draws a progress bar on standard error for each asset that
+// is being uploaded, redrawing all of them whenever one changes.
+type uploadProgress struct {
+	names   []string
+	sizes   []int64
+	percent []int
+	lines   []string
+	width   int
+	drawn   bool
+}
+
+func newUploadProgress(assets []github.LocalAsset) *uploadProgress {
+	p := &uploadProgress{}
+	for _, asset := range assets {
+		name := filepath.Base(asset.Name)
+		p.names = append(p.names, name)
+		p.sizes = append(p.sizes, asset.Size)
+		p.percent = append(p.percent, 0)
+		if len(name) > p.width {
+			p.width = len(name)
+		}
+	}
+	for i := range assets {
+		p.lines = append(p.lines, uploadProgressLine(p.names[i], p.width, 0, p.sizes[i]))
+	}
+	p.draw()
+	return p
+}
+
+func (p *uploadProgress) handle(event uploadEvent) {
+	i := event.index
+	switch {
+	case event.done && event.err != nil:
+		p.lines[i] = fmt.Sprintf("%-*s  failed", p.width, p.names[i])
+	case event.done:
+		p.lines[i] = fmt.Sprintf("%-*s  done", p.width, p.names[i])
+	default:
+		percent := uploadPercent(event.sent, p.sizes[i])
+		if percent == p.percent[i] {
+			return
+		}
+		p.percent[i] = percent
+		p.lines[i] = uploadProgressLine(p.names[i], p.width, event.sent, p.sizes[i])
+	}
+	p.draw()
+}
+
+func (p *uploadProgress) draw() {
+	if p.drawn {
+		ui.Errorf("\033[%dA", len(p.lines))
+	}
+	for _, line := range p.lines {
+		ui.Errorf("\r\033[K%s\n", line)
+	}
+	p.drawn = true
+}
+
+func uploadPercent(sent, size int64) int {
+	if size <= 0 || sent >= size {
+		return 100
+	}
+	return int(sent * 100 / size)
+}
+
+// uploadProgressLine renders a line such as
+// "hub.tgz  [#####-----]  50% of 4.0 MB".
+func uploadProgressLine(name string, width int, sent, size int64) string {
+	percent := uploadPercent(sent, size)
+	return fmt.Sprintf("%-*s  %s %3d%% of %s", width, name, progressBar(percent, 10), percent, formatByteSize(size))
+}
@@ -0,0 +1,703 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdRepo = &Command{
+		Run: printHelp,
+		Usage: `
+repo traffic [--views] [--clones] [--referrers] [--paths] [--json]
+repo contributors [--since <TAG>]
+repo migrate [--state <FILE>] <SRC-HOST>/<OWNER>/<REPO> <DEST-HOST>/<OWNER>/<REPO>
+repo diff-settings <OWNER>/<REPO> <OWNER>/<REPO>
+repo apply -f <FILE> [--dry-run]
+repo rename-branch <OLD> <NEW>
+`,
+		Long: `Show information about the current repository.
+
+## Commands:
+
+	* _traffic_:
+		Show traffic statistics for the last 14 days. Without options, show daily
+		views and clones. Requires push access to the repository.
+
+	* _contributors_:
+		List authors of the default branch with their number of commits, lines
+		added, and lines deleted, most active first.
+
+	* _migrate_:
+		Copy a repository to another GitHub host, for example from GitHub
+		Enterprise Server to github.com. All branches and tags are pushed to the
+		destination, which is created if it doesn't exist yet. Then labels,
+		milestones, releases along with their assets, and open issues are
+		recreated there. Issue authors, comments, and assignees are not carried
+		over; each copied issue links back to the original instead.
+
+		Progress is saved to a state file after every step, so an interrupted
+		migration picks up where it left off when run again.
+
+	* _diff-settings_:
+		Compare the merge settings, topics, labels, branch protection rules, and
+		webhooks of two repositories and print the differences. Exits with a
+		non-zero status if there are any, which helps keep a fleet of
+		repositories consistent. Webhooks are only compared if both can be read,
+		which requires admin access.
+
+	* _apply_:
+		Bring the settings of the current repository in line with the YAML <FILE>.
+		The changes are listed before they are made, so ''--dry-run'' shows the
+		plan without applying it. Only what <FILE> mentions is changed; labels,
+		protection rules, and collaborators missing from it are kept. The
+		supported keys are:
+
+		description, homepage: strings
+
+		topics: the complete list of topics
+
+		allow_merge_commit, allow_squash_merge, allow_rebase_merge,
+		allow_auto_merge, delete_branch_on_merge: merge settings
+
+		labels: list of labels with "name", "color", and "description"
+
+		branch_protection: map of branch patterns to the settings accepted by
+		''hub protection set --file''
+
+		collaborators: map of logins to a permission, see hub-collab(1)
+
+		secrets: list of Actions secrets that must exist. Their values can't be
+		part of <FILE>, so missing secrets are only reported.
+
+	* _rename-branch_:
+		Rename the branch <OLD> of the repository on GitHub to <NEW>, such as when
+		moving the default branch from "master" to "main". Open pull requests
+		based on <OLD> are retargeted to <NEW> and protection rules for <OLD>
+		apply to <NEW> instead. The local branch <OLD> is renamed as well and set
+		to track <NEW> on the remote, whose default branch is updated.
+
+## Options:
+	--views
+		Show the number of page views on GitHub per day.
+
+	--clones
+		Show the number of clones per day.
+
+	--referrers
+		Show the top sites that referred visitors to the repository.
+
+	--paths
+		Show the most visited pages of the repository.
+
+	--json
+		Print the statistics as a JSON object instead of formatted text.
+
+	--since <TAG>
+		Only count contributions made since the commit <TAG> points to. GitHub
+		aggregates statistics by week, so contributions from the whole week of
+		<TAG> are included.
+
+	-f, --file <FILE>
+		The repository spec for ''apply''. Pass "-" to read from standard input.
+
+	--dry-run
+		Show what ''apply'' would change without changing anything.
+
+	--state <FILE>
+		Record the progress of ''migrate'' in <FILE> (default:
+		"<OWNER>-<REPO>.migration.json" after the destination repository).
+
+## Examples:
+		$ hub repo traffic --views
+		Views: 120 (45 unique)
+		  2026-10-01      10       3
+		  ...
+
+		$ hub repo contributors --since v2.13.0
+
+		$ hub repo migrate git.my.org/acme/widget github.com/acme/widget
+
+		$ hub repo diff-settings acme/widget acme/gadget
+		--- acme/widget
+		+++ acme/gadget
+		merge settings
+		- allow rebase merge: true
+		+ allow rebase merge: false
+		labels
+		+ wontfix: #ffffff
+
+		$ hub repo apply -f repo.yml --dry-run
+		~ delete_branch_on_merge: false -> true
+		+ label triage: #fbca04
+		+ protection main: checks=build reviews=1
+
+		$ hub repo rename-branch master main
+		Renamed branch master to main in acme/widget
+		Renamed local branch master to main, tracking origin/main
+
+## See also:
+
+hub(1)
+`,
+	}
+
+	cmdRepoTraffic = &Command{
+		Key: "traffic",
+		Run: repoTraffic,
+		KnownFlags: `
+		--views
+		--clones
+		--referrers
+		--paths
+		--json
+`,
+	}
+
+	cmdRepoContributors = &Command{
+		Key: "contributors",
+		Run: repoContributors,
+		KnownFlags: `
+		--since TAG
+`,
+	}
+
+	cmdRepoMigrate = &Command{
+		Key: "migrate",
+		Run: repoMigrate,
+		KnownFlags: `
+		--state FILE
+`,
+	}
+
+	cmdRepoDiffSettings = &Command{
+		Key: "diff-settings",
+		Run: repoDiffSettings,
+	}
+
+	cmdRepoApply = &Command{
+		Key: "apply",
+		Run: repoApply,
+		KnownFlags: `
+		-f, --file FILE
+		--dry-run
+`,
+	}
+
+	cmdRepoRenameBranch = &Command{
+		Key: "rename-branch",
+		Run: repoRenameBranch,
+	}
+)
+
+func init() {
+	cmdRepo.Use(cmdRepoTraffic)
+	cmdRepo.Use(cmdRepoContributors)
+	cmdRepo.Use(cmdRepoMigrate)
+	cmdRepo.Use(cmdRepoDiffSettings)
+	cmdRepo.Use(cmdRepoApply)
+	cmdRepo.Use(cmdRepoRenameBranch)
+	CmdRunner.Use(cmdRepo)
+}
+
+func repoTraffic(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	showViews := args.Flag.Bool("--views")
+	showClones := args.Flag.Bool("--clones")
+	showReferrers := args.Flag.Bool("--referrers")
+	showPaths := args.Flag.Bool("--paths")
+	if !showViews && !showClones && !showReferrers && !showPaths {
+		showViews = true
+		showClones = true
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request traffic statistics for %s\n", project)
+		return
+	}
+
+	report := struct {
+		Views     *github.TrafficStats   `json:"views,omitempty"`
+		Clones    *github.TrafficStats   `json:"clones,omitempty"`
+		Referrers []github.TrafficSource `json:"referrers,omitempty"`
+		Paths     []github.TrafficSource `json:"paths,omitempty"`
+	}{}
+
+	if showViews {
+		report.Views, err = gh.FetchTraffic(project, "views")
+		utils.Check(err)
+	}
+	if showClones {
+		report.Clones, err = gh.FetchTraffic(project, "clones")
+		utils.Check(err)
+	}
+	if showReferrers {
+		report.Referrers, err = gh.FetchPopularTraffic(project, "referrers")
+		utils.Check(err)
+	}
+	if showPaths {
+		report.Paths, err = gh.FetchPopularTraffic(project, "paths")
+		utils.Check(err)
+	}
+
+	if args.Flag.Bool("--json") {
+		out, err := json.MarshalIndent(report, "", "  ")
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+
+	sections := []string{}
+	if report.Views != nil {
+		sections = append(sections, formatTrafficDays("Views", report.Views.Count, report.Views.Uniques, report.Views.Views))
+	}
+	if report.Clones != nil {
+		sections = append(sections, formatTrafficDays("Clones", report.Clones.Count, report.Clones.Uniques, report.Clones.Clones))
+	}
+	if showReferrers {
+		sections = append(sections, formatTrafficSources("Referrers", report.Referrers))
+	}
+	if showPaths {
+		sections = append(sections, formatTrafficSources("Paths", report.Paths))
+	}
+
+	for i, section := range sections {
+		if i > 0 {
+			ui.Println()
+		}
+		ui.Print(section)
+	}
+}
+
+func formatTrafficDays(label string, count, uniques int, days []github.TrafficDay) string {
+	out := fmt.Sprintf("%s: %d (%d unique)\n", label, count, uniques)
+	for _, day := range days {
+		out += fmt.Sprintf("  %s  %6d  %6d\n", day.Timestamp.UTC().Format("2006-01-02"), day.Count, day.Uniques)
+	}
+	return out
+}
+
+func formatTrafficSources(label string, sources []github.TrafficSource) string {
+	width := 0
+	for _, source := range sources {
+		if name := source.Referrer + source.Path; len(name) > width {
+			width = len(name)
+		}
+	}
+
+	out := fmt.Sprintf("%s:\n", label)
+	for _, source := range sources {
+		out += fmt.Sprintf("  %-*s  %6d  %6d\n", width, source.Referrer+source.Path, source.Count, source.Uniques)
+	}
+	return out
+}
+
+type contributorSummary struct {
+	Login     string
+	Commits   int
+	Additions int
+	Deletions int
+}
+
+func repoContributors(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	var since time.Time
+	if args.Flag.HasReceived("--since") {
+		since, err = git.CommitTime(args.Flag.Value("--since"))
+		utils.Check(err)
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request contributor statistics for %s\n", project)
+		return
+	}
+
+	stats, err := gh.FetchContributorStats(project)
+	utils.Check(err)
+
+	contributors := summarizeContributors(stats, since)
+	width := 0
+	for _, c := range contributors {
+		if len(c.Login) > width {
+			width = len(c.Login)
+		}
+	}
+	for _, c := range contributors {
+		ui.Printf("%-*s  %5d %-7s  +%d  -%d\n", width, c.Login, c.Commits, pluralize(c.Commits, "commit"), c.Additions, c.Deletions)
+	}
+}
+
+// summarizeContributors adds up weekly statistics for each author, counting
+// only weeks that end after since, and sorts authors by number of commits.
+func summarizeContributors(stats []github.ContributorStats, since time.Time) []contributorSummary {
+	const week = 7 * 24 * 60 * 60
+
+	contributors := []contributorSummary{}
+	for _, s := range stats {
+		summary := contributorSummary{}
+		if s.Author != nil {
+			summary.Login = s.Author.Login
+		}
+		for _, w := range s.Weeks {
+			if !since.IsZero() && w.Week+week <= since.Unix() {
+				continue
+			}
+			summary.Commits += w.Commits
+			summary.Additions += w.Additions
+			summary.Deletions += w.Deletions
+		}
+		if summary.Commits > 0 {
+			contributors = append(contributors, summary)
+		}
+	}
+
+	sort.SliceStable(contributors, func(i, j int) bool {
+		if contributors[i].Commits != contributors[j].Commits {
+			return contributors[i].Commits > contributors[j].Commits
+		}
+		return contributors[i].Login < contributors[j].Login
+	})
+
+	return contributors
+}
+
+// migrationState records which parts of a repository migration are done.
+// Numbers of milestones and issues are keyed by their number in the source
+// repository.
+type migrationState struct {
+	GitPushed  bool            `json:"git_pushed"`
+	Labels     bool            `json:"labels"`
+	Milestones map[string]int  `json:"milestones"`
+	Releases   map[string]bool `json:"releases"`
+	Issues     map[string]int  `json:"issues"`
+
+	path string
+}
+
+func loadMigrationState(path string) (*migrationState, error) {
+	state := &migrationState{path: path}
+	if content, err := ioutil.ReadFile(path); err == nil {
+		if err := json.Unmarshal(content, state); err != nil {
+			return nil, fmt.Errorf("error reading %s: %s", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if state.Milestones == nil {
+		state.Milestones = map[string]int{}
+	}
+	if state.Releases == nil {
+		state.Releases = map[string]bool{}
+	}
+	if state.Issues == nil {
+		state.Issues = map[string]int{}
+	}
+	return state, nil
+}
+
+func (s *migrationState) save() {
+	content, err := json.MarshalIndent(s, "", "  ")
+	utils.Check(err)
+	utils.Check(ioutil.WriteFile(s.path, content, 0644))
+}
+
+// parseHostProject parses "HOST/OWNER/REPO".
+func parseHostProject(value string) (*github.Project, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("error: expected HOST/OWNER/REPO, got '%s'", value)
+	}
+	return github.NewProject(parts[1], parts[2], parts[0]), nil
+}
+
+func repoMigrate(cmd *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	source, err := parseHostProject(args.GetParam(0))
+	utils.Check(err)
+	dest, err := parseHostProject(args.GetParam(1))
+	utils.Check(err)
+
+	statePath := fmt.Sprintf("%s-%s.migration.json", dest.Owner, dest.Name)
+	if args.Flag.HasReceived("--state") {
+		statePath = args.Flag.Value("--state")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would migrate %s/%s to %s/%s\n", source.Host, source, dest.Host, dest)
+		return
+	}
+
+	state, err := loadMigrationState(statePath)
+	utils.Check(err)
+
+	sourceClient := github.NewClient(source.Host)
+	destClient := github.NewClient(dest.Host)
+
+	sourceRepo, err := sourceClient.Repository(source)
+	utils.Check(err)
+	destExists, err := destClient.RepositoryExists(dest)
+	utils.Check(err)
+	if !destExists {
+		_, err = destClient.CreateRepository(dest, sourceRepo.Description, "", sourceRepo.Private)
+		utils.Check(err)
+		ui.Printf("Created repository %s/%s\n", dest.Host, dest)
+	}
+
+	if !state.GitPushed {
+		utils.Check(migrateGitData(source, dest))
+		state.GitPushed = true
+		state.save()
+	}
+
+	if !state.Labels {
+		sourceLabels, err := sourceClient.FetchLabels(source)
+		utils.Check(err)
+		destLabels, err := destClient.FetchLabels(dest)
+		utils.Check(err)
+		toCreate, _, _ := planLabelClone(sourceLabels, destLabels, false)
+		for _, label := range toCreate {
+			utils.Check(destClient.CreateLabel(dest, label))
+		}
+		ui.Printf("Copied %d %s\n", len(toCreate), pluralize(len(toCreate), "label"))
+		state.Labels = true
+		state.save()
+	}
+
+	milestones, err := sourceClient.FetchMilestones(source, map[string]interface{}{"state": "all"})
+	utils.Check(err)
+	for _, milestone := range milestones {
+		key := strconv.Itoa(milestone.Number)
+		if _, done := state.Milestones[key]; done {
+			continue
+		}
+		params := map[string]interface{}{
+			"title":       milestone.Title,
+			"description": milestone.Description,
+			"state":       milestone.State,
+		}
+		if !milestone.DueOn.IsZero() {
+			params["due_on"] = milestone.DueOn.Format(time.RFC3339)
+		}
+		created, err := destClient.CreateMilestone(dest, params)
+		utils.Check(err)
+		ui.Printf("Copied milestone '%s'\n", milestone.Title)
+		state.Milestones[key] = created.Number
+		state.save()
+	}
+
+	releases, err := sourceClient.FetchReleases(source, 0, nil)
+	utils.Check(err)
+	for i := len(releases) - 1; i >= 0; i-- {
+		release := releases[i]
+		if state.Releases[release.TagName] {
+			continue
+		}
+		utils.Check(migrateRelease(sourceClient, destClient, dest, &release))
+		ui.Printf("Copied release %s\n", release.TagName)
+		state.Releases[release.TagName] = true
+		state.save()
+	}
+
+	issues, err := sourceClient.FetchIssues(source, map[string]interface{}{
+		"state":     "open",
+		"direction": "asc",
+	}, 0, func(issue *github.Issue) bool {
+		return issue.PullRequest == nil
+	})
+	utils.Check(err)
+	for _, issue := range issues {
+		key := strconv.Itoa(issue.Number)
+		if _, done := state.Issues[key]; done {
+			continue
+		}
+		created, err := destClient.CreateIssue(dest, migratedIssueParams(issue, state))
+		utils.Check(err)
+		ui.Printf("Copied issue #%d as #%d\n", issue.Number, created.Number)
+		state.Issues[key] = created.Number
+		state.save()
+	}
+
+	ui.Printf("Migrated %s/%s to %s/%s\n", source.Host, source, dest.Host, dest)
+}
+
+// migrateGitData pushes all branches and tags from source to dest by way of
+// a temporary mirror clone.
+func migrateGitData(source, dest *github.Project) error {
+	mirrorDir, err := ioutil.TempDir("", "hub-migrate")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(mirrorDir)
+
+	if err := git.Spawn("clone", "--mirror", source.GitURL("", "", true), mirrorDir); err != nil {
+		return err
+	}
+	return git.Spawn("--git-dir", mirrorDir, "push", dest.GitURL("", "", true),
+		"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*")
+}
+
+// migrateRelease recreates a release in dest along with its assets. Releases
+// and assets that already exist in dest from an earlier, interrupted attempt
+// are kept. Drafts are also matched by name, since their tag may not exist yet.
+func migrateRelease(sourceClient, destClient *github.Client, dest *github.Project, release *github.Release) error {
+	destReleases, err := destClient.FetchReleases(dest, 0, func(r *github.Release) bool {
+		return r.TagName == release.TagName || (release.Draft && r.Draft && r.Name == release.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	var destRelease *github.Release
+	if len(destReleases) > 0 {
+		destRelease = &destReleases[0]
+	} else {
+		destRelease, err = destClient.CreateRelease(dest, &github.Release{
+			TagName:         release.TagName,
+			TargetCommitish: release.TargetCommitish,
+			Name:            release.Name,
+			Body:            release.Body,
+			Draft:           release.Draft,
+			Prerelease:      release.Prerelease,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	existing := map[string]bool{}
+	for _, asset := range destRelease.Assets {
+		existing[asset.Name] = true
+	}
+
+	assetDir, err := ioutil.TempDir("", "hub-migrate")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(assetDir)
+
+	for _, asset := range release.Assets {
+		if existing[asset.Name] {
+			continue
+		}
+
+		localPath := filepath.Join(assetDir, asset.Name)
+		if err := saveReleaseAsset(sourceClient, asset, localPath); err != nil {
+			return err
+		}
+		file, err := os.Open(localPath)
+		if err != nil {
+			return err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return err
+		}
+		_, err = destClient.UploadReleaseAssets(destRelease, []github.LocalAsset{{
+			Name:     localPath,
+			Label:    asset.Label,
+			Contents: file,
+			Size:     info.Size(),
+		}})
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveReleaseAsset downloads asset to localPath, overwriting any existing file.
+func saveReleaseAsset(gh *github.Client, asset github.ReleaseAsset, localPath string) error {
+	body, err := gh.DownloadReleaseAsset(asset.APIURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	file, err := os.OpenFile(localPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, body)
+	return err
+}
+
+// migratedIssueParams describes a copy of issue that links back to the
+// original and points to milestones in the destination repository.
+func migratedIssueParams(issue github.Issue, state *migrationState) map[string]interface{} {
+	body := strings.TrimSpace(issue.Body)
+	if body != "" {
+		body += "\n\n"
+	}
+	author := "ghost"
+	if issue.User != nil {
+		author = issue.User.Login
+	}
+	// The author is a login on the source host, so it's not written as an
+	// @-mention that would notify whoever has that login on the target host.
+	sourceHost := issue.HTMLURL
+	if u, err := url.Parse(issue.HTMLURL); err == nil && u.Host != "" {
+		sourceHost = u.Host
+	}
+	body += fmt.Sprintf("_Migrated from %s (opened by `%s` on %s)._", issue.HTMLURL, author, sourceHost)
+
+	params := map[string]interface{}{
+		"title": issue.Title,
+		"body":  body,
+	}
+
+	labels := []string{}
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+	if len(labels) > 0 {
+		params["labels"] = labels
+	}
+
+	if issue.Milestone != nil {
+		if number, ok := state.Milestones[strconv.Itoa(issue.Milestone.Number)]; ok {
+			params["milestone"] = number
+		}
+	}
+
+	return params
+}
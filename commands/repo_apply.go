@@ -0,0 +1,298 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// repoSpec is the declarative description of a repository read by
+// `repo apply`. Settings that are left out are not changed.
+type repoSpec struct {
+	Description         *string                   `yaml:"description"`
+	Homepage            *string                   `yaml:"homepage"`
+	Topics              []string                  `yaml:"topics"`
+	AllowMergeCommit    *bool                     `yaml:"allow_merge_commit"`
+	AllowSquashMerge    *bool                     `yaml:"allow_squash_merge"`
+	AllowRebaseMerge    *bool                     `yaml:"allow_rebase_merge"`
+	AllowAutoMerge      *bool                     `yaml:"allow_auto_merge"`
+	DeleteBranchOnMerge *bool                     `yaml:"delete_branch_on_merge"`
+	Labels              []github.IssueLabel       `yaml:"labels"`
+	BranchProtection    map[string]protectionSpec `yaml:"branch_protection"`
+	Collaborators       map[string]string         `yaml:"collaborators"`
+	Secrets             []string                  `yaml:"secrets"`
+}
+
+// repoState is the current configuration of a repository that a repoSpec is
+// compared against.
+type repoState struct {
+	Repo          *github.Repository
+	Labels        []github.IssueLabel
+	RepoID        string
+	Rules         []branchProtectionRule
+	Collaborators []github.Collaborator
+	Secrets       []github.ActionsSecret
+}
+
+// repoChange is a single step of the plan made by `repo apply`. Changes
+// without an apply function are only reported.
+type repoChange struct {
+	Description string
+	apply       func(gh *github.Client, project *github.Project) error
+}
+
+func parseRepoSpec(content string) (spec repoSpec, err error) {
+	if err = yaml.UnmarshalStrict([]byte(content), &spec); err != nil {
+		err = fmt.Errorf("error parsing repository spec: %s", err)
+	}
+	return
+}
+
+func repoApply(cmd *Command, args *Args) {
+	if !args.Flag.HasReceived("--file") {
+		utils.Check(cmd.UsageError("missing --file"))
+	}
+	content, err := msgFromFile(args.Flag.Value("--file"))
+	utils.Check(err)
+	spec, err := parseRepoSpec(content)
+	utils.Check(err)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would apply %s to %s\n", args.Flag.Value("--file"), project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	state, err := fetchRepoState(gh, project, len(spec.Secrets) > 0)
+	utils.Check(err)
+
+	changes := planRepoChanges(spec, state)
+	if len(changes) == 0 {
+		ui.Printf("%s is up to date\n", project)
+		return
+	}
+	for _, change := range changes {
+		ui.Println(change.Description)
+	}
+	if args.Flag.Bool("--dry-run") {
+		return
+	}
+
+	applied := 0
+	for _, change := range changes {
+		if change.apply == nil {
+			continue
+		}
+		utils.Check(change.apply(gh, project))
+		applied++
+	}
+	ui.Printf("Applied %d %s to %s\n", applied, pluralize(applied, "change"), project)
+}
+
+func fetchRepoState(gh *github.Client, project *github.Project, withSecrets bool) (state repoState, err error) {
+	if state.Repo, err = gh.Repository(project); err != nil {
+		return
+	}
+	if state.Labels, err = gh.FetchLabels(project); err != nil {
+		return
+	}
+	if state.RepoID, state.Rules, err = fetchBranchProtectionRules(gh, project); err != nil {
+		return
+	}
+	if state.Collaborators, err = gh.FetchCollaborators(project); err != nil {
+		return
+	}
+	if withSecrets {
+		state.Secrets, err = gh.FetchActionsSecrets(fmt.Sprintf("repos/%s/%s/actions", project.Owner, project.Name), "secrets")
+	}
+	return
+}
+
+// planRepoChanges lists the changes that make the repository in state match
+// spec. Labels, protection rules, and collaborators that spec doesn't mention
+// are kept.
+func planRepoChanges(spec repoSpec, state repoState) []repoChange {
+	changes := []repoChange{}
+	repo := state.Repo
+
+	// all settings are updated in a single request, made by whichever of their
+	// changes is applied first
+	params := map[string]interface{}{}
+	updated := false
+	updateSettings := func(gh *github.Client, project *github.Project) error {
+		if updated {
+			return nil
+		}
+		updated = true
+		return gh.UpdateRepository(project, params)
+	}
+	setting := func(name string, want interface{}, have interface{}) {
+		if fmt.Sprint(want) != fmt.Sprint(have) {
+			params[name] = want
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("~ %s: %v -> %v", name, have, want),
+				apply:       updateSettings,
+			})
+		}
+	}
+	if spec.Description != nil {
+		setting("description", *spec.Description, repo.Description)
+	}
+	if spec.Homepage != nil {
+		setting("homepage", *spec.Homepage, repo.Homepage)
+	}
+	for _, s := range []struct {
+		name string
+		want *bool
+		have bool
+	}{
+		{"allow_merge_commit", spec.AllowMergeCommit, repo.AllowMergeCommit},
+		{"allow_squash_merge", spec.AllowSquashMerge, repo.AllowSquashMerge},
+		{"allow_rebase_merge", spec.AllowRebaseMerge, repo.AllowRebaseMerge},
+		{"allow_auto_merge", spec.AllowAutoMerge, repo.AllowAutoMerge},
+		{"delete_branch_on_merge", spec.DeleteBranchOnMerge, repo.DeleteBranchOnMerge},
+	} {
+		if s.want != nil {
+			setting(s.name, *s.want, s.have)
+		}
+	}
+
+	if spec.Topics != nil {
+		want := append([]string{}, spec.Topics...)
+		have := append([]string{}, repo.Topics...)
+		sort.Strings(want)
+		sort.Strings(have)
+		if strings.Join(want, ",") != strings.Join(have, ",") {
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("~ topics: [%s] -> [%s]", strings.Join(have, ", "), strings.Join(want, ", ")),
+				apply: func(gh *github.Client, project *github.Project) error {
+					return gh.ReplaceTopics(project, want)
+				},
+			})
+		}
+	}
+
+	existingLabels := map[string]github.IssueLabel{}
+	for _, label := range state.Labels {
+		existingLabels[strings.ToLower(label.Name)] = label
+	}
+	for _, label := range spec.Labels {
+		label := label
+		label.Color = strings.TrimPrefix(label.Color, "#")
+		existing, found := existingLabels[strings.ToLower(label.Name)]
+		if !found {
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("+ label %s: %s", label.Name, formatLabelSetting(label)),
+				apply: func(gh *github.Client, project *github.Project) error {
+					return gh.CreateLabel(project, label)
+				},
+			})
+		} else if !strings.EqualFold(existing.Color, label.Color) || existing.Description != label.Description || existing.Name != label.Name {
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("~ label %s: %s -> %s", label.Name, formatLabelSetting(existing), formatLabelSetting(label)),
+				apply: func(gh *github.Client, project *github.Project) error {
+					return gh.UpdateLabel(project, existing.Name, label)
+				},
+			})
+		}
+	}
+
+	patterns := []string{}
+	for pattern := range spec.BranchProtection {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		pattern, want := pattern, spec.BranchProtection[pattern]
+		var rule *branchProtectionRule
+		for i := range state.Rules {
+			if state.Rules[i].Pattern == pattern {
+				rule = &state.Rules[i]
+			}
+		}
+		apply := func(gh *github.Client, project *github.Project) error {
+			return saveBranchProtectionRule(gh, state.RepoID, rule, pattern, want)
+		}
+		if rule == nil {
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("+ protection %s: %s", pattern, formatProtectionSpec(want)),
+				apply:       apply,
+			})
+		} else if have := formatProtectionSpec(rule.spec()); have != formatProtectionSpec(want) {
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("~ protection %s: %s -> %s", pattern, have, formatProtectionSpec(want)),
+				apply:       apply,
+			})
+		}
+	}
+
+	roles := map[string]string{}
+	for _, collaborator := range state.Collaborators {
+		roles[strings.ToLower(collaborator.Login)] = collaboratorPermission(collaboratorRole(collaborator))
+	}
+	logins := []string{}
+	for login := range spec.Collaborators {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	for _, login := range logins {
+		login, want := login, spec.Collaborators[login]
+		apply := func(gh *github.Client, project *github.Project) error {
+			_, err := gh.AddCollaborator(project, login, want)
+			return err
+		}
+		if have, found := roles[strings.ToLower(login)]; !found {
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("+ collaborator %s: %s", login, want),
+				apply:       apply,
+			})
+		} else if have != collaboratorPermission(want) {
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("~ collaborator %s: %s -> %s", login, have, want),
+				apply:       apply,
+			})
+		}
+	}
+
+	secrets := map[string]bool{}
+	for _, secret := range state.Secrets {
+		secrets[strings.ToUpper(secret.Name)] = true
+	}
+	for _, name := range spec.Secrets {
+		if !secrets[strings.ToUpper(name)] {
+			changes = append(changes, repoChange{
+				Description: fmt.Sprintf("! secret %s is missing; set it with `hub secret set %s`", name, name),
+			})
+		}
+	}
+
+	return changes
+}
+
+func formatLabelSetting(label github.IssueLabel) string {
+	return strings.TrimSpace("#" + label.Color + " " + label.Description)
+}
+
+// collaboratorPermission maps role names to the permission names accepted by
+// the API.
+func collaboratorPermission(role string) string {
+	switch role {
+	case "read":
+		return "pull"
+	case "write":
+		return "push"
+	default:
+		return role
+	}
+}
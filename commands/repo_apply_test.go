@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestPlanRepoChanges(t *testing.T) {
+	spec, err := parseRepoSpec(`
+description: Widgets
+topics: [go, cli]
+delete_branch_on_merge: true
+allow_squash_merge: true
+labels:
+  - name: bug
+    color: "#ee0701"
+  - name: triage
+    color: fbca04
+    description: Needs a look
+branch_protection:
+  main:
+    required_status_checks: [build]
+    required_approving_reviews: 1
+  release/*:
+    enforce_admins: true
+collaborators:
+  octocat: push
+  jdoe: maintain
+secrets: [NPM_TOKEN, DEPLOY_KEY]
+`)
+	assert.Equal(t, nil, err)
+
+	state := repoState{
+		Repo: &github.Repository{
+			Description:      "Widgets",
+			Topics:           []string{"cli", "go"},
+			AllowSquashMerge: true,
+		},
+		Labels: []github.IssueLabel{{Name: "bug", Color: "d73a4a"}},
+		Rules: []branchProtectionRule{
+			{Pattern: "release/*", IsAdminEnforced: true},
+			{Pattern: "main", RequiresApprovingReviews: true, RequiredApprovingReviewCount: 2},
+		},
+		Collaborators: []github.Collaborator{{Login: "OctoCat", RoleName: "write"}, {Login: "jdoe", RoleName: "read"}},
+		Secrets:       []github.ActionsSecret{{Name: "NPM_TOKEN"}},
+	}
+
+	descriptions := []string{}
+	for _, change := range planRepoChanges(spec, state) {
+		descriptions = append(descriptions, change.Description)
+	}
+	assert.Equal(t, []string{
+		"~ delete_branch_on_merge: false -> true",
+		"~ label bug: #d73a4a -> #ee0701",
+		"+ label triage: #fbca04 Needs a look",
+		"~ protection main: reviews=2 -> checks=build reviews=1",
+		"~ collaborator jdoe: pull -> maintain",
+		"! secret DEPLOY_KEY is missing; set it with `hub secret set DEPLOY_KEY`",
+	}, descriptions)
+}
+
+func TestParseRepoSpec_Unknown(t *testing.T) {
+	_, err := parseRepoSpec("colour: red\n")
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+func repoRenameBranch(cmd *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+	oldName, newName := args.GetParam(0), args.GetParam(1)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would rename branch %s to %s in %s\n", oldName, newName, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	utils.Check(gh.RenameBranch(project, oldName, newName))
+	ui.Printf("Renamed branch %s to %s in %s\n", oldName, newName, project)
+
+	// GitHub retargets pull requests and moves protection rules as part of the
+	// rename, so these only catch what it left behind
+	pulls, err := gh.FetchPullRequests(project, map[string]interface{}{
+		"state": "open",
+		"base":  oldName,
+	}, 0, nil)
+	utils.Check(err)
+	for _, pr := range pulls {
+		_, err := gh.UpdatePullRequest(project, pr.Number, map[string]interface{}{"base": newName})
+		utils.Check(err)
+		ui.Printf("Retargeted pull request #%d to %s\n", pr.Number, newName)
+	}
+
+	_, rules, err := fetchBranchProtectionRules(gh, project)
+	utils.Check(err)
+	for _, rule := range rules {
+		if rule.Pattern != oldName {
+			continue
+		}
+		err = gh.GraphQL(`
+		mutation($input: UpdateBranchProtectionRuleInput!) {
+			updateBranchProtectionRule(input: $input) {
+				branchProtectionRule {
+					id
+				}
+			}
+		}`, map[string]interface{}{
+			"input": map[string]interface{}{
+				"branchProtectionRuleId": rule.ID,
+				"pattern":                newName,
+			},
+		}, &struct{}{})
+		utils.Check(err)
+		ui.Printf("Moved protection rule from %s to %s\n", oldName, newName)
+	}
+
+	remote, err := localRepo.RemoteForProject(project)
+	if err != nil {
+		return
+	}
+	utils.Check(git.Spawn("fetch", "--prune", "--quiet", remote.Name))
+	if git.Quiet("show-ref", "--verify", "--quiet", "refs/heads/"+oldName) {
+		utils.Check(git.Spawn("branch", "--move", oldName, newName))
+		utils.Check(git.Spawn("branch", "--set-upstream-to", remote.Name+"/"+newName, newName))
+		ui.Printf("Renamed local branch %s to %s, tracking %s/%s\n", oldName, newName, remote.Name, newName)
+	}
+	git.Quiet("remote", "set-head", remote.Name, "--auto")
+}
@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+// repoSettingSections lists the sections of repoSettings in display order.
+var repoSettingSections = []string{
+	"merge settings",
+	"topics",
+	"labels",
+	"branch protection",
+	"webhooks",
+}
+
+// repoSettings maps section names to the settings within each section. A
+// section is missing if its settings couldn't be read.
+type repoSettings map[string]map[string]string
+
+func repoDiffSettings(cmd *Command, args *Args) {
+	if args.ParamsSize() != 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	host := ""
+	if localRepo, err := github.LocalRepo(); err == nil {
+		if project, err := localRepo.MainProject(); err == nil {
+			host = project.Host
+		}
+	}
+	if host == "" {
+		defaultHost, err := github.CurrentConfig().DefaultHostNoPrompt()
+		utils.Check(err)
+		host = defaultHost.Host
+	}
+
+	projects := []*github.Project{}
+	for _, param := range args.Params {
+		parts := strings.Split(param, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			utils.Check(fmt.Errorf("error: expected OWNER/REPO, got '%s'", param))
+		}
+		projects = append(projects, github.NewProject(parts[0], parts[1], host))
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would compare settings of %s and %s\n", projects[0], projects[1])
+		return
+	}
+
+	gh := github.NewClient(host)
+	settings := []repoSettings{}
+	for _, project := range projects {
+		s, err := fetchRepoSettings(gh, project)
+		utils.Check(err)
+		settings = append(settings, s)
+	}
+
+	diff := diffRepoSettings(settings[0], settings[1])
+	if diff == "" {
+		ui.Printf("No differences in settings between %s and %s\n", projects[0], projects[1])
+		return
+	}
+	ui.Printf("--- %s\n+++ %s\n%s", projects[0], projects[1], diff)
+	os.Exit(1)
+}
+
+func fetchRepoSettings(gh *github.Client, project *github.Project) (repoSettings, error) {
+	settings := repoSettings{}
+
+	repo, err := gh.Repository(project)
+	if err != nil {
+		return nil, err
+	}
+	settings["merge settings"] = map[string]string{
+		"allow merge commits":           strconv.FormatBool(repo.AllowMergeCommit),
+		"allow squash merge":            strconv.FormatBool(repo.AllowSquashMerge),
+		"allow rebase merge":            strconv.FormatBool(repo.AllowRebaseMerge),
+		"allow auto-merge":              strconv.FormatBool(repo.AllowAutoMerge),
+		"delete head branches on merge": strconv.FormatBool(repo.DeleteBranchOnMerge),
+	}
+
+	settings["topics"] = map[string]string{}
+	for _, topic := range repo.Topics {
+		settings["topics"][topic] = ""
+	}
+
+	labels, err := gh.FetchLabels(project)
+	if err != nil {
+		return nil, err
+	}
+	settings["labels"] = map[string]string{}
+	for _, label := range labels {
+		settings["labels"][label.Name] = formatLabelSetting(label)
+	}
+
+	_, rules, err := fetchBranchProtectionRules(gh, project)
+	if err != nil {
+		return nil, err
+	}
+	settings["branch protection"] = map[string]string{}
+	for _, rule := range rules {
+		settings["branch protection"][rule.Pattern] = formatProtectionSpec(rule.spec())
+	}
+
+	if hooks, err := gh.FetchWebhooks(project); err == nil {
+		settings["webhooks"] = map[string]string{}
+		for _, hook := range hooks {
+			settings["webhooks"][hook.Config.URL] = formatWebhook(hook)
+		}
+	} else {
+		ui.Errorf("warning: can't compare webhooks of %s: %s\n", project, err)
+	}
+
+	return settings, nil
+}
+
+// formatProtectionSpec summarizes a branch protection rule on one line.
+func formatProtectionSpec(spec protectionSpec) string {
+	parts := []string{}
+	if len(spec.RequiredStatusChecks) > 0 {
+		checks := append([]string{}, spec.RequiredStatusChecks...)
+		sort.Strings(checks)
+		parts = append(parts, "checks="+strings.Join(checks, ","))
+	}
+	if spec.StrictStatusChecks {
+		parts = append(parts, "strict")
+	}
+	if spec.RequiredApprovingReviews > 0 {
+		parts = append(parts, fmt.Sprintf("reviews=%d", spec.RequiredApprovingReviews))
+	}
+	if spec.DismissStaleReviews {
+		parts = append(parts, "dismiss-stale-reviews")
+	}
+	if spec.RequireCodeOwnerReviews {
+		parts = append(parts, "code-owner-reviews")
+	}
+	if spec.EnforceAdmins {
+		parts = append(parts, "enforce-admins")
+	}
+	if spec.RequireLinearHistory {
+		parts = append(parts, "linear-history")
+	}
+	if len(parts) == 0 {
+		return "(no requirements)"
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatWebhook summarizes the configuration of a webhook on one line.
+func formatWebhook(hook github.Webhook) string {
+	events := append([]string{}, hook.Events...)
+	sort.Strings(events)
+	parts := []string{"events=" + strings.Join(events, ",")}
+	if hook.Config.ContentType != "" {
+		parts = append(parts, "content-type="+hook.Config.ContentType)
+	}
+	if !hook.Active {
+		parts = append(parts, "inactive")
+	}
+	return strings.Join(parts, " ")
+}
+
+// diffRepoSettings lists the settings that differ between a and b, grouped
+// by section. Sections missing from either side are skipped.
+func diffRepoSettings(a, b repoSettings) string {
+	var out strings.Builder
+	for _, section := range repoSettingSections {
+		left, okLeft := a[section]
+		right, okRight := b[section]
+		if !okLeft || !okRight {
+			continue
+		}
+
+		names := []string{}
+		for name := range left {
+			names = append(names, name)
+		}
+		for name := range right {
+			if _, ok := left[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		lines := []string{}
+		for _, name := range names {
+			valueLeft, inLeft := left[name]
+			valueRight, inRight := right[name]
+			if inLeft && inRight && valueLeft == valueRight {
+				continue
+			}
+			if inLeft {
+				lines = append(lines, formatSettingLine("-", name, valueLeft))
+			}
+			if inRight {
+				lines = append(lines, formatSettingLine("+", name, valueRight))
+			}
+		}
+
+		if len(lines) > 0 {
+			fmt.Fprintf(&out, "%s\n%s\n", section, strings.Join(lines, "\n"))
+		}
+	}
+	return out.String()
+}
+
+func formatSettingLine(sign, name, value string) string {
+	if value == "" {
+		return fmt.Sprintf("%s %s", sign, name)
+	}
+	return fmt.Sprintf("%s %s: %s", sign, name, value)
+}
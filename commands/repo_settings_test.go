@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestDiffRepoSettings(t *testing.T) {
+	a := repoSettings{
+		"merge settings": {"allow rebase merge": "true", "allow squash merge": "true"},
+		"topics":         {"go": "", "cli": ""},
+		"labels":         {"bug": "#d73a4a", "help wanted": "#008672"},
+		"webhooks":       {"https://example.com/hook": "events=push"},
+	}
+	b := repoSettings{
+		"merge settings": {"allow rebase merge": "false", "allow squash merge": "true"},
+		"topics":         {"go": ""},
+		"labels":         {"bug": "#d73a4a", "wontfix": "#ffffff"},
+	}
+
+	assert.Equal(t, `merge settings
+- allow rebase merge: true
++ allow rebase merge: false
+topics
+- cli
+labels
+- help wanted: #008672
++ wontfix: #ffffff
+`, diffRepoSettings(a, b))
+
+	assert.Equal(t, "", diffRepoSettings(b, b))
+}
+
+func TestFormatProtectionSpec(t *testing.T) {
+	assert.Equal(t, "(no requirements)", formatProtectionSpec(protectionSpec{}))
+	assert.Equal(t, "checks=build,lint strict reviews=2 enforce-admins", formatProtectionSpec(protectionSpec{
+		RequiredStatusChecks:     []string{"lint", "build"},
+		StrictStatusChecks:       true,
+		RequiredApprovingReviews: 2,
+		EnforceAdmins:            true,
+	}))
+}
+
+func TestFormatWebhook(t *testing.T) {
+	hook := github.Webhook{Events: []string{"push", "issues"}}
+	hook.Config.ContentType = "json"
+	assert.Equal(t, "events=issues,push content-type=json inactive", formatWebhook(hook))
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatTrafficDays(t *testing.T) {
+	days := []github.TrafficDay{
+		{Timestamp: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Count: 10, Uniques: 3},
+		{Timestamp: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), Count: 125, Uniques: 42},
+	}
+	assert.Equal(t, "Views: 135 (45 unique)\n  2026-10-01      10       3\n  2026-10-02     125      42\n",
+		formatTrafficDays("Views", 135, 45, days))
+}
+
+func TestFormatTrafficSources(t *testing.T) {
+	sources := []github.TrafficSource{
+		{Referrer: "google.com", Count: 20, Uniques: 10},
+		{Referrer: "t.co", Count: 3, Uniques: 1},
+	}
+	assert.Equal(t, "Referrers:\n  google.com      20      10\n  t.co             3       1\n",
+		formatTrafficSources("Referrers", sources))
+}
+
+func TestSummarizeContributors(t *testing.T) {
+	stats := []github.ContributorStats{
+		{
+			Author: &github.User{Login: "octocat"},
+			Weeks: []github.ContributorWeek{
+				{Week: 1790812800, Commits: 5, Additions: 100, Deletions: 10},
+				{Week: 1791417600, Commits: 1, Additions: 2, Deletions: 1},
+			},
+		},
+		{
+			Author: &github.User{Login: "mislav"},
+			Weeks: []github.ContributorWeek{
+				{Week: 1791417600, Commits: 3, Additions: 30, Deletions: 5},
+			},
+		},
+		{
+			Author: &github.User{Login: "hubot"},
+			Weeks: []github.ContributorWeek{
+				{Week: 1790812800, Commits: 2},
+			},
+		},
+	}
+
+	assert.Equal(t, []contributorSummary{
+		{Login: "octocat", Commits: 6, Additions: 102, Deletions: 11},
+		{Login: "mislav", Commits: 3, Additions: 30, Deletions: 5},
+		{Login: "hubot", Commits: 2},
+	}, summarizeContributors(stats, time.Time{}))
+
+	since := time.Unix(1791417600+3600, 0)
+	assert.Equal(t, []contributorSummary{
+		{Login: "mislav", Commits: 3, Additions: 30, Deletions: 5},
+		{Login: "octocat", Commits: 1, Additions: 2, Deletions: 1},
+	}, summarizeContributors(stats, since))
+}
+
+func TestParseHostProject(t *testing.T) {
+	project, err := parseHostProject("git.my.org/acme/widget")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "git.my.org", project.Host)
+	assert.Equal(t, "acme", project.Owner)
+	assert.Equal(t, "widget", project.Name)
+
+	_, err = parseHostProject("acme/widget")
+	assert.Equal(t, "error: expected HOST/OWNER/REPO, got 'acme/widget'", err.Error())
+}
+
+func TestMigratedIssueParams(t *testing.T) {
+	state := &migrationState{Milestones: map[string]int{"3": 1}}
+	issue := github.Issue{
+		Title:     "Crash on startup",
+		Body:      "It crashes.\n",
+		HTMLURL:   "https://git.my.org/acme/widget/issues/12",
+		User:      &github.User{Login: "octocat"},
+		Labels:    []github.IssueLabel{{Name: "bug"}},
+		Milestone: &github.Milestone{Number: 3},
+	}
+
+	params := migratedIssueParams(issue, state)
+	assert.Equal(t, "Crash on startup", params["title"])
+	assert.Equal(t, "It crashes.\n\n_Migrated from https://git.my.org/acme/widget/issues/12 (opened by `octocat` on git.my.org)._", params["body"])
+	assert.Equal(t, []string{"bug"}, params["labels"])
+	assert.Equal(t, 1, params["milestone"])
+}
@@ -0,0 +1,388 @@
+package commands
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdRun = &Command{
+		Run: printHelp,
+		Usage: `
+run logs [-j <JOB>] [-g <PATTERN>] [--follow] <RUN-ID>
+run approve [-e <ENVIRONMENT>] [-m <COMMENT>] [--reject] <RUN-ID>
+`,
+		Long: `Inspect GitHub Actions workflow runs of the current repository.
+
+## Commands:
+
+	* _logs_:
+		Print the logs of the workflow run <RUN-ID>, step by step, with a
+		"==> <JOB> / <STEP> <==" header before the log of each step.
+
+		With ''--follow'', wait for a run that is in progress: the status of
+		each step is reported on standard error as it changes, and the log of
+		each job is printed as soon as the job completes.
+
+	* _approve_:
+		Approve the deployments of workflow run <RUN-ID> that are waiting for a
+		review because of the protection rules of their environments. Only the
+		deployments that you are allowed to review are approved.
+
+## Options:
+	-j, --job <JOB>
+		Print only the logs of jobs named <JOB>, which may be a glob pattern such
+		as "test (*)". Can be repeated.
+
+	-g, --grep <PATTERN>
+		Print only the log lines that match the regular expression <PATTERN>.
+		Steps without matching lines are omitted.
+
+	--follow
+		Stream the logs of a run that is still in progress until it completes.
+
+	-e, --env <ENVIRONMENT>
+		For ''approve'', only review deployments to <ENVIRONMENT>. Can be
+		repeated.
+
+	-m, --comment <COMMENT>
+		For ''approve'', a comment to record along with the review.
+
+	--reject
+		For ''approve'', reject the deployments instead.
+
+## Examples:
+		$ hub run logs --job build 1234567890
+		$ hub run logs --follow -g 'FAIL|panic' 1234567890
+		$ hub run approve -e production -m "Release window open" 1234567890
+
+## See also:
+
+hub-ci-status(1), hub-env(1), hub(1)
+`,
+	}
+
+	cmdRunLogs = &Command{
+		Key: "logs",
+		Run: runLogs,
+		KnownFlags: `
+		-j, --job NAME
+		-g, --grep PATTERN
+		--follow
+`,
+	}
+
+	cmdRunApprove = &Command{
+		Key: "approve",
+		Run: runApprove,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		-m, --comment COMMENT
+		--reject
+`,
+	}
+)
+
+func init() {
+	cmdRun.Use(cmdRunLogs)
+	cmdRun.Use(cmdRunApprove)
+	CmdRunner.Use(cmdRun)
+}
+
+// the delay between polls of a workflow run that is being followed
+const runFollowInterval = 3 * time.Second
+
+func runLogs(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	runID, err := strconv.ParseInt(args.GetParam(0), 10, 64)
+	if err != nil {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	printer := &runLogPrinter{jobs: args.Flag.AllValues("--job")}
+	if pattern := args.Flag.Value("--grep"); pattern != "" {
+		printer.grep, err = regexp.Compile(pattern)
+		if err != nil {
+			utils.Check(fmt.Errorf("error: invalid --grep pattern: %s", err))
+		}
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would print the logs of workflow run %d in %s\n", runID, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+
+	if args.Flag.Bool("--follow") {
+		utils.Check(followRunLogs(gh, project, runID, printer))
+		return
+	}
+
+	run, err := gh.FetchWorkflowRun(project, runID)
+	utils.Check(err)
+	if run.Status != "completed" {
+		utils.Check(fmt.Errorf("error: workflow run %d is still %s; use --follow to wait for its logs", runID, strings.Replace(run.Status, "_", " ", -1)))
+	}
+
+	archive, err := gh.DownloadWorkflowRunLogs(project, runID)
+	utils.Check(err)
+	data, err := ioutil.ReadAll(archive)
+	archive.Close()
+	utils.Check(err)
+
+	steps, err := readRunLogArchive(data)
+	utils.Check(err)
+	for _, step := range steps {
+		if printer.includesJob(step.Job) {
+			printer.print(step.Job+" / "+step.Name, step.Log)
+		}
+	}
+}
+
+func runApprove(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	runID, err := strconv.ParseInt(args.GetParam(0), 10, 64)
+	if err != nil {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	state, verb := "approved", "Approved"
+	if args.Flag.Bool("--reject") {
+		state, verb = "rejected", "Rejected"
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would review pending deployments of workflow run %d in %s\n", runID, project)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+
+	deployments, err := gh.FetchPendingDeployments(project, runID)
+	utils.Check(err)
+
+	environmentIDs, names := reviewableDeployments(deployments, args.Flag.AllValues("--env"))
+	if len(environmentIDs) == 0 {
+		utils.Check(fmt.Errorf("error: workflow run %d has no pending deployments that you can review", runID))
+	}
+
+	err = gh.ReviewPendingDeployments(project, runID, map[string]interface{}{
+		"environment_ids": environmentIDs,
+		"state":           state,
+		"comment":         args.Flag.Value("--comment"),
+	})
+	utils.Check(err)
+
+	ui.Printf("%s deployment to %s.\n", verb, strings.Join(names, ", "))
+}
+
+// reviewableDeployments returns the IDs and names of the environments of
+// pending deployments that the current user can review, optionally limited
+// to the given environment names.
+func reviewableDeployments(deployments []github.PendingDeployment, environments []string) (ids []int, names []string) {
+	for _, d := range deployments {
+		if !d.CurrentUserCanApprove {
+			continue
+		}
+		if len(environments) > 0 {
+			found := false
+			for _, name := range environments {
+				if strings.EqualFold(name, d.Environment.Name) {
+					found = true
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		ids = append(ids, d.Environment.ID)
+		names = append(names, d.Environment.Name)
+	}
+	return
+}
+
+// followRunLogs waits for a workflow run to complete, reporting the progress
+// of its steps on standard error and printing the log of each job once it
+// completes.
+func followRunLogs(gh *github.Client, project *github.Project, runID int64, printer *runLogPrinter) (err error) {
+	stepStatus := map[string]string{}
+	printed := map[int64]bool{}
+
+	waitErr := gh.WaitForWorkflowRun(project, runID, runFollowInterval, func(run *github.WorkflowRun, jobs []github.WorkflowJob) bool {
+		for _, job := range jobs {
+			if printed[job.ID] || !printer.includesJob(job.Name) {
+				continue
+			}
+			if job.Status == "completed" {
+				var log string
+				if log, err = gh.FetchWorkflowJobLog(project, job.ID); err != nil {
+					return true
+				}
+				printed[job.ID] = true
+				printer.print(job.Name, log)
+				continue
+			}
+			for _, step := range job.Steps {
+				status := step.Status
+				if step.Conclusion != "" {
+					status = step.Conclusion
+				}
+				key := fmt.Sprintf("%d/%d", job.ID, step.Number)
+				if stepStatus[key] != status {
+					stepStatus[key] = status
+					ui.Errorf("%s / %s: %s\n", job.Name, step.Name, strings.Replace(status, "_", " ", -1))
+				}
+			}
+		}
+		return false
+	})
+	if err == nil {
+		err = waitErr
+	}
+	return
+}
+
+// runLogPrinter prints sections of workflow logs, optionally limited to
+// some jobs and to the lines that match a pattern.
+type runLogPrinter struct {
+	jobs    []string
+	grep    *regexp.Regexp
+	printed bool
+}
+
+func (p *runLogPrinter) includesJob(name string) bool {
+	if len(p.jobs) == 0 {
+		return true
+	}
+	for _, pattern := range p.jobs {
+		if strings.EqualFold(pattern, name) {
+			return true
+		}
+		if isMatch, _ := filepath.Match(pattern, name); isMatch {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *runLogPrinter) print(header, log string) {
+	if section := formatRunLogSection(header, log, p.grep); section != "" {
+		if p.printed {
+			ui.Println()
+		}
+		ui.Print(section)
+		p.printed = true
+	}
+}
+
+// formatRunLogSection renders log under a header. With grep, only the
+// matching lines are kept, and nothing is rendered if no line matches.
+func formatRunLogSection(header, log string, grep *regexp.Regexp) string {
+	log = strings.Replace(log, "\r\n", "\n", -1)
+	lines := strings.Split(strings.TrimSuffix(log, "\n"), "\n")
+	if grep != nil {
+		matching := []string{}
+		for _, line := range lines {
+			if grep.MatchString(line) {
+				matching = append(matching, line)
+			}
+		}
+		if len(matching) == 0 {
+			return ""
+		}
+		lines = matching
+	}
+	return fmt.Sprintf("==> %s <==\n%s\n", header, strings.Join(lines, "\n"))
+}
+
+type runLogStep struct {
+	Job    string
+	Number int
+	Name   string
+	Log    string
+}
+
+var (
+	runLogStepRegexp = regexp.MustCompile(`^([^/]+)/(\d+)_(.+)\.txt$`)
+	runLogJobRegexp  = regexp.MustCompile(`^(\d+)_(.+)\.txt$`)
+)
+
+// readRunLogArchive extracts the step logs from the zip archive of a
+// workflow run, which has a "<JOB>/<NUMBER>_<STEP>.txt" file for each step
+// and a "<NUMBER>_<JOB>.txt" file with the whole log of each job. Steps are
+// returned in the order in which the jobs and steps ran.
+func readRunLogArchive(data []byte) ([]runLogStep, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error reading workflow run logs: %s", err)
+	}
+
+	jobOrder := map[string]int{}
+	steps := []runLogStep{}
+	for _, file := range archive.File {
+		if match := runLogJobRegexp.FindStringSubmatch(file.Name); match != nil {
+			jobOrder[match[2]], _ = strconv.Atoi(match[1])
+			continue
+		}
+		match := runLogStepRegexp.FindStringSubmatch(file.Name)
+		if match == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(match[2])
+		content, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		log, err := ioutil.ReadAll(content)
+		content.Close()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, runLogStep{Job: match[1], Number: number, Name: match[3], Log: string(log)})
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		a, b := steps[i], steps[j]
+		if a.Job != b.Job {
+			orderA, okA := jobOrder[a.Job]
+			orderB, okB := jobOrder[b.Job]
+			if okA && okB && orderA != orderB {
+				return orderA < orderB
+			}
+			return a.Job < b.Job
+		}
+		return a.Number < b.Number
+	})
+	return steps, nil
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestReadRunLogArchive(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for _, file := range []struct{ name, content string }{
+		{"1_test.txt", "whole test log\n"},
+		{"test/2_Run tests.txt", "ok\n"},
+		{"test/1_Set up job.txt", "setting up\n"},
+		{"0_build.txt", "whole build log\n"},
+		{"build/10_Complete job.txt", "done\n"},
+		{"build/9_Compile.txt", "compiling\n"},
+	} {
+		f, err := w.Create(file.name)
+		assert.Equal(t, nil, err)
+		f.Write([]byte(file.content))
+	}
+	assert.Equal(t, nil, w.Close())
+
+	steps, err := readRunLogArchive(buf.Bytes())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []runLogStep{
+		{Job: "build", Number: 9, Name: "Compile", Log: "compiling\n"},
+		{Job: "build", Number: 10, Name: "Complete job", Log: "done\n"},
+		{Job: "test", Number: 1, Name: "Set up job", Log: "setting up\n"},
+		{Job: "test", Number: 2, Name: "Run tests", Log: "ok\n"},
+	}, steps)
+}
+
+func TestFormatRunLogSection(t *testing.T) {
+	log := "=== RUN TestA\r\n--- FAIL: TestA\r\nFAIL\r\n"
+	assert.Equal(t, "==> test / Run tests <==\n=== RUN TestA\n--- FAIL: TestA\nFAIL\n", formatRunLogSection("test / Run tests", log, nil))
+	assert.Equal(t, "==> test <==\n--- FAIL: TestA\nFAIL\n", formatRunLogSection("test", log, regexp.MustCompile("FAIL")))
+	assert.Equal(t, "", formatRunLogSection("test", log, regexp.MustCompile("panic")))
+}
+
+func TestRunLogPrinterIncludesJob(t *testing.T) {
+	p := &runLogPrinter{}
+	assert.Equal(t, true, p.includesJob("build"))
+
+	p = &runLogPrinter{jobs: []string{"Build", "test (*)"}}
+	assert.Equal(t, true, p.includesJob("build"))
+	assert.Equal(t, true, p.includesJob("test (ubuntu, 18)"))
+	assert.Equal(t, false, p.includesJob("lint"))
+}
+
+func TestReviewableDeployments(t *testing.T) {
+	var deployments []github.PendingDeployment
+	err := json.Unmarshal([]byte(`[
+		{"environment": {"id": 1, "name": "staging"}, "current_user_can_approve": true},
+		{"environment": {"id": 2, "name": "production"}, "current_user_can_approve": true},
+		{"environment": {"id": 3, "name": "audit"}, "current_user_can_approve": false}
+	]`), &deployments)
+	assert.Equal(t, nil, err)
+
+	ids, names := reviewableDeployments(deployments, nil)
+	assert.Equal(t, []int{1, 2}, ids)
+	assert.Equal(t, []string{"staging", "production"}, names)
+
+	ids, names = reviewableDeployments(deployments, []string{"Production", "audit"})
+	assert.Equal(t, []int{2}, ids)
+	assert.Equal(t, []string{"production"}, names)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/github/hub/v2/cmd"
 	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
 	"github.com/github/hub/v2/ui"
 	"github.com/kballard/go-shellquote"
 )
@@ -51,6 +52,14 @@ func (r *Runner) Execute(cliArgs []string) error {
 	}
 
 	git.GlobalFlags = args.GlobalFlags // preserve git global flags
+	github.RemoteOverride = args.Remote
+	github.RepoOverride = args.Repo
+	if args.Host != "" {
+		if args.Repo == "" || strings.Count(args.Repo, "/") != 1 {
+			return fmt.Errorf("the `--host` flag requires `--repo OWNER/NAME`")
+		}
+		github.RepoOverride = args.Host + "/" + args.Repo
+	}
 	if !isBuiltInHubCommand(cmdName) {
 		expandAlias(args)
 		cmdName = args.Command
@@ -0,0 +1,540 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdSearch = &Command{
+		Run: printHelp,
+		Usage: `
+search code [-r <OWNER>/<REPO>] [--org <ORG>] [-l <LANGUAGE>] [-L <LIMIT>] [--open <N>] <QUERY>
+search repos [--org <ORG>] [-l <LANGUAGE>] [-s <SORT>] [-o <ORDER>] [-L <LIMIT>] [-f <FORMAT>] [--color] <QUERY>
+search users [-s <SORT>] [-o <ORDER>] [-L <LIMIT>] [-f <FORMAT>] [--color] <QUERY>
+search commits [-r <OWNER>/<REPO>] [--org <ORG>] [--author <USER>] [-s <SORT>] [-o <ORDER>] [-L <LIMIT>] [-f <FORMAT>] [--color] <QUERY>
+`,
+		Long: `Search GitHub.
+
+## Commands:
+
+	* _code_:
+		Search for code and print the path and repository of each matching file,
+		followed by the matching fragments prefixed with their line numbers.
+
+	* _repos_:
+		Search for repositories.
+
+	* _users_:
+		Search for users and organizations.
+
+	* _commits_:
+		Search for commits by their message.
+
+## Options:
+	-r, --repo <OWNER>/<REPO>
+		Only search the repository <OWNER>/<REPO>.
+
+	--author <USER>
+		Only search commits authored by the GitHub user <USER>. Use "@me" for
+		the authenticated user.
+
+	--org <ORG>
+		Only search repositories owned by <ORG>.
+
+	-l, --language <LANGUAGE>
+		Only search files written in <LANGUAGE>.
+
+	-L, --limit <LIMIT>
+		Display only the first <LIMIT> results (default: 30).
+
+	--open <N>
+		Open the <N>th result in a web browser instead of printing results.
+
+	-s, --sort <SORT>
+		Sort repositories by "stars", "forks", "help-wanted-issues", or "updated";
+		sort users by "followers", "repositories", or "joined"; sort commits by
+		"author-date" or "committer-date" (default: best match).
+
+	-o, --order <ORDER>
+		Sort results in "desc" (default) or "asc" order.
+
+	-f, --format <FORMAT>
+		Pretty print repositories (default: "%<(40)%R %>(6)%Ns  %d%n"), users
+		(default: "%<(20)%l %t%n"), or commits (default: "%R %h  %s%n") using
+		format <FORMAT>. See the "PRETTY FORMATS"
+		section of git-log(1) for some additional details on how placeholders are
+		used in format. The available placeholders for repositories are:
+
+		%R: name with owner
+
+		%d: description
+
+		%l: primary language
+
+		%U: the URL of this repository
+
+		%V: visibility (i.e. "public", "private")
+
+		%Ns: number of stars
+
+		%Nf: number of forks
+
+		%Ni: number of open issues
+
+		%uD: updated date-only (no time of day)
+
+		%ur: updated date, relative
+
+		%uI: updated date, ISO 8601 format
+
+		The available placeholders for users are:
+
+		%l: login name
+
+		%t: account type (i.e. "User", "Organization")
+
+		%U: the URL of this user
+
+		The available placeholders for commits are:
+
+		%H: commit SHA
+
+		%h: abbreviated commit SHA
+
+		%s: commit subject
+
+		%an: author name
+
+		%al: author login name
+
+		%aD: author date-only (no time of day)
+
+		%ar: author date, relative
+
+		%aI: author date, ISO 8601 format
+
+		%R: name with owner of the repository
+
+		%U: the URL of this commit
+
+		Placeholders for all results:
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+	<QUERY>
+		The search terms, optionally including search qualifiers such as
+		"path:docs" or "extension:md".
+
+## Examples:
+		$ hub search code --org github -l go "func NewClient"
+		github/hub github/client.go
+		   42: func NewClient(h string) *Client {
+
+		$ hub search code -r github/hub --open 1 BrowserLauncher
+
+		$ hub search repos -l go -s stars "github cli"
+		$ hub search users -f "%l%n" "location:Zagreb"
+		$ hub search commits -r github/hub --author mislav "fix typo"
+
+## Notes:
+
+The GitHub code search API is rate limited to 10 requests per minute.
+
+## See also:
+
+hub-browse(1), hub(1)
+`,
+	}
+
+	cmdSearchCode = &Command{
+		Key: "code",
+		Run: searchCode,
+		KnownFlags: `
+		-r, --repo REPO
+		--org ORG
+		-l, --language LANGUAGE
+		-L, --limit N
+		--open N
+`,
+	}
+
+	cmdSearchRepos = &Command{
+		Key: "repos",
+		Run: searchRepos,
+		KnownFlags: `
+		--org ORG
+		-l, --language LANGUAGE
+		-s, --sort SORT
+		-o, --order ORDER
+		-L, --limit N
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdSearchCommits = &Command{
+		Key: "commits",
+		Run: searchCommits,
+		KnownFlags: `
+		-r, --repo REPO
+		--org ORG
+		--author USER
+		-s, --sort SORT
+		-o, --order ORDER
+		-L, --limit N
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdSearchUsers = &Command{
+		Key: "users",
+		Run: searchUsers,
+		KnownFlags: `
+		-s, --sort SORT
+		-o, --order ORDER
+		-L, --limit N
+		-f, --format FMT
+		--color
+`,
+	}
+)
+
+func init() {
+	cmdSearch.Use(cmdSearchCode)
+	cmdSearch.Use(cmdSearchRepos)
+	cmdSearch.Use(cmdSearchUsers)
+	cmdSearch.Use(cmdSearchCommits)
+	CmdRunner.Use(cmdSearch)
+}
+
+// searchQuery joins search terms with qualifiers for each flag that was
+// passed, e.g. "--repo" becomes "repo:OWNER/REPO".
+func searchQuery(args *Args, qualifiers map[string]string) string {
+	terms := args.Params
+	flags := make([]string, 0, len(qualifiers))
+	for flag := range qualifiers {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+
+	query := strings.Join(terms, " ")
+	for _, flag := range flags {
+		for _, value := range args.Flag.AllValues(flag) {
+			if strings.ContainsAny(value, " \t") {
+				value = strconv.Quote(value)
+			}
+			query += fmt.Sprintf(" %s:%s", qualifiers[flag], value)
+		}
+	}
+	return strings.TrimSpace(query)
+}
+
+func searchCode(cmd *Command, args *Args) {
+	query := searchQuery(args, map[string]string{
+		"--repo":     "repo",
+		"--org":      "org",
+		"--language": "language",
+	})
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	gh := github.NewClient(host.Host)
+
+	limit := searchLimit(args)
+	openIndex := 0
+	if args.Flag.HasReceived("--open") {
+		openIndex = args.Flag.Int("--open")
+		if openIndex < 1 {
+			utils.Check(fmt.Errorf("error: invalid result number: %s", args.Flag.Value("--open")))
+		}
+		if openIndex > limit {
+			limit = openIndex
+		}
+	}
+
+	if args.Noop {
+		args.NoForward()
+		ui.Printf("Would search code for %q\n", query)
+		return
+	}
+
+	results, err := gh.SearchCode(map[string]interface{}{"q": query}, limit)
+	utils.Check(err)
+
+	if openIndex > 0 {
+		if openIndex > len(results) {
+			utils.Check(fmt.Errorf("error: there are only %d results", len(results)))
+		}
+		printBrowseOrCopy(args, results[openIndex-1].HTMLURL, true, false)
+		return
+	}
+
+	args.NoForward()
+	for i, result := range results {
+		if i > 0 {
+			ui.Println()
+		}
+		repoName := ""
+		var project *github.Project
+		if result.Repository != nil {
+			repoName = result.Repository.FullName
+			if result.Repository.Owner != nil {
+				project = github.NewProject(result.Repository.Owner.Login, result.Repository.Name, host.Host)
+			}
+		}
+		ui.Printf("%s %s\n", repoName, result.Path)
+
+		if len(result.TextMatches) == 0 {
+			continue
+		}
+		var contents string
+		if project != nil && result.SHA != "" {
+			if blob, err := gh.FetchBlob(project, result.SHA); err == nil {
+				contents = string(blob)
+			}
+		}
+		for _, match := range result.TextMatches {
+			for _, line := range numberFragment(contents, match.Fragment) {
+				ui.Println(line)
+			}
+		}
+	}
+}
+
+// numberFragment prefixes each line of a search result fragment with its line
+// number in the file contents. If the fragment can't be located in contents,
+// the lines are only indented.
+func numberFragment(contents, fragment string) []string {
+	lines := strings.Split(strings.TrimRight(fragment, "\n"), "\n")
+
+	start := 0
+	if i := strings.Index(contents, fragment); contents != "" && i >= 0 {
+		start = strings.Count(contents[:i], "\n") + 1
+		// fragments may begin in the middle of a line
+		if i > 0 && contents[i-1] != '\n' {
+			lines[0] = contents[strings.LastIndex(contents[:i], "\n")+1:i] + lines[0]
+		}
+	}
+
+	numbered := make([]string, len(lines))
+	for i, line := range lines {
+		if start > 0 {
+			numbered[i] = fmt.Sprintf("%5d: %s", start+i, line)
+		} else {
+			numbered[i] = fmt.Sprintf("       %s", line)
+		}
+	}
+	return numbered
+}
+
+// searchParams builds the query string parameters for sorted searches.
+func searchParams(args *Args, query string) map[string]interface{} {
+	params := map[string]interface{}{
+		"q": query,
+	}
+	if args.Flag.HasReceived("--sort") {
+		params["sort"] = args.Flag.Value("--sort")
+	}
+	if args.Flag.HasReceived("--order") {
+		params["order"] = args.Flag.Value("--order")
+	}
+	return params
+}
+
+func searchLimit(args *Args) int {
+	if args.Flag.HasReceived("--limit") {
+		return args.Flag.Int("--limit")
+	}
+	return 30
+}
+
+func searchRepos(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	query := searchQuery(args, map[string]string{
+		"--org":      "org",
+		"--language": "language",
+	})
+
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	gh := github.NewClient(host.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would search repositories for %q\n", query)
+		return
+	}
+
+	repos, err := gh.SearchRepositories(searchParams(args, query), searchLimit(args))
+	utils.Check(err)
+
+	format := "%<(40)%R %>(6)%Ns  %d%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, repo := range repos {
+		ui.Print(formatSearchRepo(repo, format, colorize))
+	}
+}
+
+func formatSearchRepo(repo github.Repository, format string, colorize bool) string {
+	visibility := "public"
+	if repo.Private {
+		visibility = "private"
+	}
+
+	var updatedDate, updatedAtISO8601, updatedAtRelative string
+	if !repo.UpdatedAt.IsZero() {
+		updatedDate = repo.UpdatedAt.Format("02 Jan 2006")
+		updatedAtISO8601 = repo.UpdatedAt.Format(time.RFC3339)
+		updatedAtRelative = utils.TimeAgo(repo.UpdatedAt)
+	}
+
+	placeholders := map[string]string{
+		"R":  repo.FullName,
+		"d":  repo.Description,
+		"l":  repo.Language,
+		"U":  repo.HTMLURL,
+		"V":  visibility,
+		"Ns": strconv.Itoa(repo.StargazersCount),
+		"Nf": strconv.Itoa(repo.ForksCount),
+		"Ni": strconv.Itoa(repo.OpenIssuesCount),
+		"uD": updatedDate,
+		"uI": updatedAtISO8601,
+		"ur": updatedAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func searchUsers(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	query := searchQuery(args, map[string]string{})
+
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	gh := github.NewClient(host.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would search users for %q\n", query)
+		return
+	}
+
+	users, err := gh.SearchUsers(searchParams(args, query), searchLimit(args))
+	utils.Check(err)
+
+	format := "%<(20)%l %t%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, user := range users {
+		placeholders := map[string]string{
+			"l": user.Login,
+			"t": user.Type,
+			"U": user.HTMLURL,
+		}
+		ui.Print(ui.Expand(format, placeholders, colorize))
+	}
+}
+
+func searchCommits(cmd *Command, args *Args) {
+	if args.IsParamsEmpty() {
+		utils.Check(cmd.UsageError(""))
+	}
+	query := searchQuery(args, map[string]string{
+		"--repo":   "repo",
+		"--org":    "org",
+		"--author": "author",
+	})
+
+	host, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	gh := github.NewClient(host.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would search commits for %q\n", query)
+		return
+	}
+
+	commits, err := gh.SearchCommits(searchParams(args, query), searchLimit(args))
+	utils.Check(err)
+
+	format := "%R %h  %s%n"
+	if args.Flag.HasReceived("--format") {
+		format = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, commit := range commits {
+		ui.Print(formatSearchCommit(commit, format, colorize))
+	}
+}
+
+func formatSearchCommit(commit github.Commit, format string, colorize bool) string {
+	shortSHA := commit.SHA
+	if len(shortSHA) > 7 {
+		shortSHA = shortSHA[:7]
+	}
+
+	subject := strings.SplitN(commit.Commit.Message, "\n", 2)[0]
+
+	authorLogin := ""
+	if commit.Author != nil {
+		authorLogin = commit.Author.Login
+	}
+
+	repoName := ""
+	if commit.Repository != nil {
+		repoName = commit.Repository.FullName
+	}
+
+	var authorDate, authorDateISO8601, authorDateRelative string
+	if date := commit.Commit.Author.Date; !date.IsZero() {
+		authorDate = date.Format("02 Jan 2006")
+		authorDateISO8601 = date.Format(time.RFC3339)
+		authorDateRelative = utils.TimeAgo(date)
+	}
+
+	placeholders := map[string]string{
+		"H":  commit.SHA,
+		"h":  shortSHA,
+		"s":  subject,
+		"an": commit.Commit.Author.Name,
+		"al": authorLogin,
+		"aD": authorDate,
+		"aI": authorDateISO8601,
+		"ar": authorDateRelative,
+		"R":  repoName,
+		"U":  commit.HTMLURL,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
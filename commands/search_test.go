@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestSearchQuery(t *testing.T) {
+	args := NewArgs([]string{"code", "-l", "go", "--org", "github", "-r", "github/hub", "func", "NewClient"})
+	assert.Equal(t, nil, cmdSearchCode.parseArguments(args))
+
+	query := searchQuery(args, map[string]string{
+		"--repo":     "repo",
+		"--org":      "org",
+		"--language": "language",
+	})
+	assert.Equal(t, "func NewClient language:go org:github repo:github/hub", query)
+
+	args = NewArgs([]string{"code", "-l", "Emacs Lisp", "defun"})
+	assert.Equal(t, nil, cmdSearchCode.parseArguments(args))
+	assert.Equal(t, `defun language:"Emacs Lisp"`, searchQuery(args, map[string]string{"--language": "language"}))
+}
+
+func TestNumberFragment(t *testing.T) {
+	contents := "package main\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n"
+
+	assert.Equal(t, []string{
+		"    3: func main() {",
+		"    4: \tfmt.Println(\"hi\")",
+	}, numberFragment(contents, "func main() {\n\tfmt.Println(\"hi\")\n"))
+
+	assert.Equal(t, []string{
+		"    3: func main() {",
+	}, numberFragment(contents, "main() {"))
+
+	assert.Equal(t, []string{
+		"       not found",
+	}, numberFragment(contents, "not found"))
+
+	assert.Equal(t, []string{
+		"       func main() {",
+	}, numberFragment("", "func main() {"))
+}
+
+func TestFormatSearchRepo(t *testing.T) {
+	repo := github.Repository{
+		FullName:        "github/hub",
+		Description:     "A command-line tool",
+		Language:        "Go",
+		StargazersCount: 22000,
+		ForksCount:      2200,
+	}
+	assert.Equal(t, "github/hub                                22000  A command-line tool\n",
+		formatSearchRepo(repo, "%<(40)%R %>(6)%Ns  %d%n", false))
+	assert.Equal(t, "Go public 2200", formatSearchRepo(repo, "%l %V %Nf", false))
+}
+
+func TestFormatSearchCommit(t *testing.T) {
+	commit := github.Commit{
+		SHA:        "abc123def456",
+		Author:     &github.User{Login: "mislav"},
+		Repository: &github.Repository{FullName: "github/hub"},
+	}
+	commit.Commit.Message = "Fix typo\n\nDetails"
+	commit.Commit.Author.Name = "Mislav"
+
+	assert.Equal(t, "github/hub abc123d  Fix typo\n", formatSearchCommit(commit, "%R %h  %s%n", false))
+	assert.Equal(t, "Mislav (mislav) abc123def456", formatSearchCommit(commit, "%an (%al) %H", false))
+}
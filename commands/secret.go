@@ -0,0 +1,312 @@
+package commands
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/nacl/box"
+)
+
+var (
+	cmdSecret = &Command{
+		Run: printHelp,
+		Usage: `
+secret list [--env <ENVIRONMENT> | --org]
+secret set [-b <VALUE> | -F <FILE>] [--env <ENVIRONMENT> | --org [--visibility <VISIBILITY>]] <NAME>
+secret remove [--env <ENVIRONMENT> | --org] <NAME>
+`,
+		Long: `Manage GitHub Actions secrets for the current repository.
+
+## Commands:
+
+	* _list_:
+		List the names of secrets and when they were last updated. Secret values
+		can never be read back.
+
+	* _set_:
+		Create or update the secret <NAME>. The value is encrypted locally with the
+		public key of the repository, environment, or organization before being
+		sent to GitHub. Without ''--body'' or ''--file'', the value is read from
+		standard input.
+
+	* _remove_:
+		Delete the secret <NAME>.
+
+## Options:
+	-b, --body <VALUE>
+		Use <VALUE> as the secret value.
+
+	-F, --file <FILE>
+		Read the secret value from <FILE>. Pass "-" to read from standard input.
+
+	-e, --env <ENVIRONMENT>
+		Manage secrets of the deployment environment <ENVIRONMENT> instead of
+		repository secrets.
+
+	--org
+		Manage secrets of the organization that owns the current repository.
+
+	--visibility <VISIBILITY>
+		Which repositories of the organization can access the secret: "all",
+		"private" (default), or "selected".
+
+## Examples:
+		$ hub secret set NPM_TOKEN < token.txt
+		$ hub secret set -e production -b hunter2 DEPLOY_PASSWORD
+		$ hub secret list --org
+
+## See also:
+
+hub-variable(1), hub(1)
+`,
+	}
+
+	cmdListSecrets = &Command{
+		Key: "list",
+		Run: listSecrets,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		--org
+`,
+	}
+
+	cmdSetSecret = &Command{
+		Key: "set",
+		Run: setSecret,
+		KnownFlags: `
+		-b, --body VALUE
+		-F, --file FILE
+		-e, --env ENVIRONMENT
+		--org
+		--visibility VISIBILITY
+`,
+	}
+
+	cmdRemoveSecret = &Command{
+		Key: "remove",
+		Run: removeSecret,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		--org
+`,
+	}
+)
+
+func init() {
+	cmdSecret.Use(cmdListSecrets)
+	cmdSecret.Use(cmdSetSecret)
+	cmdSecret.Use(cmdRemoveSecret)
+	CmdRunner.Use(cmdSecret)
+}
+
+// actionsPrefix returns the API path under which secrets and variables are
+// managed, along with a human-readable description of that scope.
+func actionsPrefix(project *github.Project, args *Args) (prefix, scope string, err error) {
+	if args.Flag.Bool("--org") {
+		if args.Flag.HasReceived("--env") {
+			err = fmt.Errorf("error: --env and --org are mutually exclusive")
+			return
+		}
+		return fmt.Sprintf("orgs/%s/actions", project.Owner), project.Owner, nil
+	}
+
+	repoPath := fmt.Sprintf("repos/%s/%s", project.Owner, project.Name)
+	if env := args.Flag.Value("--env"); env != "" {
+		return fmt.Sprintf("%s/environments/%s", repoPath, url.PathEscape(env)), fmt.Sprintf("%s (%s)", project, env), nil
+	}
+	return repoPath + "/actions", project.String(), nil
+}
+
+func listSecrets(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of secrets for %s\n", scope)
+		return
+	}
+
+	secrets, err := gh.FetchActionsSecrets(prefix, "secrets")
+	utils.Check(err)
+
+	ui.Print(formatActionsSecrets(secrets, func(s github.ActionsSecret) string {
+		updated := "Updated " + s.UpdatedAt.Format("2006-01-02")
+		if s.Visibility != "" {
+			updated += fmt.Sprintf(" (%s)", s.Visibility)
+		}
+		return updated
+	}))
+}
+
+// formatActionsSecrets prints one secret or variable per line, with details
+// aligned in a column after the longest name.
+func formatActionsSecrets(secrets []github.ActionsSecret, details func(github.ActionsSecret) string) string {
+	width := 0
+	for _, s := range secrets {
+		if len(s.Name) > width {
+			width = len(s.Name)
+		}
+	}
+
+	out := ""
+	for _, s := range secrets {
+		out += fmt.Sprintf("%-*s  %s\n", width, s.Name, details(s))
+	}
+	return out
+}
+
+// actionsValueFromArgs reads the value of a secret or a variable from the
+// --body or --file flags, falling back to standard input.
+func actionsValueFromArgs(args *Args) (value string, err error) {
+	if args.Flag.HasReceived("--body") {
+		return args.Flag.Value("--body"), nil
+	}
+
+	filename := "-"
+	if args.Flag.HasReceived("--file") {
+		filename = args.Flag.Value("--file")
+	}
+	value, err = msgFromFile(filename)
+	if err == nil {
+		value = strings.TrimSuffix(value, "\n")
+	}
+	return
+}
+
+func setSecret(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set secret %s for %s\n", name, scope)
+		return
+	}
+
+	value, err := actionsValueFromArgs(args)
+	utils.Check(err)
+
+	publicKey, err := gh.FetchActionsPublicKey(prefix)
+	utils.Check(err)
+
+	encrypted, err := encryptSecret(publicKey.Key, value, rand.Reader)
+	utils.Check(err)
+
+	params := map[string]interface{}{
+		"encrypted_value": encrypted,
+		"key_id":          publicKey.KeyID,
+	}
+	if args.Flag.Bool("--org") {
+		visibility := "private"
+		if args.Flag.HasReceived("--visibility") {
+			visibility = args.Flag.Value("--visibility")
+		}
+		params["visibility"] = visibility
+	}
+
+	utils.Check(gh.SetActionsSecret(prefix, name, params))
+}
+
+// encryptSecret seals value for the base64-encoded Curve25519 public key
+// using a libsodium-compatible sealed box and returns the base64-encoded
+// result, as expected by the GitHub Actions secrets API.
+func encryptSecret(publicKey, value string, random io.Reader) (string, error) {
+	keyBytes, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(keyBytes) != 32 {
+		return "", fmt.Errorf("error: invalid public key for encrypting secrets")
+	}
+	var recipientKey [32]byte
+	copy(recipientKey[:], keyBytes)
+
+	sealed, err := sealAnonymous([]byte(value), &recipientKey, random)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// sealAnonymous implements libsodium's crypto_box_seal: the message is
+// encrypted with an ephemeral key pair whose public half is prepended to the
+// ciphertext, and the nonce is derived from both public keys.
+func sealAnonymous(message []byte, recipientKey *[32]byte, random io.Reader) ([]byte, error) {
+	ephemeralPublic, ephemeralPrivate, err := box.GenerateKey(random)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := sealedBoxNonce(ephemeralPublic, recipientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return box.Seal(ephemeralPublic[:], message, nonce, recipientKey, ephemeralPrivate), nil
+}
+
+func sealedBoxNonce(ephemeralPublic, recipientKey *[32]byte) (*[24]byte, error) {
+	h, err := blake2b.New(24, nil)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(ephemeralPublic[:])
+	h.Write(recipientKey[:])
+
+	var nonce [24]byte
+	copy(nonce[:], h.Sum(nil))
+	return &nonce, nil
+}
+
+func removeSecret(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove secret %s from %s\n", name, scope)
+		return
+	}
+
+	utils.Check(gh.DeleteActionsSecret(prefix, "secrets", name))
+}
@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdSecretScanning = &Command{
+		Run: printHelp,
+		Usage: `
+secret-scanning list [-s <STATE>] [--secret-type <TYPE>] [-f <FORMAT>] [--color]
+secret-scanning resolve -R <REASON> [-m <COMMENT>] <NUMBER>
+`,
+		Long: `Triage secret scanning alerts for the current repository.
+
+## Commands:
+
+	* _list_:
+		List alerts about secrets that were committed to the repository.
+
+	* _resolve_:
+		Resolve the secret scanning alert <NUMBER>.
+
+## Options:
+	-s, --state <STATE>
+		Filter alerts by state: "open" (default), "resolved", or "all".
+
+	--secret-type <TYPE>
+		Only list alerts for secrets of type <TYPE>. Multiple types can be
+		separated by commas.
+
+	-R, --reason <REASON>
+		How the alert was resolved: "revoked", "false-positive", "wont-fix", or
+		"used-in-tests".
+
+	-m, --message <COMMENT>
+		Explain how the alert was resolved.
+
+	-f, --format <FORMAT>
+		Pretty print the list of alerts using format <FORMAT> (default:
+		"%>(6)%I  %<(9)%S  %t%n"). See the "PRETTY FORMATS" section of git-log(1)
+		for some additional details on how placeholders are used in format. The
+		available placeholders are:
+
+		%I: alert number
+
+		%U: the URL of this alert
+
+		%S: state (i.e. "open", "resolved")
+
+		%t: human-readable type of the secret
+
+		%T: type of the secret
+
+		%R: resolution
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+## Examples:
+		$ hub secret-scanning list
+		$ hub secret-scanning resolve -R revoked -m "Rotated" 3
+
+## See also:
+
+hub-code-scanning(1), hub-security(1), hub(1)
+`,
+	}
+
+	cmdListSecretScanningAlerts = &Command{
+		Key: "list",
+		Run: listSecretScanningAlerts,
+		KnownFlags: `
+		-s, --state STATE
+		--secret-type TYPE
+		-f, --format FMT
+		--color
+`,
+	}
+
+	cmdResolveSecretScanningAlert = &Command{
+		Key: "resolve",
+		Run: resolveSecretScanningAlert,
+		KnownFlags: `
+		-R, --reason REASON
+		-m, --message COMMENT
+`,
+	}
+
+	secretScanningResolutions = map[string]string{
+		"revoked":        "revoked",
+		"false-positive": "false_positive",
+		"wont-fix":       "wont_fix",
+		"used-in-tests":  "used_in_tests",
+	}
+)
+
+func init() {
+	cmdSecretScanning.Use(cmdListSecretScanningAlerts)
+	cmdSecretScanning.Use(cmdResolveSecretScanningAlert)
+	CmdRunner.Use(cmdSecretScanning)
+}
+
+func listSecretScanningAlerts(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of secret scanning alerts for %s\n", project)
+		return
+	}
+
+	filters := map[string]interface{}{}
+	state := "open"
+	if args.Flag.HasReceived("--state") {
+		state = args.Flag.Value("--state")
+	}
+	if state != "all" {
+		filters["state"] = state
+	}
+	if args.Flag.HasReceived("--secret-type") {
+		filters["secret_type"] = args.Flag.Value("--secret-type")
+	}
+
+	alerts, err := gh.FetchSecretScanningAlerts(project, filters)
+	utils.Check(err)
+
+	flagAlertFormat := "%>(6)%I  %<(9)%S  %t%n"
+	if args.Flag.HasReceived("--format") {
+		flagAlertFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, alert := range alerts {
+		ui.Print(formatSecretScanningAlert(alert, flagAlertFormat, colorize))
+	}
+}
+
+func formatSecretScanningAlert(alert github.SecretScanningAlert, format string, colorize bool) string {
+	displayName := alert.SecretTypeDisplayName
+	if displayName == "" {
+		displayName = alert.SecretType
+	}
+
+	var createdDate, createdAtISO8601, createdAtRelative string
+	if !alert.CreatedAt.IsZero() {
+		createdDate = alert.CreatedAt.Format("02 Jan 2006")
+		createdAtISO8601 = alert.CreatedAt.Format(time.RFC3339)
+		createdAtRelative = utils.TimeAgo(alert.CreatedAt)
+	}
+
+	placeholders := map[string]string{
+		"I":  strconv.Itoa(alert.Number),
+		"U":  alert.HTMLURL,
+		"S":  alert.State,
+		"t":  displayName,
+		"T":  alert.SecretType,
+		"R":  alert.Resolution,
+		"cD": createdDate,
+		"cI": createdAtISO8601,
+		"cr": createdAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func resolveSecretScanningAlert(cmd *Command, args *Args) {
+	alertNumber := 0
+	if args.ParamsSize() > 0 {
+		alertNumber, _ = strconv.Atoi(strings.TrimPrefix(args.GetParam(0), "#"))
+	}
+	if alertNumber == 0 || !args.Flag.HasReceived("--reason") {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	resolution, err := alertReason(args.Flag.Value("--reason"), secretScanningResolutions)
+	utils.Check(err)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would resolve secret scanning alert %d in %s\n", alertNumber, project)
+		return
+	}
+
+	params := map[string]interface{}{
+		"state":      "resolved",
+		"resolution": resolution,
+	}
+	if args.Flag.HasReceived("--message") {
+		params["resolution_comment"] = args.Flag.Value("--message")
+	}
+
+	utils.Check(gh.UpdateSecretScanningAlert(project, alertNumber, params))
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	recipientPublic, recipientPrivate, err := box.GenerateKey(rand.Reader)
+	assert.Equal(t, nil, err)
+
+	encrypted, err := encryptSecret(base64.StdEncoding.EncodeToString(recipientPublic[:]), "hunter2", rand.Reader)
+	assert.Equal(t, nil, err)
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 32+box.Overhead+len("hunter2"), len(sealed))
+
+	var ephemeralPublic [32]byte
+	copy(ephemeralPublic[:], sealed[:32])
+	nonce, err := sealedBoxNonce(&ephemeralPublic, recipientPublic)
+	assert.Equal(t, nil, err)
+
+	decrypted, ok := box.Open(nil, sealed[32:], nonce, &ephemeralPublic, recipientPrivate)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "hunter2", string(decrypted))
+}
+
+func TestEncryptSecret_InvalidKey(t *testing.T) {
+	_, err := encryptSecret("bm90IGEga2V5", "hunter2", rand.Reader)
+	assert.Equal(t, "error: invalid public key for encrypting secrets", err.Error())
+}
+
+func TestFormatActionsSecrets(t *testing.T) {
+	secrets := []github.ActionsSecret{
+		{Name: "NPM_TOKEN", Value: "a"},
+		{Name: "GH", Value: "b"},
+	}
+	out := formatActionsSecrets(secrets, func(s github.ActionsSecret) string { return s.Value })
+	assert.Equal(t, "NPM_TOKEN  a\nGH         b\n", out)
+}
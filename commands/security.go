@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdSecurity = &Command{
+		Run: printHelp,
+		Usage: `
+security alerts [-s <STATE>] [--severity <SEVERITY>] [-f <FORMAT> | --json] [--color]
+`,
+		Long: `Inspect security alerts for the current repository.
+
+## Commands:
+
+	* _alerts_:
+		List Dependabot alerts about vulnerable dependencies.
+
+## Options:
+	-s, --state <STATE>
+		Filter alerts by state: "open" (default), "fixed", "dismissed",
+		"auto_dismissed", or "all".
+
+	--severity <SEVERITY>
+		Only list alerts with the given severity: "low", "medium", "high", or
+		"critical". Multiple severities can be separated by commas.
+
+	--json
+		Print alerts as a JSON array instead of formatted text.
+
+	-f, --format <FORMAT>
+		Pretty print the list of alerts using format <FORMAT> (default:
+		"%sC%>(6)%I%Creset  %<(8)%sV  %p  %t%n"). See the "PRETTY FORMATS" section
+		of git-log(1) for some additional details on how placeholders are used in
+		format. The available placeholders are:
+
+		%I: alert number
+
+		%U: the URL of this alert
+
+		%S: state (i.e. "open", "fixed", "dismissed")
+
+		%sV: severity
+
+		%sC: set color to red, yellow, or default depending on severity
+
+		%t: advisory summary
+
+		%G: GHSA identifier of the advisory
+
+		%V: CVE identifier of the advisory
+
+		%p: name of the vulnerable package
+
+		%e: package ecosystem (e.g. "npm", "pip")
+
+		%m: path to the manifest that declares the dependency
+
+		%r: vulnerable version range
+
+		%P: first patched version
+
+		%cD: created date-only (no time of day)
+
+		%cr: created date, relative
+
+		%cI: created date, ISO 8601 format
+
+		%n: newline
+
+		%%: a literal %
+
+	--color[=<WHEN>]
+		Enable colored output even if stdout is not a terminal. <WHEN> can be one
+		of "always" (default for ''--color''), "never", or "auto" (default).
+
+## Examples:
+		$ hub security alerts --severity high,critical
+		$ hub security alerts --json | jq '.[].number'
+
+## Notes:
+
+The access token used by hub must include the "security_events" scope to read
+alerts of private repositories.
+
+## See also:
+
+hub-code-scanning(1), hub-secret-scanning(1), hub(1)
+`,
+	}
+
+	cmdSecurityAlerts = &Command{
+		Key: "alerts",
+		Run: listSecurityAlerts,
+		KnownFlags: `
+		-s, --state STATE
+		--severity SEVERITY
+		--json
+		-f, --format FMT
+		--color
+`,
+	}
+)
+
+func init() {
+	cmdSecurity.Use(cmdSecurityAlerts)
+	CmdRunner.Use(cmdSecurity)
+}
+
+func listSecurityAlerts(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of Dependabot alerts for %s\n", project)
+		return
+	}
+
+	filters := map[string]interface{}{}
+	state := "open"
+	if args.Flag.HasReceived("--state") {
+		state = args.Flag.Value("--state")
+	}
+	if state != "all" {
+		filters["state"] = state
+	}
+	if args.Flag.HasReceived("--severity") {
+		filters["severity"] = strings.ToLower(args.Flag.Value("--severity"))
+	}
+
+	alerts, err := gh.FetchDependabotAlerts(project, filters)
+	utils.Check(err)
+
+	if args.Flag.Bool("--json") {
+		out, err := json.MarshalIndent(alerts, "", "  ")
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+
+	flagAlertFormat := "%sC%>(6)%I%Creset  %<(8)%sV  %p  %t%n"
+	if args.Flag.HasReceived("--format") {
+		flagAlertFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(args.Flag.HasReceived("--color"), args.Flag.Value("--color"))
+	for _, alert := range alerts {
+		ui.Print(formatDependabotAlert(alert, flagAlertFormat, colorize))
+	}
+}
+
+// severityColor returns the terminal color code used for an alert severity,
+// or 0 for no color.
+func severityColor(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical", "high", "error":
+		return 31
+	case "medium", "moderate", "warning":
+		return 33
+	default:
+		return 0
+	}
+}
+
+func formatDependabotAlert(alert github.DependabotAlert, format string, colorize bool) string {
+	var severityColorSwitch string
+	if colorize {
+		if color := severityColor(alert.SecurityAdvisory.Severity); color > 0 {
+			severityColorSwitch = fmt.Sprintf("\033[%dm", color)
+		}
+	}
+
+	patched := ""
+	if alert.SecurityVulnerability.FirstPatchedVersion != nil {
+		patched = alert.SecurityVulnerability.FirstPatchedVersion.Identifier
+	}
+
+	var createdDate, createdAtISO8601, createdAtRelative string
+	if !alert.CreatedAt.IsZero() {
+		createdDate = alert.CreatedAt.Format("02 Jan 2006")
+		createdAtISO8601 = alert.CreatedAt.Format(time.RFC3339)
+		createdAtRelative = utils.TimeAgo(alert.CreatedAt)
+	}
+
+	placeholders := map[string]string{
+		"I":  strconv.Itoa(alert.Number),
+		"U":  alert.HTMLURL,
+		"S":  alert.State,
+		"sV": alert.SecurityAdvisory.Severity,
+		"sC": severityColorSwitch,
+		"t":  alert.SecurityAdvisory.Summary,
+		"G":  alert.SecurityAdvisory.GHSAID,
+		"V":  alert.SecurityAdvisory.CVEID,
+		"p":  alert.Dependency.Package.Name,
+		"e":  alert.Dependency.Package.Ecosystem,
+		"m":  alert.Dependency.ManifestPath,
+		"r":  alert.SecurityVulnerability.VulnerableVersionRange,
+		"P":  patched,
+		"cD": createdDate,
+		"cI": createdAtISO8601,
+		"cr": createdAtRelative,
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
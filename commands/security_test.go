@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatDependabotAlert(t *testing.T) {
+	alert := github.DependabotAlert{Number: 7, State: "open"}
+	alert.Dependency.Package.Name = "lodash"
+	alert.Dependency.Package.Ecosystem = "npm"
+	alert.SecurityAdvisory.Severity = "high"
+	alert.SecurityAdvisory.Summary = "Prototype pollution"
+	alert.SecurityVulnerability.VulnerableVersionRange = "< 4.17.21"
+
+	assert.Equal(t, "     7  high      lodash  Prototype pollution\n",
+		formatDependabotAlert(alert, "%sC%>(6)%I%Creset  %<(8)%sV  %p  %t%n", false))
+	assert.Equal(t, "\033[31m7\033[m high",
+		formatDependabotAlert(alert, "%sC%I%Creset %sV", true))
+	assert.Equal(t, "npm lodash < 4.17.21 ()",
+		formatDependabotAlert(alert, "%e %p %r (%P)", false))
+}
+
+func TestSeverityColor(t *testing.T) {
+	assert.Equal(t, 31, severityColor("CRITICAL"))
+	assert.Equal(t, 33, severityColor("medium"))
+	assert.Equal(t, 0, severityColor("low"))
+}
@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdActionsRunner = &Command{
+		Run: printHelp,
+		Usage: `
+runner list [--org] [-f <FORMAT>]
+runner token [--org] [--remove]
+runner remove [--org] <RUNNER>
+`,
+		Long: `Manage self-hosted GitHub Actions runners of the current repository.
+
+## Commands:
+
+	* _list_:
+		List runners with their status and labels.
+
+	* _token_:
+		Print a token for registering a new runner with ''config.sh --token''.
+		The token expires after an hour.
+
+	* _remove_:
+		Remove the runner <RUNNER>, given by its ID or name.
+
+## Options:
+	--org
+		Manage runners of the organization that owns the current repository.
+
+	--remove
+		For ''token'', print a token for removing a runner with
+		''config.sh remove --token'' instead.
+
+	-f, --format <FORMAT>
+		Pretty print runners using format <FORMAT> (default:
+		"%>(10)%I  %<(24)%N  %<(8)%S  %L%n"). See the "PRETTY FORMATS" section of
+		git-log(1) for some additional details on how placeholders are used in
+		format. The available placeholders are:
+
+		%I: runner ID
+
+		%N: runner name
+
+		%S: status ("online", "busy", or "offline")
+
+		%o: operating system
+
+		%L: comma-separated labels
+
+## Examples:
+		$ hub runner list --org
+		$ ./config.sh --url https://github.com/OWNER/REPO --token "$(hub runner token)"
+		$ hub runner remove build-03
+
+## See also:
+
+hub-secret(1), hub(1)
+`,
+	}
+
+	cmdListActionsRunners = &Command{
+		Key: "list",
+		Run: listActionsRunners,
+		KnownFlags: `
+		--org
+		-f, --format FMT
+`,
+	}
+
+	cmdActionsRunnerToken = &Command{
+		Key: "token",
+		Run: actionsRunnerToken,
+		KnownFlags: `
+		--org
+		--remove
+`,
+	}
+
+	cmdRemoveActionsRunner = &Command{
+		Key: "remove",
+		Run: removeActionsRunner,
+		KnownFlags: `
+		--org
+`,
+	}
+)
+
+func init() {
+	cmdActionsRunner.Use(cmdListActionsRunners)
+	cmdActionsRunner.Use(cmdActionsRunnerToken)
+	cmdActionsRunner.Use(cmdRemoveActionsRunner)
+	CmdRunner.Use(cmdActionsRunner)
+}
+
+func listActionsRunners(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of runners for %s\n", scope)
+		return
+	}
+
+	runners, err := gh.FetchActionsRunners(prefix)
+	utils.Check(err)
+
+	flagRunnerFormat := "%>(10)%I  %<(24)%N  %<(8)%S  %L%n"
+	if args.Flag.HasReceived("--format") {
+		flagRunnerFormat = args.Flag.Value("--format")
+	}
+
+	colorize := colorizeOutput(false, "")
+	for _, r := range runners {
+		ui.Print(formatActionsRunner(r, flagRunnerFormat, colorize))
+	}
+}
+
+func formatActionsRunner(r github.ActionsRunner, format string, colorize bool) string {
+	status := r.Status
+	if status == "online" && r.Busy {
+		status = "busy"
+	}
+
+	labels := []string{}
+	for _, label := range r.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	placeholders := map[string]string{
+		"I": strconv.Itoa(r.ID),
+		"N": r.Name,
+		"S": status,
+		"o": r.OS,
+		"L": strings.Join(labels, ", "),
+	}
+
+	return ui.Expand(format, placeholders, colorize)
+}
+
+func actionsRunnerToken(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	kind := "registration"
+	if args.Flag.Bool("--remove") {
+		kind = "remove"
+	}
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create a runner %s token for %s\n", kind, scope)
+		return
+	}
+
+	token, err := gh.CreateActionsRunnerToken(prefix, kind)
+	utils.Check(err)
+
+	ui.Println(token.Token)
+}
+
+func removeActionsRunner(cmd *Command, args *Args) {
+	if args.ParamsSize() != 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	runner := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove runner %s from %s\n", runner, scope)
+		return
+	}
+
+	runnerID, err := strconv.Atoi(runner)
+	if err != nil {
+		runners, err := gh.FetchActionsRunners(prefix)
+		utils.Check(err)
+		runnerID = findActionsRunner(runners, runner)
+		if runnerID == 0 {
+			utils.Check(fmt.Errorf("error: no runner named '%s' in %s", runner, scope))
+		}
+	}
+
+	utils.Check(gh.DeleteActionsRunner(prefix, runnerID))
+}
+
+// findActionsRunner returns the ID of the runner with the given name, or 0 if
+// there is none.
+func findActionsRunner(runners []github.ActionsRunner, name string) int {
+	for _, r := range runners {
+		if strings.EqualFold(r.Name, name) {
+			return r.ID
+		}
+	}
+	return 0
+}
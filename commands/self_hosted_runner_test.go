@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFormatActionsRunner(t *testing.T) {
+	var runners []github.ActionsRunner
+	err := json.Unmarshal([]byte(`[
+		{"id": 21, "name": "build-01", "os": "linux", "status": "online", "busy": true,
+		 "labels": [{"name": "self-hosted"}, {"name": "linux"}]},
+		{"id": 22, "name": "build-02", "os": "macos", "status": "online", "busy": false, "labels": []},
+		{"id": 23, "name": "build-03", "os": "linux", "status": "offline", "busy": false, "labels": []}
+	]`), &runners)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "21 build-01 busy linux self-hosted, linux", formatActionsRunner(runners[0], "%I %N %S %o %L", false))
+	assert.Equal(t, "online", formatActionsRunner(runners[1], "%S", false))
+	assert.Equal(t, "offline", formatActionsRunner(runners[2], "%S", false))
+
+	assert.Equal(t, 23, findActionsRunner(runners, "Build-03"))
+	assert.Equal(t, 0, findActionsRunner(runners, "build-04"))
+}
@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdStatus = &Command{
+		Run:          status,
+		GitExtension: true,
+		Usage: `
+status --github
+status create -s <STATE> [-c <CONTEXT>] [-u <URL>] [-d <DESCRIPTION>] [<COMMIT>]
+`,
+		Long: `Show an overview of the current repository on GitHub, or set commit statuses.
+
+## Commands:
+
+	* _create_:
+		Set the status of <COMMIT> (default: HEAD) for a context, e.g. to report
+		the outcome of a deployment script. Outside of a git repository, pass the
+		full SHA of <COMMIT> and the repository with the global ''--repo'' flag.
+
+## Options:
+	--github
+		Instead of the working tree status, show the current branch compared to
+		its upstream, the pull request for the current branch and the state of
+		its checks, open issues assigned to you, pull requests awaiting your
+		review, and unread notifications for the repository.
+
+	-s, --state <STATE>
+		One of "pending", "success", "failure", or "error".
+
+	-c, --context <CONTEXT>
+		The name that distinguishes this status from the statuses of other
+		systems, e.g. "deploy/prod" (default: "default").
+
+	-u, --target-url <URL>
+		The URL of the page with details about the status.
+
+	-d, --description <DESCRIPTION>
+		A short description of the status.
+
+## Examples:
+		$ hub status --github
+		Branch: feature (ahead 2 of origin/feature)
+
+		Pull request:
+		  #12  Add feature (success, 3 checks)
+
+		Assigned issues:
+		  #5  Crash on startup
+
+		Review requests:
+		  #9  Refactor config loading (octocat)
+
+		Unread notifications:
+		  PullRequest  Refactor config loading (review_requested)
+
+		$ hub status create -s success -c deploy/prod -u "$DEPLOY_URL" "$GIT_COMMIT"
+
+## Notes:
+
+Without ''--github'' or ''create'', ''hub status'' runs git-status(1) as usual so
+that it keeps working when git is aliased to hub. To show the working tree
+status of a file named "create", use ''git status -- create''.
+
+## See also:
+
+hub-pr(1), hub-issue(1), hub-ci-status(1), hub-check-run(1), hub(1), git-status(1)
+`,
+	}
+
+	cmdCreateStatus = &Command{
+		Key:   "create",
+		Run:   createStatus,
+		Usage: "status create -s <STATE> [-c <CONTEXT>] [-u <URL>] [-d <DESCRIPTION>] [<COMMIT>]",
+		KnownFlags: `
+		-s, --state STATE
+		-c, --context CONTEXT
+		-u, --target-url URL
+		-d, --description DESCRIPTION
+`,
+	}
+)
+
+func init() {
+	CmdRunner.Use(cmdStatus)
+}
+
+func status(command *Command, args *Args) {
+	// `status` can't have regular subcommands, since any other argument is
+	// forwarded to git-status(1)
+	if !args.IsParamsEmpty() && args.FirstParam() == "create" {
+		args.RemoveParam(0)
+		utils.Check(cmdCreateStatus.parseArguments(args))
+		cmdCreateStatus.Run(cmdCreateStatus, args)
+		return
+	}
+
+	i := args.IndexOfParam("--github")
+	if i == -1 {
+		return
+	}
+	args.RemoveParam(i)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request status of %s\n", project)
+		return
+	}
+
+	user, err := gh.CurrentUser()
+	utils.Check(err)
+
+	if branch, err := localRepo.CurrentBranch(); err == nil {
+		ui.Println(formatBranchStatus(branch))
+
+		if pr, err := findCurrentPullRequest(localRepo, gh, project, ""); err == nil {
+			checks := "no checks"
+			if ciStatus, err := gh.FetchCIStatus(project, pr.Head.Sha); err == nil && len(ciStatus.Statuses) > 0 {
+				state := ""
+				for _, s := range ciStatus.Statuses {
+					if checkSeverity(s.State) > checkSeverity(state) {
+						state = s.State
+					}
+				}
+				checks = fmt.Sprintf("%s, %d %s", state, len(ciStatus.Statuses), pluralize(len(ciStatus.Statuses), "check"))
+			}
+			ui.Printf("\nPull request:\n  #%d  %s (%s)\n", pr.Number, pr.Title, checks)
+		}
+	} else {
+		ui.Println("Branch: (detached HEAD)")
+	}
+
+	issues, err := gh.FetchIssues(project, map[string]interface{}{
+		"state":    "open",
+		"assignee": user.Login,
+	}, 0, func(issue *github.Issue) bool {
+		return issue.PullRequest == nil
+	})
+	utils.Check(err)
+	if len(issues) > 0 {
+		ui.Printf("\nAssigned issues:\n")
+		for _, issue := range issues {
+			ui.Printf("  #%d  %s\n", issue.Number, issue.Title)
+		}
+	}
+
+	reviewRequests, err := gh.FetchPullRequests(project, map[string]interface{}{
+		"state": "open",
+	}, 0, func(pr *github.PullRequest) bool {
+		for _, reviewer := range pr.RequestedReviewers {
+			if strings.EqualFold(reviewer.Login, user.Login) {
+				return true
+			}
+		}
+		return false
+	})
+	utils.Check(err)
+	if len(reviewRequests) > 0 {
+		ui.Printf("\nReview requests:\n")
+		for _, pr := range reviewRequests {
+			ui.Printf("  #%d  %s (%s)\n", pr.Number, pr.Title, pr.User.Login)
+		}
+	}
+
+	notifications, err := gh.FetchNotifications(project)
+	utils.Check(err)
+	if len(notifications) > 0 {
+		ui.Printf("\nUnread notifications:\n")
+		for _, n := range notifications {
+			ui.Printf("  %s  %s (%s)\n", n.Subject.Type, n.Subject.Title, n.Reason)
+		}
+	}
+}
+
+// formatBranchStatus describes how branch compares to its upstream branch.
+func formatBranchStatus(branch *github.Branch) string {
+	upstream, err := branch.Upstream()
+	if err != nil {
+		return fmt.Sprintf("Branch: %s (no upstream)", branch.ShortName())
+	}
+
+	ahead, behind, err := git.AheadBehind(branch.Name, upstream.Name)
+	if err != nil {
+		return fmt.Sprintf("Branch: %s", branch.ShortName())
+	}
+
+	return fmt.Sprintf("Branch: %s (%s)", branch.ShortName(), describeAheadBehind(ahead, behind, upstream.LongName()))
+}
+
+func describeAheadBehind(ahead, behind int, upstream string) string {
+	switch {
+	case ahead > 0 && behind > 0:
+		return fmt.Sprintf("ahead %d, behind %d of %s", ahead, behind, upstream)
+	case ahead > 0:
+		return fmt.Sprintf("ahead %d of %s", ahead, upstream)
+	case behind > 0:
+		return fmt.Sprintf("behind %d of %s", behind, upstream)
+	default:
+		return fmt.Sprintf("up to date with %s", upstream)
+	}
+}
+
+func createStatus(command *Command, args *Args) {
+	state := args.Flag.Value("--state")
+	switch state {
+	case "pending", "success", "failure", "error":
+	case "":
+		utils.Check(command.UsageError("missing --state"))
+	default:
+		utils.Check(fmt.Errorf("error: invalid state '%s'; expected one of pending, success, failure, error", state))
+	}
+
+	ref := "HEAD"
+	if !args.IsParamsEmpty() {
+		ref = args.GetParam(0)
+	}
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	sha, err := git.Ref(ref)
+	if _, dirErr := git.Dir(); err != nil && dirErr != nil && ref != "HEAD" {
+		// outside of a git repository, let the API resolve the SHA
+		sha, err = ref, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("Aborted: no revision could be determined from '%s'", ref)
+	}
+	utils.Check(err)
+
+	context := args.Flag.Value("--context")
+	if context == "" {
+		context = "default"
+	}
+	params := map[string]interface{}{
+		"state":   state,
+		"context": context,
+	}
+	if args.Flag.HasReceived("--target-url") {
+		params["target_url"] = args.Flag.Value("--target-url")
+	}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set status of %s to %s for %s\n", sha, state, context)
+		return
+	}
+
+	gh := github.NewClient(project.Host)
+	_, err = gh.CreateCIStatus(project, sha, params)
+	utils.Check(err)
+}
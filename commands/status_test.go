@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestDescribeAheadBehind(t *testing.T) {
+	assert.Equal(t, "up to date with origin/main", describeAheadBehind(0, 0, "origin/main"))
+	assert.Equal(t, "ahead 2 of origin/main", describeAheadBehind(2, 0, "origin/main"))
+	assert.Equal(t, "behind 1 of origin/main", describeAheadBehind(0, 1, "origin/main"))
+	assert.Equal(t, "ahead 2, behind 1 of origin/main", describeAheadBehind(2, 1, "origin/main"))
+}
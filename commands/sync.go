@@ -13,7 +13,7 @@ import (
 
 var cmdSync = &Command{
 	Run:   sync,
-	Usage: "sync [--color]",
+	Usage: "sync [--color] [--retarget]",
 	Long: `Fetch git objects from upstream and update local branches.
 
 - If the local branch is outdated, fast-forward it;
@@ -23,14 +23,21 @@ var cmdSync = &Command{
 If a local branch does not have any upstream configuration, but has a
 same-named branch on the remote, treat that as its upstream branch.
 
+With ''--retarget'', also change the base of open pull requests whose base
+branch was deleted or renamed on the remote to the default branch.
+
 ## Options:
 	--color[=<WHEN>]
 		Enable colored output even if stdout is not a terminal. <WHEN> can be one
 		of "always" (default for ''--color''), "never", or "auto" (default).
 
+	--retarget
+		Retarget pull requests whose base branch no longer exists on the remote
+		to the default branch.
+
 ## See also:
 
-hub(1), git-fetch(1)
+hub-pr(1), hub(1), git-fetch(1)
 `,
 }
 
@@ -133,5 +140,56 @@ func sync(cmd *Command, args *Args) {
 		}
 	}
 
+	if args.Flag.Bool("--retarget") {
+		retargetOrphanedPulls(remote, defaultBranch, args.Noop)
+	}
+
 	args.NoForward()
 }
+
+// retargetOrphanedPulls changes the base of open pull requests whose base
+// branch is gone from remote after pruning to defaultBranch. A missing
+// remote-tracking branch is only a hint, since single-branch clones and narrow
+// fetch refspecs don't track every branch, so each candidate base branch is
+// confirmed to be gone on the server as well.
+func retargetOrphanedPulls(remote *github.Remote, defaultBranch string, noop bool) {
+	project, err := remote.Project()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+	pulls, err := gh.FetchPullRequests(project, map[string]interface{}{"state": "open"}, 0, func(pr *github.PullRequest) bool {
+		if pr.Base.Ref == defaultBranch {
+			return false
+		}
+		_, err := git.Ref(fmt.Sprintf("refs/remotes/%s/%s", remote.Name, pr.Base.Ref))
+		return err != nil
+	})
+	utils.Check(err)
+
+	goneBranches := map[string]bool{}
+	for _, pr := range pulls {
+		gone, checked := goneBranches[pr.Base.Ref]
+		if !checked {
+			exists, err := gh.BranchExists(project, pr.Base.Ref)
+			utils.Check(err)
+			gone = !exists
+			goneBranches[pr.Base.Ref] = gone
+		}
+		if !gone {
+			continue
+		}
+
+		if noop {
+			ui.Printf("Would retarget pull request #%d from %s to %s\n", pr.Number, pr.Base.Ref, defaultBranch)
+			continue
+		}
+		_, err := gh.UpdatePullRequest(project, pr.Number, map[string]interface{}{
+			"base": defaultBranch,
+		})
+		if err != nil {
+			ui.Errorf("warning: could not retarget #%d: %s\n", pr.Number, err)
+			continue
+		}
+		ui.Printf("Retargeted pull request #%d from %s to %s.\n", pr.Number, pr.Base.Ref, defaultBranch)
+	}
+}
@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdTagProtection = &Command{
+		Run: printHelp,
+		Usage: `
+tag-protection list
+tag-protection create <PATTERN>
+tag-protection delete <PATTERN>
+`,
+		Long: `Manage tag protection rules for the current repository.
+
+Tags matching a protected pattern can only be created or deleted by users with
+the maintain or admin role.
+
+## Commands:
+
+	* _list_:
+		List the patterns of tag protection rules.
+
+	* _create_:
+		Protect tags matching <PATTERN>.
+
+	* _delete_:
+		Delete the tag protection rule for <PATTERN>.
+
+## Options:
+	<PATTERN>
+		A tag name or a pattern such as "v*".
+
+## Examples:
+		$ hub tag-protection create 'v*'
+		$ hub tag-protection list
+		v*
+		$ hub tag-protection delete 'v*'
+
+## See also:
+
+hub-protection(1), hub-release(1), hub(1)
+`,
+	}
+
+	cmdListTagProtection = &Command{
+		Key: "list",
+		Run: listTagProtection,
+	}
+
+	cmdCreateTagProtection = &Command{
+		Key: "create",
+		Run: createTagProtection,
+	}
+
+	cmdDeleteTagProtection = &Command{
+		Key: "delete",
+		Run: deleteTagProtection,
+	}
+)
+
+func init() {
+	cmdTagProtection.Use(cmdListTagProtection)
+	cmdTagProtection.Use(cmdCreateTagProtection)
+	cmdTagProtection.Use(cmdDeleteTagProtection)
+	CmdRunner.Use(cmdTagProtection)
+}
+
+func listTagProtection(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of tag protection rules for %s\n", project)
+		return
+	}
+
+	rules, err := gh.FetchTagProtections(project)
+	utils.Check(err)
+
+	for _, rule := range rules {
+		ui.Println(rule.Pattern)
+	}
+}
+
+func createTagProtection(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	pattern := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would protect tags matching `%s' in %s\n", pattern, project)
+		return
+	}
+
+	_, err = gh.CreateTagProtection(project, pattern)
+	utils.Check(err)
+}
+
+func deleteTagProtection(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	pattern := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would delete tag protection rule `%s' from %s\n", pattern, project)
+		return
+	}
+
+	rules, err := gh.FetchTagProtections(project)
+	utils.Check(err)
+
+	rule := findTagProtection(rules, pattern)
+	if rule == nil {
+		utils.Check(fmt.Errorf("error: no tag protection rule found for `%s'", pattern))
+	}
+
+	utils.Check(gh.DeleteTagProtection(project, rule.ID))
+}
+
+func findTagProtection(rules []github.TagProtection, pattern string) *github.TagProtection {
+	for i := range rules {
+		if rules[i].Pattern == pattern {
+			return &rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestFindTagProtection(t *testing.T) {
+	rules := []github.TagProtection{
+		{ID: 2, Pattern: "v*"},
+		{ID: 5, Pattern: "release-*"},
+	}
+
+	assert.Equal(t, 5, findTagProtection(rules, "release-*").ID)
+	assert.Equal(t, (*github.TagProtection)(nil), findTagProtection(rules, "V*"))
+}
@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdTeam = &Command{
+		Run: printHelp,
+		Usage: `
+team list [<ORG>]
+team create [-d <DESCRIPTION>] [--secret] <ORG>/<NAME>
+team add-member [-r <ROLE>] <ORG>/<SLUG> <USER>
+team add-repo [-p <PERMISSION>] <ORG>/<SLUG> [<OWNER>/<REPO>]
+`,
+		Long: `Manage teams of a GitHub organization.
+
+## Commands:
+
+	* _list_:
+		List teams of <ORG> (default: the owner of the current repository).
+
+	* _create_:
+		Create a team named <NAME> in <ORG> and print its URL.
+
+	* _add-member_:
+		Add <USER> to the team, inviting them to the organization if necessary.
+
+	* _add-repo_:
+		Give the team access to <OWNER>/<REPO> (default: the current repository).
+
+## Options:
+	-d, --description <DESCRIPTION>
+		The description of the new team.
+
+	--secret
+		Make the new team visible only to its members and organization owners.
+
+	-r, --role <ROLE>
+		The role of the new team member: "member" (default) or "maintainer".
+
+	-p, --permission <PERMISSION>
+		The permission the team has on the repository: "pull" (default), "triage",
+		"push", "maintain", or "admin".
+
+## Examples:
+		$ hub team create -d "Release engineers" github/releasers
+		$ hub team add-member -r maintainer github/releasers mislav
+		$ hub team add-repo -p push github/releasers
+
+## See also:
+
+hub-collab(1), hub(1)
+`,
+	}
+
+	cmdListTeams = &Command{
+		Key: "list",
+		Run: listTeams,
+	}
+
+	cmdCreateTeam = &Command{
+		Key: "create",
+		Run: createTeam,
+		KnownFlags: `
+		-d, --description DESCRIPTION
+		--secret
+`,
+	}
+
+	cmdAddTeamMember = &Command{
+		Key: "add-member",
+		Run: addTeamMember,
+		KnownFlags: `
+		-r, --role ROLE
+`,
+	}
+
+	cmdAddTeamRepo = &Command{
+		Key: "add-repo",
+		Run: addTeamRepo,
+		KnownFlags: `
+		-p, --permission PERMISSION
+`,
+	}
+)
+
+func init() {
+	cmdTeam.Use(cmdListTeams)
+	cmdTeam.Use(cmdCreateTeam)
+	cmdTeam.Use(cmdAddTeamMember)
+	cmdTeam.Use(cmdAddTeamRepo)
+	CmdRunner.Use(cmdTeam)
+}
+
+// parseTeamName splits "ORG/SLUG" into its components.
+func parseTeamName(name string) (org, slug string, err error) {
+	split := strings.SplitN(name, "/", 2)
+	if len(split) != 2 || split[0] == "" || split[1] == "" {
+		return "", "", fmt.Errorf("error: invalid team name '%s'; expected ORG/TEAM", name)
+	}
+	return split[0], split[1], nil
+}
+
+func listTeams(cmd *Command, args *Args) {
+	project, host := projectOrDefaultHost()
+
+	org := ""
+	if !args.IsParamsEmpty() {
+		org = args.FirstParam()
+	} else if project != nil {
+		org = project.Owner
+	} else {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of teams for %s\n", org)
+		return
+	}
+
+	teams, err := gh.FetchTeams(org)
+	utils.Check(err)
+
+	width := 0
+	for _, team := range teams {
+		if len(team.Slug) > width {
+			width = len(team.Slug)
+		}
+	}
+	for _, team := range teams {
+		ui.Printf("%-*s  %s\n", width, team.Slug, team.Name)
+	}
+}
+
+func createTeam(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	org, name, err := parseTeamName(args.GetParam(0))
+	utils.Check(err)
+
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would create team `%s' in %s\n", name, org)
+		return
+	}
+
+	privacy := "closed"
+	if args.Flag.Bool("--secret") {
+		privacy = "secret"
+	}
+	params := map[string]interface{}{
+		"name":    name,
+		"privacy": privacy,
+	}
+	if args.Flag.HasReceived("--description") {
+		params["description"] = args.Flag.Value("--description")
+	}
+
+	team, err := gh.CreateTeam(org, params)
+	utils.Check(err)
+
+	ui.Println(team.HTMLURL)
+}
+
+func addTeamMember(cmd *Command, args *Args) {
+	if args.ParamsSize() < 2 {
+		utils.Check(cmd.UsageError(""))
+	}
+	org, slug, err := parseTeamName(args.GetParam(0))
+	utils.Check(err)
+	login := strings.TrimPrefix(args.GetParam(1), "@")
+
+	_, host := projectOrDefaultHost()
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would add %s to team %s/%s\n", login, org, slug)
+		return
+	}
+
+	state, err := gh.AddTeamMember(org, slug, login, args.Flag.Value("--role"))
+	utils.Check(err)
+
+	if state == "pending" {
+		ui.Printf("Invited %s to join %s/%s.\n", login, org, slug)
+	}
+}
+
+func addTeamRepo(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	org, slug, err := parseTeamName(args.GetParam(0))
+	utils.Check(err)
+
+	project, host := projectOrDefaultHost()
+	if args.ParamsSize() > 1 {
+		repoName := args.GetParam(1)
+		if !strings.Contains(repoName, "/") || !regexp.MustCompile(NameWithOwnerRe).MatchString(repoName) {
+			utils.Check(cmd.UsageError(""))
+		}
+		split := strings.SplitN(repoName, "/", 2)
+		project = github.NewProject(split[0], split[1], host)
+	} else if project == nil {
+		utils.Check(cmd.UsageError(""))
+	}
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would give team %s/%s access to %s\n", org, slug, project)
+		return
+	}
+
+	utils.Check(gh.AddTeamRepository(org, slug, project, args.Flag.Value("--permission")))
+}
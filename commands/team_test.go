@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestParseTeamName(t *testing.T) {
+	org, slug, err := parseTeamName("github/release-engineers")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "github", org)
+	assert.Equal(t, "release-engineers", slug)
+
+	_, _, err = parseTeamName("release-engineers")
+	assert.Equal(t, "error: invalid team name 'release-engineers'; expected ORG/TEAM", err.Error())
+
+	_, _, err = parseTeamName("github/")
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var relativeTimeRegexp = regexp.MustCompile(`^(\d+)([hdwy])$`)
+
+// parseTimeFilter parses the value of a `--since` or `--until` flag: a date
+// such as "2021-03-15", a timestamp in ISO 8601 format, or a duration such as
+// "12h", "3d", "2w", or "1y" that is counted back from now.
+func parseTimeFilter(value string, now time.Time) (time.Time, error) {
+	if match := relativeTimeRegexp.FindStringSubmatch(value); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "h":
+			return now.Add(-time.Duration(n) * time.Hour), nil
+		case "d":
+			return now.AddDate(0, 0, -n), nil
+		case "w":
+			return now.AddDate(0, 0, -7*n), nil
+		case "y":
+			return now.AddDate(-n, 0, 0), nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("error: invalid time '%s'; expected a date such as 2021-03-15, a timestamp in ISO 8601 format, or a duration such as 3d or 2w", value)
+}
+
+// timeRange holds the bounds given with `--since` and `--until`. A zero bound
+// leaves that end of the range open.
+type timeRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// timeRangeFromArgs reads `--since` and `--until`. A date given to `--until`
+// includes the whole day.
+func timeRangeFromArgs(args *Args, now time.Time) (r timeRange, err error) {
+	if value := args.Flag.Value("--since"); value != "" {
+		if r.Since, err = parseTimeFilter(value, now); err != nil {
+			return
+		}
+	}
+	if value := args.Flag.Value("--until"); value != "" {
+		if r.Until, err = parseTimeFilter(value, now); err != nil {
+			return
+		}
+		if _, dateErr := time.Parse("2006-01-02", value); dateErr == nil {
+			r.Until = r.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+	return
+}
+
+// Includes reports whether t is within the range.
+func (r timeRange) Includes(t time.Time) bool {
+	return (r.Since.IsZero() || !t.Before(r.Since)) && (r.Until.IsZero() || !t.After(r.Until))
+}
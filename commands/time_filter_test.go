@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestParseTimeFilter(t *testing.T) {
+	now := time.Date(2021, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Time{
+		"12h":                  time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+		"3d":                   time.Date(2021, 3, 12, 12, 0, 0, 0, time.UTC),
+		"2w":                   time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC),
+		"1y":                   time.Date(2020, 3, 15, 12, 0, 0, 0, time.UTC),
+		"2016-08-18T09:11:32Z": time.Date(2016, 8, 18, 9, 11, 32, 0, time.UTC),
+		"2021-01-02":           time.Date(2021, 1, 2, 0, 0, 0, 0, time.Local),
+	}
+	for value, expected := range tests {
+		parsed, err := parseTimeFilter(value, now)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, expected.Equal(parsed))
+	}
+
+	_, err := parseTimeFilter("yesterday", now)
+	assert.Equal(t, "error: invalid time 'yesterday'; expected a date such as 2021-03-15, a timestamp in ISO 8601 format, or a duration such as 3d or 2w", err.Error())
+}
+
+func TestTimeRangeFromArgs(t *testing.T) {
+	now := time.Date(2021, 3, 15, 12, 0, 0, 0, time.UTC)
+	args := NewArgs([]string{"issue", "--since", "2w", "--until", "2021-03-10"})
+	assert.Equal(t, nil, cmdIssue.parseArguments(args))
+
+	r, err := timeRangeFromArgs(args, now)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, r.Includes(time.Date(2021, 3, 10, 23, 0, 0, 0, time.Local)))
+	assert.Equal(t, false, r.Includes(time.Date(2021, 3, 11, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, false, r.Includes(time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, true, timeRange{}.Includes(now))
+}
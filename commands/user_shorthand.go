@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/hub/v2/github"
+)
+
+// userResolver expands the shorthands accepted by flags that take GitHub
+// handles: `@me` stands for the authenticated user and `@<ORG>/<TEAM>` for a
+// team of an organization.
+type userResolver struct {
+	gh   *github.Client
+	noop bool
+	me   string
+}
+
+func newUserResolver(gh *github.Client, noop bool) *userResolver {
+	return &userResolver{gh: gh, noop: noop}
+}
+
+func isTeamHandle(value string) bool {
+	return strings.Contains(value, "/")
+}
+
+// login resolves a single handle. Teams are rejected since the flag expects
+// one user.
+func (r *userResolver) login(value string) (string, error) {
+	if isTeamHandle(value) {
+		return "", fmt.Errorf("error: '%s' is a team; expected a single user", value)
+	}
+	if value != "@me" {
+		return strings.TrimPrefix(value, "@"), nil
+	}
+	if r.noop {
+		return value, nil
+	}
+	if r.me == "" {
+		user, err := r.gh.CurrentUser()
+		if err != nil {
+			return "", err
+		}
+		r.me = user.Login
+	}
+	return r.me, nil
+}
+
+// logins resolves a list of handles, replacing each team with its members.
+func (r *userResolver) logins(values []string) ([]string, error) {
+	logins := []string{}
+	seen := map[string]bool{}
+	add := func(login string) {
+		if !seen[strings.ToLower(login)] {
+			seen[strings.ToLower(login)] = true
+			logins = append(logins, login)
+		}
+	}
+
+	for _, value := range values {
+		if !isTeamHandle(value) {
+			login, err := r.login(value)
+			if err != nil {
+				return nil, err
+			}
+			add(login)
+			continue
+		}
+		if r.noop {
+			add(value)
+			continue
+		}
+		team := strings.SplitN(strings.TrimPrefix(value, "@"), "/", 2)
+		members, err := r.gh.FetchTeamMembers(team[0], team[1])
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			add(member.Login)
+		}
+	}
+	return logins, nil
+}
+
+// reviewers resolves `@me` in a list of review requests. Teams are kept as
+// `<ORG>/<TEAM>` so that the review is requested from the team itself.
+func (r *userResolver) reviewers(values []string) ([]string, error) {
+	reviewers := []string{}
+	for _, value := range values {
+		if isTeamHandle(value) {
+			reviewers = append(reviewers, strings.TrimPrefix(value, "@"))
+			continue
+		}
+		login, err := r.login(value)
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, login)
+	}
+	return reviewers, nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestUserResolverLogin(t *testing.T) {
+	users := &userResolver{me: "mislav"}
+
+	login, err := users.login("@me")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "mislav", login)
+
+	login, err = users.login("@octocat")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "octocat", login)
+
+	login, err = users.login("none")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "none", login)
+
+	_, err = users.login("@github/hubbers")
+	assert.Equal(t, "error: '@github/hubbers' is a team; expected a single user", err.Error())
+}
+
+func TestUserResolverLogins(t *testing.T) {
+	users := &userResolver{me: "mislav"}
+	logins, err := users.logins([]string{"@me", "octocat", "Mislav"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"mislav", "octocat"}, logins)
+
+	users = newUserResolver(nil, true)
+	logins, err = users.logins([]string{"@me", "@github/hubbers"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"@me", "@github/hubbers"}, logins)
+}
+
+func TestUserResolverReviewers(t *testing.T) {
+	users := &userResolver{me: "mislav"}
+	reviewers, err := users.reviewers([]string{"@me", "@github/hubbers", "github/core", "@octocat"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"mislav", "github/hubbers", "github/core", "octocat"}, reviewers)
+}
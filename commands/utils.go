@@ -3,13 +3,16 @@ package commands
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/atotto/clipboard"
 	"github.com/github/hub/v2/git"
+	"github.com/github/hub/v2/github"
 	"github.com/github/hub/v2/ui"
 	"github.com/github/hub/v2/utils"
 )
@@ -120,3 +123,175 @@ func printBrowseOrCopy(args *Args, msg string, openBrowser bool, performCopy boo
 		})
 	}
 }
+
+// editorTemplate loads the custom template of the given kind that should
+// pre-fill the text editor, with its "{{name}}" variables replaced by vars.
+func editorTemplate(kind string, vars map[string]string) (message string, found bool) {
+	workdir, _ := git.WorkdirName()
+	body, found, err := github.ReadEditorTemplate(kind, workdir)
+	utils.Check(err)
+	if found {
+		message = github.ExpandEditorTemplate(body, vars)
+	}
+	return
+}
+
+// chooseTemplate reads the templates of the given kind from a directory such
+// as ".github/ISSUE_TEMPLATE/" and, if there are several, asks which one to
+// use. The second return value is false if there is no template directory or
+// if the last option, named blank, was chosen.
+func chooseTemplate(kind, workdir, blank string) (github.TemplateChoice, bool) {
+	choices, err := github.ReadTemplateChoices(kind, workdir)
+	utils.Check(err)
+	choice, ok, err := selectTemplate(choices, blank, os.Stdin)
+	utils.Check(err)
+	return choice, ok
+}
+
+func selectTemplate(choices []github.TemplateChoice, blank string, input io.Reader) (choice github.TemplateChoice, ok bool, err error) {
+	if len(choices) == 0 {
+		return
+	}
+	if len(choices) == 1 {
+		return choices[0], true, nil
+	}
+
+	ui.Errorf("Choose a template:\n")
+	for i, c := range choices {
+		if c.About != "" {
+			ui.Errorf(" %d. %s - %s\n", i+1, c.Name, c.About)
+		} else {
+			ui.Errorf(" %d. %s\n", i+1, c.Name)
+		}
+	}
+	ui.Errorf(" %d. %s\n> ", len(choices)+1, blank)
+
+	answer := ""
+	scanner := bufio.NewScanner(input)
+	if scanner.Scan() {
+		answer = strings.TrimSpace(scanner.Text())
+	}
+	i, convErr := strconv.Atoi(answer)
+	if convErr != nil || i < 1 || i > len(choices)+1 {
+		err = fmt.Errorf("Error: must enter a number [1-%d]", len(choices)+1)
+		return
+	}
+	if i > len(choices) {
+		return
+	}
+	return choices[i-1], true, nil
+}
+
+// formatCommitSubjects renders commit subjects as a Markdown list.
+func formatCommitSubjects(subjects []string) string {
+	lines := []string{}
+	for _, subject := range subjects {
+		lines = append(lines, "- "+subject)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// projectOrDefaultHost picks the GitHub host of the current repository,
+// falling back to the default host when run outside of a repository.
+func projectOrDefaultHost() (project *github.Project, host string) {
+	if localRepo, err := github.LocalRepo(); err == nil {
+		if project, err = localRepo.MainProject(); err == nil {
+			return project, project.Host
+		}
+	}
+
+	defHost, err := github.CurrentConfig().DefaultHostNoPrompt()
+	utils.Check(err)
+	return nil, defHost.Host
+}
+
+// expandReferences rewrites references to issues and pull requests in the
+// title and body of a message submitted to project into a consistent form.
+// It fails if any reference to project points to something that doesn't exist
+// and warns about references to closed issues and pull requests. References
+// to other repositories that can't be looked up, e.g. because they are
+// private, are left alone.
+func expandReferences(gh *github.Client, project *github.Project, title, body string) (string, string, error) {
+	title, titleRefs := github.ExpandReferences(title, project)
+	body, bodyRefs := github.ExpandReferences(body, project)
+
+	seen := map[string]bool{}
+	missing := []string{}
+	for _, ref := range append(titleRefs, bodyRefs...) {
+		key := strings.ToLower(ref.String())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		refProject := ref.Project(project.Host)
+		sameRepo := refProject.SameAs(project)
+		issue, err := gh.LookupIssue(refProject, ref.Number)
+		if err != nil {
+			if sameRepo {
+				return title, body, err
+			}
+			continue
+		}
+		if issue == nil {
+			if sameRepo {
+				missing = append(missing, ref.Shorthand(project))
+			}
+		} else if issue.State == "closed" {
+			ui.Errorf("warning: %s is closed: %s\n", ref.Shorthand(project), issue.Title)
+		}
+	}
+
+	if len(missing) > 0 {
+		return title, body, fmt.Errorf("Aborted: no issue or pull request found for %s", strings.Join(missing, ", "))
+	}
+	return title, body, nil
+}
+
+// emojiExpander returns a function that replaces emoji shortcodes such as
+// ":tada:" with their Unicode characters. When the list of emoji can't be
+// fetched, text is returned unchanged.
+func emojiExpander(gh *github.Client) func(string) string {
+	emojis, err := gh.FetchEmojis()
+	if err != nil {
+		return func(text string) string { return text }
+	}
+	return func(text string) string {
+		return github.ExpandEmoji(text, emojis)
+	}
+}
+
+// prefetchEmojiExpander starts fetching the list of emoji in the background
+// if enabled, and returns a function that waits for the emojiExpander.
+func prefetchEmojiExpander(gh *github.Client, enabled bool) func() func(string) string {
+	expand := func(text string) string { return text }
+	if !enabled {
+		return func() func(string) string { return expand }
+	}
+	wait := prefetch(gh, func() error {
+		expand = emojiExpander(gh)
+		return nil
+	})
+	return func() func(string) string {
+		wait()
+		return expand
+	}
+}
+
+// prefetch runs fn in the background, e.g. while the user is busy writing a
+// message in their editor, and returns a function that waits for fn to finish.
+// Without an access token fn would have to prompt for credentials, so in that
+// case it runs only once its result is waited for.
+func prefetch(gh *github.Client, fn func() error) func() error {
+	if gh.Host == nil || gh.Host.AccessToken == "" {
+		return fn
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+	return func() error {
+		return <-done
+	}
+}
@@ -1,10 +1,13 @@
 package commands
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/github/hub/v2/github"
 	"github.com/github/hub/v2/internal/assert"
 )
 
@@ -30,3 +33,59 @@ func createTempDir(t *testing.T) string {
 	}
 	return dir
 }
+
+func TestPrefetch(t *testing.T) {
+	gh := &github.Client{Host: &github.Host{AccessToken: "OTOKEN"}}
+
+	started := make(chan bool)
+	wait := prefetch(gh, func() error {
+		started <- true
+		return fmt.Errorf("lookup failed")
+	})
+	assert.Equal(t, true, <-started)
+	assert.Equal(t, "lookup failed", wait().Error())
+
+	gh.Host.AccessToken = ""
+	ran := false
+	wait = prefetch(gh, func() error {
+		ran = true
+		return nil
+	})
+	assert.Equal(t, false, ran)
+	assert.Equal(t, nil, wait())
+	assert.Equal(t, true, ran)
+}
+
+func TestPrefetchEmojiExpanderDisabled(t *testing.T) {
+	gh := &github.Client{Host: &github.Host{AccessToken: "OTOKEN"}}
+	expand := prefetchEmojiExpander(gh, false)()
+	assert.Equal(t, "Released :tada:", expand("Released :tada:"))
+}
+
+func TestSelectTemplate(t *testing.T) {
+	choices := []github.TemplateChoice{
+		{Name: "Bug report", About: "Report a problem", Body: "Steps"},
+		{Name: "feature", Body: "Idea"},
+	}
+
+	_, ok, err := selectTemplate(nil, "Blank issue", strings.NewReader(""))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, ok)
+
+	choice, ok, err := selectTemplate(choices[:1], "Blank issue", strings.NewReader(""))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "Bug report", choice.Name)
+
+	choice, ok, err = selectTemplate(choices, "Blank issue", strings.NewReader("2\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "feature", choice.Name)
+
+	_, ok, err = selectTemplate(choices, "Blank issue", strings.NewReader("3\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, ok)
+
+	_, _, err = selectTemplate(choices, "Blank issue", strings.NewReader("bug\n"))
+	assert.Equal(t, "Error: must enter a number [1-3]", err.Error())
+}
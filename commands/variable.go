@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var (
+	cmdVariable = &Command{
+		Run: printHelp,
+		Usage: `
+variable list [--env <ENVIRONMENT> | --org]
+variable set [-b <VALUE> | -F <FILE>] [--env <ENVIRONMENT> | --org [--visibility <VISIBILITY>]] <NAME>
+variable remove [--env <ENVIRONMENT> | --org] <NAME>
+`,
+		Long: `Manage GitHub Actions configuration variables for the current repository.
+
+## Commands:
+
+	* _list_:
+		List variables with their values.
+
+	* _set_:
+		Create or update the variable <NAME>. Without ''--body'' or ''--file'', the
+		value is read from standard input.
+
+	* _remove_:
+		Delete the variable <NAME>.
+
+## Options:
+	-b, --body <VALUE>
+		Use <VALUE> as the variable value.
+
+	-F, --file <FILE>
+		Read the variable value from <FILE>. Pass "-" to read from standard input.
+
+	-e, --env <ENVIRONMENT>
+		Manage variables of the deployment environment <ENVIRONMENT> instead of
+		repository variables.
+
+	--org
+		Manage variables of the organization that owns the current repository.
+
+	--visibility <VISIBILITY>
+		Which repositories of the organization can access the variable: "all",
+		"private" (default), or "selected".
+
+## Examples:
+		$ hub variable set -b 18 NODE_VERSION
+		$ hub variable list
+		NODE_VERSION  18
+
+## See also:
+
+hub-secret(1), hub(1)
+`,
+	}
+
+	cmdListVariables = &Command{
+		Key: "list",
+		Run: listVariables,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		--org
+`,
+	}
+
+	cmdSetVariable = &Command{
+		Key: "set",
+		Run: setVariable,
+		KnownFlags: `
+		-b, --body VALUE
+		-F, --file FILE
+		-e, --env ENVIRONMENT
+		--org
+		--visibility VISIBILITY
+`,
+	}
+
+	cmdRemoveVariable = &Command{
+		Key: "remove",
+		Run: removeVariable,
+		KnownFlags: `
+		-e, --env ENVIRONMENT
+		--org
+`,
+	}
+)
+
+func init() {
+	cmdVariable.Use(cmdListVariables)
+	cmdVariable.Use(cmdSetVariable)
+	cmdVariable.Use(cmdRemoveVariable)
+	CmdRunner.Use(cmdVariable)
+}
+
+func listVariables(cmd *Command, args *Args) {
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request list of variables for %s\n", scope)
+		return
+	}
+
+	variables, err := gh.FetchActionsSecrets(prefix, "variables")
+	utils.Check(err)
+
+	ui.Print(formatActionsSecrets(variables, func(v github.ActionsSecret) string {
+		return v.Value
+	}))
+}
+
+func setVariable(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would set variable %s for %s\n", name, scope)
+		return
+	}
+
+	value, err := actionsValueFromArgs(args)
+	utils.Check(err)
+
+	params := map[string]interface{}{
+		"value": value,
+	}
+	if args.Flag.Bool("--org") {
+		visibility := "private"
+		if args.Flag.HasReceived("--visibility") {
+			visibility = args.Flag.Value("--visibility")
+		}
+		params["visibility"] = visibility
+	}
+
+	utils.Check(gh.SetActionsVariable(prefix, name, params))
+}
+
+func removeVariable(cmd *Command, args *Args) {
+	if args.ParamsSize() < 1 {
+		utils.Check(cmd.UsageError(""))
+	}
+	name := args.GetParam(0)
+
+	localRepo, err := github.LocalRepo()
+	utils.Check(err)
+
+	project, err := localRepo.MainProject()
+	utils.Check(err)
+
+	prefix, scope, err := actionsPrefix(project, args)
+	utils.Check(err)
+
+	gh := github.NewClient(project.Host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would remove variable %s from %s\n", name, scope)
+		return
+	}
+
+	utils.Check(gh.DeleteActionsSecret(prefix, "variables", name))
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/hub/v2/github"
+	"github.com/github/hub/v2/ui"
+	"github.com/github/hub/v2/utils"
+)
+
+var cmdWhoami = &Command{
+	Run:   whoami,
+	Usage: "whoami [--host <HOST>] [--json]",
+	Long: `Show the GitHub user that hub is authenticated as.
+
+## Options:
+	--host <HOST>
+		Check authentication for <HOST> instead of the GitHub host of the current
+		repository (default: "github.com").
+
+	--json
+		Output the login along with the type and OAuth scopes of the access token
+		and the current API rate limit as JSON.
+
+## Examples:
+		$ hub whoami
+		mislav
+
+		$ hub whoami --host github.example.com --json
+		{
+		  "host": "github.example.com",
+		  "login": "mislav",
+		  "token_type": "classic",
+		  "scopes": [
+		    "repo"
+		  ],
+		  "rate_limit": {
+		    "limit": 5000,
+		    "remaining": 4987,
+		    "reset": "2020-01-29T13:12:33Z"
+		  }
+		}
+
+## Notes:
+
+Exits with a non-zero status if no credentials are configured for the host or
+if the access token is no longer valid. Unlike other commands, ''whoami'' never
+prompts for credentials, which makes it suitable for verifying authentication
+in scripts.
+
+The type of the access token is detected from its prefix and reported as one
+of "classic" or "fine-grained" for personal access tokens, "oauth" for OAuth
+app tokens and tokens that predate prefixes, and "app-user" or
+"app-installation" for GitHub App tokens. Only classic and OAuth tokens have
+scopes; fine-grained and GitHub App tokens are granted permissions instead,
+which are listed in error messages when a request is denied.
+
+A GitHub App installation token acts on behalf of the app rather than a user,
+so instead of a login, the account that the app is installed on is shown, and
+reported as "installation" in JSON output. When such a token is given via
+''GITHUB_TOKEN'' without ''GITHUB_USER'', hub uses that account as the user.
+
+## See also:
+
+hub(1)
+`,
+	KnownFlags: `
+		--host HOST
+		--json
+`,
+}
+
+func init() {
+	CmdRunner.Use(cmdWhoami)
+}
+
+func whoami(cmd *Command, args *Args) {
+	host := args.Flag.Value("--host")
+	if host == "" {
+		_, host = projectOrDefaultHost()
+	}
+
+	config := github.CurrentConfig()
+	if config.Find(host) == nil && config.DetectToken() == "" {
+		utils.Check(fmt.Errorf("error: not logged in to %s", host))
+	}
+
+	gh := github.NewClient(host)
+
+	args.NoForward()
+	if args.Noop {
+		ui.Printf("Would request current user for %s\n", host)
+		return
+	}
+
+	status, err := gh.FetchAuthStatus()
+	utils.Check(err)
+
+	if args.Flag.Bool("--json") {
+		out, err := json.MarshalIndent(status, "", "  ")
+		utils.Check(err)
+		ui.Println(string(out))
+		return
+	}
+
+	if status.Installation != nil {
+		ui.Printf("GitHub App installation on %s\n", status.Installation.Owner)
+		return
+	}
+
+	ui.Println(status.Login)
+}
@@ -0,0 +1,103 @@
+Feature: hub auth
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And $HUB_OAUTH_CLIENT_ID is "CLIENTID"
+
+  Scenario: Refresh the access token
+    Given the GitHub API server:
+      """
+      count = 0
+      get('/user') {
+        response.headers['X-Oauth-Scopes'] = 'repo, gist, workflow'
+        halt 401 unless ['token OTOKEN', 'token NEWTOKEN'].include?(request.env['HTTP_AUTHORIZATION'])
+        json :login => 'mislav'
+      }
+      post('/login/device/code') {
+        assert :client_id => 'CLIENTID', :scope => 'repo gist workflow'
+        json :device_code => 'DEVCODE', :user_code => '1A2B-3C4D',
+             :verification_uri => 'https://github.com/login/device',
+             :expires_in => 900, :interval => 0
+      }
+      post('/login/oauth/access_token') {
+        assert :device_code => 'DEVCODE'
+        count += 1
+        if count < 2
+          json :error => 'authorization_pending'
+        else
+          json :access_token => 'NEWTOKEN'
+        end
+      }
+      post('/credentials/revoke') {
+        assert :credentials => ['OTOKEN']
+        status 202
+        json({})
+      }
+      """
+    When I successfully run `hub auth refresh`
+    Then the output should contain exactly "Refreshed the access token of mislav for github.com\n"
+    And the stderr should contain exactly:
+      """
+      First copy your one-time code: 1A2B-3C4D
+      Then open https://github.com/login/device in a web browser to authorize it.\n
+      """
+    And the file "../home/.config/hub" should contain "oauth_token: NEWTOKEN"
+
+  Scenario: Warn when the old token can't be revoked
+    Given the GitHub API server:
+      """
+      get('/user') { json :login => 'mislav' }
+      post('/login/device/code') {
+        assert :scope => 'repo gist'
+        json :device_code => 'DEVCODE', :user_code => '1A2B-3C4D',
+             :verification_uri => 'https://github.com/login/device', :interval => 0
+      }
+      post('/login/oauth/access_token') { json :access_token => 'NEWTOKEN' }
+      post('/credentials/revoke') { status 404; json :message => 'Not Found' }
+      """
+    When I successfully run `hub auth refresh --scopes repo,gist`
+    Then the stderr should contain "warning: the old access token could not be revoked"
+    And the file "../home/.config/hub" should contain "oauth_token: NEWTOKEN"
+
+  Scenario: The new token belongs to another user
+    Given the GitHub API server:
+      """
+      get('/user') {
+        if request.env['HTTP_AUTHORIZATION'] == 'token NEWTOKEN'
+          json :login => 'octocat'
+        else
+          json :login => 'mislav'
+        end
+      }
+      post('/login/device/code') {
+        json :device_code => 'DEVCODE', :user_code => '1A2B-3C4D',
+             :verification_uri => 'https://github.com/login/device', :interval => 0
+      }
+      post('/login/oauth/access_token') { json :access_token => 'NEWTOKEN' }
+      """
+    When I run `hub auth refresh`
+    Then the exit status should be 1
+    And the stderr should contain "Aborted: the new access token belongs to octocat instead of mislav"
+    And the file "../home/.config/hub" should contain "oauth_token: OTOKEN"
+
+  Scenario: Denied authorization
+    Given the GitHub API server:
+      """
+      get('/user') { json :login => 'mislav' }
+      post('/login/device/code') {
+        json :device_code => 'DEVCODE', :user_code => '1A2B-3C4D',
+             :verification_uri => 'https://github.com/login/device', :interval => 0
+      }
+      post('/login/oauth/access_token') {
+        json :error => 'access_denied', :error_description => 'The authorization request was denied.'
+      }
+      """
+    When I run `hub auth refresh`
+    Then the exit status should be 1
+    And the stderr should contain "Error requesting access token: The authorization request was denied."
+
+  Scenario: Token from the environment
+    Given $GITHUB_TOKEN is "ENVTOKEN"
+    When I run `hub auth refresh`
+    Then the exit status should be 1
+    And the stderr should contain exactly "error: can't refresh the access token given via GITHUB_TOKEN\n"
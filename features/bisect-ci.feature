@@ -0,0 +1,43 @@
+Feature: hub bisect-ci
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And I make a commit with message "Known good"
+    And I successfully run `git tag known-good`
+    And I make 4 commits
+
+  Scenario: Every commit after the good one fails
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/commits/:sha/status') {
+        json :state => "failure", :statuses => [{ :state => "failure", :context => "ci/build" }]
+      }
+      get('/repos/github/hub/commits/:sha/check-runs') {
+        json :check_runs => []
+      }
+      """
+    When I successfully run `hub bisect-ci known-good HEAD`
+    Then the output should contain "failure -> bad"
+    And the output should contain "is the first bad commit"
+    And the output should not contain "good"
+    When I run `git bisect log`
+    Then the exit status should be 1
+
+  Scenario: Commits without CI status are skipped
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/commits/:sha/status') {
+        json :state => "pending", :statuses => []
+      }
+      get('/repos/github/hub/commits/:sha/check-runs') {
+        json :check_runs => []
+      }
+      """
+    When I successfully run `hub bisect-ci known-good HEAD`
+    Then the output should contain "no status -> skip"
+    And the output should contain "There are only 'skip'ped commits left to test."
+
+  Scenario: Missing arguments
+    When I run `hub bisect-ci known-good`
+    Then the exit status should be 1
+    And the stderr should contain "Usage: hub bisect-ci <GOOD> <BAD>"
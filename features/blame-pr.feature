@@ -0,0 +1,60 @@
+Feature: hub blame-pr
+  Background:
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And a file named "README.md" with:
+      """
+      # dotfiles
+      My configuration files.
+      """
+    And I successfully run `git add README.md`
+    And I make a commit with message "Add README"
+
+  Scenario: Open the pull request that changed a line
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/commits/:sha/pulls') {
+        json [
+          { :number => 7,
+            :title => "Describe the repository",
+            :state => "closed",
+            :merged_at => "2026-10-01T12:00:00Z",
+            :html_url => "https://github.com/mislav/dotfiles/pull/7",
+          },
+        ]
+      }
+      """
+    When I successfully run `hub blame-pr README.md 2`
+    Then "open https://github.com/mislav/dotfiles/pull/7" should be run
+
+  Scenario: Print the pull request
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/commits/:sha/pulls') {
+        json [
+          { :number => 7,
+            :title => "Describe the repository",
+            :state => "closed",
+            :merged_at => "2026-10-01T12:00:00Z",
+            :html_url => "https://github.com/mislav/dotfiles/pull/7",
+          },
+        ]
+      }
+      """
+    When I successfully run `hub blame-pr -u README.md 2`
+    Then the output should contain exactly:
+      """
+      #7  Describe the repository (merged)
+      https://github.com/mislav/dotfiles/pull/7\n
+      """
+
+  Scenario: Commit without a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles/commits/:sha/pulls') {
+        json []
+      }
+      """
+    When I run `hub blame-pr README.md 1`
+    Then the stderr should match /^Aborted: no pull request found for commit [0-9a-f]{7}$/
+    And the exit status should be 1
@@ -0,0 +1,83 @@
+Feature: hub branch
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub') {
+        json :default_branch => "main"
+      }
+      post('/graphql') {
+        assert :variables => { :owner => "github", :repo => "hub", :defaultBranch => "main" }
+        json :data => { :repository => { :refs => {
+          :nodes => [
+            { :name => "main",
+              :target => { :committedDate => Time.now.utc.iso8601, :author => { :name => "Mislav", :user => { :login => "mislav" } } },
+              :associatedPullRequests => { :nodes => [] },
+              :compare => { :aheadBy => 0, :behindBy => 0 } },
+            { :name => "fix-login",
+              :target => { :committedDate => (Time.now - 12 * 86400).utc.iso8601, :author => { :name => "The Octocat", :user => nil } },
+              :associatedPullRequests => { :nodes => [{ :number => 1234 }] },
+              :compare => { :aheadBy => 4, :behindBy => 1 } },
+            { :name => "old-feature",
+              :target => { :committedDate => (Time.now - 120 * 86400).utc.iso8601, :author => { :name => "Mislav", :user => { :login => "mislav" } } },
+              :associatedPullRequests => { :nodes => [] },
+              :compare => { :aheadBy => 210, :behindBy => 3 } },
+          ],
+          :pageInfo => { :hasNextPage => false, :endCursor => "abc" },
+        } } }
+      }
+      delete('/repos/github/hub/git/refs/heads/old-feature') {
+        status 204
+      }
+      """
+
+  Scenario: Report on branches
+    When I successfully run `hub branch report`
+    Then the output should contain exactly:
+      """
+      BRANCH       AGE   AHEAD  BEHIND  PULL REQUESTS  AUTHOR
+      old-feature  120d  3      210                    mislav       stale
+      fix-login    12d   1      4       #1234          The Octocat\n
+      """
+
+  Scenario: Stale branches with a custom age
+    When I successfully run `hub branch report --older-than 1w`
+    Then the output should contain:
+      """
+      fix-login    12d   1      4       #1234          The Octocat  stale
+      """
+
+  Scenario: Delete stale branches
+    When I run `hub branch report --delete-stale` interactively
+    And I type "y"
+    Then the exit status should be 0
+    And the output should contain:
+      """
+      Stale branches without pull requests:
+        old-feature
+      Delete 1 stale branches from github/hub (y/N)? Deleted branch old-feature
+      """
+
+  Scenario: Abort deleting stale branches
+    When I run `hub branch report --delete-stale` interactively
+    And I type "n"
+    Then the exit status should be 1
+    And the stderr should contain "Aborted: no branches were deleted"
+
+  Scenario: Delete stale branches without confirmation
+    When I successfully run `hub branch report --delete-stale --older-than 12w --yes`
+    Then the output should contain "Deleted branch old-feature"
+
+  Scenario: Invalid age
+    When I run `hub branch report --older-than 3mo`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: invalid age '3mo'; expected a number of days or weeks such as 90d or 12w\n
+      """
+
+  Scenario: Other branch invocations are forwarded to git
+    When I successfully run `hub branch topic`
+    And I successfully run `git branch --list topic`
+    Then the output should contain "topic"
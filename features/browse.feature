@@ -5,7 +5,11 @@ Feature: hub browse
   Scenario: No repo
     When I run `hub browse`
     Then the exit status should be 1
-    Then the output should contain exactly "Usage: hub browse [-uc] [[<USER>/]<REPOSITORY>|--] [<SUBPAGE>]\n"
+    Then the output should contain exactly:
+      """
+      Usage: hub browse [-uc] [[<USER>/]<REPOSITORY>|--] [<SUBPAGE>]
+             hub browse [-uc] --blame <FILE>[:<LINE>]\n
+      """
 
   Scenario: Project with owner
     When I successfully run `hub browse mislav/dotfiles`
@@ -141,6 +145,22 @@ Feature: hub browse
     When I successfully run `hub browse`
     Then "open https://github.com/mislav/dotfiles" should be run
 
+  Scenario: No branch to commits
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I am in detached HEAD
+    When I successfully run `hub browse -u -- commits`
+    Then the output should match /^https:\/\/github\.com\/mislav\/dotfiles\/commits\/[0-9a-f]{40}$/
+
+  Scenario: Blame a file
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    And I am on the "feature" branch pushed to "origin/feature"
+    And a file named "lib/utils.rb" with:
+      """
+      puts "hi"
+      """
+    When I successfully run `hub browse --blame lib/utils.rb:12`
+    Then "open https://github.com/mislav/dotfiles/blame/feature/lib/utils.rb#L12" should be run
+
   Scenario: No branch to pulls
     Given I am in "git://github.com/mislav/dotfiles.git" git repo
     And I am in detached HEAD
@@ -0,0 +1,57 @@
+Feature: hub cache
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List caches
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/caches') {
+        assert :key => "Linux-", :ref => "refs/heads/main"
+        json :total_count => 2, :actions_caches => [
+          { :id => 505, :key => "Linux-node-abc123", :ref => "refs/heads/main",
+            :size_in_bytes => 2097152 },
+          { :id => 12, :key => "Linux-go-def456", :ref => "refs/heads/main",
+            :size_in_bytes => 512 },
+        ]
+      }
+      """
+    When I successfully run `hub cache list -k Linux- -b main -f "%I %K %s%n"`
+    Then the output should contain exactly:
+      """
+      505 Linux-node-abc123 2.0 MB
+      12 Linux-go-def456 512 B\n
+      """
+
+  Scenario: Delete caches by key prefix
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/caches') {
+        assert :key => "Linux-node-", :ref => nil
+        json :total_count => 2, :actions_caches => [
+          { :id => 505, :key => "Linux-node-abc123", :ref => "refs/heads/main" },
+          { :id => 506, :key => "Linux-node-def456", :ref => "refs/heads/topic" },
+        ]
+      }
+      delete('/repos/github/hub/actions/caches/505') { status 204 }
+      delete('/repos/github/hub/actions/caches/506') { status 204 }
+      """
+    When I successfully run `hub cache delete --key Linux-node-`
+    Then the output should contain exactly:
+      """
+      Deleted cache 505 (Linux-node-abc123).
+      Deleted cache 506 (Linux-node-def456).\n
+      """
+
+  Scenario: Delete caches by ID
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/actions/caches/505') { status 204 }
+      """
+    When I successfully run `hub cache delete 505`
+    Then the output should contain exactly "Deleted cache 505.\n"
+
+  Scenario: Delete requires IDs or filters
+    When I run `hub cache delete`
+    Then the exit status should be 1
+    And the stderr should contain "specify either cache IDs or --key and --branch"
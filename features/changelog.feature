@@ -0,0 +1,147 @@
+Feature: hub changelog
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And I make 3 commits
+
+  Scenario: Group merged pull requests by label
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :variables => { :owner => "github", :repo => "hub" }
+        halt 400 unless params[:query].include?('c2: object(oid:')
+        json :data => { :repository => {
+          :c0 => {
+            :oid => "1111111aaaaaaa", :messageHeadline => "Add thing",
+            :parents => { :totalCount => 1 },
+            :associatedPullRequests => { :nodes => [
+              { :number => 12, :title => "Add thing", :merged => true,
+                :labels => { :nodes => [{ :name => "enhancement" }] } },
+            ] },
+          },
+          :c1 => {
+            :oid => "2222222bbbbbbb", :messageHeadline => "Fix thing",
+            :parents => { :totalCount => 1 },
+            :associatedPullRequests => { :nodes => [
+              { :number => 13, :title => "Fix thing", :merged => true,
+                :labels => { :nodes => [{ :name => "Type: Bug" }] } },
+            ] },
+          },
+          :c2 => {
+            :oid => "3333333ccccccc", :messageHeadline => "Bump version",
+            :parents => { :totalCount => 1 },
+            :associatedPullRequests => { :nodes => [] },
+          },
+        } }
+      }
+      """
+    When I successfully run `hub changelog HEAD~3`
+    Then the output should contain exactly:
+      """
+      ## [Unreleased]
+
+      ### Added
+
+      - Add thing (#12)
+
+      ### Changed
+
+      - Bump version (3333333)
+
+      ### Fixed
+
+      - Fix thing (#13)\n
+      """
+
+  Scenario: Output JSON
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => {
+          :c0 => {
+            :oid => "1111111aaaaaaa", :messageHeadline => "Add thing",
+            :parents => { :totalCount => 1 },
+            :associatedPullRequests => { :nodes => [
+              { :number => 12, :title => "Add thing", :merged => true, :url => "https://github.com/github/hub/pull/12",
+                :author => { :login => "octocat" },
+                :labels => { :nodes => [{ :name => "feature" }] } },
+            ] },
+          },
+        } }
+      }
+      """
+    When I successfully run `hub changelog HEAD~1.. --format json`
+    Then the output should contain exactly:
+      """
+      {
+        "version": "Unreleased",
+        "sections": [
+          {
+            "name": "Added",
+            "entries": [
+              {
+                "title": "Add thing",
+                "number": 12,
+                "url": "https://github.com/github/hub/pull/12",
+                "author": "octocat",
+                "labels": [
+                  "feature"
+                ]
+              }
+            ]
+          }
+        ]
+      }\n
+      """
+
+  Scenario: Group by Conventional Commits type
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => {
+          :c0 => {
+            :oid => "1111111aaaaaaa", :messageHeadline => "feat(cli): add thing",
+            :parents => { :totalCount => 1 },
+            :associatedPullRequests => { :nodes => [
+              { :number => 12, :title => "feat(cli): add thing", :merged => true,
+                :labels => { :nodes => [{ :name => "bug" }] } },
+            ] },
+          },
+          :c1 => {
+            :oid => "2222222bbbbbbb", :messageHeadline => "fix!: drop old config",
+            :parents => { :totalCount => 1 },
+            :associatedPullRequests => { :nodes => [
+              { :number => 13, :title => "fix!: drop old config", :merged => true,
+                :labels => { :nodes => [] } },
+            ] },
+          },
+          :c2 => {
+            :oid => "3333333ccccccc", :messageHeadline => "chore: bump version",
+            :parents => { :totalCount => 1 },
+            :associatedPullRequests => { :nodes => [] },
+          },
+        } }
+      }
+      """
+    When I successfully run `hub changelog --conventional HEAD~3`
+    Then the output should contain exactly:
+      """
+      ## [Unreleased]
+
+      ### Breaking Changes
+
+      - drop old config (#13)
+
+      ### Features
+
+      - **cli:** add thing (#12)
+
+      ### Chores
+
+      - bump version (3333333)\n
+      """
+
+  Scenario: Invalid format
+    When I run `hub changelog HEAD~3 --format yaml`
+    Then the exit status should be 1
+    And the stderr should contain exactly "error: invalid format 'yaml' (expected md or json)\n"
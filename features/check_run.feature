@@ -0,0 +1,49 @@
+Feature: hub check-run
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+    And there is a commit named "the_sha"
+
+  Scenario: Create a completed check run with a report
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/check-runs') {
+        assert :name => "build",
+          :head_sha => /\A\h{40}\z/,
+          :status => "completed",
+          :conclusion => "success",
+          :output => { :title => "Build report", :summary => "All 42 tests passed.\n" }
+        status 201
+        json :id => 4, :html_url => "https://github.com/github/hub/runs/4"
+      }
+      """
+    Given a file named "report.md" with:
+      """
+      All 42 tests passed.
+
+      """
+    When I successfully run `hub check-run create -n build -c success -t "Build report" -F report.md the_sha`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/runs/4\n
+      """
+
+  Scenario: Create a queued check run for HEAD
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/check-runs') {
+        assert :name => "lint", :status => :no, :conclusion => :no, :output => :no
+        status 201
+        json :id => 5, :html_url => "https://github.com/github/hub/runs/5"
+      }
+      """
+    When I successfully run `hub check-run create -n lint`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/runs/5\n
+      """
+
+  Scenario: Name is required
+    When I run `hub check-run create`
+    Then the exit status should be 1
+    And the stderr should contain "missing --name"
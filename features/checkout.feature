@@ -154,6 +154,61 @@ Feature: hub checkout <PULLREQ-URL>
     And "git checkout -f -b fixes --no-track origin/fixes -q" should be run
     And "fixes" should merge "refs/heads/fixes" from remote "origin"
 
+  Scenario: Merged pull request with deleted branch
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/pulls/77') {
+        json :number => 77, :state => "closed",
+          :merged_at => "2026-10-01T12:00:00Z",
+          :merge_commit_sha => "8a1c3f57d6e0c1b2a3f4e5d6c7b8a9f0e1d2c3b4",
+          :head => {
+            :ref => "fixes",
+            :sha => "b7ee5c1a2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a",
+            :repo => {
+              :name => "jekyll",
+              :owner => { :login => "mojombo" },
+            }
+          }, :base => {
+            :repo => {
+              :name => "jekyll",
+              :html_url => "https://github.com/mojombo/jekyll",
+              :owner => { :login => "mojombo" },
+            }
+          }
+      }
+      """
+    When I successfully run `hub checkout https://github.com/mojombo/jekyll/pull/77`
+    Then "git fetch origin 8a1c3f57d6e0c1b2a3f4e5d6c7b8a9f0e1d2c3b4" should be run
+    And "git checkout 8a1c3f57d6e0c1b2a3f4e5d6c7b8a9f0e1d2c3b4" should be run
+    And the stderr should contain "Pull request #77 was merged; checking out its merge commit 8a1c3f5\n"
+
+  Scenario: Closed pull request with custom branch name
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/pulls/77') {
+        json :number => 77, :state => "closed",
+          :merged_at => nil,
+          :head => {
+            :ref => "fixes",
+            :sha => "b7ee5c1a2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a",
+            :repo => {
+              :name => "jekyll",
+              :owner => { :login => "mojombo" },
+            }
+          }, :base => {
+            :repo => {
+              :name => "jekyll",
+              :html_url => "https://github.com/mojombo/jekyll",
+              :owner => { :login => "mojombo" },
+            }
+          }
+      }
+      """
+    When I successfully run `hub checkout https://github.com/mojombo/jekyll/pull/77 old-fixes`
+    Then "git fetch origin refs/pull/77/head" should be run
+    And "git checkout -b old-fixes b7ee5c1a2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a" should be run
+    And the stderr should contain "Pull request #77 is closed; checking out its last commit b7ee5c1\n"
+
   Scenario: Same-repo with custom branch name
     Given the GitHub API server:
       """
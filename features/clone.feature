@@ -174,6 +174,55 @@ Feature: hub clone
     And the stderr should contain exactly "Error: repository mislav/dotfiles doesn't exist\n"
     And it should not clone anything
 
+  Scenario: Accept a pending invitation before cloning
+    Given the GitHub API server:
+      """
+      accepted = false
+      get('/repos/defunkt/secret') {
+        halt 404 unless accepted
+        json :private => true,
+             :name => 'secret', :owner => { :login => 'defunkt' },
+             :permissions => { :push => true }
+      }
+      get('/user/repository_invitations') {
+        json [
+          { :id => 42,
+            :repository => { :full_name => "defunkt/secret" },
+            :inviter => { :login => "defunkt" },
+            :permissions => "write",
+          },
+        ]
+      }
+      patch('/user/repository_invitations/42') {
+        accepted = true
+        status 204
+      }
+      """
+    When I run `hub clone defunkt/secret` interactively
+    And I type "y"
+    Then the exit status should be 0
+    And it should clone "git@github.com:defunkt/secret.git"
+    And the stderr should contain "You have a pending invitation to collaborate on defunkt/secret from defunkt."
+
+  Scenario: Decline to accept a pending invitation
+    Given the GitHub API server:
+      """
+      get('/repos/defunkt/secret') { status 404 }
+      get('/user/repository_invitations') {
+        json [
+          { :id => 42,
+            :repository => { :full_name => "defunkt/secret" },
+            :permissions => "write",
+          },
+        ]
+      }
+      """
+    When I run `hub clone defunkt/secret` interactively
+    And I type "n"
+    Then the exit status should be 1
+    And the stderr should contain "Error: accept the invitation with `hub invitation accept defunkt/secret` before cloning"
+    And it should not clone anything
+
   Scenario: Clone my repo with arguments
     Given the GitHub API server:
       """
@@ -0,0 +1,59 @@
+Feature: hub codeowners
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Check a valid CODEOWNERS file
+    Given a file named ".github/CODEOWNERS" with:
+      """
+      *       @mislav
+      *.go    @github/go-reviewers docs@example.com
+      """
+    Given the GitHub API server:
+      """
+      get('/users/mislav') { json :login => "mislav" }
+      get('/orgs/github/teams/go-reviewers') { json :slug => "go-reviewers" }
+      """
+    When I successfully run `hub codeowners check`
+    Then the output should contain exactly ""
+
+  Scenario: Report problems in CODEOWNERS
+    Given a file named "CODEOWNERS" with:
+      """
+      *       @mislav
+      !vendor @mislav
+      docs/   octocat @octocta
+      """
+    Given the GitHub API server:
+      """
+      get('/users/mislav') { json :login => "mislav" }
+      get('/users/octocta') { status 404; json :message => "Not Found" }
+      """
+    When I run `hub codeowners check`
+    Then the exit status should be 1
+    And the output should contain exactly:
+      """
+      CODEOWNERS:2: negated pattern '!vendor' is not supported
+      CODEOWNERS:3: invalid owner 'octocat'
+      CODEOWNERS:3: unknown user or team '@octocta'\n
+      """
+
+  Scenario: No CODEOWNERS file
+    When I run `hub codeowners check`
+    Then the exit status should be 1
+    And the stderr should contain "error: no CODEOWNERS file found in .github/CODEOWNERS, CODEOWNERS, docs/CODEOWNERS"
+
+  Scenario: Look up owners of files
+    Given a file named "docs/CODEOWNERS" with:
+      """
+      *       @mislav
+      /lib/   @github/hub-maintainers
+      *.md
+      """
+    When I successfully run `hub codeowners who README.md lib/ lib/hub.rb`
+    Then the output should contain exactly:
+      """
+      README.md   (no owners)
+      lib/        @github/hub-maintainers
+      lib/hub.rb  @github/hub-maintainers\n
+      """
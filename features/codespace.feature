@@ -0,0 +1,91 @@
+Feature: hub codespace
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List codespaces for the current repository
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/codespaces') {
+        json :total_count => 2, :codespaces => [
+          { :name => "mislav-hub-a1b2c3", :state => "Available",
+            :repository => { :full_name => "github/hub" },
+            :git_status => { :ref => "master" } },
+          { :name => "mislav-hub-d4e5f6", :state => "Shutdown",
+            :repository => { :full_name => "github/hub" },
+            :git_status => { :ref => "feature" } },
+        ]
+      }
+      """
+    When I successfully run `hub codespace list`
+    Then the output should contain exactly:
+      """
+      mislav-hub-a1b2c3                 Available   github/hub@master
+      mislav-hub-d4e5f6                 Shutdown    github/hub@feature\n
+      """
+
+  Scenario: List all codespaces
+    Given the GitHub API server:
+      """
+      get('/user/codespaces') {
+        json :total_count => 1, :codespaces => [
+          { :name => "mislav-octo-x1", :machine => { :name => "basicLinux32gb" } },
+        ]
+      }
+      """
+    When I successfully run `hub codespace list --all -f "%I %M%n"`
+    Then the output should contain exactly:
+      """
+      mislav-octo-x1 basicLinux32gb\n
+      """
+
+  Scenario: Create a codespace for the current branch
+    Given I am on the "feature" branch
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/codespaces') {
+        assert :ref => "feature",
+               :machine => "basicLinux32gb",
+               :idle_timeout_minutes => 30
+        status 202
+        json :name => "mislav-hub-g7h8i9", :state => "Queued"
+      }
+      """
+    When I successfully run `hub codespace create -m basicLinux32gb --idle-timeout 30`
+    Then the output should contain exactly:
+      """
+      mislav-hub-g7h8i9\n
+      """
+
+  Scenario: Stop the codespace for the current branch
+    Given I am on the "feature" branch
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/codespaces') {
+        json :total_count => 2, :codespaces => [
+          { :name => "mislav-hub-a1b2c3", :git_status => { :ref => "master" } },
+          { :name => "mislav-hub-d4e5f6", :git_status => { :ref => "feature" } },
+        ]
+      }
+      post('/user/codespaces/mislav-hub-d4e5f6/stop') {
+        json :name => "mislav-hub-d4e5f6", :state => "ShuttingDown"
+      }
+      """
+    When I successfully run `hub codespace stop`
+    Then the output should contain exactly ""
+
+  Scenario: No codespace for the current branch
+    Given I am on the "topic" branch
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/codespaces') {
+        json :total_count => 0, :codespaces => []
+      }
+      """
+    When I run `hub codespace ssh`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: no codespace found for branch topic
+      (use `hub codespace create` to create one)\n
+      """
@@ -0,0 +1,107 @@
+Feature: hub collab
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List collaborators
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/collaborators') {
+        json [
+          { :login => "mislav", :role_name => "admin" },
+          { :login => "octocat", :role_name => "maintain" },
+        ]
+      }
+      """
+    When I successfully run `hub collab list`
+    Then the output should contain exactly:
+      """
+      mislav   admin
+      octocat  maintain\n
+      """
+
+  Scenario: Invite a collaborator
+    Given the GitHub API server:
+      """
+      put('/repos/github/hub/collaborators/octocat') {
+        assert :permission => "maintain"
+        status 201
+        json :id => 123
+      }
+      """
+    When I successfully run `hub collab add -p maintain @octocat`
+    Then the output should contain exactly:
+      """
+      Invited octocat to collaborate on github/hub.\n
+      """
+
+  Scenario: Update permission of an existing collaborator
+    Given the GitHub API server:
+      """
+      put('/repos/github/hub/collaborators/octocat') {
+        assert :permission => :no
+        status 204
+      }
+      """
+    When I successfully run `hub collab add octocat`
+    Then the output should contain exactly:
+      """
+      Updated permission of octocat on github/hub.\n
+      """
+
+  Scenario: Remove a collaborator
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/collaborators/octocat') {
+        status 204
+      }
+      """
+    When I successfully run `hub collab remove octocat`
+    Then the output should contain exactly:
+      """
+      Removed octocat from github/hub.\n
+      """
+
+  Scenario: List invitations
+    Given the GitHub API server:
+      """
+      get('/user/repository_invitations') {
+        json [
+          { :id => 123456,
+            :repository => { :full_name => "octocat/dotfiles" },
+            :inviter => { :login => "octocat" },
+            :permissions => "write",
+          },
+        ]
+      }
+      """
+    When I successfully run `hub invitation list`
+    Then the output should contain exactly:
+      """
+        123456  octocat/dotfiles (write) from octocat\n
+      """
+
+  Scenario: Accept an invitation by repository name
+    Given the GitHub API server:
+      """
+      get('/user/repository_invitations') {
+        json [
+          { :id => 123456, :repository => { :full_name => "octocat/dotfiles" } },
+        ]
+      }
+      patch('/user/repository_invitations/123456') {
+        status 204
+      }
+      """
+    When I successfully run `hub invitation accept octocat/dotfiles`
+    Then the output should not contain anything
+
+  Scenario: Decline an invitation by ID
+    Given the GitHub API server:
+      """
+      delete('/user/repository_invitations/123456') {
+        status 204
+      }
+      """
+    When I successfully run `hub invitation decline 123456`
+    Then the output should not contain anything
@@ -0,0 +1,48 @@
+Feature: hub commit view
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: View a commit with pull requests and checks
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/commits/abc123') {
+        json :sha => "abc123def",
+          :commit => {
+            :message => "Fix typo",
+            :author => { :name => "Mislav", :email => "mislav@example.com", :date => "2026-10-01T12:00:00Z" },
+          }
+      }
+      get('/repos/github/hub/commits/abc123def/pulls') {
+        json [
+          { :number => 1234, :title => "Fix typo", :state => "closed", :merged_at => "2026-10-02T00:00:00Z" },
+        ]
+      }
+      get('/repos/github/hub/commits/abc123def/status') {
+        json :state => "success", :statuses => [
+          { :state => "success", :context => "build", :target_url => "https://ci.example.com/1" },
+        ]
+      }
+      get('/repos/github/hub/commits/abc123def/check-runs') {
+        json :check_runs => []
+      }
+      """
+    When I successfully run `hub commit view abc123`
+    Then the output should contain exactly:
+      """
+      commit abc123def
+      Author: Mislav <mislav@example.com>
+      Date:   Thu, 01 Oct 2026 12:00:00 +0000
+
+          Fix typo
+
+      Pull requests:
+         #1234  Fix typo (merged)
+
+      Checks:
+      ✔︎	build	https://ci.example.com/1\n
+      """
+
+  Scenario: Other commit invocations are passed to git
+    When I successfully run `hub commit --allow-empty -m "empty"`
+    Then "git commit --allow-empty -m empty" should be run
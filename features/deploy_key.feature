@@ -0,0 +1,70 @@
+Feature: hub deploy-key
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List deploy keys
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/keys') {
+        json [
+          { :id => 123, :title => "ci", :read_only => true },
+          { :id => 4567, :title => "release bot", :read_only => false },
+        ]
+      }
+      """
+    When I successfully run `hub deploy-key list`
+    Then the output should contain exactly:
+      """
+             123  ci (read-only)
+            4567  release bot (read-write)\n
+      """
+
+  Scenario: Add a read-only deploy key
+    Given a file named "ci.pub" with:
+      """
+      ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI ci@example.com
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/keys') {
+        assert :title => "ci@example.com",
+               :key => "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI ci@example.com",
+               :read_only => true
+        status 201
+        json :id => 123
+      }
+      """
+    When I successfully run `hub deploy-key add --read-only ci.pub`
+    Then the output should contain exactly "123\n"
+
+  Scenario: Add a deploy key with a custom title
+    Given a file named "ci.pub" with:
+      """
+      ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/keys') {
+        assert :title => "release bot", :read_only => false
+        status 201
+        json :id => 4567
+      }
+      """
+    When I successfully run `hub deploy-key add -t "release bot" ci.pub`
+    Then the output should contain exactly "4567\n"
+
+  Scenario: Remove a deploy key
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/keys/123') {
+        status 204
+      }
+      """
+    When I successfully run `hub deploy-key remove 123`
+    Then the output should not contain anything
+
+  Scenario: Remove without an ID
+    When I run `hub deploy-key remove`
+    Then the exit status should be 1
+    And the stderr should contain "Usage: hub deploy-key list"
@@ -0,0 +1,82 @@
+Feature: hub deployment
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List deployments for an environment
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/deployments') {
+        assert :environment => "staging", :ref => :no
+        json [
+          { :id => 42,
+            :sha => "a5f3ff1b8c4d1f5b2e0ad4c7b3e8f0a1b2c3d4e5",
+            :ref => "v2.1.0",
+            :environment => "staging",
+            :creator => { :login => "mislav" },
+          },
+        ]
+      }
+      """
+    When I successfully run `hub deployment list -e staging -f "%I %r %sh %au%n"`
+    Then the output should contain exactly:
+      """
+      42 v2.1.0 a5f3ff1 mislav\n
+      """
+
+  Scenario: Create a deployment
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/deployments') {
+        assert :ref => "v2.1.0",
+          :environment => "production",
+          :auto_merge => false,
+          :required_contexts => :no
+        status 201
+        json :id => 42
+      }
+      """
+    When I successfully run `hub deployment create -r v2.1.0`
+    Then the output should contain exactly:
+      """
+      42\n
+      """
+
+  Scenario: Create a deployment bypassing checks
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/deployments') {
+        assert :environment => "staging",
+          :description => "Hotfix",
+          :required_contexts => []
+        status 201
+        json :id => 43
+      }
+      """
+    When I successfully run `hub deployment create -e staging -r main -d Hotfix --skip-checks`
+    Then the output should contain exactly:
+      """
+      43\n
+      """
+
+  Scenario: Report deployment status
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/deployments/42/statuses') {
+        assert :state => "success",
+          :log_url => "https://ci.example.com/1",
+          :environment_url => :no
+        status 201
+        json :id => 1
+      }
+      """
+    When I successfully run `hub deployment status -u https://ci.example.com/1 42 success`
+    Then the output should not contain anything
+
+  Scenario: Invalid deployment state
+    When I run `hub deployment status 42 done`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: invalid deployment state 'done'\n
+      """
@@ -0,0 +1,70 @@
+Feature: hub digest
+  Background:
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Digest outside of a repository
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :variables => {
+          :reviewQuery => "is:pr is:open archived:false org:github review-requested:@me",
+          :failingQuery => "is:pr is:open archived:false org:github author:@me status:failure",
+        }
+        json :data => {
+          :viewer => { :login => "mislav" },
+          :reviewRequests => { :nodes => [
+            { :number => 12, :title => "Add feature", :createdAt => (Time.now - 3 * 86400).utc.iso8601,
+              :author => { :login => "octocat" }, :repository => { :nameWithOwner => "github/hub" } },
+          ] },
+          :failingPulls => { :nodes => [] },
+        }
+      }
+      """
+    When I successfully run `hub digest --org github`
+    Then the output should contain exactly:
+      """
+      Digest for mislav
+
+      Awaiting your review (1):
+        github/hub#12  Add feature (octocat, 3d)
+
+      Your pull requests with failing checks (0)\n
+      """
+
+  Scenario: Stale branches in the current repository
+    Given I am in "git://github.com/github/hub.git" git repo
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        if params[:query].include?("refs(")
+          assert :variables => { :owner => "github", :repo => "hub" }
+          json :data => { :repository => {
+            :defaultBranchRef => { :name => "master" },
+            :refs => { :nodes => [
+              { :name => "master", :target => { :committedDate => "2010-01-01T00:00:00Z", :author => { :user => { :login => "mislav" } } } },
+              { :name => "old-experiment", :target => { :committedDate => (Time.now - 45 * 86400).utc.iso8601, :author => { :user => { :login => "mislav" } } } },
+              { :name => "someone-else", :target => { :committedDate => "2010-01-01T00:00:00Z", :author => { :user => { :login => "octocat" } } } },
+              { :name => "fresh", :target => { :committedDate => Time.now.utc.iso8601, :author => { :user => { :login => "mislav" } } } },
+            ] },
+          } }
+        else
+          json :data => {
+            :viewer => { :login => "mislav" },
+            :reviewRequests => { :nodes => [] },
+            :failingPulls => { :nodes => [] },
+          }
+        end
+      }
+      """
+    When I successfully run `hub digest`
+    Then the output should contain exactly:
+      """
+      Digest for mislav
+
+      Awaiting your review (0)
+
+      Your pull requests with failing checks (0)
+
+      Stale branches in github/hub (1):
+        old-experiment (45d)\n
+      """
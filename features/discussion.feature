@@ -0,0 +1,206 @@
+Feature: hub discussion
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List discussions
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :query => /discussions\(/,
+          :variables => { :owner => "github", :repo => "hub", :category => nil }
+        json :data => {
+          :repository => {
+            :discussions => {
+              :nodes => [
+                { :number => 42, :title => "How do I configure hub?", :category => { :name => "Q&A" } },
+                { :number => 7, :title => "Support GitLab", :category => { :name => "Ideas" } },
+              ]
+            }
+          }
+        }
+      }
+      """
+    When I successfully run `hub discussion list`
+    Then the output should contain exactly:
+      """
+           #42  How do I configure hub?  (Q&A)
+            #7  Support GitLab  (Ideas)\n
+      """
+
+  Scenario: List discussions in a category
+    Given the GitHub API server:
+      """
+      count = 0
+      post('/graphql') {
+        count += 1
+        case count
+        when 1
+          assert :query => /discussionCategories\(/
+          json :data => { :repository => { :id => "REPO-ID", :discussionCategories => { :nodes => [
+            { :id => "CAT-1", :name => "General", :slug => "general" },
+            { :id => "CAT-2", :name => "Q&A", :slug => "q-a" },
+          ] } } }
+        when 2
+          assert :variables => { :category => "CAT-2", :limit => 10 }
+          json :data => { :repository => { :discussions => { :nodes => [
+            { :number => 42, :title => "How do I configure hub?", :url => "the://url" },
+          ] } } }
+        else
+          status 400
+          json :message => "request not stubbed"
+        end
+      }
+      """
+    When I successfully run `hub discussion list --category "q&a" -L 10 -f "%I %U%n"`
+    Then the output should contain exactly:
+      """
+      42 the://url\n
+      """
+
+  Scenario: List discussions in an unknown category
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => { :id => "REPO-ID", :discussionCategories => { :nodes => [
+          { :id => "CAT-1", :name => "General", :slug => "general" },
+        ] } } }
+      }
+      """
+    When I run `hub discussion list --category Ideas`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: no discussion category named 'Ideas'
+      Available categories: General\n
+      """
+
+  Scenario: View a discussion
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :variables => { :number => 42, :comments => 100 }
+        json :data => {
+          :repository => {
+            :discussion => {
+              :number => 42,
+              :title => "How do I configure hub?",
+              :body => "I can't find the docs.",
+              :url => "https://github.com/github/hub/discussions/42",
+              :isAnswered => true,
+              :createdAt => "2017-04-14T16:00:49Z",
+              :author => { :login => "octocat" },
+              :category => { :name => "Q&A" },
+              :commentList => {
+                :nodes => [
+                  { :body => "See hub(1).", :createdAt => "2017-04-14T17:00:49Z", :author => { :login => "mislav" } },
+                ]
+              }
+            }
+          }
+        }
+      }
+      """
+    When I successfully run `hub discussion view 42`
+    Then the output should contain exactly:
+      """
+      # [ANSWERED] How do I configure hub?
+
+      * created by @octocat on 2017-04-14 16:00:49 +0000 UTC
+      * category: Q&A
+      * https://github.com/github/hub/discussions/42
+
+      I can't find the docs.
+
+      ## Comments:
+
+      ### comment by @mislav on 2017-04-14 17:00:49 +0000 UTC
+
+      See hub(1).\n
+      """
+
+  Scenario: View a nonexistent discussion
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => { :discussion => nil } }
+      }
+      """
+    When I run `hub discussion view 9`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: no discussion found with number 9 in github/hub\n
+      """
+
+  Scenario: Create a discussion
+    Given the GitHub API server:
+      """
+      count = 0
+      post('/graphql') {
+        count += 1
+        case count
+        when 1
+          json :data => { :repository => { :id => "REPO-ID", :discussionCategories => { :nodes => [
+            { :id => "CAT-3", :name => "Ideas", :slug => "ideas" },
+          ] } } }
+        when 2
+          assert :query => /createDiscussion\(/,
+            :variables => { :input => {
+              :repositoryId => "REPO-ID",
+              :categoryId => "CAT-3",
+              :title => "Support GitLab",
+              :body => "It would be nice.",
+            } }
+          json :data => { :createDiscussion => { :discussion => {
+            :url => "https://github.com/github/hub/discussions/43",
+          } } }
+        else
+          status 400
+          json :message => "request not stubbed"
+        end
+      }
+      """
+    When I successfully run `hub discussion create --category ideas -m "Support GitLab" -m "It would be nice."`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/discussions/43\n
+      """
+
+  Scenario: Create a discussion without a category
+    When I run `hub discussion create -m "Support GitLab"`
+    Then the exit status should be 1
+    And the stderr should contain "missing --category"
+
+  Scenario: Comment on a discussion
+    Given the GitHub API server:
+      """
+      count = 0
+      post('/graphql') {
+        count += 1
+        case count
+        when 1
+          assert :variables => { :number => 42, :comments => 0 }
+          json :data => { :repository => { :discussion => { :id => "DISCUSSION-ID" } } }
+        when 2
+          assert :query => /addDiscussionComment\(/,
+            :variables => { :discussion => "DISCUSSION-ID", :body => "Have you tried\nhub api?" }
+          json :data => { :addDiscussionComment => { :comment => {
+            :url => "https://github.com/github/hub/discussions/42#discussioncomment-1",
+          } } }
+        else
+          status 400
+          json :message => "request not stubbed"
+        end
+      }
+      """
+    Given a file named "comment.txt" with:
+      """
+      Have you tried
+      hub api?
+      """
+    When I successfully run `hub discussion comment -F comment.txt 42`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/discussions/42#discussioncomment-1\n
+      """
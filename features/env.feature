@@ -0,0 +1,93 @@
+Feature: hub env
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List environments
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/environments') {
+        json :total_count => 2, :environments => [
+          { :name => "production",
+            :protection_rules => [
+              { :type => "required_reviewers", :reviewers => [
+                { :type => "User", :reviewer => { :id => 1, :login => "mislav" } },
+                { :type => "Team", :reviewer => { :id => 2, :slug => "ops" } },
+              ] },
+              { :type => "wait_timer", :wait_timer => 30 },
+            ],
+            :deployment_branch_policy => { :protected_branches => true, :custom_branch_policies => false } },
+          { :name => "staging", :protection_rules => [], :deployment_branch_policy => nil },
+        ]
+      }
+      """
+    When I successfully run `hub env list`
+    Then the output should contain exactly:
+      """
+      production  reviewers: mislav, github/ops; wait timer: 30 minutes; branches: protected
+      staging     no protection rules\n
+      """
+
+  Scenario: Create an environment
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/environments/production') {
+        status 404
+        json :message => "Not Found"
+      }
+      get('/users/mislav') {
+        json :id => 1, :login => "mislav"
+      }
+      get('/orgs/github/teams/ops') {
+        json :id => 2, :slug => "ops"
+      }
+      put('/repos/github/hub/environments/production') {
+        assert :wait_timer => 30,
+               :reviewers => [{ :type => "User", :id => 1 }, { :type => "Team", :id => 2 }],
+               :deployment_branch_policy => { :protected_branches => false, :custom_branch_policies => true }
+        json :name => "production"
+      }
+      post('/repos/github/hub/environments/production/deployment-branch-policies') {
+        assert :name => "releases/*"
+        json :id => 3, :name => "releases/*"
+      }
+      """
+    When I successfully run `hub env create -r mislav,github/ops -w 30 -b "releases/*" production`
+    Then the output should not contain anything
+
+  Scenario: Create an environment that exists
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/environments/production') {
+        json :name => "production", :protection_rules => []
+      }
+      """
+    When I run `hub env create production`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: environment 'production' already exists in github/hub; use `hub env edit` to change it\n
+      """
+
+  Scenario: Edit an environment keeps other rules
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/environments/production') {
+        json :name => "production",
+          :protection_rules => [
+            { :type => "required_reviewers", :reviewers => [
+              { :type => "User", :reviewer => { :id => 1, :login => "mislav" } },
+            ] },
+            { :type => "wait_timer", :wait_timer => 30 },
+          ],
+          :deployment_branch_policy => { :protected_branches => true, :custom_branch_policies => false }
+      }
+      put('/repos/github/hub/environments/production') {
+        assert :wait_timer => 0,
+               :reviewers => [{ :type => "User", :id => 1 }],
+               :deployment_branch_policy => { :protected_branches => true, :custom_branch_policies => false }
+        json :name => "production"
+      }
+      """
+    When I successfully run `hub env edit --wait-timer 0 production`
+    Then the output should not contain anything
@@ -0,0 +1,58 @@
+Feature: hub foreach
+  Background:
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Run a command in repositories with a topic
+    Given the GitHub API server:
+      """
+      get('/orgs/acme/repos') {
+        json [
+          { :name => "web", :full_name => "acme/web", :owner => { :login => "acme" }, :topics => ["service"] },
+          { :name => "docs", :full_name => "acme/docs", :owner => { :login => "acme" }, :topics => [] },
+          { :name => "api", :full_name => "acme/api", :owner => { :login => "acme" }, :topics => ["service"] },
+          { :name => "old", :full_name => "acme/old", :owner => { :login => "acme" }, :topics => ["service"], :archived => true },
+        ]
+      }
+      get('/repos/acme/api/labels') {
+        json [{ :name => "bug", :color => "d73a4a" }]
+      }
+      get('/repos/acme/web/labels') {
+        json [{ :name => "feature", :color => "008672" }]
+      }
+      """
+    When I successfully run `hub foreach --org acme --topic service -j 1 -- label`
+    Then the output should contain exactly:
+      """
+      ==> acme/api
+      bug
+      ==> acme/web
+      feature
+
+      2 succeeded\n
+      """
+
+  Scenario: Report failed runs
+    Given the GitHub API server:
+      """
+      get('/orgs/acme/repos') {
+        json [
+          { :name => "api", :full_name => "acme/api", :owner => { :login => "acme" } },
+          { :name => "web", :full_name => "acme/web", :owner => { :login => "acme" } },
+        ]
+      }
+      get('/repos/acme/api/labels') {
+        json [{ :name => "bug", :color => "d73a4a" }]
+      }
+      get('/repos/acme/web/labels') {
+        status 404
+        json :message => "Not Found"
+      }
+      """
+    When I run `hub foreach --org acme -j 1 -- label`
+    Then the exit status should be 1
+    And the output should contain "1 succeeded, 1 failed: acme/web"
+
+  Scenario: Command is required
+    When I run `hub foreach --org acme`
+    Then the exit status should be 1
+    And the stderr should contain "missing command after '--'"
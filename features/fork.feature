@@ -313,3 +313,81 @@ Feature: hub fork
     When I successfully run `hub fork --org=acme`
     Then the output should contain exactly "new remote: acme\n"
     Then the url for "acme" should be "git@github.com:acme/dotfiles.git"
+
+  Scenario: Sync your fork of the repository
+    Given the GitHub API server:
+      """
+      get('/repos/evilchelu/dotfiles') {
+        json :name => 'dotfiles', :default_branch => 'master',
+             :html_url => 'https://github.com/evilchelu/dotfiles'
+      }
+      get('/repos/mislav/dotfiles') {
+        json :name => 'dotfiles', :default_branch => 'master',
+             :html_url => 'https://github.com/mislav/dotfiles',
+             :parent => { :name => 'dotfiles', :html_url => 'https://github.com/evilchelu/dotfiles' }
+      }
+      post('/repos/mislav/dotfiles/merge-upstream') {
+        assert :branch => 'master'
+        json :message => 'Successfully fetched and fast-forwarded from upstream evilchelu:master',
+             :merge_type => 'fast-forward', :base_branch => 'evilchelu:master'
+      }
+      """
+    When I successfully run `hub fork sync`
+    Then the output should contain exactly "Synced mislav/dotfiles:master with evilchelu/dotfiles (fast-forward)\n"
+
+  Scenario: Sync a branch of a fork that is up to date
+    Given the "origin" remote has url "git://github.com/mislav/dotfiles.git"
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/dotfiles') {
+        json :name => 'dotfiles', :default_branch => 'master',
+             :html_url => 'https://github.com/mislav/dotfiles',
+             :parent => { :name => 'dotfiles', :html_url => 'https://github.com/evilchelu/dotfiles' }
+      }
+      post('/repos/mislav/dotfiles/merge-upstream') {
+        assert :branch => 'feature'
+        json :message => 'This branch is not behind the upstream evilchelu:feature.',
+             :merge_type => 'none', :base_branch => 'evilchelu:feature'
+      }
+      """
+    When I successfully run `hub fork sync feature`
+    Then the output should contain exactly "mislav/dotfiles:feature is already up to date with evilchelu/dotfiles\n"
+
+  Scenario: Sync without a fork
+    Given the GitHub API server:
+      """
+      get('/repos/evilchelu/dotfiles') {
+        json :name => 'dotfiles', :default_branch => 'master',
+             :html_url => 'https://github.com/evilchelu/dotfiles'
+      }
+      get('/repos/mislav/dotfiles') { 404 }
+      """
+    When I run `hub fork sync`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Error syncing fork: evilchelu/dotfiles has no fork owned by mislav\n"
+
+  Scenario: Sync a fork on an older Enterprise version
+    Given the "origin" remote has url "git@git.my.org:mislav/dotfiles.git"
+    And the "upstream" remote has url "git@git.my.org:evilchelu/dotfiles.git"
+    And I am "mislav" on git.my.org with OAuth token "FITOKEN"
+    And "git.my.org" is a whitelisted Enterprise host
+    Given the GitHub API server:
+      """
+      get('/api/v3/meta', :host_name => 'git.my.org') {
+        json :installed_version => "3.2.5"
+      }
+      get('/api/v3/repos/evilchelu/dotfiles', :host_name => 'git.my.org') {
+        json :name => 'dotfiles', :default_branch => 'master',
+             :html_url => 'https://git.my.org/evilchelu/dotfiles'
+      }
+      get('/api/v3/repos/mislav/dotfiles', :host_name => 'git.my.org') {
+        json :name => 'dotfiles', :default_branch => 'master',
+             :html_url => 'https://git.my.org/mislav/dotfiles',
+             :parent => { :name => 'dotfiles', :html_url => 'https://git.my.org/evilchelu/dotfiles' }
+      }
+      post('/api/v3/repos/mislav/dotfiles/merge-upstream', :host_name => 'git.my.org') { 404 }
+      """
+    When I successfully run `hub fork sync`
+    Then the output should contain exactly "Synced mislav/dotfiles:master with evilchelu/dotfiles by pushing from upstream to origin\n"
+    And "git fetch --quiet upstream master" should be run
+    And "git push --quiet origin FETCH_HEAD:refs/heads/master" should be run
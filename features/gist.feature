@@ -152,6 +152,54 @@ Feature: hub gist
       http://gists.github.com/somehash
       """
 
+  Scenario: Create a gist from stdin with an inferred extension
+    Given the GitHub API server:
+      """
+      post('/gists') {
+        halt 400 unless params[:files]["gistfile1.sh"]["content"] == "#!/bin/sh\necho hello\n"
+        status 201
+        json :html_url => 'http://gists.github.com/somehash'
+      }
+      """
+    When I run `hub gist create` interactively
+    And I pass in:
+      """
+      #!/bin/sh
+      echo hello
+      """
+    Then the output should contain exactly:
+      """
+      http://gists.github.com/somehash
+      """
+
+  Scenario: Create a gist from stdin with a filename
+    Given the GitHub API server:
+      """
+      post('/gists') {
+        halt 400 unless params[:files]["snippet.go"]["content"] == "package main\n"
+        status 201
+        json :html_url => 'http://gists.github.com/somehash'
+      }
+      """
+    When I run `hub gist create -n snippet.go` interactively
+    And I pass in:
+      """
+      package main
+      """
+    Then the output should contain exactly:
+      """
+      http://gists.github.com/somehash
+      """
+
+  Scenario: Combine clipboard with files
+    Given a file named "testfile.txt" with:
+      """
+      this is a test file
+      """
+    When I run `hub gist create --from-clipboard testfile.txt`
+    Then the exit status should be 1
+    And the stderr should contain "--from-clipboard can't be combined with files"
+
   Scenario: Insufficient OAuth scopes
     Given the GitHub API server:
       """
@@ -0,0 +1,53 @@
+Feature: hub guard
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Pull request satisfies the requirements
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :variables => { :owner => "github", :repo => "hub", :number => 12 }
+        json :data => { :repository => { :pullRequest => {
+          :number => 12,
+          :reviewDecision => "APPROVED",
+          :mergeStateStatus => "CLEAN",
+          :baseRef => { :branchProtectionRule => { :requiredStatusCheckContexts => ["build"] } },
+          :commits => { :nodes => [{ :commit => { :statusCheckRollup => { :contexts => { :nodes => [
+            { :name => "build", :conclusion => "SUCCESS" },
+          ] } } } }] },
+        } } }
+      }
+      """
+    When I successfully run `hub guard 12`
+    Then the output should contain exactly:
+      """
+      ✔ checks: 1 of 1 required checks passed
+      ✔ reviews: approved
+      ✔ up-to-date: head branch is up to date\n
+      """
+
+  Scenario: Pull request does not satisfy the requirements
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => { :pullRequest => {
+          :number => 12,
+          :reviewDecision => "REVIEW_REQUIRED",
+          :mergeStateStatus => "BEHIND",
+          :baseRef => { :branchProtectionRule => nil },
+          :commits => { :nodes => [] },
+        } } }
+      }
+      """
+    When I run `hub guard --reviews --up-to-date 12`
+    Then the exit status should be 1
+    And the output should contain exactly:
+      """
+      ✖ reviews: review required
+      ✖ up-to-date: head branch is behind the base branch\n
+      """
+    And the stderr should contain exactly:
+      """
+      Aborted: pull request #12 does not satisfy: reviews, up-to-date\n
+      """
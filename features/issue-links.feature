@@ -0,0 +1,57 @@
+Feature: hub issue links
+  Background:
+    Given I am in "git://github.com/octocat/hello-world.git" git repo
+    And I am "srafi1" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Show linked items
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      assert :query => /issueOrPullRequest\(number: \$number\)/,
+        :variables => { :owner => "octocat", :repo => "hello-world", :number => 123 }
+      repo = { :nameWithOwner => "octocat/hello-world" }
+      json :data => { :repository => { :issueOrPullRequest => {
+        :number => 123, :title => "Crash on empty remote", :state => "OPEN", :repository => repo,
+        :closedByPullRequestsReferences => { :nodes => [
+          { :number => 130, :title => "Handle empty remotes", :state => "MERGED", :repository => repo },
+        ] },
+        :trackedIssues => { :nodes => [] },
+        :trackedInIssues => { :nodes => [
+          { :number => 100, :title => "v2.15 tasks", :state => "OPEN", :repository => repo },
+        ] },
+        :issueTimeline => { :nodes => [
+          { :willCloseTarget => true,
+            :source => { :number => 130, :title => "Handle empty remotes", :state => "MERGED", :repository => repo } },
+          { :willCloseTarget => false,
+            :source => { :number => 5, :title => "Pin hello-world", :state => "CLOSED", :repository => { :nameWithOwner => "acme/deploy" } } },
+          { :canonical => { :number => 123, :title => "Crash on empty remote", :state => "OPEN", :repository => repo },
+            :duplicate => { :number => 125, :title => "Panic in remote", :state => "CLOSED", :repository => repo } },
+          {},
+        ] },
+      } } }
+    }
+    """
+    When I successfully run `hub issue links 123`
+    Then the output should contain exactly:
+      """
+      #123 Crash on empty remote [open]
+      ├── closed by
+      │   └── #130 Handle empty remotes [merged]
+      ├── tracked by
+      │   └── #100 v2.15 tasks [open]
+      ├── duplicates
+      │   └── #125 Panic in remote [closed]
+      └── referenced by
+          └── acme/deploy#5 Pin hello-world [closed]\n
+      """
+
+  Scenario: Issue not found
+    Given the GitHub API server:
+    """
+    post('/graphql') {
+      json :data => { :repository => { :issueOrPullRequest => nil } }
+    }
+    """
+    When I run `hub issue links 999`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Error: no issue or pull request #999 in octocat/hello-world\n"
@@ -38,6 +38,32 @@ Feature: hub issue
            #13  Second issue\n
       """
 
+  Scenario: Fetch issues outside of a git repository
+    Given the current dir is not a repo
+    Given the GitHub API server:
+    """
+    get('/repos/octocat/hello/issues') {
+      json [
+        { :number => 7,
+          :title => "Remote issue",
+          :state => "open",
+          :user => { :login => "octocat" },
+        },
+      ]
+    }
+    """
+    When I successfully run `hub --repo octocat/hello issue`
+    Then the output should contain exactly:
+      """
+            #7  Remote issue\n
+      """
+
+  Scenario: Not a git repository without --repo
+    Given the current dir is not a repo
+    When I run `hub issue`
+    Then the stderr should contain exactly "fatal: Not a git repository\n"
+    And the exit status should be 1
+
   Scenario: List limited number of issues
     Given the GitHub API server:
     """
@@ -196,6 +222,97 @@ Feature: hub issue
     """
     When I successfully run `hub issue -o comments -^`
 
+  Scenario: Fetch issues with a named filter
+    Given I successfully run `git config --global hub.filter.bugs "label:bug label:\"help wanted\" state:all sort:updated"`
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/issues') {
+      assert :labels => "bug,help wanted",
+             :state => "closed",
+             :sort => "updated",
+             :direction => "desc"
+      json [
+        { :number => 102, :title => "First issue", :state => "closed", :user => { :login => "octocat" } },
+      ]
+    }
+    """
+    When I successfully run `hub issue list --filter bugs -s closed`
+    Then the output should contain exactly:
+      """
+          #102  First issue\n
+      """
+
+  Scenario: Fetch issues across an organization
+    Given the GitHub API server:
+    """
+    get('/search/issues') {
+      assert :q => "org:acme is:issue is:open assignee:@me",
+             :order => "desc"
+      json :items => [
+        { :number => 12, :title => "Fix login", :state => "open", :user => { :login => "octocat" },
+          :repository_url => "https://api.github.com/repos/acme/web" },
+        { :number => 3, :title => "Crash on start", :state => "open", :user => { :login => "octocat" },
+          :repository_url => "https://api.github.com/repos/acme/api" },
+      ]
+    }
+    """
+    When I successfully run `hub issue list --org acme --assignee @me`
+    Then the output should contain exactly:
+      """
+      acme/api
+            #3  Crash on start
+
+      acme/web
+           #12  Fix login\n
+      """
+
+  Scenario: Unknown named filter
+    When I run `hub issue list --filter triage`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: no filter named 'triage'; define one with `git config hub.filter.triage "label:bug state:open"`\n
+      """
+
+  Scenario: Fetch issues with reactions
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/issues') {
+      json [
+        { :number => 102, :title => "First issue", :state => "open", :user => { :login => "octocat" },
+          :reactions => { :"+1" => 4, :"-1" => 1 } },
+        { :number => 13, :title => "Second issue", :state => "open", :user => { :login => "octocat" },
+          :reactions => { :"+1" => 0, :"-1" => 0 } },
+      ]
+    }
+    """
+    When I successfully run `hub issue --reactions`
+    Then the output should contain exactly:
+      """
+          #102  First issue  👍 4 👎 1
+           #13  Second issue  👍 0 👎 0\n
+      """
+
+  Scenario: Fetch issues sorted by reactions
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/issues') {
+      assert :sort => nil, :direction => "desc"
+      json [
+        { :number => 102, :title => "First issue", :state => "open", :user => { :login => "octocat" },
+          :reactions => { :"+1" => 4, :"-1" => 1 } },
+        { :number => 13, :title => "Second issue", :state => "open", :user => { :login => "octocat" },
+          :reactions => { :"+1" => 9, :"-1" => 0 } },
+      ]
+    }
+    """
+    When I successfully run `hub issue -o reactions`
+    Then the output should contain exactly:
+      """
+           #13  Second issue
+          #102  First issue\n
+      """
+
   Scenario: Fetch issues across multiple pages
     Given the GitHub API server:
     """
@@ -357,6 +474,79 @@ Feature: hub issue
       https://github.com/github/hub/issues/1337\n
       """
 
+  Scenario: Create an issue with emoji shortcodes
+    Given the GitHub API server:
+      """
+      get('/emojis') {
+        json :tada => "https://github.githubassets.com/images/icons/emoji/unicode/1f389.png?v8",
+             :shipit => "https://github.githubassets.com/images/icons/emoji/shipit.png?v8"
+      }
+      post('/repos/github/hub/issues') {
+        assert :title => "Released \u{1f389}",
+               :body => "Time to :shipit: `:tada:`"
+
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue create --emoji -m "Released :tada:" -m "Time to :shipit: \`:tada:\`"`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337\n
+      """
+
+  Scenario: Create an issue that references other issues
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/12') { json :number => 12, :state => 'open', :title => 'Crash' }
+      get('/repos/mislav/coral/issues/3') { json :number => 3, :state => 'closed', :title => 'Old bug' }
+      post('/repos/github/hub/issues') {
+        assert :title => "Crash again (#12)",
+               :body => "Similar to mislav/coral#3"
+
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue create -m "Crash again (GH-12)" -m "Similar to https://github.com/mislav/coral/issues/3"`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337\n
+      """
+    And the stderr should contain exactly:
+      """
+      warning: mislav/coral#3 is closed: Old bug\n
+      """
+
+  Scenario: Create an issue that references a nonexistent issue
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/issues/12') { status 404 }
+      """
+    When I run `hub issue create -m "Fixes #12"`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: no issue or pull request found for #12\n
+      """
+
+  Scenario: Create an issue that references an inaccessible repository
+    Given the GitHub API server:
+      """
+      get('/repos/secret/stuff/issues/3') { status 404 }
+      post('/repos/github/hub/issues') {
+        assert :body => "Reported in secret/stuff#3"
+
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue create -m "Crash" -m "Reported in secret/stuff#3"`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337\n
+      """
+
   Scenario: Create an issue and open in browser
     Given the GitHub API server:
       """
@@ -409,6 +599,64 @@ Feature: hub issue
       https://github.com/github/hub/issues/1337\n
       """
 
+  Scenario: Create an issue assigned to myself and a team
+    Given the GitHub API server:
+      """
+      get('/user') {
+        json :login => 'cornwe19'
+      }
+      get('/orgs/github/teams/hubbers/members') {
+        assert :per_page => "100"
+        json [
+          { :login => 'mislav' },
+          { :login => 'Cornwe19' },
+        ]
+      }
+      post('/repos/github/hub/issues') {
+        assert :title => "hello",
+               :assignees => ["cornwe19", "mislav"]
+
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue create -m "hello" --assign @me,@github/hubbers`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337\n
+      """
+
+  Scenario: Fetch issues created by me
+    Given the GitHub API server:
+      """
+      get('/user') {
+        json :login => 'cornwe19'
+      }
+      get('/repos/github/hub/issues') {
+        assert :creator => "cornwe19"
+        json [
+          { :number => 102,
+            :title => "First issue",
+            :state => "open",
+            :user => { :login => "cornwe19" },
+          },
+        ]
+      }
+      """
+    When I successfully run `hub issue list --creator @me`
+    Then the output should contain exactly:
+      """
+          #102  First issue\n
+      """
+
+  Scenario: Fetch issues assigned to a team
+    When I run `hub issue list --assignee @github/hubbers`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: '@github/hubbers' is a team; expected a single user\n
+      """
+
   Scenario: Create an issue with milestone by name
     Given the GitHub API server:
       """
@@ -482,6 +730,51 @@ Feature: hub issue
       https://github.com/github/hub/issues/1337\n
       """
 
+  Scenario: Choose an issue template from a directory
+    Given the git commit editor is "vim"
+    And the text editor adds:
+      """
+      hello
+      """
+    And a file named ".github/ISSUE_TEMPLATE/bug_report.md" with:
+      """
+      ---
+      name: Bug report
+      about: Report a problem
+      labels: bug
+      ---
+      Steps to reproduce
+      """
+    And a file named ".github/ISSUE_TEMPLATE/feature_request.md" with:
+      """
+      ---
+      name: Feature request
+      ---
+      Describe the feature
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues') {
+        assert :title => "hello",
+               :body => "Steps to reproduce",
+               :labels => ["bug"]
+
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I run `hub issue create` interactively
+    And I type "1"
+    Then the output should contain:
+      """
+      Choose a template:
+       1. Bug report - Report a problem
+       2. Feature request
+       3. Blank issue
+      """
+    And the output should contain "https://github.com/github/hub/issues/1337"
+    And the exit status should be 0
+
   Scenario: Issue template from a subdirectory
     Given the git commit editor is "vim"
     And the text editor adds:
@@ -618,6 +911,44 @@ Feature: hub issue
       """
     Then I successfully run `hub issue update 1337 -s closed`
     
+  Scenario: Close an issue
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/1337') {
+        assert :title => :no,
+               :state => "closed",
+               :state_reason => :no
+      }
+      """
+    Then I successfully run `hub issue close 1337`
+
+  Scenario: Close an issue as not planned
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/1337') {
+        assert :state => "closed",
+               :state_reason => "not_planned"
+      }
+      """
+    Then I successfully run `hub issue close --reason not-planned 1337`
+
+  Scenario: Close an issue with an invalid reason
+    When I run `hub issue close --reason wontfix 1337`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid close reason: 'wontfix'; expected "completed" or "not-planned"\n
+      """
+
+  Scenario: Reopen an issue
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/issues/1337') {
+        assert :state => "open"
+      }
+      """
+    Then I successfully run `hub issue reopen 1337`
+
   Scenario: Update an issue's labels
     Given the GitHub API server:
       """
@@ -929,3 +1260,54 @@ Feature: hub issue
       """
       Error fetching comments for issue: Not Found (HTTP 404)\n
       """
+
+  Scenario: Preview an issue import
+    Given a file named "issues.csv" with:
+      """
+      Summary,Description,Tags
+      Login fails,Steps to reproduce,bug
+      Add dark mode,,
+      """
+    When I successfully run `hub issue import --file issues.csv --map title=Summary,body=Description,labels=Tags -l imported --dry-run`
+    Then the output should contain exactly:
+      """
+      Would create issue `Login fails' with labels bug, imported
+      Would create issue `Add dark mode' with labels imported
+      Would import 2 issues into github/hub\n
+      """
+
+  Scenario: Import issues with the --label alias
+    Given a file named "issues.csv" with:
+      """
+      Summary,Description
+      Login fails,Steps to reproduce
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues') {
+        assert :title => "Login fails",
+               :body => "Steps to reproduce",
+               :labels => ["imported"]
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue import --file issues.csv --map title=Summary,body=Description --label imported`
+    Then the output should contain exactly "https://github.com/github/hub/issues/1337\n"
+
+  Scenario: Import issues from JSON
+    Given a file named "issues.json" with:
+      """
+      [{"title": "Login fails", "labels": ["bug"]}]
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues') {
+        assert :title => "Login fails",
+               :labels => ["bug"]
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337"
+      }
+      """
+    When I successfully run `hub issue import --file issues.json`
+    Then the output should contain exactly "https://github.com/github/hub/issues/1337\n"
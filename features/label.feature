@@ -0,0 +1,88 @@
+Feature: hub label
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List labels
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/labels') {
+        json [
+          { :name => "feature", :color => "008672" },
+          { :name => "bug", :color => "d73a4a" },
+        ]
+      }
+      """
+    When I successfully run `hub label`
+    Then the output should contain exactly:
+      """
+      bug
+      feature\n
+      """
+
+  Scenario: Clone labels from another repository
+    Given the GitHub API server:
+      """
+      get('/repos/github/templates/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a", :description => "Something isn't working" },
+          { :name => "Feature", :color => "a2eeef", :description => "" },
+          { :name => "help wanted", :color => "008672", :description => "" },
+        ]
+      }
+      get('/repos/github/hub/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a", :description => "Something isn't working" },
+          { :name => "feature", :color => "ffffff", :description => "" },
+          { :name => "wontfix", :color => "ffffff", :description => "" },
+        ]
+      }
+      post('/repos/github/hub/labels') {
+        assert :name => "help wanted", :color => "008672"
+        status 201
+        json :name => "help wanted"
+      }
+      patch('/repos/github/hub/labels/feature') {
+        assert :new_name => "Feature", :color => "a2eeef"
+        json :name => "Feature"
+      }
+      """
+    When I successfully run `hub label clone templates`
+    Then the output should contain exactly:
+      """
+      Created label 'help wanted'.
+      Updated label 'Feature'.\n
+      """
+
+  Scenario: Clone labels and prune the rest
+    Given the GitHub API server:
+      """
+      get('/repos/octocat/templates/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a" },
+        ]
+      }
+      get('/repos/github/hub/labels') {
+        json [
+          { :name => "bug", :color => "d73a4a" },
+          { :name => "wontfix", :color => "ffffff" },
+        ]
+      }
+      delete('/repos/github/hub/labels/wontfix') {
+        status 204
+      }
+      """
+    When I successfully run `hub label clone --prune octocat/templates`
+    Then the output should contain exactly:
+      """
+      Deleted label 'wontfix'.\n
+      """
+
+  Scenario: Clone without a source repository
+    When I run `hub label clone`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Usage: hub label [--color]
+             hub label clone [--prune] [<OWNER>/]<REPO>\n
+      """
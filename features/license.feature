@@ -0,0 +1,63 @@
+Feature: hub license and hub gitignore
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List licenses
+    Given the GitHub API server:
+      """
+      get('/licenses') {
+        json [
+          { :key => "apache-2.0", :name => "Apache License 2.0" },
+          { :key => "mit", :name => "MIT License" },
+        ]
+      }
+      """
+    When I successfully run `hub license list`
+    Then the output should contain exactly:
+      """
+      apache-2.0  Apache License 2.0
+      mit         MIT License\n
+      """
+
+  Scenario: Apply a license
+    Given the GitHub API server:
+      """
+      get('/licenses/mit') {
+        json :key => "mit", :name => "MIT License",
+             :body => "MIT License\n\nCopyright (c) [year] [fullname]\n"
+      }
+      """
+    When I successfully run `hub license apply --author "Mona Lisa" --year 2020 mit`
+    Then the output should contain exactly ""
+    And the file "LICENSE" should contain "Copyright (c) 2020 Mona Lisa"
+
+  Scenario: Refuse to overwrite an existing license
+    Given a file named "LICENSE" with:
+      """
+      All rights reserved.
+      """
+    When I run `hub license apply mit`
+    Then the exit status should be 1
+    And the stderr should contain "LICENSE already exists (use --force to overwrite)"
+
+  Scenario: Append a gitignore template
+    Given a file named ".gitignore" with:
+      """
+      /bin
+      """
+    Given the GitHub API server:
+      """
+      get('/gitignore/templates/Go') {
+        json :name => "Go", :source => "*.test\n*.out\n"
+      }
+      """
+    When I successfully run `hub gitignore apply Go`
+    Then the output should contain exactly ""
+    And the file ".gitignore" should contain:
+      """
+      /bin
+
+      *.test
+      *.out
+      """
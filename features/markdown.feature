@@ -0,0 +1,39 @@
+Feature: hub markdown
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Preview a Markdown file
+    Given a file named "NOTES.md" with:
+      """
+      Fixes #123
+      """
+    Given the GitHub API server:
+      """
+      post('/markdown') {
+        assert :text => "Fixes #123\n",
+               :mode => "gfm",
+               :context => "github/hub"
+        content_type 'text/html'
+        '<p>Fixes <a href="https://github.com/github/hub/issues/123">#123</a></p>'
+      }
+      """
+    When I successfully run `hub markdown preview -u NOTES.md`
+    Then the output should match /hub-markdown-\d+\.html$/
+
+  Scenario: Preview Markdown in the context of another repository
+    Given the GitHub API server:
+      """
+      post('/markdown') {
+        assert :context => "octocat/Spoon-Knife"
+        content_type 'text/html'
+        '<p>See #1</p>'
+      }
+      """
+    When I run `hub markdown preview --context octocat/Spoon-Knife -u -` interactively
+    And I pass in:
+      """
+      See #1
+      """
+    Then the exit status should be 0
+    And the output should match /hub-markdown-\d+\.html$/
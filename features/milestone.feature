@@ -0,0 +1,119 @@
+Feature: hub milestone
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List milestones with progress
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => :no
+        json [
+          { :number => 3,
+            :title => "v2.0",
+            :state => "open",
+            :open_issues => 6,
+            :closed_issues => 4,
+            :due_on => "2026-10-31T07:00:00Z",
+          },
+          { :number => 12,
+            :title => "Someday",
+            :state => "open",
+            :open_issues => 0,
+            :closed_issues => 0,
+            :due_on => nil,
+          },
+        ]
+      }
+      """
+    When I successfully run `hub milestone`
+    Then the output should contain exactly:
+      """
+         3  [####------]  40%  31 Oct 2026  v2.0
+        12  [----------]   0%               Someday\n
+      """
+
+  Scenario: List closed milestones with custom format via the list subcommand
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => "closed"
+        json [
+          { :number => 1,
+            :title => "v1.0",
+            :state => "closed",
+            :open_issues => 0,
+            :closed_issues => 12,
+          },
+        ]
+      }
+      """
+    When I successfully run `hub milestone list -s closed -f "%t %NC/%NT%n"`
+    Then the output should contain exactly:
+      """
+      v1.0 12/12\n
+      """
+
+  Scenario: Create a milestone with due date
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/milestones') {
+        assert :title => "v2.1",
+          :description => "Bugfix release",
+          :due_on => "2026-12-01T00:00:00Z"
+        status 201
+        json :html_url => "https://github.com/github/hub/milestone/4"
+      }
+      """
+    When I successfully run `hub milestone create -m "v2.1" -m "Bugfix release" --due 2026-12-01`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/milestone/4\n
+      """
+
+  Scenario: Remove due date from a milestone
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        assert :state => "all"
+        json [
+          { :number => 3, :title => "v2.0", :state => "open" },
+        ]
+      }
+      patch('/repos/github/hub/milestones/3') {
+        assert :due_on => nil, :title => :no
+        json :number => 3
+      }
+      """
+    When I successfully run `hub milestone edit v2.0 --due ""`
+    Then the output should not contain anything
+
+  Scenario: Close a milestone by number
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        json [
+          { :number => 3, :title => "v2.0", :state => "open" },
+        ]
+      }
+      patch('/repos/github/hub/milestones/3') {
+        assert :state => "closed"
+        json :number => 3
+      }
+      """
+    When I successfully run `hub milestone close 3`
+    Then the output should not contain anything
+
+  Scenario: Close a nonexistent milestone
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/milestones') {
+        json []
+      }
+      """
+    When I run `hub milestone close v9.0`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: no milestone found with name 'v9.0'\n
+      """
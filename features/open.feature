@@ -0,0 +1,33 @@
+Feature: hub open
+  Background:
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Issue in the current repository
+    Given I am in "git://github.com/mislav/dotfiles.git" git repo
+    When I successfully run `hub open '#12'`
+    Then the output should not contain anything
+    And "open https://github.com/mislav/dotfiles/issues/12" should be run
+
+  Scenario: Issue in another repository
+    When I successfully run `hub open github/hub#2000`
+    Then "open https://github.com/github/hub/issues/2000" should be run
+
+  Scenario: Commit in another repository
+    When I successfully run `hub open github/hub@4dd71c9`
+    Then "open https://github.com/github/hub/commit/4dd71c9" should be run
+
+  Scenario: User profile
+    When I successfully run `hub open -u @mislav`
+    Then the output should contain exactly "https://github.com/mislav\n"
+
+  Scenario: Enterprise repository context
+    Given I am in "git://git.my.org/mislav/dotfiles.git" git repo
+    And I am "mislav" on git.my.org with OAuth token "FITOKEN"
+    And "git.my.org" is a whitelisted Enterprise host
+    When I successfully run `hub open -u '#12'`
+    Then the output should contain exactly "https://git.my.org/mislav/dotfiles/issues/12\n"
+
+  Scenario: Issue number outside of a repository
+    When I run `hub open '#12'`
+    Then the stderr should contain exactly "Aborted: could not find any git remote pointing to a GitHub repository to resolve '#12'\n"
+    And the exit status should be 1
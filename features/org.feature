@@ -0,0 +1,42 @@
+Feature: hub org
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Export the audit log across pages
+    Given the GitHub API server:
+      """
+      get('/orgs/github/audit-log') {
+        assert :phrase => "action:repo created:>=2020-01-01"
+        if params[:after] == 'cursor2'
+          json [{ :'@timestamp' => 1580303500000, :action => 'repo.create', :actor => 'octocat', :repo => 'github/old' }]
+        else
+          response.headers['Link'] = %(<https://api.github.com/orgs/github/audit-log?after=cursor2&phrase=action%3Arepo+created%3A%3E%3D2020-01-01>; rel="next")
+          json [{ :'@timestamp' => 1580303553000, :action => 'repo.archived', :actor => 'mislav', :repo => 'github/hub' }]
+        end
+      }
+      """
+    When I successfully run `hub org audit-log --phrase action:repo --since 2020-01-01`
+    Then the output should contain exactly:
+      """
+      2020-01-29T13:12:33Z  repo.archived  mislav  github/hub
+      2020-01-29T13:11:40Z  repo.create  octocat  github/old\n
+      """
+
+  Scenario: Export the audit log as JSON lines
+    Given the GitHub API server:
+      """
+      get('/orgs/acme/audit-log') {
+        json [{ :'@timestamp' => 1580303553000, :action => 'team.create', :actor => 'mislav', :team => 'acme/ops' }]
+      }
+      """
+    When I successfully run `hub org audit-log --json acme`
+    Then the output should contain exactly:
+      """
+      {"@timestamp":1580303553000,"action":"team.create","actor":"mislav","team":"acme/ops"}\n
+      """
+
+  Scenario: Invalid date
+    When I run `hub org audit-log --since yesterday`
+    Then the exit status should be 1
+    And the stderr should contain exactly "error: invalid date 'yesterday'; expected YYYY-MM-DD\n"
@@ -0,0 +1,85 @@
+Feature: hub package
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List container packages of the user
+    Given the GitHub API server:
+      """
+      get('/user/packages') {
+        assert :package_type => "container"
+        json [
+          { :name => "hub", :visibility => "public" },
+          { :name => "hub-ci", :visibility => "private" },
+        ]
+      }
+      """
+    When I successfully run `hub package list`
+    Then the output should contain exactly:
+      """
+      hub                                       public
+      hub-ci                                    private\n
+      """
+
+  Scenario: List npm packages of the organization
+    Given the GitHub API server:
+      """
+      get('/orgs/github/packages') {
+        assert :package_type => "npm"
+        json [
+          { :name => "hub-js", :version_count => 4, :repository => { :full_name => "github/hub" } },
+        ]
+      }
+      """
+    When I successfully run `hub package list --org -t npm -f "%N %C %R%n"`
+    Then the output should contain exactly:
+      """
+      hub-js 4 github/hub\n
+      """
+
+  Scenario: List package versions
+    Given the GitHub API server:
+      """
+      get('/user/packages/container/hub/versions') {
+        json [
+          { :id => 81234567, :name => "sha256:abc", :metadata => { :container => { :tags => ["latest", "v2.14.2"] } } },
+          { :id => 80123456, :name => "sha256:def", :metadata => { :container => { :tags => [] } } },
+        ]
+      }
+      """
+    When I successfully run `hub package versions -f "%I %V %T%n" hub`
+    Then the output should contain exactly:
+      """
+      81234567 sha256:abc latest, v2.14.2
+      80123456 sha256:def \n
+      """
+
+  Scenario: Delete package versions
+    Given the GitHub API server:
+      """
+      delete('/orgs/github/packages/container/hub/versions/80123456') { status 204 }
+      delete('/orgs/github/packages/container/hub/versions/70123456') { status 204 }
+      """
+    When I successfully run `hub package delete-version --org hub 80123456 70123456`
+    Then the output should contain exactly:
+      """
+      Deleted version 80123456 of package 'hub'.
+      Deleted version 70123456 of package 'hub'.\n
+      """
+
+  Scenario: Delete untagged package versions
+    Given the GitHub API server:
+      """
+      get('/user/packages/container/hub/versions') {
+        json [
+          { :id => 81234567, :metadata => { :container => { :tags => ["latest"] } } },
+          { :id => 80123456, :metadata => { :container => { :tags => [] } } },
+        ]
+      }
+      delete('/user/packages/container/hub/versions/80123456') { status 204 }
+      """
+    When I successfully run `hub package delete-version --untagged hub`
+    Then the output should contain exactly:
+      """
+      Deleted version 80123456 of package 'hub'.\n
+      """
@@ -30,6 +30,38 @@ Feature: hub pr checkout <PULLREQ-NUMBER>
     And "git checkout fixes" should be run
     And "fixes" should merge "refs/pull/77/head" from remote "origin"
 
+  Scenario: Checkout a pull request by URL
+    Given the GitHub API server:
+      """
+      get('/repos/mojombo/jekyll/pulls/77') {
+        json :number => 77, :head => {
+          :ref => "fixes",
+          :repo => {
+            :owner => { :login => "mislav" },
+            :name => "jekyll",
+            :private => false
+          }
+        }, :base => {
+          :repo => {
+            :name => 'jekyll',
+            :html_url => 'https://github.com/mojombo/jekyll',
+            :owner => { :login => "mojombo" },
+          }
+        },
+        :maintainer_can_modify => false,
+        :html_url => 'https://github.com/mojombo/jekyll/pull/77'
+      }
+      """
+    When I successfully run `hub pr checkout https://github.com/mojombo/jekyll/pull/77`
+    Then "git fetch origin refs/pull/77/head:fixes" should be run
+    And "git checkout fixes" should be run
+    And "fixes" should merge "refs/pull/77/head" from remote "origin"
+
+  Scenario: Invalid pull request reference
+    When I run `hub pr checkout fixes`
+    Then the exit status should be 1
+    And the stderr should contain exactly "invalid pull request number or URL: 'fixes'\n"
+
   Scenario: Custom name for new branch
     Given the GitHub API server:
       """
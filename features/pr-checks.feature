@@ -0,0 +1,103 @@
+Feature: hub pr checks
+  Background:
+    Given I am in "git://github.com/ashemesh/hub.git" git repo
+    And I am "ashemesh" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Checks of a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        json :number => 12,
+             :head => { :ref => "topic", :sha => "abc123" }
+      }
+      get('/repos/ashemesh/hub/commits/abc123/status') {
+        json :statuses => []
+      }
+      get('/repos/ashemesh/hub/commits/abc123/check-runs') {
+        json :check_runs => [
+          { :id => 7,
+            :status => "completed",
+            :conclusion => "success",
+            :name => "lint" },
+        ]
+      }
+      """
+    When I successfully run `hub pr checks 12`
+    Then the output should contain exactly "✔︎\tlint\n"
+
+  Scenario: Annotations of failed checks
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls/12') {
+        json :number => 12,
+             :head => { :ref => "topic", :sha => "abc123" }
+      }
+      get('/repos/ashemesh/hub/commits/abc123/status') {
+        json :statuses => []
+      }
+      get('/repos/ashemesh/hub/commits/abc123/check-runs') {
+        json :check_runs => [
+          { :id => 7,
+            :status => "completed",
+            :conclusion => "failure",
+            :name => "test",
+            :output => { :annotations_count => 2 } },
+          { :id => 8,
+            :status => "completed",
+            :conclusion => "success",
+            :name => "lint",
+            :output => { :annotations_count => 1 } },
+        ]
+      }
+      get('/repos/ashemesh/hub/check-runs/7/annotations') {
+        json [
+          { :path => "commands/pr.go",
+            :start_line => 12,
+            :end_line => 12,
+            :annotation_level => "failure",
+            :message => "undefined: bar" },
+          { :path => "commands/pr_test.go",
+            :start_line => 40,
+            :end_line => 42,
+            :annotation_level => "warning",
+            :message => "unused variable" },
+        ]
+      }
+      get('/repos/ashemesh/hub/check-runs/8/annotations') {
+        status 500
+      }
+      """
+    When I run `hub pr checks --annotations 12`
+    Then the exit status should be 1
+    And the output should contain exactly:
+      """
+      ✖︎	test
+      ✔︎	lint
+
+      test:
+        commands/pr.go:12: failure: undefined: bar
+        commands/pr_test.go:40-42: warning: unused variable\n
+      """
+
+  Scenario: Checks of the current branch
+    Given I am on the "topic" branch
+    Given the GitHub API server:
+      """
+      get('/repos/ashemesh/hub/pulls'){
+        assert :state => "open",
+               :head => "ashemesh:topic"
+        json [
+          { :number => 12,
+            :head => { :ref => "topic", :sha => "abc123" } },
+        ]
+      }
+      get('/repos/ashemesh/hub/commits/abc123/status') {
+        json :statuses => []
+      }
+      get('/repos/ashemesh/hub/commits/abc123/check-runs') {
+        json :check_runs => []
+      }
+      """
+    When I run `hub pr checks`
+    Then the exit status should be 3
+    And the output should contain exactly "no status\n"
@@ -257,3 +257,140 @@ Feature: hub pr list
           #999  First
            #13  Third\n
       """
+
+  Scenario: List pulls with reactions
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/pulls') {
+      json [
+        { :number => 999, :title => "First", :state => "open", :user => { :login => "octocat" } },
+        { :number => 102, :title => "Second", :state => "open", :user => { :login => "octocat" } },
+      ]
+    }
+    post('/graphql') {
+      assert :variables => { :owner => "github", :repo => "hub" }
+      halt 400 unless params[:query].include?("issue999: issueOrPullRequest(number: 999)")
+      json :data => { :repository => {
+        :issue999 => { :thumbsUp => { :totalCount => 1 }, :thumbsDown => { :totalCount => 0 } },
+        :issue102 => { :thumbsUp => { :totalCount => 7 }, :thumbsDown => { :totalCount => 2 } },
+      } }
+    }
+    """
+    When I successfully run `hub pr list --reactions`
+    Then the output should contain exactly:
+      """
+          #999  First  👍 1 👎 0
+          #102  Second  👍 7 👎 2\n
+      """
+
+  Scenario: Sort pulls by reactions
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/pulls') {
+      assert :sort => nil, :per_page => "100"
+      json [
+        { :number => 999, :title => "First", :state => "open", :user => { :login => "octocat" } },
+        { :number => 102, :title => "Second", :state => "open", :user => { :login => "octocat" } },
+        { :number => 42, :title => "Third", :state => "open", :user => { :login => "octocat" } },
+      ]
+    }
+    post('/graphql') {
+      json :data => { :repository => {
+        :issue999 => { :thumbsUp => { :totalCount => 1 }, :thumbsDown => { :totalCount => 0 } },
+        :issue102 => { :thumbsUp => { :totalCount => 7 }, :thumbsDown => { :totalCount => 2 } },
+        :issue42 => { :thumbsUp => { :totalCount => 3 }, :thumbsDown => { :totalCount => 0 } },
+      } }
+    }
+    """
+    When I successfully run `hub pr list --sort reactions -L 2 -f "%I %Ru%n"`
+    Then the output should contain exactly:
+      """
+      102 7
+      42 3\n
+      """
+
+  Scenario: List pulls updated in a time range
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/pulls') {
+      json [
+        { :number => 999, :title => "First", :state => "open",
+          :updated_at => "2021-04-02T10:00:00Z",
+          :base => { :ref => "master", :label => "github:master" },
+          :head => { :ref => "patch-1", :label => "octocat:patch-1" },
+          :user => { :login => "octocat" },
+        },
+        { :number => 102, :title => "Second", :state => "open",
+          :updated_at => "2021-03-10T10:00:00Z",
+          :base => { :ref => "master", :label => "github:master" },
+          :head => { :ref => "patch-2", :label => "octocat:patch-2" },
+          :user => { :login => "octocat" },
+        },
+      ]
+    }
+    """
+    When I successfully run `hub pr list --since 2021-03-01T00:00:00Z --until 2021-04-01T00:00:00Z`
+    Then the output should contain exactly:
+      """
+          #102  Second\n
+      """
+
+  Scenario: Filter pulls by labels, assignee, milestone, creator, and mentions
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/issues') {
+      assert :labels => "bug,ready",
+             :assignee => "mislav",
+             :milestone => "3",
+             :creator => "octocat",
+             :mentioned => "hubot",
+             :state => "open",
+             :direction => "desc"
+      json [
+        { :number => 999, :title => "An issue", :state => "open",
+          :user => { :login => "octocat" },
+        },
+        { :number => 102, :title => "Second", :state => "open",
+          :user => { :login => "octocat" },
+          :pull_request => { :url => "https://api.github.com/repos/github/hub/pulls/102" },
+        },
+      ]
+    }
+    """
+    When I successfully run `hub pr list -l bug,ready -a mislav -M 3 --creator octocat -@ hubot -f "%i %pS%n"`
+    Then the output should contain exactly:
+      """
+      #102 open\n
+      """
+
+  Scenario: Filter merged pulls by labels
+    Given the GitHub API server:
+    """
+    get('/repos/github/hub/issues') {
+      assert :labels => "bug",
+             :state => "closed"
+      json [
+        { :number => 12, :title => "Merged", :state => "closed",
+          :user => { :login => "octocat" },
+          :pull_request => { :merged_at => "2026-10-01T12:00:00Z" },
+        },
+        { :number => 11, :title => "Closed", :state => "closed",
+          :user => { :login => "octocat" },
+          :pull_request => { :merged_at => nil },
+        },
+      ]
+    }
+    """
+    When I successfully run `hub pr list -s merged -l bug -f "%i %pS%n"`
+    Then the output should contain exactly:
+      """
+      #12 merged\n
+      """
+
+  Scenario: Filters of the issues API can't be combined with branches
+    When I run `hub pr list -l bug -b main`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: --head and --base can't be combined with --labels, --assignee, --milestone, --creator, or --mentioned\n
+      """
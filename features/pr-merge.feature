@@ -213,3 +213,67 @@ Feature: hub pr merge
       """
     When I successfully run `hub pr merge -d 12`
     Then the output should contain exactly ""
+
+  Scenario: Squash merge with a message template
+    Given I successfully run `git config hub.mergeMessageFormat '{{if .HasLabel "bug"}}fix{{else}}feat{{end}}: {{lower .Title}} (#{{.Number}}){{"\n\n"}}{{.Body}}'`
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12,
+          :title => "Handle Empty Remotes",
+          :body => "Fixes a crash.",
+          :labels => [{ :name => "Bug" }]
+      }
+      put('/repos/friederbluemle/hub/pulls/12/merge'){
+        assert :merge_method => "squash",
+               :commit_title => "fix: handle empty remotes (#12)",
+               :commit_message => "Fixes a crash."
+
+        json :merged => true,
+          :sha => "MERGESHA",
+          :message => "All done!"
+      }
+      """
+    When I successfully run `hub pr merge --squash 12`
+    Then the output should contain exactly ""
+
+  Scenario: Message template is ignored when a message is given
+    Given I successfully run `git config hub.mergeMessageFormat '{{.Title}}'`
+    Given the GitHub API server:
+      """
+      put('/repos/friederbluemle/hub/pulls/12/merge'){
+        assert :commit_title => "mytitle"
+        json :merged => true
+      }
+      """
+    When I successfully run `hub pr merge 12 -m mytitle`
+    Then the output should contain exactly ""
+
+  Scenario: Invalid message template
+    Given I successfully run `git config hub.mergeMessageFormat '{{.Title'`
+    When I run `hub pr merge 12`
+    Then the exit status should be 1
+    And the stderr should contain "error: invalid hub.mergeMessageFormat:"
+
+  Scenario: Add to the merge queue
+    Given the GitHub API server:
+      """
+      get('/repos/friederbluemle/hub/pulls/12') {
+        json :number => 12, :node_id => "PR_12", :base => { :ref => "main" }
+      }
+      post('/graphql') {
+        assert :query => /enqueuePullRequest\(/,
+          :variables => { :input => { :pullRequestId => "PR_12", :expectedHeadOid => "MYSHA" } }
+        json :data => { :enqueuePullRequest => { :mergeQueueEntry => { :position => 3 } } }
+      }
+      """
+    When I successfully run `hub pr merge --queue 12 --head-sha MYSHA`
+    Then the output should contain exactly:
+      """
+      Added pull request #12 to the merge queue for main at position 3\n
+      """
+
+  Scenario: Merge queue with merge options
+    When I run `hub pr merge --queue --squash 12`
+    Then the exit status should be 1
+    And the stderr should contain "--queue can't be combined with merge options"
@@ -0,0 +1,34 @@
+Feature: hub pr retarget
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Retarget a pull request
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12, :base => { :ref => "old" }
+      }
+      patch('/repos/github/hub/pulls/12') {
+        assert :base => "main"
+        json :number => 12, :base => { :ref => "main" }
+      }
+      """
+    When I successfully run `hub pr retarget 12 main`
+    Then the output should contain exactly:
+      """
+      Changed the base of pull request #12 from old to main\n
+      """
+
+  Scenario: Already based on the branch
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12') {
+        json :number => 12, :base => { :ref => "main" }
+      }
+      """
+    When I successfully run `hub pr retarget 12 main`
+    Then the output should contain exactly:
+      """
+      Pull request #12 is already based on main\n
+      """
@@ -0,0 +1,55 @@
+Feature: hub pr stack
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Retarget and open pull requests in a stack
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls') {
+        assert :state => "all"
+        case params[:head]
+        when "github:one"
+          json [{ :number => 1, :state => "closed", :merged_at => "2020-01-01T00:00:00Z",
+                  :base => { :ref => "main" } }]
+        when "github:two"
+          json [{ :number => 2, :state => "open", :body => "Part two",
+                  :base => { :ref => "one" },
+                  :html_url => "https://github.com/github/hub/pull/2" }]
+        else
+          json []
+        end
+      }
+      post('/repos/github/hub/pulls') {
+        assert :base => "two", :head => "github:three", :title => "three"
+        status 201
+        json :number => 3, :state => "open", :body => nil,
+             :base => { :ref => "two" },
+             :html_url => "https://github.com/github/hub/pull/3"
+      }
+      patch('/repos/github/hub/pulls/2') {
+        if params[:base]
+          assert :base => "main"
+        else
+          assert :body => "Part two\n\n<!-- hub-stack -->\n**Stack:**\n\n- #1 (merged)\n- #2 ← this pull request\n- #3\n<!-- /hub-stack -->"
+        end
+        json :number => 2, :state => "open", :body => "Part two",
+             :base => { :ref => "main" },
+             :html_url => "https://github.com/github/hub/pull/2"
+      }
+      patch('/repos/github/hub/pulls/3') {
+        assert :body => "<!-- hub-stack -->\n**Stack:**\n\n- #1 (merged)\n- #2\n- #3 ← this pull request\n<!-- /hub-stack -->"
+        json :number => 3
+      }
+      """
+    When I successfully run `hub pr stack -b main one two three`
+    Then the output should contain exactly:
+      """
+      #2  two -> main  https://github.com/github/hub/pull/2
+      #3  three -> two  https://github.com/github/hub/pull/3\n
+      """
+
+  Scenario: No branches given
+    When I run `hub pr stack`
+    Then the exit status should be 1
+    And the stderr should contain "no branches given"
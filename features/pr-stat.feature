@@ -0,0 +1,35 @@
+Feature: hub pr stat
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Show changed files
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12/files') {
+        json [
+          { :filename => "commands/pr.go", :additions => 3, :deletions => 1 },
+          { :filename => "README.md", :additions => 10, :deletions => 0 },
+        ]
+      }
+      """
+    When I successfully run `hub pr stat 12`
+    Then the output should contain exactly:
+      """
+       commands/pr.go |  4 +++-
+       README.md      | 10 ++++++++++
+       2 files changed, 13 insertions(+), 1 deletion(-)\n
+      """
+
+  Scenario: Pull request over the size threshold
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/pulls/12/files') {
+        json [
+          { :filename => "README.md", :additions => 10, :deletions => 5 },
+        ]
+      }
+      """
+    When I run `hub pr stat --threshold 12 12`
+    Then the stderr should contain exactly "Pull request #12 changes 15 lines, which exceeds the threshold of 12\n"
+    And the exit status should be 1
@@ -0,0 +1,72 @@
+Feature: hub pr status
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Show pull request status
+    Given I am on the "topic" branch
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :query => /viewerCreated: search\(/,
+          :variables => {
+            :owner => "github", :repo => "hub", :branch => "topic",
+            :created => "repo:github/hub is:pr is:open author:@me",
+            :reviewRequested => "repo:github/hub is:pr is:open review-requested:@me",
+            :limit => 10, :onBranch => true,
+          }
+        json :data => {
+          :repository => { :pullRequests => { :nodes => [
+            { :number => 102, :title => "Topic work", :mergeable => "MERGEABLE", :reviewDecision => "APPROVED",
+              :labels => { :nodes => [] },
+              :commits => { :nodes => [{ :commit => { :statusCheckRollup => { :state => "SUCCESS" } } }] } },
+          ] } },
+          :viewerCreated => { :nodes => [] },
+          :reviewRequested => { :nodes => [
+            { :number => 99, :title => "Fix docs", :mergeable => "CONFLICTING", :reviewDecision => "REVIEW_REQUIRED",
+              :labels => { :nodes => [] },
+              :commits => { :nodes => [{ :commit => { :statusCheckRollup => { :state => "FAILURE" } } }] } },
+          ] },
+        }
+      }
+      """
+    When I successfully run `hub pr status`
+    Then the output should contain exactly:
+      """
+      Current branch
+          #102  Topic work
+                ✔︎ Checks passing  ✔︎ Approved
+
+      Created by you
+        You have no open pull requests
+
+      Requesting a code review from you
+           #99  Fix docs
+                ✖︎ Checks failing  ● Review required  ✖︎ Merge conflicts\n
+      """
+
+  Scenario: Show pull request status in detached HEAD
+    Given I am in detached HEAD
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :query => /repository\(owner: \$owner, name: \$repo\) @include\(if: \$onBranch\)/,
+          :variables => { :branch => "", :onBranch => false }
+        json :data => {
+          :viewerCreated => { :nodes => [] },
+          :reviewRequested => { :nodes => [] },
+        }
+      }
+      """
+    When I successfully run `hub pr status`
+    Then the output should contain exactly:
+      """
+      Current branch
+        Not on any branch
+
+      Created by you
+        You have no open pull requests
+
+      Requesting a code review from you
+        You have no pull requests to review\n
+      """
@@ -0,0 +1,35 @@
+Feature: hub pr todo
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List unresolved feedback
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :query => /reviewThreads\(/,
+          :variables => { :owner => "github", :repo => "hub", :number => 12 }
+        json :data => {
+          :repository => { :pullRequest => {
+            :body => "Fixes #1\r\n\r\n- [x] Write code\r\n- [ ] Update docs\r\n",
+            :reviewThreads => { :nodes => [
+              { :isResolved => false, :isOutdated => false, :path => "commands/pr.go", :line => 42,
+                :comments => { :nodes => [{ :author => { :login => "jdoe" }, :body => "Handle this error" }] } },
+              { :isResolved => true, :isOutdated => false, :path => "README.md", :line => 3,
+                :comments => { :nodes => [{ :author => { :login => "jdoe" }, :body => "Typo" }] } },
+            ] },
+          } }
+        }
+      }
+      """
+    When I successfully run `hub pr todo 12`
+    Then the output should contain exactly:
+      """
+      - [ ] commands/pr.go:42 @jdoe: Handle this error
+      - [ ] Update docs\n
+      """
+
+  Scenario: Missing pull request number
+    When I run `hub pr todo`
+    Then the exit status should be 1
+    And the stderr should contain "Usage: hub pr"
@@ -0,0 +1,170 @@
+Feature: hub project
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List projects
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :query => /projectsV2\(/,
+          :variables => { :owner => "github" }
+        json :data => {
+          :repositoryOwner => {
+            :projectsV2 => {
+              :nodes => [
+                { :number => 1, :title => "Roadmap", :closed => false },
+                { :number => 2, :title => "Old roadmap", :closed => true },
+                { :number => 4, :title => "Bug triage", :closed => false },
+              ]
+            }
+          }
+        }
+      }
+      """
+    When I successfully run `hub project list`
+    Then the output should contain exactly:
+      """
+         1  Roadmap
+         4  Bug triage\n
+      """
+
+  Scenario: List projects including closed for another owner
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :variables => { :owner => "octocat" }
+        json :data => {
+          :repositoryOwner => {
+            :projectsV2 => {
+              :nodes => [
+                { :number => 2, :title => "Old roadmap", :closed => true, :url => "the://url" },
+              ]
+            }
+          }
+        }
+      }
+      """
+    When I successfully run `hub project list -c --owner octocat -f "%I %S %U%n"`
+    Then the output should contain exactly:
+      """
+      2 closed the://url\n
+      """
+
+  Scenario: View a project
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :variables => { :owner => "github", :number => 1 }
+        json :data => {
+          :repositoryOwner => {
+            :projectV2 => {
+              :number => 1,
+              :title => "Roadmap",
+              :shortDescription => "What we are working on",
+              :url => "https://github.com/orgs/github/projects/1",
+              :closed => false,
+              :items => { :totalCount => 2 },
+              :itemList => {
+                :nodes => [
+                  { :type => "ISSUE", :content => { :number => 123, :title => "Fix the build", :state => "OPEN" } },
+                  { :type => "DRAFT_ISSUE", :content => { :title => "Write release notes" } },
+                ]
+              }
+            }
+          }
+        }
+      }
+      """
+    When I successfully run `hub project view 1`
+    Then the output should contain exactly:
+      """
+      # Roadmap
+
+      * https://github.com/orgs/github/projects/1
+      * 2 items
+
+      What we are working on
+
+      ## Items:
+
+        #123  Fix the build (open)
+              Write release notes (draft)\n
+      """
+
+  Scenario: View a nonexistent project
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repositoryOwner => { :projectV2 => nil } }
+      }
+      """
+    When I run `hub project view 9`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: no project found with number 9 for github\n
+      """
+
+  Scenario: Add an issue to a project
+    Given the GitHub API server:
+      """
+      count = 0
+      post('/graphql') {
+        count += 1
+        case count
+        when 1
+          assert :query => /issueOrPullRequest\(/,
+            :variables => { :owner => "github", :repo => "hub", :number => 123 }
+          json :data => { :repository => { :issueOrPullRequest => { :id => "ISSUE-ID" } } }
+        when 2
+          assert :variables => { :owner => "github", :number => 1 }
+          json :data => { :repositoryOwner => { :projectV2 => {
+            :id => "PROJECT-ID",
+            :url => "https://github.com/orgs/github/projects/1",
+          } } }
+        when 3
+          assert :query => /\A\s*mutation\(/,
+            :variables => { :project => "PROJECT-ID", :content => "ISSUE-ID" }
+          json :data => { :addProjectV2ItemById => { :item => { :id => "ITEM-ID" } } }
+        else
+          status 400
+          json :message => "request not stubbed"
+        end
+      }
+      """
+    When I successfully run `hub project add-item 1 123`
+    Then the output should contain exactly:
+      """
+      https://github.com/orgs/github/projects/1\n
+      """
+
+  Scenario: Create an issue in a project
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/issues') {
+        status 201
+        json :html_url => "https://github.com/github/hub/issues/1337",
+             :node_id => "ISSUE-ID"
+      }
+      count = 0
+      post('/graphql') {
+        count += 1
+        case count
+        when 1
+          assert :variables => { :owner => "github", :number => 4 }
+          json :data => { :repositoryOwner => { :projectV2 => { :id => "PROJECT-ID" } } }
+        when 2
+          assert :variables => { :project => "PROJECT-ID", :content => "ISSUE-ID" }
+          json :data => { :addProjectV2ItemById => { :item => { :id => "ITEM-ID" } } }
+        else
+          status 400
+          json :message => "request not stubbed"
+        end
+      }
+      """
+    When I successfully run `hub issue create -m "Not workie, pls fix" --project 4`
+    Then the output should contain exactly:
+      """
+      https://github.com/github/hub/issues/1337\n
+      """
@@ -0,0 +1,140 @@
+Feature: hub protection
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Show a protection rule
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :variables => { :owner => "github", :repo => "hub" }
+        json :data => {
+          :repository => {
+            :id => "REPO-ID",
+            :branchProtectionRules => {
+              :nodes => [
+                { :id => "RULE-1", :pattern => "release/*" },
+                { :id => "RULE-2",
+                  :pattern => "main",
+                  :requiresStatusChecks => true,
+                  :requiredStatusCheckContexts => ["ci/build"],
+                  :requiresStrictStatusChecks => true,
+                  :requiresApprovingReviews => true,
+                  :requiredApprovingReviewCount => 2,
+                  :isAdminEnforced => true,
+                },
+              ]
+            }
+          }
+        }
+      }
+      """
+    When I successfully run `hub protection show main`
+    Then the output should contain exactly:
+      """
+      required_status_checks:
+      - ci/build
+      strict_status_checks: true
+      required_approving_reviews: 2
+      dismiss_stale_reviews: false
+      require_code_owner_reviews: false
+      enforce_admins: true
+      require_linear_history: false\n
+      """
+
+  Scenario: Create a protection rule from a spec file
+    Given a file named "protection.yml" with:
+      """
+      required_status_checks:
+      - ci/build
+      required_approving_reviews: 1
+      enforce_admins: true
+      """
+    Given the GitHub API server:
+      """
+      count = 0
+      post('/graphql') {
+        count += 1
+        case count
+        when 1
+          json :data => {
+            :repository => {
+              :id => "REPO-ID",
+              :branchProtectionRules => { :nodes => [] }
+            }
+          }
+        when 2
+          assert :query => /createBranchProtectionRule\(/,
+            :variables => { :input => {
+              :repositoryId => "REPO-ID",
+              :pattern => "release/*",
+              :requiresStatusChecks => true,
+              :requiredStatusCheckContexts => ["ci/build"],
+              :requiresApprovingReviews => true,
+              :requiredApprovingReviewCount => 1,
+              :isAdminEnforced => true,
+              :requiresLinearHistory => true,
+            } }
+          json :data => {}
+        else
+          status 400
+          json :message => "request not stubbed"
+        end
+      }
+      """
+    When I successfully run `hub protection set -F protection.yml --linear-history release/*`
+    Then the output should not contain anything
+
+  Scenario: Update an existing protection rule
+    Given the GitHub API server:
+      """
+      count = 0
+      post('/graphql') {
+        count += 1
+        case count
+        when 1
+          json :data => {
+            :repository => {
+              :id => "REPO-ID",
+              :branchProtectionRules => { :nodes => [
+                { :id => "RULE-2", :pattern => "main" },
+              ] }
+            }
+          }
+        when 2
+          assert :query => /updateBranchProtectionRule\(/,
+            :variables => { :input => {
+              :branchProtectionRuleId => "RULE-2",
+              :requiresStatusChecks => false,
+              :requiredStatusCheckContexts => [],
+              :requiresApprovingReviews => true,
+              :requiredApprovingReviewCount => 2,
+            } }
+          json :data => {}
+        else
+          status 400
+          json :message => "request not stubbed"
+        end
+      }
+      """
+    When I successfully run `hub protection set -r 2 main`
+    Then the output should not contain anything
+
+  Scenario: Delete a nonexistent protection rule
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => {
+          :repository => {
+            :id => "REPO-ID",
+            :branchProtectionRules => { :nodes => [] }
+          }
+        }
+      }
+      """
+    When I run `hub protection delete main`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: no protection rule found for 'main'\n
+      """
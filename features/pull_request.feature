@@ -119,6 +119,7 @@ Feature: hub pull-request
       """
     Given the GitHub API server:
       """
+      get('/repos/mislav/coral/issues/1234') { json :number => 1234, :state => 'open' }
       post('/repos/mislav/coral/pulls') {
         halt 400 if request.content_charset != 'utf-8'
         assert :title => 'This is somewhat of a longish title that does not get wrapped & references #1234',
@@ -178,6 +179,37 @@ Feature: hub pull-request
     When I successfully run `hub pull-request`
     Then the output should contain exactly "the://url\n"
 
+  Scenario: Custom hub pull request template
+    Given the git commit editor is "true"
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/coral/pulls') {
+        assert :title => 'Merge topic into master',
+               :body => "Changes:\n\n- First change\n- Second change"
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    Given I am on the "master" branch pushed to "origin/master"
+    When I successfully run `git checkout --quiet -b topic`
+    And I make a commit with message "First change"
+    And I make a commit with message "Second change"
+    And the "topic" branch is pushed to "origin/topic"
+    Given a file named ".github/HUB_PULL_REQUEST_TEMPLATE.md" with:
+      """
+      Merge {{branch}} into {{base}}
+
+      Changes:
+
+      {{commits}}
+      """
+    And a file named "pull_request_template.md" with:
+      """
+      This template is not used
+      """
+    When I successfully run `hub pull-request`
+    Then the output should contain exactly "the://url\n"
+
   Scenario: Single-commit with PULL_REQUEST_TEMPLATE directory
     Given the git commit editor is "true"
     Given the GitHub API server:
@@ -435,10 +467,8 @@ Feature: hub pull-request
       """
     When I run `hub pull-request`
     Then the exit status should be 1
-    And the stderr should contain exactly:
-      """
-      Error creating pull request: Unprocessable Entity (HTTP 422)\n
-      """
+    And the stderr should contain "Error creating pull request: Unprocessable Entity (HTTP 422)\n"
+    And the stderr should contain "/.git/PULLREQ_EDITMSG; use --recover to submit it again.\n"
     Given the text editor adds:
       """
       But this title will prevail
@@ -446,6 +476,40 @@ Feature: hub pull-request
     When I successfully run `hub pull-request`
     Then the file ".git/PULLREQ_EDITMSG" should not exist
 
+  Scenario: Recover message of a failed pull request
+    Given I am on the "topic" branch pushed to "origin/topic"
+    Given the text editor adds:
+      """
+      My title
+
+      A long description
+      """
+    Given the GitHub API server:
+      """
+      count = 0
+      post('/repos/mislav/coral/pulls') {
+        count += 1
+        halt 502 if count == 1
+        assert :title => "My title",
+               :body => "A long description"
+        status 201
+        json :html_url => "https://github.com/mislav/coral/pull/12"
+      }
+      """
+    When I run `hub pull-request`
+    Then the exit status should be 1
+    And the file ".git/PULLREQ_EDITMSG" should contain "A long description"
+    Given the text editor exits with error status
+    When I successfully run `hub pull-request --recover`
+    Then the output should contain exactly "https://github.com/mislav/coral/pull/12\n"
+    And the file ".git/PULLREQ_EDITMSG" should not exist
+
+  Scenario: Nothing to recover
+    Given I am on the "topic" branch pushed to "origin/topic"
+    When I run `hub pull-request --recover`
+    Then the exit status should be 1
+    And the stderr should contain exactly "no saved pull request message to recover\n"
+
   Scenario: Text editor fails
     Given I am on the "topic" branch pushed to "origin/topic"
     Given the text editor exits with error status
@@ -764,6 +828,25 @@ Feature: hub pull-request
     When I successfully run `hub pull-request -m enterprisey`
     Then the output should contain exactly "the://url\n"
 
+  Scenario: Draft pull request on an older Enterprise version
+    Given the "origin" remote has url "git@git.my.org:mislav/coral.git"
+    And I am "mislav" on git.my.org with OAuth token "FITOKEN"
+    And "git.my.org" is a whitelisted Enterprise host
+    And I am on the "topic" branch pushed to "origin/topic"
+    Given the GitHub API server:
+      """
+      get('/api/v3/meta', :host_name => 'git.my.org') {
+        json :installed_version => "2.16.3"
+      }
+      """
+    When I run `hub pull-request -d -m enterprisey`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Error creating pull request: draft pull requests require GitHub Enterprise Server 2.17 or newer
+      git.my.org runs version 2.16.3\n
+      """
+
   Scenario: Enterprise remote witch matching branch but no tracking
     Given the "origin" remote has url "git@git.my.org:mislav/coral.git"
     And I am "mislav" on git.my.org with OAuth token "FITOKEN"
@@ -1012,6 +1095,34 @@ Feature: hub pull-request
     When I successfully run `hub pull-request -m hereyougo -r mislav,josh -rgithub/robots -rpcorpet -r github/js`
     Then the output should contain exactly "the://url\n"
 
+  Scenario: Pull request with reviewers and assignees given as shorthands
+    Given I am on the "feature" branch with upstream "origin/feature"
+    Given the GitHub API server:
+      """
+      get('/user') {
+        json :login => 'mislav'
+      }
+      get('/orgs/github/teams/robots/members') {
+        json [{ :login => 'josh' }, { :login => 'pcorpet' }]
+      }
+      post('/repos/mislav/coral/pulls') {
+        status 201
+        json :html_url => "the://url", :number => 1234
+      }
+      patch('/repos/mislav/coral/issues/1234') {
+        assert :assignees => ["mislav", "josh", "pcorpet"]
+        json :html_url => "the://url"
+      }
+      post('/repos/mislav/coral/pulls/1234/requested_reviewers') {
+        assert :reviewers => ["mislav"]
+        assert :team_reviewers => ["js"]
+        status 201
+        json :html_url => "the://url"
+      }
+      """
+    When I successfully run `hub pull-request -m hereyougo -a @me,@github/robots -r @me,@github/js`
+    Then the output should contain exactly "the://url\n"
+
   Scenario: Pull request with reviewers from CODEOWNERS
     Given I am on the "feature" branch with upstream "origin/feature"
     Given the GitHub API server:
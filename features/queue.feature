@@ -0,0 +1,46 @@
+Feature: hub queue
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Show the merge queue
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        assert :query => /mergeQueue\(/,
+          :variables => { :owner => "github", :repo => "hub", :branch => "release" }
+        json :data => { :repository => { :mergeQueue => { :entries => { :nodes => [
+          { :position => 1, :state => "MERGEABLE", :estimatedTimeToMerge => 290,
+            :pullRequest => { :number => 1234, :title => "Fix the frobnicator" } },
+          { :position => 2, :state => "AWAITING_CHECKS", :estimatedTimeToMerge => nil,
+            :pullRequest => { :number => 1237, :title => "Update deps" } },
+        ] } } } }
+      }
+      """
+    When I successfully run `hub queue status -b release`
+    Then the output should contain exactly:
+      """
+        1  #1234  Fix the frobnicator  mergeable  ~5m
+        2  #1237  Update deps          awaiting checks\n
+      """
+
+  Scenario: Empty merge queue
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => { :mergeQueue => { :entries => { :nodes => [] } } } }
+      }
+      """
+    When I successfully run `hub queue status`
+    Then the output should contain exactly "The merge queue is empty\n"
+
+  Scenario: Merge queue not enabled
+    Given the GitHub API server:
+      """
+      post('/graphql') {
+        json :data => { :repository => { :mergeQueue => nil } }
+      }
+      """
+    When I run `hub queue status`
+    Then the exit status should be 1
+    And the stderr should contain exactly "Error: merge queue is not enabled for this branch\n"
@@ -34,6 +34,28 @@ Feature: hub release
       v1.0.2\n
       """
 
+  Scenario: List releases published in a time range
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [
+          { tag_name: 'v1.3.0', published_at: '2021-04-02T10:00:00Z' },
+          { tag_name: 'v1.2.0', published_at: '2021-03-10T10:00:00Z' },
+          { tag_name: 'v1.1.0', published_at: '2021-02-20T10:00:00Z' },
+        ]
+      }
+      """
+    When I successfully run `hub release --since 2021-03-01T00:00:00Z --until 2021-04-01T00:00:00Z`
+    Then the output should contain exactly:
+      """
+      v1.2.0\n
+      """
+
+  Scenario: List releases with an invalid time
+    When I run `hub release --since yesterday`
+    Then the exit status should be 1
+    And the stderr should contain "error: invalid time 'yesterday'"
+
   Scenario: List non-prerelease releases
     Given the GitHub API server:
       """
@@ -362,6 +384,45 @@ MARKDOWN
       - everything\n
       """
 
+  Scenario: Verify the signature of a release tag
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [{ tag_name: 'v1.2.0', name: 'will_paginate 1.2.0', body: '' }]
+      }
+      get('/repos/mislav/will_paginate/git/ref/tags/v1.2.0') {
+        json :object => { :type => 'tag', :sha => 'abc123' }
+      }
+      get('/repos/mislav/will_paginate/git/tags/abc123') {
+        json :verification => { :verified => false, :reason => 'unknown_key' }
+      }
+      """
+    When I successfully run `hub release show --verify-tag v1.2.0`
+    Then the output should contain exactly:
+      """
+      will_paginate 1.2.0
+
+      Tag v1.2.0: signature not verified (unknown_key)\n
+      """
+
+  Scenario: Verify a lightweight release tag
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [{ tag_name: 'v1.2.0', name: 'will_paginate 1.2.0', body: '' }]
+      }
+      get('/repos/mislav/will_paginate/git/ref/tags/v1.2.0') {
+        json :object => { :type => 'commit', :sha => 'abc123' }
+      }
+      """
+    When I successfully run `hub release show --verify-tag -f '%T%n' v1.2.0`
+    Then the output should contain exactly:
+      """
+      v1.2.0
+
+      Tag v1.2.0: signature not verified (unsigned)\n
+      """
+
   Scenario: Show release no tag
     When I run `hub release show`
     Then the exit status should be 1
@@ -387,6 +448,144 @@ MARKDOWN
       https://github.com/mislav/will_paginate/releases/v1.2.0\n
       """
 
+  Scenario: Create a release with notes from the changelog
+    Given a file named "CHANGELOG.md" with:
+      """
+      # Changelog
+
+      ## [1.2.0] - 2020-01-31
+      ### Added
+      - Instant gratification
+
+      ## [1.1.0] - 2019-12-01
+      ### Fixed
+      - Monkeys
+
+      [1.2.0]: https://github.com/mislav/will_paginate/compare/v1.1.0...v1.2.0
+      """
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/will_paginate/releases') {
+        assert :tag_name => "v1.2.0",
+               :name => "v1.2.0",
+               :body => "### Added\n- Instant gratification"
+
+        status 201
+        json :html_url => "https://github.com/mislav/will_paginate/releases/v1.2.0"
+      }
+      """
+    When I successfully run `hub release create --notes-from-changelog v1.2.0`
+    Then the output should contain exactly:
+      """
+      https://github.com/mislav/will_paginate/releases/v1.2.0\n
+      """
+
+  Scenario: Changelog has no notes for the release
+    Given a file named "CHANGELOG.md" with:
+      """
+      ## v1.1.0
+      Monkeys
+      """
+    When I run `hub release create --notes-from-changelog v1.2.0`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: no section for v1.2.0 found in CHANGELOG.md\n
+      """
+
+  Scenario: Create a release with a signed tag
+    Given I make a commit with message "Initial"
+    When I successfully run `hub --noop release create --sign -m "will_paginate 1.2.0" v1.2.0`
+    Then the output should contain exactly:
+      """
+      Would create signed tag `v1.2.0' at HEAD
+      Would push tag `v1.2.0' to origin
+      Would create release `will_paginate 1.2.0' for mislav/will_paginate with tag name `v1.2.0'\n
+      """
+
+  Scenario: Refuse to sign a release for an existing unsigned tag
+    Given I make a commit with message "Initial"
+    And I successfully run `git tag v1.2.0`
+    When I run `hub release create --sign -m "will_paginate 1.2.0" v1.2.0`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Aborted: tag v1.2.0 already exists and has no valid signature\n
+      """
+
+  Scenario: Create a release with a configured editor template
+    Given the git commit editor is "true"
+    And I make a commit with message "Initial"
+    And I successfully run `git tag v1.1.0`
+    And I make a commit with message "Fix a bug"
+    And I make a commit with message "Add a feature"
+    And a file named "notes/release.md" with:
+      """
+      will_paginate {{tag}}
+
+      Changes since {{previous_tag}}:
+
+      {{commits}}
+      """
+    And I successfully run `git config hub.releaseTemplate notes/release.md`
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/will_paginate/releases') {
+        assert :tag_name => "v1.2.0",
+               :name => "will_paginate v1.2.0",
+               :body => "Changes since v1.1.0:\n\n- Fix a bug\n- Add a feature"
+
+        status 201
+        json :html_url => "https://github.com/mislav/will_paginate/releases/v1.2.0"
+      }
+      """
+    When I successfully run `hub release create v1.2.0`
+    Then the output should contain exactly:
+      """
+      https://github.com/mislav/will_paginate/releases/v1.2.0\n
+      """
+
+  Scenario: Create a release without editing the generated message
+    Given I make a commit with message "Initial"
+    And I successfully run `git tag v1.1.0`
+    And I make a commit with message "Fix a bug"
+    And I make a commit with message "Add a feature"
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/will_paginate/releases') {
+        assert :tag_name => "v1.2.0",
+               :name => "v1.2.0",
+               :body => "- Fix a bug\n- Add a feature"
+
+        status 201
+        json :html_url => "https://github.com/mislav/will_paginate/releases/v1.2.0"
+      }
+      """
+    When I successfully run `hub release create --no-edit v1.2.0`
+    Then the output should contain exactly:
+      """
+      https://github.com/mislav/will_paginate/releases/v1.2.0\n
+      """
+
+  Scenario: Commits since the previous tag are listed in the editor
+    Given the text editor adds:
+      """
+      Version 1.2
+      """
+    And I make a commit with message "Initial"
+    And I successfully run `git tag v1.1.0`
+    And I make a commit with message "Fix a bug"
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/will_paginate/releases') {
+        status 500
+      }
+      """
+    When I run `hub release create v1.2.0`
+    Then the exit status should be 1
+    And the file ".git/RELEASE_EDITMSG" should contain "Changes since v1.1.0:"
+    And the file ".git/RELEASE_EDITMSG" should contain "Fix a bug"
+
   Scenario: Create a release from file
     Given the GitHub API server:
       """
@@ -456,6 +655,43 @@ MARKDOWN
       Attaching 1 asset...\n
       """
 
+  Scenario: Create a release with automatically labeled assets
+    Given the GitHub API server:
+      """
+      post('/repos/mislav/will_paginate/releases') {
+        status 201
+        json :html_url => "https://github.com/mislav/will_paginate/releases/v1.2.0",
+             :upload_url => "https://uploads.github.com/uploads/assets{?name,label}"
+      }
+      post('/uploads/assets', :host_name => 'uploads.github.com') {
+        expected = {
+          'hello_v1.2.0_linux_amd64.tar.gz' => 'Linux x86-64 (tar.gz)',
+          'hello_v1.2.0_darwin_arm64.zip' => 'Apple Silicon',
+          'checksums.txt' => nil,
+        }
+        halt 400 unless expected.key?(params[:name]) && params[:label] == expected[params[:name]]
+        status 201
+      }
+      """
+    And a file named "hello_v1.2.0_linux_amd64.tar.gz" with:
+      """
+      TARBALL
+      """
+    And a file named "hello_v1.2.0_darwin_arm64.zip" with:
+      """
+      ZIP
+      """
+    And a file named "checksums.txt" with:
+      """
+      SUMS
+      """
+    When I successfully run `hub release create -m "hello" v1.2.0 --auto-label -a hello_v1.2.0_linux_amd64.tar.gz -a "hello_v1.2.0_darwin_arm64.zip#Apple Silicon" -a checksums.txt`
+    Then the output should contain exactly:
+      """
+      https://github.com/mislav/will_paginate/releases/v1.2.0
+      Attaching 3 assets...\n
+      """
+
   Scenario: Retry attaching assets on 5xx errors
     Given the GitHub API server:
       """
@@ -514,8 +750,8 @@ MARKDOWN
     Then the stderr should contain exactly:
       """
       Attaching 3 assets...
-      The release was created, but attaching 2 assets failed. You can retry with:
-      hub release edit v1.2.0 -m '' -a two -a three
+      The release was created, but attaching 1 asset failed. You can retry with:
+      hub release edit v1.2.0 -m '' -a two
       
       Error uploading release asset: Unprocessable Entity (HTTP 422)\n
       """
@@ -829,6 +1065,77 @@ MARKDOWN
         hello-amd32-1.2.2.tar.gz\n
         """
 
+  Scenario: Download release assets matching several patterns to a directory
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [
+          { tag_name: 'v1.2.0',
+            assets: [
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9876',
+                name: 'hello-linux.tar.gz',
+              },
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9877',
+                name: 'hello-windows.zip',
+              },
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9878',
+                name: 'checksums.txt',
+              },
+            ],
+          },
+        ]
+      }
+      get('/repos/mislav/will_paginate/assets/9876') { "LINUX" }
+      get('/repos/mislav/will_paginate/assets/9877') { "WINDOWS" }
+      """
+    When I successfully run `hub release download v1.2.0 -p '*.tar.gz' -p '*.zip' -d dist/v1.2.0`
+    Then the output should contain exactly:
+      """
+      Downloading hello-linux.tar.gz ...
+      Downloading hello-windows.zip ...\n
+      """
+    And the file "dist/v1.2.0/hello-linux.tar.gz" should contain exactly:
+      """
+      LINUX
+      """
+    And the file "dist/v1.2.0/checksums.txt" should not exist
+
+  Scenario: Download the source archive of a release
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+        json [
+          { tag_name: 'v1.2.0',
+            zipball_url: 'https://api.github.com/repos/mislav/will_paginate/zipball/v1.2.0',
+            assets: [
+              { url: 'https://api.github.com/repos/mislav/will_paginate/assets/9876',
+                name: 'hello-linux.tar.gz',
+              },
+            ],
+          },
+        ]
+      }
+      get('/repos/mislav/will_paginate/zipball/v1.2.0') { "SOURCE" }
+      """
+    When I successfully run `hub release download v1.2.0 --archive zip`
+    Then the output should contain exactly:
+      """
+      Downloading will_paginate-v1.2.0.zip ...\n
+      """
+    And the file "will_paginate-v1.2.0.zip" should contain exactly:
+      """
+      SOURCE
+      """
+    And the file "hello-linux.tar.gz" should not exist
+
+  Scenario: Download release with an invalid archive format
+    When I run `hub release download v1.2.0 --archive rar`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      invalid archive format 'rar'; expected "tar.gz" or "zip"\n
+      """
+
   Scenario: Download release no tag
     When I run `hub release download`
     Then the exit status should be 1
@@ -852,6 +1159,73 @@ MARKDOWN
     When I successfully run `hub release delete v1.2.0`
     Then the output should not contain anything
 
+  Scenario: Delete a release along with its tag
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+          json [
+            { url: 'https://api.github.com/repos/mislav/will_paginate/releases/123',
+              tag_name: 'v1.2.0',
+            },
+          ]
+      }
+
+      delete('/repos/mislav/will_paginate/releases/123') {
+        status 204
+      }
+
+      delete('/repos/mislav/will_paginate/git/refs/tags/v1.2.0') {
+        status 204
+      }
+      """
+    When I successfully run `hub release delete --with-tag v1.2.0`
+    Then the output should not contain anything
+
+  Scenario: Delete a release asset
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+          json [
+            { url: 'https://api.github.com/repos/mislav/will_paginate/releases/123',
+              tag_name: 'v1.2.0',
+              assets: [
+                { url: 'https://api.github.com/repos/mislav/will_paginate/releases/assets/9876',
+                  name: 'hello-1.2.0.tar.gz',
+                },
+                { url: 'https://api.github.com/repos/mislav/will_paginate/releases/assets/9877',
+                  name: 'hello-1.2.0.zip',
+                },
+              ],
+            },
+          ]
+      }
+
+      delete('/repos/mislav/will_paginate/releases/assets/9877') {
+        status 204
+      }
+      """
+    When I successfully run `hub release delete-asset v1.2.0 hello-1.2.0.zip`
+    Then the output should not contain anything
+
+  Scenario: Delete a missing release asset
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/releases') {
+          json [
+            { url: 'https://api.github.com/repos/mislav/will_paginate/releases/123',
+              tag_name: 'v1.2.0',
+              assets: [],
+            },
+          ]
+      }
+      """
+    When I run `hub release delete-asset v1.2.0 hello-1.2.0.zip`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Error: release v1.2.0 has no asset named 'hello-1.2.0.zip'\n
+      """
+
   Scenario: Release not found
     Given the GitHub API server:
       """
@@ -896,3 +1270,81 @@ MARKDOWN
       """
       v1.2.0\n
       """
+
+  Scenario: Watch for a new release
+    Given the GitHub API server:
+      """
+      polls = 0
+      get('/repos/rails/rails/releases') {
+        polls += 1
+        if polls == 1
+          response.headers['ETag'] = '"v1"'
+          json [
+            { tag_name: 'v6.0.0', html_url: 'https://github.com/rails/rails/releases/v6.0.0' },
+          ]
+        elsif request.env['HTTP_IF_NONE_MATCH'] == '"v1"' && polls == 2
+          status 304
+        else
+          json [
+            { tag_name: 'v6.1.0', html_url: 'https://github.com/rails/rails/releases/v6.1.0' },
+            { tag_name: 'v6.1.0.rc1', prerelease: true },
+            { tag_name: 'v6.0.0', html_url: 'https://github.com/rails/rails/releases/v6.0.0' },
+          ]
+        end
+      }
+      """
+    When I successfully run `hub release watch --once --interval 1 rails/rails`
+    Then the output should contain exactly:
+      """
+      v6.1.0  https://github.com/rails/rails/releases/v6.1.0\n
+      """
+
+  Scenario: Run a command for a new release
+    Given the GitHub API server:
+      """
+      polls = 0
+      get('/repos/rails/rails/releases') {
+        polls += 1
+        releases = [{ tag_name: 'v6.0.0' }]
+        releases.unshift({ tag_name: 'v6.1.0', name: 'Rails 6.1' }) if polls > 1
+        json releases
+      }
+      """
+    When I successfully run `hub release watch --once --interval 1 --exec 'echo "updating to $HUB_RELEASE_NAME"' rails/rails`
+    Then the output should contain exactly:
+      """
+      updating to Rails 6.1\n
+      """
+
+  Scenario: Compare two releases
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/will_paginate/compare/v1.0.0...v1.1.0') {
+        json :commits => [
+          { :sha => "1111111111", :author => { :login => "mislav" } },
+          { :sha => "2222222222", :author => nil, :commit => { :author => { :name => "Jane Doe" } } },
+        ]
+      }
+      post('/graphql') {
+        json :data => { :repository => {
+          :c0 => { :oid => "1111111111", :messageHeadline => "Add pagination", :parents => { :totalCount => 1 },
+                   :associatedPullRequests => { :nodes => [
+                     { :number => 4, :title => "Add pagination", :merged => true, :author => { :login => "octocat" }, :labels => { :nodes => [] } },
+                   ] } },
+          :c1 => { :oid => "2222222222", :messageHeadline => "Fix typo", :parents => { :totalCount => 1 },
+                   :associatedPullRequests => { :nodes => [] } },
+        } }
+      }
+      """
+    When I successfully run `hub release diff --markdown v1.0.0 v1.1.0`
+    Then the output should contain exactly:
+      """
+      ## Changes from v1.0.0 to v1.1.0
+
+      - Add pagination (#4) by @octocat
+      - Fix typo (2222222)
+
+      ### Contributors
+
+      Thanks to Jane Doe, @mislav, and @octocat!\n
+      """
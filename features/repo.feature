@@ -0,0 +1,292 @@
+Feature: hub repo
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Show views and clones
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/traffic/views') {
+        assert :per => "day"
+        json :count => 135, :uniques => 45, :views => [
+          { :timestamp => "2026-10-01T00:00:00Z", :count => 10, :uniques => 3 },
+          { :timestamp => "2026-10-02T00:00:00Z", :count => 125, :uniques => 42 },
+        ]
+      }
+      get('/repos/github/hub/traffic/clones') {
+        json :count => 4, :uniques => 2, :clones => [
+          { :timestamp => "2026-10-02T00:00:00Z", :count => 4, :uniques => 2 },
+        ]
+      }
+      """
+    When I successfully run `hub repo traffic`
+    Then the output should contain exactly:
+      """
+      Views: 135 (45 unique)
+        2026-10-01      10       3
+        2026-10-02     125      42
+
+      Clones: 4 (2 unique)
+        2026-10-02       4       2\n
+      """
+
+  Scenario: Show popular paths as JSON
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/traffic/popular/paths') {
+        json [
+          { :path => "/github/hub", :title => "github/hub", :count => 30, :uniques => 12 },
+        ]
+      }
+      """
+    When I successfully run `hub repo traffic --paths --json`
+    Then the output should contain exactly:
+      """
+      {
+        "paths": [
+          {
+            "path": "/github/hub",
+            "title": "github/hub",
+            "count": 30,
+            "uniques": 12
+          }
+        ]
+      }\n
+      """
+
+  Scenario: List contributors
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/stats/contributors') {
+        json [
+          { :author => { :login => "octocat" }, :total => 1,
+            :weeks => [ { :w => 1790812800, :a => 2, :d => 1, :c => 1 } ] },
+          { :author => { :login => "mislav" }, :total => 120,
+            :weeks => [ { :w => 1790812800, :a => 3400, :d => 1200, :c => 120 } ] },
+        ]
+      }
+      """
+    When I successfully run `hub repo contributors`
+    Then the output should contain exactly:
+      """
+      mislav     120 commits  +3400  -1200
+      octocat      1 commit   +2  -1\n
+      """
+
+  Scenario: Contributors since an unknown tag
+    When I run `hub repo contributors --since v99.0`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      Unknown revision: v99.0\n
+      """
+
+  Scenario: Compare settings of two repositories
+    Given the GitHub API server:
+      """
+      get('/repos/acme/widget') {
+        json :allow_merge_commit => true, :allow_squash_merge => true, :allow_rebase_merge => true,
+             :topics => ["go", "cli"]
+      }
+      get('/repos/acme/gadget') {
+        json :allow_merge_commit => true, :allow_squash_merge => true, :allow_rebase_merge => false,
+             :topics => ["go", "cli"]
+      }
+      get('/repos/acme/widget/labels') { json [{ :name => "bug", :color => "d73a4a" }] }
+      get('/repos/acme/gadget/labels') { json [{ :name => "bug", :color => "d73a4a" }, { :name => "wontfix", :color => "ffffff" }] }
+      post('/graphql') {
+        rules = params[:variables][:repo] == "widget" ?
+          [{ :pattern => "main", :requiresApprovingReviews => true, :requiredApprovingReviewCount => 1 }] : []
+        json :data => { :repository => { :id => "R_1", :branchProtectionRules => { :nodes => rules } } }
+      }
+      get('/repos/acme/widget/hooks') { json [] }
+      get('/repos/acme/gadget/hooks') { json [] }
+      """
+    When I run `hub repo diff-settings acme/widget acme/gadget`
+    Then the exit status should be 1
+    And the output should contain exactly:
+      """
+      --- acme/widget
+      +++ acme/gadget
+      merge settings
+      - allow rebase merge: true
+      + allow rebase merge: false
+      labels
+      + wontfix: #ffffff
+      branch protection
+      - main: reviews=1\n
+      """
+
+  Scenario: Repositories with the same settings
+    Given the GitHub API server:
+      """
+      get('/repos/acme/:repo') { json :topics => [] }
+      get('/repos/acme/:repo/labels') { json [] }
+      post('/graphql') {
+        json :data => { :repository => { :id => "R_1", :branchProtectionRules => { :nodes => [] } } }
+      }
+      get('/repos/acme/:repo/hooks') { status 404; json({}) }
+      """
+    When I successfully run `hub repo diff-settings acme/widget acme/gadget`
+    Then the output should contain exactly:
+      """
+      No differences in settings between acme/widget and acme/gadget\n
+      """
+
+  Scenario: Plan changes to a repository
+    Given a file named "repo.yml" with:
+      """
+      delete_branch_on_merge: true
+      labels:
+        - name: triage
+          color: fbca04
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub') { json :delete_branch_on_merge => false, :topics => [] }
+      get('/repos/github/hub/labels') { json [] }
+      post('/graphql') {
+        json :data => { :repository => { :id => "R_1", :branchProtectionRules => { :nodes => [] } } }
+      }
+      get('/repos/github/hub/collaborators') { json [] }
+      """
+    When I successfully run `hub repo apply -f repo.yml --dry-run`
+    Then the output should contain exactly:
+      """
+      ~ delete_branch_on_merge: false -> true
+      + label triage: #fbca04\n
+      """
+
+  Scenario: Apply changes to a repository
+    Given a file named "repo.yml" with:
+      """
+      delete_branch_on_merge: true
+      allow_rebase_merge: false
+      secrets: [NPM_TOKEN]
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub') { json :delete_branch_on_merge => false, :allow_rebase_merge => true, :topics => [] }
+      get('/repos/github/hub/labels') { json [] }
+      post('/graphql') {
+        json :data => { :repository => { :id => "R_1", :branchProtectionRules => { :nodes => [] } } }
+      }
+      get('/repos/github/hub/collaborators') { json [] }
+      get('/repos/github/hub/actions/secrets') { json :total_count => 0, :secrets => [] }
+      patch('/repos/github/hub') {
+        assert :delete_branch_on_merge => true, :allow_rebase_merge => false
+        json({})
+      }
+      """
+    When I successfully run `hub repo apply -f repo.yml`
+    Then the output should contain exactly:
+      """
+      ~ allow_rebase_merge: true -> false
+      ~ delete_branch_on_merge: false -> true
+      ! secret NPM_TOKEN is missing; set it with `hub secret set NPM_TOKEN`
+      Applied 2 changes to github/hub\n
+      """
+
+  Scenario: Rename a branch
+    Given I make a commit
+    And I successfully run `git update-ref refs/remotes/origin/main HEAD`
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/branches/master/rename') {
+        assert :new_name => "main"
+        status 201
+        json :name => "main"
+      }
+      get('/repos/github/hub/pulls') {
+        assert :state => "open", :base => "master"
+        json [{ :number => 12 }]
+      }
+      patch('/repos/github/hub/pulls/12') {
+        assert :base => "main"
+        json :number => 12
+      }
+      post('/graphql') {
+        if params[:query].include?("updateBranchProtectionRule")
+          assert :variables => { :input => { :branchProtectionRuleId => "BPR_1", :pattern => "main" } }
+          json :data => {}
+        else
+          json :data => { :repository => { :id => "R_1", :branchProtectionRules => { :nodes => [
+            { :id => "BPR_1", :pattern => "master" },
+            { :id => "BPR_2", :pattern => "release/*" },
+          ] } } }
+        end
+      }
+      """
+    When I successfully run `hub repo rename-branch master main`
+    Then the output should contain exactly:
+      """
+      Renamed branch master to main in github/hub
+      Retargeted pull request #12 to main
+      Moved protection rule from master to main
+      Renamed local branch master to main, tracking origin/main\n
+      """
+    And "git fetch --prune --quiet origin" should be run
+    And "git remote set-head origin --auto" should be run
+    When I successfully run `git rev-parse --abbrev-ref main@{upstream}`
+    Then the output should contain "origin/main"
+
+  Scenario: Rename a branch that doesn't exist locally
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/branches/feature/rename') {
+        status 201
+        json :name => "topic"
+      }
+      get('/repos/github/hub/pulls') { json [] }
+      post('/graphql') {
+        json :data => { :repository => { :id => "R_1", :branchProtectionRules => { :nodes => [] } } }
+      }
+      """
+    When I successfully run `hub repo rename-branch feature topic`
+    Then the output should contain exactly:
+      """
+      Renamed branch feature to topic in github/hub\n
+      """
+
+  Scenario: Rename branch requires two names
+    When I run `hub repo rename-branch main`
+    Then the exit status should be 1
+    And the stderr should contain "Usage: hub repo rename-branch"
+
+  Scenario: Migrate doesn't create the destination when it can't be fetched
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/old') { json :full_name => "mislav/old", :private => false }
+      get('/repos/mislav/new') { status 500 }
+      post('/user/repos') { halt 400, "repository shouldn't be created" }
+      """
+    When I run `hub repo migrate --state state.json github.com/mislav/old github.com/mislav/new`
+    Then the exit status should be 1
+    And the stdout should not contain "Created repository"
+    And the stderr should contain "Error getting repository info: Internal Server Error (HTTP 500)"
+
+  Scenario: Migrate reuses a draft release created by an interrupted run
+    Given a file named "state.json" with:
+      """
+      {"git_pushed": true, "labels": true}
+      """
+    Given the GitHub API server:
+      """
+      get('/repos/mislav/old') { json :full_name => "mislav/old", :private => false }
+      get('/repos/mislav/new') { json :full_name => "mislav/new", :private => false }
+      get('/repos/mislav/old/milestones') { json [] }
+      get('/repos/mislav/old/releases') {
+        json [{ :tag_name => "v2.0", :name => "Version 2.0", :draft => true, :assets => [] }]
+      }
+      get('/repos/mislav/new/releases') {
+        json [{ :tag_name => "untagged-1a2b3c", :name => "Version 2.0", :draft => true, :assets => [] }]
+      }
+      post('/repos/mislav/new/releases') { halt 400, "release shouldn't be created" }
+      get('/repos/mislav/old/issues') { json [] }
+      """
+    When I successfully run `hub repo migrate --state state.json github.com/mislav/old github.com/mislav/new`
+    Then the output should contain exactly:
+      """
+      Copied release v2.0
+      Migrated github.com/mislav/old to github.com/mislav/new\n
+      """
@@ -0,0 +1,85 @@
+Feature: hub run
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Logs of a run in progress
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/runs/42') {
+        json :id => 42, :status => "in_progress"
+      }
+      """
+    When I run `hub run logs 42`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: workflow run 42 is still in progress; use --follow to wait for its logs\n
+      """
+
+  Scenario: Follow the logs of a run
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/runs/42') {
+        json :id => 42, :status => "completed", :conclusion => "failure"
+      }
+      get('/repos/github/hub/actions/runs/42/jobs') {
+        json :jobs => [
+          { :id => 7, :name => "build", :status => "completed", :conclusion => "success" },
+          { :id => 8, :name => "test (ubuntu)", :status => "completed", :conclusion => "failure" },
+          { :id => 9, :name => "test (macos)", :status => "completed", :conclusion => "success" },
+        ]
+      }
+      get('/repos/github/hub/actions/jobs/8/logs') {
+        content_type :text
+        "=== RUN TestA\n--- FAIL: TestA\nFAIL\n"
+      }
+      get('/repos/github/hub/actions/jobs/9/logs') {
+        content_type :text
+        "=== RUN TestA\n--- PASS: TestA\nok\n"
+      }
+      """
+    When I successfully run `hub run logs --follow --job "test (*)" --grep FAIL 42`
+    Then the output should contain exactly:
+      """
+      ==> test (ubuntu) <==
+      --- FAIL: TestA
+      FAIL\n
+      """
+
+  Scenario: Invalid run ID
+    When I run `hub run logs latest`
+    Then the exit status should be 1
+    And the stderr should contain "Usage: hub run logs"
+
+  Scenario: Approve pending deployments
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/runs/42/pending_deployments') {
+        json [
+          { :environment => { :id => 1, :name => "staging" }, :current_user_can_approve => true },
+          { :environment => { :id => 2, :name => "production" }, :current_user_can_approve => true },
+        ]
+      }
+      post('/repos/github/hub/actions/runs/42/pending_deployments') {
+        assert :environment_ids => [2],
+               :state => "approved",
+               :comment => "Release window open"
+        json []
+      }
+      """
+    When I successfully run `hub run approve -e production -m "Release window open" 42`
+    Then the output should contain exactly "Approved deployment to production.\n"
+
+  Scenario: No pending deployments to approve
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/runs/42/pending_deployments') {
+        json [
+          { :environment => { :id => 2, :name => "production" }, :current_user_can_approve => false },
+        ]
+      }
+      """
+    When I run `hub run approve 42`
+    Then the exit status should be 1
+    And the stderr should contain exactly "error: workflow run 42 has no pending deployments that you can review\n"
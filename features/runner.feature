@@ -0,0 +1,58 @@
+Feature: hub runner
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List repository runners
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/runners') {
+        json :total_count => 2, :runners => [
+          { :id => 21, :name => "build-01", :os => "linux", :status => "online", :busy => true,
+            :labels => [{ :name => "self-hosted" }, { :name => "linux" }] },
+          { :id => 22, :name => "build-02", :os => "linux", :status => "offline", :busy => false,
+            :labels => [{ :name => "self-hosted" }] },
+        ]
+      }
+      """
+    When I successfully run `hub runner list`
+    Then the output should contain exactly:
+      """
+              21  build-01                  busy      self-hosted, linux
+              22  build-02                  offline   self-hosted\n
+      """
+
+  Scenario: Registration token for an organization
+    Given the GitHub API server:
+      """
+      post('/orgs/github/actions/runners/registration-token') {
+        status 201
+        json :token => "LLBF3JGZDX3P5PMEXLND6TS6FCWO6", :expires_at => "2021-03-15T12:00:00Z"
+      }
+      """
+    When I successfully run `hub runner token --org`
+    Then the output should contain exactly "LLBF3JGZDX3P5PMEXLND6TS6FCWO6\n"
+
+  Scenario: Remove a runner by name
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/runners') {
+        json :total_count => 1, :runners => [
+          { :id => 22, :name => "build-02", :status => "offline" },
+        ]
+      }
+      delete('/repos/github/hub/actions/runners/22') { status 204 }
+      """
+    When I successfully run `hub runner remove build-02`
+    Then the output should not contain anything
+
+  Scenario: Remove an unknown runner
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/runners') {
+        json :total_count => 0, :runners => []
+      }
+      """
+    When I run `hub runner remove build-09`
+    Then the exit status should be 1
+    And the stderr should contain exactly "error: no runner named 'build-09' in github/hub\n"
@@ -0,0 +1,79 @@
+Feature: hub code-scanning and secret-scanning
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List code scanning alerts
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/code-scanning/alerts') {
+        assert :state => "open", :tool_name => "CodeQL"
+        json [
+          { :number => 42,
+            :state => "open",
+            :rule => { :severity => "error", :description => "SQL injection" },
+            :most_recent_instance => { :location => { :path => "lib/db.js", :start_line => 12 } },
+          },
+        ]
+      }
+      """
+    When I successfully run `hub code-scanning list --tool CodeQL`
+    Then the output should contain exactly:
+      """
+          42  error     lib/db.js:12  SQL injection\n
+      """
+
+  Scenario: Dismiss a code scanning alert
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/code-scanning/alerts/42') {
+        assert :state => "dismissed",
+          :dismissed_reason => "used in tests",
+          :dismissed_comment => "Fixture data"
+        json :number => 42
+      }
+      """
+    When I successfully run `hub code-scanning dismiss -R used-in-tests -m "Fixture data" 42`
+    Then the output should not contain anything
+
+  Scenario: Invalid dismissal reason
+    When I run `hub code-scanning dismiss -R bogus 42`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: invalid reason 'bogus'\n
+      """
+
+  Scenario: List resolved secret scanning alerts
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/secret-scanning/alerts') {
+        assert :state => "resolved"
+        json [
+          { :number => 3,
+            :state => "resolved",
+            :secret_type => "github_personal_access_token",
+            :secret_type_display_name => "GitHub Personal Access Token",
+            :resolution => "revoked",
+          },
+        ]
+      }
+      """
+    When I successfully run `hub secret-scanning list -s resolved -f "%I %T %R%n"`
+    Then the output should contain exactly:
+      """
+      3 github_personal_access_token revoked\n
+      """
+
+  Scenario: Resolve a secret scanning alert
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/secret-scanning/alerts/3') {
+        assert :state => "resolved",
+          :resolution => "revoked",
+          :resolution_comment => :no
+        json :number => 3
+      }
+      """
+    When I successfully run `hub secret-scanning resolve -R revoked 3`
+    Then the output should not contain anything
@@ -0,0 +1,105 @@
+Feature: hub search
+  Background:
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Search code with line numbers
+    Given the GitHub API server:
+      """
+      get('/search/code') {
+        halt 400 unless request.env['HTTP_ACCEPT'].include?('text-match')
+        assert :q => "NewClient language:go org:github"
+        json :total_count => 1, :items => [
+          { :name => "client.go",
+            :path => "github/client.go",
+            :sha => "BLOBSHA",
+            :repository => { :name => "hub", :full_name => "github/hub", :owner => { :login => "github" } },
+            :text_matches => [
+              { :fragment => "func NewClient(h string) *Client {\n" },
+            ],
+          },
+        ]
+      }
+      get('/repos/github/hub/git/blobs/BLOBSHA') {
+        content_type 'application/vnd.github.v3.raw'
+        "package github\n\nfunc NewClient(h string) *Client {\n\treturn nil\n}\n"
+      }
+      """
+    When I successfully run `hub search code --org github -l go NewClient`
+    Then the output should contain exactly:
+      """
+      github/hub github/client.go
+          3: func NewClient(h string) *Client {\n
+      """
+
+  Scenario: Open a code search result
+    Given the GitHub API server:
+      """
+      get('/search/code') {
+        assert :q => "BrowserLauncher repo:github/hub"
+        json :total_count => 2, :items => [
+          { :path => "utils/utils.go", :html_url => "https://github.com/github/hub/blob/SHA/utils/utils.go" },
+          { :path => "commands/utils.go", :html_url => "https://github.com/github/hub/blob/SHA/commands/utils.go" },
+        ]
+      }
+      """
+    When I successfully run `hub search code -r github/hub --open 2 BrowserLauncher`
+    Then "open https://github.com/github/hub/blob/SHA/commands/utils.go" should be run
+
+  Scenario: Missing query
+    When I run `hub search code`
+    Then the exit status should be 1
+    And the stderr should contain "Usage: hub search code"
+
+  Scenario: Search repositories sorted by stars
+    Given the GitHub API server:
+      """
+      get('/search/repositories') {
+        assert :q => "cli language:go", :sort => "stars", :order => "asc"
+        json :total_count => 1, :items => [
+          { :full_name => "github/hub", :stargazers_count => 22000, :description => "A command-line tool" },
+        ]
+      }
+      """
+    When I successfully run `hub search repos -l go -s stars -o asc cli`
+    Then the output should contain exactly:
+      """
+      github/hub                                22000  A command-line tool\n
+      """
+
+  Scenario: Search users with custom format
+    Given the GitHub API server:
+      """
+      get('/search/users') {
+        assert :q => "location:Zagreb", :sort => :no
+        json :total_count => 2, :items => [
+          { :login => "mislav", :type => "User" },
+          { :login => "zagreb-js", :type => "Organization" },
+        ]
+      }
+      """
+    When I successfully run `hub search users -f "%l (%t)%n" location:Zagreb`
+    Then the output should contain exactly:
+      """
+      mislav (User)
+      zagreb-js (Organization)\n
+      """
+
+  Scenario: Search commits by author
+    Given the GitHub API server:
+      """
+      get('/search/commits') {
+        assert :q => "fix typo author:mislav repo:github/hub", :sort => "author-date"
+        json :total_count => 1, :items => [
+          { :sha => "abc123def456",
+            :commit => { :message => "Fix typo\n\nDetails", :author => { :name => "Mislav" } },
+            :author => { :login => "mislav" },
+            :repository => { :full_name => "github/hub" },
+          },
+        ]
+      }
+      """
+    When I successfully run `hub search commits -r github/hub --author mislav -s author-date fix typo`
+    Then the output should contain exactly:
+      """
+      github/hub abc123d  Fix typo\n
+      """
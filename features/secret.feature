@@ -0,0 +1,102 @@
+Feature: hub secret
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List repository secrets
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/secrets') {
+        json :total_count => 2, :secrets => [
+          { :name => "NPM_TOKEN", :updated_at => "2026-01-02T10:00:00Z" },
+          { :name => "GH_PAT", :updated_at => "2026-03-04T10:00:00Z" },
+        ]
+      }
+      """
+    When I successfully run `hub secret list`
+    Then the output should contain exactly:
+      """
+      NPM_TOKEN  Updated 2026-01-02
+      GH_PAT     Updated 2026-03-04\n
+      """
+
+  Scenario: Set a repository secret
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/secrets/public-key') {
+        json :key_id => "KEYID", :key => "AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0eHyA="
+      }
+      put('/repos/github/hub/actions/secrets/NPM_TOKEN') {
+        assert :key_id => "KEYID", :visibility => :no,
+          :encrypted_value => %r{\A[A-Za-z0-9+/]{75}=\z}
+        status 201
+      }
+      """
+    When I successfully run `hub secret set -b hunter2 NPM_TOKEN`
+    Then the output should not contain anything
+
+  Scenario: Set an organization secret from stdin
+    Given the GitHub API server:
+      """
+      get('/orgs/github/actions/secrets/public-key') {
+        json :key_id => "KEYID", :key => "AQIDBAUGBwgJCgsMDQ4PEBESExQVFhcYGRobHB0eHyA="
+      }
+      put('/orgs/github/actions/secrets/NPM_TOKEN') {
+        assert :key_id => "KEYID", :visibility => "all"
+        status 204
+      }
+      """
+    When I run `hub secret set --org --visibility all NPM_TOKEN` interactively
+    And I pass in:
+      """
+      hunter2
+      """
+    Then the exit status should be 0
+
+  Scenario: Remove an environment secret
+    Given the GitHub API server:
+      """
+      delete('/repos/github/hub/environments/production/secrets/DEPLOY_KEY') {
+        status 204
+      }
+      """
+    When I successfully run `hub secret remove -e production DEPLOY_KEY`
+    Then the output should not contain anything
+
+  Scenario: Environment and organization are mutually exclusive
+    When I run `hub secret list -e production --org`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: --env and --org are mutually exclusive\n
+      """
+
+  Scenario: Create a variable
+    Given the GitHub API server:
+      """
+      patch('/repos/github/hub/actions/variables/NODE_VERSION') {
+        status 404
+        json :message => "Not Found"
+      }
+      post('/repos/github/hub/actions/variables') {
+        assert :name => "NODE_VERSION", :value => "18"
+        status 201
+      }
+      """
+    When I successfully run `hub variable set -b 18 NODE_VERSION`
+    Then the output should not contain anything
+
+  Scenario: List variables
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/actions/variables') {
+        json :total_count => 1, :variables => [
+          { :name => "NODE_VERSION", :value => "18" },
+        ]
+      }
+      """
+    When I successfully run `hub variable list`
+    Then the output should contain exactly:
+      """
+      NODE_VERSION  18\n
+      """
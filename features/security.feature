@@ -0,0 +1,64 @@
+Feature: hub security
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List open Dependabot alerts
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/dependabot/alerts') {
+        assert :state => "open", :severity => :no
+        json [
+          { :number => 7,
+            :state => "open",
+            :dependency => { :package => { :ecosystem => "npm", :name => "lodash" } },
+            :security_advisory => { :severity => "high", :summary => "Prototype pollution" },
+          },
+          { :number => 12,
+            :state => "open",
+            :dependency => { :package => { :ecosystem => "pip", :name => "requests" } },
+            :security_advisory => { :severity => "medium", :summary => "Leaked headers" },
+          },
+        ]
+      }
+      """
+    When I successfully run `hub security alerts`
+    Then the output should contain exactly:
+      """
+           7  high      lodash  Prototype pollution
+          12  medium    requests  Leaked headers\n
+      """
+
+  Scenario: Filter alerts by severity and state
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/dependabot/alerts') {
+        assert :state => :no, :severity => "high,critical"
+        json [
+          { :number => 7,
+            :state => "fixed",
+            :html_url => "https://github.com/github/hub/security/dependabot/7",
+          },
+        ]
+      }
+      """
+    When I successfully run `hub security alerts -s all --severity HIGH,critical -f "%I %S %U%n"`
+    Then the output should contain exactly:
+      """
+      7 fixed https://github.com/github/hub/security/dependabot/7\n
+      """
+
+  Scenario: Output alerts as JSON
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/dependabot/alerts') {
+        json [
+          { :number => 7, :state => "open" },
+        ]
+      }
+      """
+    When I successfully run `hub security alerts --json`
+    Then the output should contain:
+      """
+      "number": 7,
+      """
@@ -0,0 +1,96 @@
+Feature: hub status
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: Show repository overview
+    Given I am on the "feature" branch with upstream "origin/feature"
+    And I make 2 commits
+    Given the GitHub API server:
+      """
+      get('/user') { json :login => 'mislav' }
+      get('/repos/github/hub/pulls') {
+        if params[:head] == 'github:feature'
+          json [{ :number => 12, :title => 'Add feature', :head => { :sha => 'abc123' } }]
+        else
+          assert :state => 'open'
+          json [
+            { :number => 9, :title => 'Refactor config loading', :user => { :login => 'octocat' },
+              :requested_reviewers => [{ :login => 'mislav' }] },
+            { :number => 10, :title => 'Unrelated', :user => { :login => 'octocat' },
+              :requested_reviewers => [{ :login => 'hubot' }] },
+          ]
+        end
+      }
+      get('/repos/github/hub/commits/abc123/status') {
+        json :statuses => [
+          { :state => 'success', :context => 'build' },
+          { :state => 'pending', :context => 'lint' },
+        ]
+      }
+      get('/repos/github/hub/issues') {
+        assert :assignee => 'mislav', :state => 'open'
+        json [
+          { :number => 5, :title => 'Crash on startup' },
+          { :number => 12, :title => 'Add feature', :pull_request => {} },
+        ]
+      }
+      get('/repos/github/hub/notifications') {
+        json [{ :reason => 'review_requested',
+                :subject => { :title => 'Refactor config loading', :type => 'PullRequest' } }]
+      }
+      """
+    When I successfully run `hub status --github`
+    Then the output should contain exactly:
+      """
+      Branch: feature (ahead 2 of origin/feature)
+
+      Pull request:
+        #12  Add feature (pending, 2 checks)
+
+      Assigned issues:
+        #5  Crash on startup
+
+      Review requests:
+        #9  Refactor config loading (octocat)
+
+      Unread notifications:
+        PullRequest  Refactor config loading (review_requested)\n
+      """
+
+  Scenario: Plain status is passed to git
+    When I successfully run `hub status --short --branch`
+    Then the output should contain "## master"
+
+  Scenario: Set a commit status
+    Given there is a commit named "the_sha"
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/statuses/:sha') {
+        assert :state => "success",
+               :context => "deploy/prod",
+               :target_url => "https://deploy.example.com/42",
+               :description => "Deployed to production"
+        status 201
+        json :state => "success", :context => "deploy/prod"
+      }
+      """
+    When I successfully run `hub status create -s success -c deploy/prod -u https://deploy.example.com/42 -d "Deployed to production" the_sha`
+    Then the output should not contain anything
+
+  Scenario: Set a commit status with the default context
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/statuses/:sha') {
+        assert :state => "pending", :context => "default", :target_url => nil
+        status 201
+        json :state => "pending", :context => "default"
+      }
+      """
+    When I successfully run `hub status create --state pending`
+    Then the output should not contain anything
+
+  Scenario: Commit status requires a state
+    When I run `hub status create`
+    Then the exit status should be 1
+    And the stderr should contain "missing --state"
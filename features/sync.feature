@@ -54,3 +54,52 @@ Feature: hub sync
       """
       warning: 'feature' was deleted on origin, but appears not merged into 'master'\n
       """
+
+  Scenario: Retargets pull requests whose base branch was deleted
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+    And the "origin" remote has url "git://github.com/lostisland/faraday.git"
+    And I am on the "feature" branch pushed to "origin/feature"
+    And I successfully run `git checkout -q master`
+    Given the GitHub API server:
+      """
+      get('/repos/lostisland/faraday/pulls') {
+        assert :state => "open"
+        json [
+          { :number => 11, :base => { :ref => "feature" } },
+          { :number => 12, :base => { :ref => "old-feature" } },
+          { :number => 13, :base => { :ref => "master" } },
+        ]
+      }
+      get('/repos/lostisland/faraday/branches/old-feature') { status 404 }
+      patch('/repos/lostisland/faraday/pulls/12') {
+        assert :base => "master"
+        json :number => 12
+      }
+      """
+    When I successfully run `hub sync --retarget`
+    Then the output should contain exactly:
+      """
+      Retargeted pull request #12 from old-feature to master.\n
+      """
+
+  Scenario: Doesn't retarget pull requests whose base branch isn't fetched
+    Given I am "mislav" on github.com with OAuth token "OTOKEN"
+    And the "origin" remote has url "git://github.com/lostisland/faraday.git"
+    And I am on the "master" branch pushed to "origin/master"
+    Given the GitHub API server:
+      """
+      get('/repos/lostisland/faraday/pulls') {
+        assert :state => "open"
+        json [
+          { :number => 11, :base => { :ref => "release-1.x" } },
+        ]
+      }
+      get('/repos/lostisland/faraday/branches/release-1.x') {
+        json :name => "release-1.x"
+      }
+      patch('/repos/lostisland/faraday/pulls/11') {
+        status 500
+      }
+      """
+    When I successfully run `hub sync --retarget`
+    Then the output should not contain "Retargeted"
@@ -0,0 +1,60 @@
+Feature: hub tag-protection
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List tag protection rules
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/tags/protection') {
+        json [
+          { :id => 2, :pattern => "v*" },
+          { :id => 5, :pattern => "release-*" },
+        ]
+      }
+      """
+    When I successfully run `hub tag-protection list`
+    Then the output should contain exactly:
+      """
+      v*
+      release-*\n
+      """
+
+  Scenario: Create a tag protection rule
+    Given the GitHub API server:
+      """
+      post('/repos/github/hub/tags/protection') {
+        assert :pattern => "v*"
+        status 201
+        json :id => 2, :pattern => "v*"
+      }
+      """
+    When I successfully run `hub tag-protection create v*`
+    Then the output should contain exactly ""
+
+  Scenario: Delete a tag protection rule
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/tags/protection') {
+        json [
+          { :id => 2, :pattern => "v*" },
+          { :id => 5, :pattern => "release-*" },
+        ]
+      }
+      delete('/repos/github/hub/tags/protection/5') {
+        status 204
+      }
+      """
+    When I successfully run `hub tag-protection delete release-*`
+    Then the output should contain exactly ""
+
+  Scenario: Delete a nonexistent tag protection rule
+    Given the GitHub API server:
+      """
+      get('/repos/github/hub/tags/protection') {
+        json [{ :id => 2, :pattern => "v*" }]
+      }
+      """
+    When I run `hub tag-protection delete release-*`
+    Then the exit status should be 1
+    And the stderr should contain exactly "error: no tag protection rule found for `release-*'\n"
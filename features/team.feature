@@ -0,0 +1,71 @@
+Feature: hub team
+  Background:
+    Given I am in "git://github.com/github/hub.git" git repo
+    And I am "mislav" on github.com with OAuth token "OTOKEN"
+
+  Scenario: List teams of the repository owner
+    Given the GitHub API server:
+      """
+      get('/orgs/github/teams') {
+        json [
+          { :slug => "releasers", :name => "Release engineers" },
+          { :slug => "ops", :name => "Operations" },
+        ]
+      }
+      """
+    When I successfully run `hub team list`
+    Then the output should contain exactly:
+      """
+      releasers  Release engineers
+      ops        Operations\n
+      """
+
+  Scenario: Create a secret team
+    Given the GitHub API server:
+      """
+      post('/orgs/github/teams') {
+        assert :name => "releasers",
+          :privacy => "secret",
+          :description => "Ship it"
+        status 201
+        json :html_url => "https://github.com/orgs/github/teams/releasers"
+      }
+      """
+    When I successfully run `hub team create --secret -d "Ship it" github/releasers`
+    Then the output should contain exactly:
+      """
+      https://github.com/orgs/github/teams/releasers\n
+      """
+
+  Scenario: Add a team member who needs an invitation
+    Given the GitHub API server:
+      """
+      put('/orgs/github/teams/releasers/memberships/octocat') {
+        assert :role => "maintainer"
+        json :state => "pending", :role => "maintainer"
+      }
+      """
+    When I successfully run `hub team add-member -r maintainer github/releasers octocat`
+    Then the output should contain exactly:
+      """
+      Invited octocat to join github/releasers.\n
+      """
+
+  Scenario: Give a team access to the current repository
+    Given the GitHub API server:
+      """
+      put('/orgs/github/teams/releasers/repos/github/hub') {
+        assert :permission => "push"
+        status 204
+      }
+      """
+    When I successfully run `hub team add-repo -p push github/releasers`
+    Then the output should not contain anything
+
+  Scenario: Invalid team name
+    When I run `hub team add-member releasers octocat`
+    Then the exit status should be 1
+    And the stderr should contain exactly:
+      """
+      error: invalid team name 'releasers'; expected ORG/TEAM\n
+      """
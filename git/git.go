@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/github/hub/v2/cmd"
 )
@@ -223,6 +226,100 @@ func Show(sha string) (string, error) {
 	return strings.TrimSpace(output), err
 }
 
+// CommitTime returns the committer date of the commit that ref points to.
+func CommitTime(ref string) (time.Time, error) {
+	cmd := gitCmd("-c", "log.showSignature=false", "show", "-s", "--format=%ct", ref+"^{commit}")
+	cmd.Stderr = nil
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Unknown revision: %s", ref)
+	}
+
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(timestamp, 0), nil
+}
+
+// AheadBehind counts the commits that are reachable from a but not from b,
+// and the other way around.
+func AheadBehind(a, b string) (ahead, behind int, err error) {
+	ref := fmt.Sprintf("%s...%s", a, b)
+	countCmd := gitCmd("rev-list", "--left-right", "--count", ref)
+	countCmd.Stderr = nil
+	output, err := countCmd.Output()
+	if err != nil {
+		err = fmt.Errorf("Can't count commits for %s", ref)
+		return
+	}
+
+	counts := strings.Fields(output)
+	if len(counts) != 2 {
+		err = fmt.Errorf("Can't count commits for %s", ref)
+		return
+	}
+	if ahead, err = strconv.Atoi(counts[0]); err != nil {
+		return
+	}
+	behind, err = strconv.Atoi(counts[1])
+	return
+}
+
+// RevList lists SHAs of commits that are reachable from b but not from a,
+// newest first.
+func RevList(a, b string) ([]string, error) {
+	ref := fmt.Sprintf("%s..%s", a, b)
+	listCmd := gitCmd("rev-list", ref)
+	listCmd.Stderr = nil
+	output, err := listCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Can't load rev-list for %s", ref)
+	}
+
+	return outputLines(output), nil
+}
+
+// LatestTag finds the most recent tag that is reachable from ref.
+func LatestTag(ref string) (string, error) {
+	describeCmd := gitCmd("describe", "--tags", "--abbrev=0", ref)
+	describeCmd.Stderr = nil
+	output, err := describeCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("No tags reachable from %s", ref)
+	}
+
+	return firstLine(output), nil
+}
+
+// CommitSubjects lists the subject lines of commits that are in b but not in
+// a, oldest first.
+func CommitSubjects(a, b string) ([]string, error) {
+	ref := fmt.Sprintf("%s...%s", a, b)
+	logCmd := gitCmd("-c", "log.showSignature=false", "log", "--no-color", "--reverse", "--format=%s", "--cherry-pick", "--right-only", "--no-merges", ref)
+	logCmd.Stderr = nil
+	output, err := logCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Can't load commits for %s", ref)
+	}
+
+	return outputLines(output), nil
+}
+
+// ChangedFiles lists paths of files that were changed on b since it diverged
+// from a.
+func ChangedFiles(a, b string) ([]string, error) {
+	ref := fmt.Sprintf("%s...%s", a, b)
+	diffCmd := gitCmd("diff", "--name-only", "--no-renames", ref)
+	diffCmd.Stderr = nil
+	output, err := diffCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Can't load changed files for %s", ref)
+	}
+
+	return outputLines(output), nil
+}
+
 func Log(sha1, sha2 string) (string, error) {
 	execCmd := cmd.New("git")
 	execCmd.WithArg("-c").WithArg("log.showSignature=false").WithArg("log").WithArg("--no-color")
@@ -239,15 +336,143 @@ func Log(sha1, sha2 string) (string, error) {
 	return outputs, nil
 }
 
+type memoizedOutput struct {
+	key           string
+	config        map[string]string
+	configEntries []string
+	remotes       []string
+}
+
+// memo holds the output of git commands that are run at most once per
+// invocation. It is discarded whenever the working directory, HOME, or global
+// git flags change, or when hub runs a git command that might modify them.
+var memo memoizedOutput
+
+// memoMutex allows git to be queried from background goroutines.
+var memoMutex sync.Mutex
+
+func memoized() *memoizedOutput {
+	pwd, _ := os.Getwd()
+	key := strings.Join(append([]string{pwd, os.Getenv("HOME")}, GlobalFlags...), "\x00")
+	if memo.key != key {
+		memo.key = key
+		memo.config = nil
+		memo.configEntries = nil
+		memo.remotes = nil
+	}
+	return &memo
+}
+
+func forget() {
+	memoMutex.Lock()
+	defer memoMutex.Unlock()
+	memo.config = nil
+	memo.configEntries = nil
+	memo.remotes = nil
+}
+
 func Remotes() ([]string, error) {
+	memoMutex.Lock()
+	defer memoMutex.Unlock()
+
+	m := memoized()
+	if m.remotes != nil {
+		return m.remotes, nil
+	}
+
 	remoteCmd := gitCmd("remote", "-v")
 	remoteCmd.Stderr = nil
 	output, err := remoteCmd.Output()
-	return outputLines(output), err
+	if err != nil {
+		return outputLines(output), err
+	}
+	m.remotes = outputLines(output)
+	return m.remotes, nil
 }
 
+// Config reads a single value from the git configuration. All configuration
+// is loaded with a single `git config --list` the first time it is needed.
 func Config(name string) (string, error) {
-	return gitGetConfig(name)
+	values, err := configValues()
+	if err != nil {
+		return gitGetConfig(name)
+	}
+	if value, ok := values[normalizeConfigName(name)]; ok {
+		return value, nil
+	}
+	return "", fmt.Errorf("Unknown config %s", name)
+}
+
+func configValues() (map[string]string, error) {
+	memoMutex.Lock()
+	defer memoMutex.Unlock()
+
+	m := memoized()
+	if err := loadConfig(m); err != nil {
+		return nil, err
+	}
+	return m.config, nil
+}
+
+// configEntries returns every "<name> <value>" pair from the git
+// configuration, including all values of keys that are set more than once, in
+// the format of `git config --get-regexp`.
+func configEntries() ([]string, error) {
+	memoMutex.Lock()
+	defer memoMutex.Unlock()
+
+	m := memoized()
+	if err := loadConfig(m); err != nil {
+		return nil, err
+	}
+	return m.configEntries, nil
+}
+
+func loadConfig(m *memoizedOutput) error {
+	if m.config != nil {
+		return nil
+	}
+
+	listCmd := gitCmd("config", "--list", "-z")
+	listCmd.Stderr = nil
+	output, err := listCmd.Output()
+	if err != nil {
+		return err
+	}
+	m.config, m.configEntries = parseConfigList(output)
+	return nil
+}
+
+// parseConfigList parses the output of `git config --list -z`. When a key is
+// set more than once, the last value wins in values, just like
+// `git config <name>`, while entries keeps all of them.
+func parseConfigList(output string) (values map[string]string, entries []string) {
+	values = map[string]string{}
+	for _, entry := range strings.Split(output, "\x00") {
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "\n", 2)
+		if len(kv) == 2 {
+			values[kv[0]] = kv[1]
+			entries = append(entries, kv[0]+" "+kv[1])
+		} else {
+			values[kv[0]] = ""
+			entries = append(entries, kv[0])
+		}
+	}
+	return
+}
+
+// normalizeConfigName lowercases the section and key of a config name while
+// preserving the case of the subsection, if any.
+func normalizeConfigName(name string) string {
+	first := strings.Index(name, ".")
+	last := strings.LastIndex(name, ".")
+	if first < 0 {
+		return strings.ToLower(name)
+	}
+	return strings.ToLower(name[:first]) + name[first:last] + strings.ToLower(name[last:])
 }
 
 func ConfigAll(name string) ([]string, error) {
@@ -284,6 +509,7 @@ func gitGetConfig(args ...string) (string, error) {
 }
 
 func gitConfig(args ...string) ([]string, error) {
+	forget()
 	configCmd := gitCmd(gitConfigCommand(args)...)
 	output, err := configCmd.Output()
 	return outputLines(output), err
@@ -299,20 +525,68 @@ func Alias(name string) (string, error) {
 }
 
 func Run(args ...string) error {
+	forget()
 	cmd := gitCmd(args...)
 	return cmd.Run()
 }
 
 func Spawn(args ...string) error {
+	forget()
 	cmd := gitCmd(args...)
 	return cmd.Spawn()
 }
 
 func Quiet(args ...string) bool {
+	forget()
 	cmd := gitCmd(args...)
 	return cmd.Success()
 }
 
+// LFSSmudge writes the contents of the Git LFS object that the pointer file
+// refers to into out, downloading the object if necessary.
+func LFSSmudge(path string, pointer, out *os.File) error {
+	smudgeCmd := gitCmd("lfs", "smudge", "--", path)
+	smudgeCmd.Stdin = pointer
+	smudgeCmd.Stdout = out
+	if err := smudgeCmd.Spawn(); err != nil {
+		return fmt.Errorf("error resolving Git LFS pointer %s: %s", path, err)
+	}
+	return nil
+}
+
+// BlameLine returns the SHA of the commit that last changed a line of a file.
+func BlameLine(file string, line int) (string, error) {
+	blameCmd := gitCmd("blame", "--porcelain", "-L", fmt.Sprintf("%d,%d", line, line), "--", file)
+	blameCmd.Stderr = nil
+	output, err := blameCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("Can't blame line %d of %s", line, file)
+	}
+	fields := strings.Fields(firstLine(output))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("Can't blame line %d of %s", line, file)
+	}
+	return fields[0], nil
+}
+
+// Bisect runs a git-bisect(1) subcommand and returns its combined output.
+func Bisect(args ...string) (string, error) {
+	bisectCmd := gitCmd(append([]string{"bisect"}, args...)...)
+	output, err := bisectCmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("Error running `git bisect %s`:\n%s", strings.Join(args, " "), output)
+	}
+	return output, nil
+}
+
+// IsBareRepository reports whether the current repository has no work tree.
+func IsBareRepository() bool {
+	bareCmd := gitCmd("rev-parse", "--is-bare-repository")
+	bareCmd.Stderr = nil
+	output, err := bareCmd.Output()
+	return err == nil && firstLine(output) == "true"
+}
+
 func IsGitDir(dir string) bool {
 	cmd := cmd.New("git")
 	cmd.WithArgs("--git-dir="+dir, "rev-parse", "--git-dir")
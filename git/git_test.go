@@ -81,6 +81,80 @@ func TestGitShow(t *testing.T) {
 	assert.Equal(t, "First comment\n\nMore comment", output)
 }
 
+func TestGitCommitTime(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	commitTime, err := CommitTime("9b5a719a3d76ac9dc2fa635d9b1f34fd73994c06")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1392104385), commitTime.Unix())
+
+	_, err = CommitTime("nonexistent")
+	assert.Equal(t, "Unknown revision: nonexistent", err.Error())
+}
+
+func TestGitAheadBehind(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	ahead, behind, err := AheadBehind("9b5a719a3d76ac9dc2fa635d9b1f34fd73994c06", "08f4b7b6513dffc6245857e497cfd6101dc47818")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, ahead)
+	assert.Equal(t, 0, behind)
+
+	_, _, err = AheadBehind("nonexistent", "HEAD")
+	assert.Equal(t, "Can't count commits for nonexistent...HEAD", err.Error())
+}
+
+func TestGitRevList(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	shas, err := RevList("08f4b7b6513dffc6245857e497cfd6101dc47818", "9b5a719a3d76ac9dc2fa635d9b1f34fd73994c06")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"9b5a719a3d76ac9dc2fa635d9b1f34fd73994c06"}, shas)
+
+	_, err = RevList("nonexistent", "HEAD")
+	assert.Equal(t, "Can't load rev-list for nonexistent..HEAD", err.Error())
+}
+
+func TestGitLatestTag(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	_, err := LatestTag("HEAD")
+	assert.Equal(t, "No tags reachable from HEAD", err.Error())
+
+	assert.Equal(t, nil, Run("tag", "v1.0", "08f4b7b6513dffc6245857e497cfd6101dc47818"))
+	tag, err := LatestTag("HEAD")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "v1.0", tag)
+}
+
+func TestGitCommitSubjects(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	subjects, err := CommitSubjects("08f4b7b6513dffc6245857e497cfd6101dc47818", "9b5a719a3d76ac9dc2fa635d9b1f34fd73994c06")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"First comment"}, subjects)
+
+	_, err = CommitSubjects("nonexistent", "HEAD")
+	assert.Equal(t, "Can't load commits for nonexistent...HEAD", err.Error())
+}
+
+func TestGitChangedFiles(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	files, err := ChangedFiles("08f4b7b6513dffc6245857e497cfd6101dc47818", "9b5a719a3d76ac9dc2fa635d9b1f34fd73994c06")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"test_file"}, files)
+
+	_, err = ChangedFiles("nonexistent", "HEAD")
+	assert.Equal(t, "Can't load changed files for nonexistent...HEAD", err.Error())
+}
+
 func TestGitConfig(t *testing.T) {
 	repo := fixtures.SetupTestRepo()
 	defer repo.TearDown()
@@ -184,3 +258,38 @@ func TestCommentChar(t *testing.T) {
 	_, err = CommentChar("#\n;\n@\n!\n$\n%\n^\n&\n|\n:")
 	assert.Equal(t, "unable to select a comment character that is not used in the current message", err.Error())
 }
+
+func TestConfigMemoized(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	SetGlobalConfig("hub.memoTest", "one")
+	SetGlobalConfig("Branch.MyTopic.remote", "upstream")
+	v, err := Config("hub.memotest")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "one", v)
+	v, err = Config("branch.MyTopic.Remote")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "upstream", v)
+	_, err = Config("branch.mytopic.remote")
+	assert.NotEqual(t, nil, err)
+
+	SetGlobalConfig("hub.memoTest", "two")
+	v, _ = Config("hub.memoTest")
+	assert.Equal(t, "two", v)
+}
+
+func TestParseConfigList(t *testing.T) {
+	values, entries := parseConfigList("core.bare\nfalse\x00remote.origin.url\nhttps://a\x00remote.origin.url\nhttps://b\x00core.flag\x00")
+	assert.Equal(t, map[string]string{
+		"core.bare":         "false",
+		"remote.origin.url": "https://b",
+		"core.flag":         "",
+	}, values)
+	assert.Equal(t, []string{
+		"core.bare false",
+		"remote.origin.url https://a",
+		"remote.origin.url https://b",
+		"core.flag",
+	}, entries)
+}
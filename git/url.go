@@ -9,6 +9,7 @@ import (
 var (
 	cachedSSHConfig SSHConfig
 	protocolRe      = regexp.MustCompile("^[a-zA-Z_+-]+://")
+	urlRewriteRe    = regexp.MustCompile(`^url\..*\.(push)?insteadof$`)
 )
 
 type URLParser struct {
@@ -64,3 +65,57 @@ func ParseURL(rawURL string) (u *url.URL, err error) {
 
 	return p.Parse(rawURL)
 }
+
+// URLRewrite is a `url.<base>.insteadOf` or `url.<base>.pushInsteadOf` rule
+// from git config. Git replaces the InsteadOf prefix of remote URLs with Base.
+type URLRewrite struct {
+	Base      string
+	InsteadOf string
+	Push      bool
+}
+
+// Unrewrite reverses the rule for a URL that git has already rewritten. It
+// returns false if the rule couldn't have produced rawURL.
+func (r URLRewrite) Unrewrite(rawURL string) (string, bool) {
+	if r.Base == "" || !strings.HasPrefix(rawURL, r.Base) {
+		return "", false
+	}
+	return r.InsteadOf + strings.TrimPrefix(rawURL, r.Base), true
+}
+
+// URLRewrites reads the insteadOf rules from the memoized git configuration,
+// so that looking them up for every remote doesn't spawn another git process.
+func URLRewrites() (rewrites []URLRewrite, err error) {
+	entries, err := configEntries()
+	if err != nil {
+		return nil, nil
+	}
+	lines := []string{}
+	for _, entry := range entries {
+		if urlRewriteRe.MatchString(strings.SplitN(entry, " ", 2)[0]) {
+			lines = append(lines, entry)
+		}
+	}
+	return parseURLRewrites(lines), nil
+}
+
+func parseURLRewrites(lines []string) (rewrites []URLRewrite) {
+	for _, line := range lines {
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimPrefix(parts[0], "url.")
+		rewrite := URLRewrite{InsteadOf: parts[1]}
+		if lower := strings.ToLower(key); strings.HasSuffix(lower, ".pushinsteadof") {
+			rewrite.Base = key[:len(key)-len(".pushinsteadof")]
+			rewrite.Push = true
+		} else if strings.HasSuffix(lower, ".insteadof") {
+			rewrite.Base = key[:len(key)-len(".insteadof")]
+		} else {
+			continue
+		}
+		rewrites = append(rewrites, rewrite)
+	}
+	return
+}
@@ -3,6 +3,7 @@ package git
 import (
 	"testing"
 
+	"github.com/github/hub/v2/fixtures"
 	"github.com/github/hub/v2/internal/assert"
 )
 
@@ -68,6 +69,12 @@ func TestURLParser_ParseURL_SSHURL(t *testing.T) {
 	assert.Equal(t, "ssh.git.company.com", u.Host)
 	assert.Equal(t, "ssh", u.Scheme)
 	assert.Equal(t, "/octokit/go-octokit", u.Path)
+
+	u, err = p.Parse("ssh://git@ssh.github.com:443/octokit/go-octokit.git")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ssh.github.com", u.Host)
+	assert.Equal(t, "ssh", u.Scheme)
+	assert.Equal(t, "/octokit/go-octokit.git", u.Path)
 }
 
 func TestURLParser_ParseURL_LocalPath(t *testing.T) {
@@ -83,3 +90,37 @@ func TestURLParser_ParseURL_LocalPath(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, `c:\path\to\repo.git`, u.String())
 }
+
+func TestParseURLRewrites(t *testing.T) {
+	rewrites := parseURLRewrites([]string{
+		"url.https://mirror.example.com/github/.insteadof https://github.com/",
+		"url.git@github.com:.pushinsteadof https://github.com/",
+		"url.broken",
+	})
+
+	assert.Equal(t, 2, len(rewrites))
+	assert.Equal(t, URLRewrite{Base: "https://mirror.example.com/github/", InsteadOf: "https://github.com/"}, rewrites[0])
+	assert.Equal(t, URLRewrite{Base: "git@github.com:", InsteadOf: "https://github.com/", Push: true}, rewrites[1])
+
+	original, ok := rewrites[0].Unrewrite("https://mirror.example.com/github/hub/hub.git")
+	assert.T(t, ok)
+	assert.Equal(t, "https://github.com/hub/hub.git", original)
+
+	_, ok = rewrites[0].Unrewrite("https://example.com/hub/hub.git")
+	assert.T(t, !ok)
+}
+
+func TestURLRewrites(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	assert.Equal(t, nil, Run("config", "--add", "url.https://mirror.example.com/.insteadOf", "https://github.com/"))
+	assert.Equal(t, nil, Run("config", "--add", "url.https://mirror.example.com/.insteadOf", "gh:"))
+
+	rewrites, err := URLRewrites()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []URLRewrite{
+		{Base: "https://mirror.example.com/", InsteadOf: "https://github.com/"},
+		{Base: "https://mirror.example.com/", InsteadOf: "gh:"},
+	}, rewrites)
+}
@@ -11,10 +11,11 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
-	"path"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/github/hub/v2/version"
@@ -38,6 +39,11 @@ func NewClientWithHost(host *Host) *Client {
 type Client struct {
 	Host         *Host
 	cachedClient *simpleClient
+
+	// mu guards cachedClient so that requests may be made in the background.
+	mu sync.Mutex
+
+	cachedEnterpriseVersion *string
 }
 
 type Gist struct {
@@ -56,6 +62,18 @@ type GistFile struct {
 }
 
 func (client *Client) FetchPullRequests(project *Project, filterParams map[string]interface{}, limit int, filter func(*PullRequest) bool) (pulls []PullRequest, err error) {
+	pulls = []PullRequest{}
+	err = client.EachPullRequest(project, filterParams, limit, filter, func(pr *PullRequest) error {
+		pulls = append(pulls, *pr)
+		return nil
+	})
+	return
+}
+
+// EachPullRequest pages through pull requests, calling each for every pull
+// request accepted by filter as soon as its page has been received. Paging
+// stops after limit pull requests have been accepted.
+func (client *Client) EachPullRequest(project *Project, filterParams map[string]interface{}, limit int, filter func(*PullRequest) bool, each func(*PullRequest) error) (err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
@@ -66,11 +84,15 @@ func (client *Client) FetchPullRequests(project *Project, filterParams map[strin
 		path = addQuery(path, filterParams)
 	}
 
-	pulls = []PullRequest{}
+	accepted := 0
 	var res *simpleResponse
 
 	for path != "" {
-		res, err = api.GetFile(path, draftsType)
+		if _, ok := filterParams["head"]; ok {
+			res, err = api.GetRevalidated(path, draftsType, pullRequestForBranchCacheTTL)
+		} else {
+			res, err = api.GetFile(path, draftsType)
+		}
 		if err = checkStatus(200, "fetching pull requests", res, err); err != nil {
 			return
 		}
@@ -82,8 +104,11 @@ func (client *Client) FetchPullRequests(project *Project, filterParams map[strin
 		}
 		for _, pr := range pullsPage {
 			if filter == nil || filter(&pr) {
-				pulls = append(pulls, pr)
-				if limit > 0 && len(pulls) == limit {
+				if err = each(&pr); err != nil {
+					return
+				}
+				accepted++
+				if limit > 0 && accepted == limit {
 					path = ""
 					break
 				}
@@ -131,6 +156,11 @@ func (client *Client) CreatePullRequest(project *Project, params map[string]inte
 		return
 	}
 
+	if draft, _ := params["draft"].(bool); draft && !client.supports(featureDraftPullRequests) {
+		err = client.unsupportedError("creating pull request", featureDraftPullRequests)
+		return
+	}
+
 	res, err := api.PostJSONPreview(fmt.Sprintf("repos/%s/%s/pulls", project.Owner, project.Name), params, draftsType)
 	if err = checkStatus(201, "creating pull request", res, err); err != nil {
 		if res != nil && res.StatusCode == 404 {
@@ -152,6 +182,22 @@ type PullRequestMergeResponse struct {
 	Message string
 }
 
+func (client *Client) UpdatePullRequest(project *Project, prNumber int, params map[string]interface{}) (pr *PullRequest, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/pulls/%d", project.Owner, project.Name, prNumber), params)
+	if err = checkStatus(200, "updating pull request", res, err); err != nil {
+		return
+	}
+
+	pr = &PullRequest{}
+	err = res.Unmarshal(pr)
+	return
+}
+
 func (client *Client) MergePullRequest(project *Project, prNumber int, params map[string]interface{}) (mr PullRequestMergeResponse, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -168,6 +214,42 @@ func (client *Client) MergePullRequest(project *Project, prNumber int, params ma
 	return
 }
 
+type PullRequestFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Changes   int    `json:"changes"`
+}
+
+func (client *Client) FetchPullRequestFiles(project *Project, prNumber int) (files []PullRequestFile, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/files?per_page=100", project.Owner, project.Name, prNumber)
+
+	files = []PullRequestFile{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching pull request files", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		filesPage := []PullRequestFile{}
+		if err = res.Unmarshal(&filesPage); err != nil {
+			return
+		}
+		files = append(files, filesPage...)
+	}
+
+	return
+}
+
 func (client *Client) DeleteBranch(project *Project, branchName string) (err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -188,6 +270,37 @@ func (client *Client) DeleteBranch(project *Project, branchName string) (err err
 	return
 }
 
+func (client *Client) RenameBranch(project *Project, branchName, newName string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"new_name": newName}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/branches/%s/rename", project.Owner, project.Name, branchName), params)
+	return checkStatus(201, "renaming branch", res, err)
+}
+
+// BranchExists reports whether the branch exists in project on the server.
+func (client *Client) BranchExists(project *Project, branchName string) (exists bool, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/branches/%s", project.Owner, project.Name, url.PathEscape(branchName)))
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return false, nil
+	}
+	if err = checkStatus(200, "fetching branch", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return true, nil
+}
+
 func (client *Client) RequestReview(project *Project, prNumber int, params map[string]interface{}) (err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -252,7 +365,7 @@ func (client *Client) Repository(project *Project) (repo *Repository, err error)
 		return
 	}
 
-	res, err := api.Get(fmt.Sprintf("repos/%s/%s", project.Owner, project.Name))
+	res, err := api.GetRevalidated(fmt.Sprintf("repos/%s/%s", project.Owner, project.Name), apiPayloadVersion, repositoryCacheTTL)
 	if err = checkStatus(200, "getting repository info", res, err); err != nil {
 		return
 	}
@@ -262,6 +375,88 @@ func (client *Client) Repository(project *Project) (repo *Repository, err error)
 	return
 }
 
+// RepositoryExists reports whether project exists, treating only a 404 as
+// absence so that other failures aren't mistaken for a missing repository.
+func (client *Client) RepositoryExists(project *Project) (exists bool, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s", project.Owner, project.Name))
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return false, nil
+	}
+	if err = checkStatus(200, "getting repository info", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return true, nil
+}
+
+// FetchOrgRepositories lists the repositories of an organization that are
+// visible to the current user.
+func (client *Client) FetchOrgRepositories(org string) (repos []Repository, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("orgs/%s/repos?per_page=100", org)
+
+	repos = []Repository{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching organization repositories", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		reposPage := []Repository{}
+		if err = res.Unmarshal(&reposPage); err != nil {
+			return
+		}
+		repos = append(repos, reposPage...)
+	}
+
+	return
+}
+
+func (client *Client) UpdateRepository(project *Project, params map[string]interface{}) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s", project.Owner, project.Name), params)
+	if err = checkStatus(200, "updating repository", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) ReplaceTopics(project *Project, topics []string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"names": topics}
+	res, err := api.PutJSON(fmt.Sprintf("repos/%s/%s/topics", project.Owner, project.Name), params)
+	if err = checkStatus(200, "updating topics", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
 func (client *Client) CreateRepository(project *Project, description, homepage string, isPrivate bool) (repo *Repository, err error) {
 	repoURL := "user/repos"
 	if project.Owner != client.Host.User {
@@ -301,212 +496,2110 @@ func (client *Client) DeleteRepository(project *Project) error {
 	return checkStatus(204, "deleting repository", res, err)
 }
 
-type Release struct {
-	Name            string         `json:"name"`
-	TagName         string         `json:"tag_name"`
-	TargetCommitish string         `json:"target_commitish"`
-	Body            string         `json:"body"`
-	Draft           bool           `json:"draft"`
-	Prerelease      bool           `json:"prerelease"`
-	Assets          []ReleaseAsset `json:"assets"`
-	TarballURL      string         `json:"tarball_url"`
-	ZipballURL      string         `json:"zipball_url"`
-	HTMLURL         string         `json:"html_url"`
-	UploadURL       string         `json:"upload_url"`
-	APIURL          string         `json:"url"`
-	CreatedAt       time.Time      `json:"created_at"`
-	PublishedAt     time.Time      `json:"published_at"`
-}
-
-type ReleaseAsset struct {
-	Name        string `json:"name"`
-	Label       string `json:"label"`
-	DownloadURL string `json:"browser_download_url"`
-	APIURL      string `json:"url"`
+type DeployKey struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	Key       string    `json:"key"`
+	ReadOnly  bool      `json:"read_only"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
-func (client *Client) FetchReleases(project *Project, limit int, filter func(*Release) bool) (releases []Release, err error) {
+func (client *Client) FetchDeployKeys(project *Project) (keys []DeployKey, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
-	path := fmt.Sprintf("repos/%s/%s/releases?per_page=%d", project.Owner, project.Name, perPage(limit, 100))
+	path := fmt.Sprintf("repos/%s/%s/keys?per_page=100", project.Owner, project.Name)
 
-	releases = []Release{}
+	keys = []DeployKey{}
 	var res *simpleResponse
 
 	for path != "" {
 		res, err = api.Get(path)
-		if err = checkStatus(200, "fetching releases", res, err); err != nil {
+		if err = checkStatus(200, "fetching deploy keys", res, err); err != nil {
 			return
 		}
 		path = res.Link("next")
 
-		releasesPage := []Release{}
-		if err = res.Unmarshal(&releasesPage); err != nil {
+		keysPage := []DeployKey{}
+		if err = res.Unmarshal(&keysPage); err != nil {
 			return
 		}
-		for _, release := range releasesPage {
-			if filter == nil || filter(&release) {
-				releases = append(releases, release)
-				if limit > 0 && len(releases) == limit {
-					path = ""
-					break
-				}
-			}
-		}
+		keys = append(keys, keysPage...)
 	}
 
 	return
 }
 
-func (client *Client) FetchRelease(project *Project, tagName string) (*Release, error) {
-	releases, err := client.FetchReleases(project, 100, func(release *Release) bool {
-		return release.TagName == tagName
-	})
-
+func (client *Client) CreateDeployKey(project *Project, params map[string]interface{}) (key *DeployKey, err error) {
+	api, err := client.simpleAPI()
 	if err != nil {
-		return nil, err
+		return
 	}
-	if len(releases) < 1 {
-		return nil, fmt.Errorf("Unable to find release with tag name `%s'", tagName)
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/keys", project.Owner, project.Name), params)
+	if err = checkStatus(201, "creating deploy key", res, err); err != nil {
+		return
 	}
-	return &releases[0], nil
+
+	key = &DeployKey{}
+	err = res.Unmarshal(key)
+	return
 }
 
-func (client *Client) CreateRelease(project *Project, releaseParams *Release) (release *Release, err error) {
+func (client *Client) DeleteDeployKey(project *Project, id int) (err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
-	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/releases", project.Owner, project.Name), releaseParams)
-	if err = checkStatus(201, "creating release", res, err); err != nil {
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/keys/%d", project.Owner, project.Name, id))
+	if err = checkStatus(204, "deleting deploy key", res, err); err != nil {
 		return
 	}
 
-	release = &Release{}
-	err = res.Unmarshal(release)
 	return
 }
 
-func (client *Client) EditRelease(release *Release, releaseParams map[string]interface{}) (updatedRelease *Release, err error) {
+type TagProtection struct {
+	ID      int    `json:"id"`
+	Pattern string `json:"pattern"`
+}
+
+func (client *Client) FetchTagProtections(project *Project) (rules []TagProtection, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
-	res, err := api.PatchJSON(release.APIURL, releaseParams)
-	if err = checkStatus(200, "editing release", res, err); err != nil {
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/tags/protection", project.Owner, project.Name))
+	if err = client.checkFeatureStatus(featureTagProtection, 200, "fetching tag protection rules", res, err); err != nil {
 		return
 	}
 
-	updatedRelease = &Release{}
-	err = res.Unmarshal(updatedRelease)
+	rules = []TagProtection{}
+	err = res.Unmarshal(&rules)
 	return
 }
 
-func (client *Client) DeleteRelease(release *Release) (err error) {
+func (client *Client) CreateTagProtection(project *Project, pattern string) (rule *TagProtection, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
-	res, err := api.Delete(release.APIURL)
-	if err = checkStatus(204, "deleting release", res, err); err != nil {
+	params := map[string]interface{}{"pattern": pattern}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/tags/protection", project.Owner, project.Name), params)
+	if err = client.checkFeatureStatus(featureTagProtection, 201, "creating tag protection rule", res, err); err != nil {
 		return
 	}
 
+	rule = &TagProtection{}
+	err = res.Unmarshal(rule)
 	return
 }
 
-type LocalAsset struct {
-	Name     string
-	Label    string
-	Contents io.Reader
-	Size     int64
+func (client *Client) DeleteTagProtection(project *Project, id int) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/tags/protection/%d", project.Owner, project.Name, id))
+	return checkStatus(204, "deleting tag protection rule", res, err)
 }
 
-func (client *Client) UploadReleaseAssets(release *Release, assets []LocalAsset) (doneAssets []*ReleaseAsset, err error) {
+type Webhook struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Active bool     `json:"active"`
+	Events []string `json:"events"`
+	Config struct {
+		URL         string `json:"url"`
+		ContentType string `json:"content_type"`
+	} `json:"config"`
+}
+
+func (client *Client) FetchWebhooks(project *Project) (hooks []Webhook, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
-	idx := strings.Index(release.UploadURL, "{")
-	uploadURL := release.UploadURL[0:idx]
-
-	for _, asset := range assets {
-		for _, existingAsset := range release.Assets {
-			if existingAsset.Name == asset.Name {
-				if err = client.DeleteReleaseAsset(&existingAsset); err != nil {
-					return
-				}
-				break
-			}
-		}
+	path := fmt.Sprintf("repos/%s/%s/hooks?per_page=100", project.Owner, project.Name)
 
-		params := map[string]interface{}{"name": filepath.Base(asset.Name)}
-		if asset.Label != "" {
-			params["label"] = asset.Label
-		}
-		uploadPath := addQuery(uploadURL, params)
+	hooks = []Webhook{}
+	var res *simpleResponse
 
-		var res *simpleResponse
-		attempts := 0
-		maxAttempts := 3
-		body := asset.Contents
-		for {
-			res, err = api.PostFile(uploadPath, body, asset.Size)
-			if err == nil && res.StatusCode >= 500 && res.StatusCode < 600 && attempts < maxAttempts {
-				attempts++
-				time.Sleep(time.Second * time.Duration(attempts))
-				var f *os.File
-				f, err = os.Open(asset.Name)
-				if err != nil {
-					return
-				}
-				defer f.Close()
-				body = f
-				continue
-			}
-			if err = checkStatus(201, "uploading release asset", res, err); err != nil {
-				return
-			}
-			break
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching webhooks", res, err); err != nil {
+			return
 		}
+		path = res.Link("next")
 
-		newAsset := ReleaseAsset{}
-		err = res.Unmarshal(&newAsset)
-		if err != nil {
+		hooksPage := []Webhook{}
+		if err = res.Unmarshal(&hooksPage); err != nil {
 			return
 		}
-		doneAssets = append(doneAssets, &newAsset)
+		hooks = append(hooks, hooksPage...)
 	}
 
 	return
 }
 
-func (client *Client) DeleteReleaseAsset(asset *ReleaseAsset) (err error) {
+type ActionsSecret struct {
+	Name       string    `json:"name"`
+	Value      string    `json:"value"`
+	Visibility string    `json:"visibility"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ActionsPublicKey struct {
+	KeyID string `json:"key_id"`
+	Key   string `json:"key"`
+}
+
+// FetchActionsSecrets lists secrets or variables, depending on kind, under the
+// API path prefix of a repository, environment, or organization.
+func (client *Client) FetchActionsSecrets(prefix, kind string) (secrets []ActionsSecret, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
-	res, err := api.Delete(asset.APIURL)
-	err = checkStatus(204, "deleting release asset", res, err)
+	path := fmt.Sprintf("%s/%s?per_page=100", prefix, kind)
+
+	secrets = []ActionsSecret{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching "+kind, res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		secretsPage := map[string][]ActionsSecret{}
+		if err = res.Unmarshal(&secretsPage); err != nil {
+			return
+		}
+		secrets = append(secrets, secretsPage[kind]...)
+	}
 
 	return
 }
 
-func (client *Client) DownloadReleaseAsset(url string) (asset io.ReadCloser, err error) {
+func (client *Client) FetchActionsPublicKey(prefix string) (key *ActionsPublicKey, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
-	resp, err := api.GetFile(url, "application/octet-stream")
+	res, err := api.Get(fmt.Sprintf("%s/secrets/public-key", prefix))
+	if err = checkStatus(200, "fetching public key", res, err); err != nil {
+		return
+	}
+
+	key = &ActionsPublicKey{}
+	err = res.Unmarshal(key)
+	return
+}
+
+func (client *Client) SetActionsSecret(prefix, name string, params map[string]interface{}) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PutJSON(fmt.Sprintf("%s/secrets/%s", prefix, name), params)
+	if err == nil && res.StatusCode == 204 {
+		res.StatusCode = 201
+	}
+	if err = checkStatus(201, "setting secret", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) SetActionsVariable(prefix, name string, params map[string]interface{}) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("%s/variables/%s", prefix, name), params)
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		params["name"] = name
+		res, err = api.PostJSON(fmt.Sprintf("%s/variables", prefix), params)
+		if err = checkStatus(201, "creating variable", res, err); err != nil {
+			return
+		}
+	} else if err = checkStatus(204, "updating variable", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+// DeleteActionsSecret deletes a secret or a variable, depending on kind.
+func (client *Client) DeleteActionsSecret(prefix, kind, name string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("%s/%s/%s", prefix, kind, name))
+	if err = checkStatus(204, "deleting "+strings.TrimSuffix(kind, "s"), res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+type ActionsRunner struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	OS     string `json:"os"`
+	Status string `json:"status"`
+	Busy   bool   `json:"busy"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+type ActionsRunnerToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// FetchActionsRunners lists the self-hosted runners under the API path prefix
+// of a repository or an organization.
+func (client *Client) FetchActionsRunners(prefix string) (runners []ActionsRunner, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("%s/runners?per_page=100", prefix)
+
+	runners = []ActionsRunner{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching runners", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		runnersPage := struct {
+			Runners []ActionsRunner `json:"runners"`
+		}{}
+		if err = res.Unmarshal(&runnersPage); err != nil {
+			return
+		}
+		runners = append(runners, runnersPage.Runners...)
+	}
+
+	return
+}
+
+// CreateActionsRunnerToken generates a token for configuring a new runner, or
+// for removing one, depending on kind ("registration" or "remove").
+func (client *Client) CreateActionsRunnerToken(prefix, kind string) (token *ActionsRunnerToken, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("%s/runners/%s-token", prefix, kind), map[string]interface{}{})
+	if err = checkStatus(201, "creating "+kind+" token", res, err); err != nil {
+		return
+	}
+
+	token = &ActionsRunnerToken{}
+	err = res.Unmarshal(token)
+	return
+}
+
+func (client *Client) DeleteActionsRunner(prefix string, runnerID int) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("%s/runners/%d", prefix, runnerID))
+	if err = checkStatus(204, "removing runner", res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+type WorkflowRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	HTMLURL    string `json:"html_url"`
+}
+
+type WorkflowJob struct {
+	ID         int64          `json:"id"`
+	Name       string         `json:"name"`
+	Status     string         `json:"status"`
+	Conclusion string         `json:"conclusion"`
+	Steps      []WorkflowStep `json:"steps"`
+}
+
+type WorkflowStep struct {
+	Number     int    `json:"number"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+func (client *Client) FetchWorkflowRun(project *Project, runID int64) (run *WorkflowRun, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/actions/runs/%d", project.Owner, project.Name, runID))
+	if err = checkStatus(200, "fetching workflow run", res, err); err != nil {
+		return
+	}
+
+	run = &WorkflowRun{}
+	err = res.Unmarshal(run)
+	return
+}
+
+func (client *Client) FetchWorkflowJobs(project *Project, runID int64) (jobs []WorkflowJob, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/jobs?per_page=100", project.Owner, project.Name, runID)
+
+	jobs = []WorkflowJob{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching workflow jobs", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		jobsPage := struct {
+			Jobs []WorkflowJob `json:"jobs"`
+		}{}
+		if err = res.Unmarshal(&jobsPage); err != nil {
+			return
+		}
+		jobs = append(jobs, jobsPage.Jobs...)
+	}
+
+	return
+}
+
+// DownloadWorkflowRunLogs fetches the zip archive with the logs of every
+// step of a completed workflow run.
+func (client *Client) DownloadWorkflowRunLogs(project *Project, runID int64) (archive io.ReadCloser, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/actions/runs/%d/logs", project.Owner, project.Name, runID), "application/zip")
+	if err = checkStatus(200, "downloading workflow run logs", res, err); err != nil {
+		return
+	}
+
+	return res.Body, nil
+}
+
+// FetchWorkflowJobLog fetches the plain text log of a completed job.
+func (client *Client) FetchWorkflowJobLog(project *Project, jobID int64) (log string, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", project.Owner, project.Name, jobID), "text/plain")
+	if err = checkStatus(200, "fetching job log", res, err); err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	return string(body), err
+}
+
+type Environment struct {
+	ID                     int                         `json:"id"`
+	Name                   string                      `json:"name"`
+	HTMLURL                string                      `json:"html_url"`
+	ProtectionRules        []EnvironmentProtectionRule `json:"protection_rules"`
+	DeploymentBranchPolicy *DeploymentBranchPolicy     `json:"deployment_branch_policy"`
+}
+
+type EnvironmentProtectionRule struct {
+	Type      string                `json:"type"`
+	WaitTimer int                   `json:"wait_timer"`
+	Reviewers []EnvironmentReviewer `json:"reviewers"`
+}
+
+type EnvironmentReviewer struct {
+	Type     string `json:"type"`
+	Reviewer struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Slug  string `json:"slug"`
+	} `json:"reviewer"`
+}
+
+type DeploymentBranchPolicy struct {
+	ProtectedBranches    bool `json:"protected_branches"`
+	CustomBranchPolicies bool `json:"custom_branch_policies"`
+}
+
+func (client *Client) FetchEnvironments(project *Project) (environments []Environment, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/environments?per_page=100", project.Owner, project.Name)
+
+	environments = []Environment{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching environments", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		environmentsPage := struct {
+			Environments []Environment `json:"environments"`
+		}{}
+		if err = res.Unmarshal(&environmentsPage); err != nil {
+			return
+		}
+		environments = append(environments, environmentsPage.Environments...)
+	}
+
+	return
+}
+
+// FetchEnvironment returns nil if the environment doesn't exist.
+func (client *Client) FetchEnvironment(project *Project, name string) (environment *Environment, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/environments/%s", project.Owner, project.Name, url.PathEscape(name)))
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return
+	}
+	if err = checkStatus(200, "fetching environment", res, err); err != nil {
+		return
+	}
+
+	environment = &Environment{}
+	err = res.Unmarshal(environment)
+	return
+}
+
+// UpdateEnvironment creates an environment or replaces its protection rules.
+func (client *Client) UpdateEnvironment(project *Project, name string, params map[string]interface{}) (environment *Environment, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PutJSON(fmt.Sprintf("repos/%s/%s/environments/%s", project.Owner, project.Name, url.PathEscape(name)), params)
+	if err = checkStatus(200, "updating environment", res, err); err != nil {
+		return
+	}
+
+	environment = &Environment{}
+	err = res.Unmarshal(environment)
+	return
+}
+
+// CreateDeploymentBranchPolicy allows the branches matching pattern to deploy
+// to an environment that has custom branch policies.
+func (client *Client) CreateDeploymentBranchPolicy(project *Project, environment, pattern string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/environments/%s/deployment-branch-policies", project.Owner, project.Name, url.PathEscape(environment)), map[string]interface{}{
+		"name": pattern,
+	})
+	if err = checkStatus(200, "creating deployment branch policy", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+type PendingDeployment struct {
+	Environment struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"environment"`
+	WaitTimer             int  `json:"wait_timer"`
+	CurrentUserCanApprove bool `json:"current_user_can_approve"`
+}
+
+func (client *Client) FetchPendingDeployments(project *Project, runID int64) (deployments []PendingDeployment, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/actions/runs/%d/pending_deployments", project.Owner, project.Name, runID))
+	if err = checkStatus(200, "fetching pending deployments", res, err); err != nil {
+		return
+	}
+
+	deployments = []PendingDeployment{}
+	err = res.Unmarshal(&deployments)
+	return
+}
+
+// ReviewPendingDeployments approves or rejects the deployments of a workflow
+// run that are waiting for a review.
+func (client *Client) ReviewPendingDeployments(project *Project, runID int64, params map[string]interface{}) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/actions/runs/%d/pending_deployments", project.Owner, project.Name, runID), params)
+	if err = checkStatus(200, "reviewing pending deployments", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+type ActionsCache struct {
+	ID             int       `json:"id"`
+	Ref            string    `json:"ref"`
+	Key            string    `json:"key"`
+	Version        string    `json:"version"`
+	SizeInBytes    int64     `json:"size_in_bytes"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+}
+
+// FetchActionsCaches lists the Actions caches of a repository, most recently
+// used first. The "key" filter matches caches whose key starts with it.
+func (client *Client) FetchActionsCaches(project *Project, filterParams map[string]interface{}) (caches []ActionsCache, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/actions/caches?per_page=100", project.Owner, project.Name)
+	if filterParams != nil {
+		path = addQuery(path, filterParams)
+	}
+
+	caches = []ActionsCache{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching caches", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		cachesPage := struct {
+			ActionsCaches []ActionsCache `json:"actions_caches"`
+		}{}
+		if err = res.Unmarshal(&cachesPage); err != nil {
+			return
+		}
+		caches = append(caches, cachesPage.ActionsCaches...)
+	}
+
+	return
+}
+
+func (client *Client) DeleteActionsCache(project *Project, cacheID int) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/actions/caches/%d", project.Owner, project.Name, cacheID))
+	if err = checkStatus(204, "deleting cache", res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+type Collaborator struct {
+	Login       string                 `json:"login"`
+	RoleName    string                 `json:"role_name"`
+	Permissions *RepositoryPermissions `json:"permissions"`
+}
+
+func (client *Client) FetchCollaborators(project *Project) (collaborators []Collaborator, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/collaborators?per_page=100", project.Owner, project.Name)
+
+	collaborators = []Collaborator{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching collaborators", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		collaboratorsPage := []Collaborator{}
+		if err = res.Unmarshal(&collaboratorsPage); err != nil {
+			return
+		}
+		collaborators = append(collaborators, collaboratorsPage...)
+	}
+
+	return
+}
+
+// AddCollaborator grants access to a repository. It reports whether an
+// invitation was sent, as opposed to updating the permission of an existing
+// collaborator.
+func (client *Client) AddCollaborator(project *Project, login, permission string) (invited bool, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{}
+	if permission != "" {
+		params["permission"] = permission
+	}
+
+	res, err := api.PutJSON(fmt.Sprintf("repos/%s/%s/collaborators/%s", project.Owner, project.Name, login), params)
+	if err == nil && res.StatusCode == 204 {
+		res.Body.Close()
+		return false, nil
+	}
+	if err = checkStatus(201, "adding collaborator", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return true, nil
+}
+
+func (client *Client) RemoveCollaborator(project *Project, login string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/collaborators/%s", project.Owner, project.Name, login))
+	if err = checkStatus(204, "removing collaborator", res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+type RepositoryInvitation struct {
+	ID          int         `json:"id"`
+	Repository  *Repository `json:"repository"`
+	Inviter     *User       `json:"inviter"`
+	Permissions string      `json:"permissions"`
+	HTMLURL     string      `json:"html_url"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+func (client *Client) FetchRepositoryInvitations() (invitations []RepositoryInvitation, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := "user/repository_invitations?per_page=100"
+
+	invitations = []RepositoryInvitation{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching invitations", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		invitationsPage := []RepositoryInvitation{}
+		if err = res.Unmarshal(&invitationsPage); err != nil {
+			return
+		}
+		invitations = append(invitations, invitationsPage...)
+	}
+
+	return
+}
+
+func (client *Client) AcceptRepositoryInvitation(id int) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("user/repository_invitations/%d", id), map[string]interface{}{})
+	if err = checkStatus(204, "accepting invitation", res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+func (client *Client) DeclineRepositoryInvitation(id int) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("user/repository_invitations/%d", id))
+	if err = checkStatus(204, "declining invitation", res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+type Deployment struct {
+	ID          int       `json:"id"`
+	SHA         string    `json:"sha"`
+	Ref         string    `json:"ref"`
+	Environment string    `json:"environment"`
+	Description string    `json:"description"`
+	Creator     *User     `json:"creator"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type DeploymentStatus struct {
+	ID             int       `json:"id"`
+	State          string    `json:"state"`
+	Description    string    `json:"description"`
+	EnvironmentURL string    `json:"environment_url"`
+	LogURL         string    `json:"log_url"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (client *Client) FetchDeployments(project *Project, filterParams map[string]interface{}, limit int) (deployments []Deployment, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/deployments?per_page=%d", project.Owner, project.Name, perPage(limit, 100))
+	if filterParams != nil {
+		path = addQuery(path, filterParams)
+	}
+
+	deployments = []Deployment{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching deployments", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		deploymentsPage := []Deployment{}
+		if err = res.Unmarshal(&deploymentsPage); err != nil {
+			return
+		}
+		for _, d := range deploymentsPage {
+			deployments = append(deployments, d)
+			if limit > 0 && len(deployments) == limit {
+				path = ""
+				break
+			}
+		}
+	}
+
+	return
+}
+
+func (client *Client) CreateDeployment(project *Project, params map[string]interface{}) (deployment *Deployment, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/deployments", project.Owner, project.Name), params)
+	if err = checkStatus(201, "creating deployment", res, err); err != nil {
+		return
+	}
+
+	deployment = &Deployment{}
+	err = res.Unmarshal(deployment)
+	return
+}
+
+func (client *Client) FetchDeploymentStatuses(project *Project, deploymentID int) (statuses []DeploymentStatus, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/deployments/%d/statuses", project.Owner, project.Name, deploymentID))
+	if err = checkStatus(200, "fetching deployment statuses", res, err); err != nil {
+		return
+	}
+
+	statuses = []DeploymentStatus{}
+	err = res.Unmarshal(&statuses)
+	return
+}
+
+func (client *Client) CreateDeploymentStatus(project *Project, deploymentID int, params map[string]interface{}) (status *DeploymentStatus, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/deployments/%d/statuses", project.Owner, project.Name, deploymentID), params)
+	if err = checkStatus(201, "creating deployment status", res, err); err != nil {
+		return
+	}
+
+	status = &DeploymentStatus{}
+	err = res.Unmarshal(status)
+	return
+}
+
+type DependabotAlert struct {
+	Number     int    `json:"number"`
+	State      string `json:"state"`
+	HTMLURL    string `json:"html_url"`
+	Dependency struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		ManifestPath string `json:"manifest_path"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		GHSAID   string `json:"ghsa_id"`
+		CVEID    string `json:"cve_id"`
+		Summary  string `json:"summary"`
+		Severity string `json:"severity"`
+	} `json:"security_advisory"`
+	SecurityVulnerability struct {
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+		FirstPatchedVersion    *struct {
+			Identifier string `json:"identifier"`
+		} `json:"first_patched_version"`
+	} `json:"security_vulnerability"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (client *Client) FetchDependabotAlerts(project *Project, filterParams map[string]interface{}) (alerts []DependabotAlert, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/dependabot/alerts?per_page=100", project.Owner, project.Name)
+	if filterParams != nil {
+		path = addQuery(path, filterParams)
+	}
+
+	alerts = []DependabotAlert{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching Dependabot alerts", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		alertsPage := []DependabotAlert{}
+		if err = res.Unmarshal(&alertsPage); err != nil {
+			return
+		}
+		alerts = append(alerts, alertsPage...)
+	}
+
+	return
+}
+
+type CodeScanningAlert struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+	Rule   struct {
+		ID                    string `json:"id"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+		Description           string `json:"description"`
+	} `json:"rule"`
+	Tool struct {
+		Name string `json:"name"`
+	} `json:"tool"`
+	MostRecentInstance struct {
+		Ref      string `json:"ref"`
+		Location struct {
+			Path      string `json:"path"`
+			StartLine int    `json:"start_line"`
+		} `json:"location"`
+	} `json:"most_recent_instance"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (client *Client) FetchCodeScanningAlerts(project *Project, filterParams map[string]interface{}) (alerts []CodeScanningAlert, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts?per_page=100", project.Owner, project.Name)
+	if filterParams != nil {
+		path = addQuery(path, filterParams)
+	}
+
+	alerts = []CodeScanningAlert{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = client.checkFeatureStatus(featureCodeScanning, 200, "fetching code scanning alerts", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		alertsPage := []CodeScanningAlert{}
+		if err = res.Unmarshal(&alertsPage); err != nil {
+			return
+		}
+		alerts = append(alerts, alertsPage...)
+	}
+
+	return
+}
+
+func (client *Client) UpdateCodeScanningAlert(project *Project, alertNumber int, params map[string]interface{}) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d", project.Owner, project.Name, alertNumber), params)
+	if err = checkStatus(200, "updating code scanning alert", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+type SecretScanningAlert struct {
+	Number                int       `json:"number"`
+	State                 string    `json:"state"`
+	SecretType            string    `json:"secret_type"`
+	SecretTypeDisplayName string    `json:"secret_type_display_name"`
+	Resolution            string    `json:"resolution"`
+	HTMLURL               string    `json:"html_url"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+func (client *Client) FetchSecretScanningAlerts(project *Project, filterParams map[string]interface{}) (alerts []SecretScanningAlert, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/secret-scanning/alerts?per_page=100", project.Owner, project.Name)
+	if filterParams != nil {
+		path = addQuery(path, filterParams)
+	}
+
+	alerts = []SecretScanningAlert{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching secret scanning alerts", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		alertsPage := []SecretScanningAlert{}
+		if err = res.Unmarshal(&alertsPage); err != nil {
+			return
+		}
+		alerts = append(alerts, alertsPage...)
+	}
+
+	return
+}
+
+func (client *Client) UpdateSecretScanningAlert(project *Project, alertNumber int, params map[string]interface{}) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/secret-scanning/alerts/%d", project.Owner, project.Name, alertNumber), params)
+	if err = checkStatus(200, "updating secret scanning alert", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+type TrafficStats struct {
+	Count   int          `json:"count"`
+	Uniques int          `json:"uniques"`
+	Views   []TrafficDay `json:"views,omitempty"`
+	Clones  []TrafficDay `json:"clones,omitempty"`
+}
+
+type TrafficDay struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+	Uniques   int       `json:"uniques"`
+}
+
+type TrafficSource struct {
+	Referrer string `json:"referrer,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Count    int    `json:"count"`
+	Uniques  int    `json:"uniques"`
+}
+
+// FetchTraffic fetches daily "views" or "clones" of a repository over the
+// last 14 days.
+func (client *Client) FetchTraffic(project *Project, kind string) (stats *TrafficStats, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/traffic/%s?per=day", project.Owner, project.Name, kind))
+	if err = checkStatus(200, "fetching traffic", res, err); err != nil {
+		return
+	}
+
+	stats = &TrafficStats{}
+	err = res.Unmarshal(stats)
+	return
+}
+
+// FetchPopularTraffic fetches the top "referrers" or "paths" of a repository
+// over the last 14 days.
+func (client *Client) FetchPopularTraffic(project *Project, kind string) (sources []TrafficSource, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/traffic/popular/%s", project.Owner, project.Name, kind))
+	if err = checkStatus(200, "fetching traffic", res, err); err != nil {
+		return
+	}
+
+	sources = []TrafficSource{}
+	err = res.Unmarshal(&sources)
+	return
+}
+
+type ContributorStats struct {
+	Author *User             `json:"author"`
+	Total  int               `json:"total"`
+	Weeks  []ContributorWeek `json:"weeks"`
+}
+
+type ContributorWeek struct {
+	Week      int64 `json:"w"`
+	Additions int   `json:"a"`
+	Deletions int   `json:"d"`
+	Commits   int   `json:"c"`
+}
+
+// FetchContributorStats fetches weekly commit activity per author. GitHub
+// computes these statistics in the background, so the request is retried a
+// few times while they are not ready yet.
+func (client *Client) FetchContributorStats(project *Project) (stats []ContributorStats, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	var res *simpleResponse
+	for attempt := 1; ; attempt++ {
+		res, err = api.Get(fmt.Sprintf("repos/%s/%s/stats/contributors", project.Owner, project.Name))
+		if err != nil || res.StatusCode != 202 {
+			break
+		}
+		res.Body.Close()
+		if attempt == 5 {
+			return nil, fmt.Errorf("Error fetching contributor statistics: GitHub is still computing them; try again later")
+		}
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+	if err = checkStatus(200, "fetching contributor statistics", res, err); err != nil {
+		return
+	}
+
+	stats = []ContributorStats{}
+	err = res.Unmarshal(&stats)
+	return
+}
+
+// search performs a query against the Search API of the given kind, e.g.
+// "code" or "repositories", calling appendPage for each page of results until
+// appendPage reports that limit results have been collected.
+func (client *Client) search(kind string, params map[string]interface{}, limit int, mimeType string, appendPage func(*simpleResponse) (int, error)) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := addQuery(fmt.Sprintf("search/%s?per_page=%d", kind, perPage(limit, 100)), params)
+
+	var res *simpleResponse
+	for path != "" {
+		if mimeType != "" {
+			res, err = api.GetFile(path, mimeType)
+		} else {
+			res, err = api.Get(path)
+		}
+		if err = checkStatus(200, "searching "+kind, res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		var count int
+		if count, err = appendPage(res); err != nil {
+			return
+		}
+		if limit > 0 && count >= limit {
+			break
+		}
+	}
+
+	return
+}
+
+type CodeSearchResult struct {
+	Name        string      `json:"name"`
+	Path        string      `json:"path"`
+	SHA         string      `json:"sha"`
+	HTMLURL     string      `json:"html_url"`
+	Repository  *Repository `json:"repository"`
+	TextMatches []struct {
+		Fragment string `json:"fragment"`
+	} `json:"text_matches"`
+}
+
+func (client *Client) SearchCode(params map[string]interface{}, limit int) (results []CodeSearchResult, err error) {
+	results = []CodeSearchResult{}
+	err = client.search("code", params, limit, textMatchType, func(res *simpleResponse) (int, error) {
+		page := struct {
+			Items []CodeSearchResult `json:"items"`
+		}{}
+		if err := res.Unmarshal(&page); err != nil {
+			return 0, err
+		}
+		for _, item := range page.Items {
+			if limit > 0 && len(results) == limit {
+				break
+			}
+			results = append(results, item)
+		}
+		return len(results), nil
+	})
+	return
+}
+
+func (client *Client) SearchRepositories(params map[string]interface{}, limit int) (repos []Repository, err error) {
+	repos = []Repository{}
+	err = client.search("repositories", params, limit, "", func(res *simpleResponse) (int, error) {
+		page := struct {
+			Items []Repository `json:"items"`
+		}{}
+		if err := res.Unmarshal(&page); err != nil {
+			return 0, err
+		}
+		for _, item := range page.Items {
+			if limit > 0 && len(repos) == limit {
+				break
+			}
+			repos = append(repos, item)
+		}
+		return len(repos), nil
+	})
+	return
+}
+
+func (client *Client) SearchIssues(params map[string]interface{}, limit int) (issues []Issue, err error) {
+	issues = []Issue{}
+	err = client.search("issues", params, limit, "", func(res *simpleResponse) (int, error) {
+		page := struct {
+			Items []Issue `json:"items"`
+		}{}
+		if err := res.Unmarshal(&page); err != nil {
+			return 0, err
+		}
+		for _, item := range page.Items {
+			if limit > 0 && len(issues) == limit {
+				break
+			}
+			issues = append(issues, item)
+		}
+		return len(issues), nil
+	})
+	return
+}
+
+func (client *Client) SearchUsers(params map[string]interface{}, limit int) (users []User, err error) {
+	users = []User{}
+	err = client.search("users", params, limit, "", func(res *simpleResponse) (int, error) {
+		page := struct {
+			Items []User `json:"items"`
+		}{}
+		if err := res.Unmarshal(&page); err != nil {
+			return 0, err
+		}
+		for _, item := range page.Items {
+			if limit > 0 && len(users) == limit {
+				break
+			}
+			users = append(users, item)
+		}
+		return len(users), nil
+	})
+	return
+}
+
+type Commit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	Author     *User       `json:"author"`
+	Repository *Repository `json:"repository"`
+	HTMLURL    string      `json:"html_url"`
+	Stats      *struct {
+		Additions int `json:"additions"`
+		Deletions int `json:"deletions"`
+	} `json:"stats"`
+	Files []struct {
+		Filename string `json:"filename"`
+	} `json:"files"`
+}
+
+// CompareCommits lists the commits reachable from head but not from base,
+// oldest first.
+func (client *Client) CompareCommits(project *Project, base, head string) (commits []Commit, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/compare/%s...%s?per_page=100", project.Owner, project.Name, url.PathEscape(base), url.PathEscape(head))
+
+	commits = []Commit{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "comparing commits", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		comparison := struct {
+			Commits []Commit `json:"commits"`
+		}{}
+		if err = res.Unmarshal(&comparison); err != nil {
+			return
+		}
+		commits = append(commits, comparison.Commits...)
+	}
+
+	return
+}
+
+func (client *Client) SearchCommits(params map[string]interface{}, limit int) (commits []Commit, err error) {
+	commits = []Commit{}
+	err = client.search("commits", params, limit, "", func(res *simpleResponse) (int, error) {
+		page := struct {
+			Items []Commit `json:"items"`
+		}{}
+		if err := res.Unmarshal(&page); err != nil {
+			return 0, err
+		}
+		for _, item := range page.Items {
+			if limit > 0 && len(commits) == limit {
+				break
+			}
+			commits = append(commits, item)
+		}
+		return len(commits), nil
+	})
+	return
+}
+
+func (client *Client) FetchCommit(project *Project, sha string) (commit *Commit, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/commits/%s", project.Owner, project.Name, sha))
+	if err = checkStatus(200, "fetching commit", res, err); err != nil {
+		return
+	}
+
+	commit = &Commit{}
+	err = res.Unmarshal(commit)
+	return
+}
+
+// FetchCommitPullRequests lists pull requests that contain the commit, either
+// as merged into the base branch or as part of an open pull request.
+func (client *Client) FetchCommitPullRequests(project *Project, sha string) (pulls []PullRequest, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/commits/%s/pulls", project.Owner, project.Name, sha))
+	if err = checkStatus(200, "fetching pull requests for commit", res, err); err != nil {
+		return
+	}
+
+	pulls = []PullRequest{}
+	err = res.Unmarshal(&pulls)
+	return
+}
+
+// FetchBlob fetches the raw contents of a git blob in a repository.
+func (client *Client) FetchBlob(project *Project, sha string) (contents []byte, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.GetFile(fmt.Sprintf("repos/%s/%s/git/blobs/%s", project.Owner, project.Name, sha), rawMediaType)
+	if err = checkStatus(200, "fetching blob", res, err); err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	return ioutil.ReadAll(res.Body)
+}
+
+type Codespace struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	State       string `json:"state"`
+	WebURL      string `json:"web_url"`
+	Repository  struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	GitStatus struct {
+		Ref                   string `json:"ref"`
+		Ahead                 int    `json:"ahead"`
+		Behind                int    `json:"behind"`
+		HasUncommittedChanges bool   `json:"has_uncommitted_changes"`
+	} `json:"git_status"`
+	Machine struct {
+		Name        string `json:"name"`
+		DisplayName string `json:"display_name"`
+	} `json:"machine"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// FetchCodespaces lists codespaces of the authenticated user. When project is
+// given, only codespaces for that repository are returned.
+func (client *Client) FetchCodespaces(project *Project) (codespaces []Codespace, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := "user/codespaces?per_page=100"
+	if project != nil {
+		path = fmt.Sprintf("repos/%s/%s/codespaces?per_page=100", project.Owner, project.Name)
+	}
+
+	codespaces = []Codespace{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching codespaces", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		codespacesPage := struct {
+			Codespaces []Codespace `json:"codespaces"`
+		}{}
+		if err = res.Unmarshal(&codespacesPage); err != nil {
+			return
+		}
+		codespaces = append(codespaces, codespacesPage.Codespaces...)
+	}
+
+	return
+}
+
+func (client *Client) CreateCodespace(project *Project, params map[string]interface{}) (codespace *Codespace, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/codespaces", project.Owner, project.Name), params)
+	if err == nil && res.StatusCode == 202 {
+		res.StatusCode = 201
+	}
+	if err = checkStatus(201, "creating codespace", res, err); err != nil {
+		return
+	}
+
+	codespace = &Codespace{}
+	err = res.Unmarshal(codespace)
+	return
+}
+
+func (client *Client) StopCodespace(name string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("user/codespaces/%s/stop", name), map[string]interface{}{})
+	if err = checkStatus(200, "stopping codespace", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+type Package struct {
+	ID           int         `json:"id"`
+	Name         string      `json:"name"`
+	PackageType  string      `json:"package_type"`
+	Visibility   string      `json:"visibility"`
+	VersionCount int         `json:"version_count"`
+	HTMLURL      string      `json:"html_url"`
+	Repository   *Repository `json:"repository"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type PackageVersion struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Metadata  struct {
+		Container struct {
+			Tags []string `json:"tags"`
+		} `json:"container"`
+	} `json:"metadata"`
+}
+
+// FetchPackages lists packages of the given type under prefix, which is
+// either "user" or "orgs/<ORG>".
+func (client *Client) FetchPackages(prefix, packageType string) (packages []Package, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("%s/packages?package_type=%s&per_page=100", prefix, url.QueryEscape(packageType))
+
+	packages = []Package{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching packages", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		packagesPage := []Package{}
+		if err = res.Unmarshal(&packagesPage); err != nil {
+			return
+		}
+		packages = append(packages, packagesPage...)
+	}
+
+	return
+}
+
+func (client *Client) FetchPackageVersions(prefix, packageType, name string) (versions []PackageVersion, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("%s/packages/%s/%s/versions?per_page=100", prefix, packageType, url.PathEscape(name))
+
+	versions = []PackageVersion{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching package versions", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		versionsPage := []PackageVersion{}
+		if err = res.Unmarshal(&versionsPage); err != nil {
+			return
+		}
+		versions = append(versions, versionsPage...)
+	}
+
+	return
+}
+
+func (client *Client) DeletePackageVersion(prefix, packageType, name string, versionID int) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("%s/packages/%s/%s/versions/%d", prefix, packageType, url.PathEscape(name), versionID))
+	if err = checkStatus(204, "deleting package version", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+// RenderMarkdown renders GitHub-flavored Markdown to HTML. When context is
+// given in the "OWNER/REPO" format, references such as "#123" are linked to
+// issues and pull requests of that repository.
+func (client *Client) RenderMarkdown(text, context string) (html string, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"text": text,
+		"mode": "gfm",
+	}
+	if context != "" {
+		params["context"] = context
+	}
+
+	res, err := api.PostJSON("markdown", params)
+	if err = checkStatus(200, "rendering markdown", res, err); err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	html = string(body)
+	return
+}
+
+type License struct {
+	Key            string   `json:"key"`
+	Name           string   `json:"name"`
+	SPDXID         string   `json:"spdx_id"`
+	HTMLURL        string   `json:"html_url"`
+	Description    string   `json:"description"`
+	Implementation string   `json:"implementation"`
+	Permissions    []string `json:"permissions"`
+	Conditions     []string `json:"conditions"`
+	Limitations    []string `json:"limitations"`
+	Body           string   `json:"body"`
+}
+
+func (client *Client) FetchLicenses() (licenses []License, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get("licenses?per_page=100")
+	if err = checkStatus(200, "fetching licenses", res, err); err != nil {
+		return
+	}
+
+	licenses = []License{}
+	err = res.Unmarshal(&licenses)
+	return
+}
+
+func (client *Client) FetchLicense(key string) (license *License, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("licenses/%s", url.PathEscape(key)))
+	if err = checkStatus(200, "fetching license", res, err); err != nil {
+		return
+	}
+
+	license = &License{}
+	err = res.Unmarshal(license)
+	return
+}
+
+func (client *Client) FetchGitignoreTemplates() (names []string, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get("gitignore/templates")
+	if err = checkStatus(200, "fetching gitignore templates", res, err); err != nil {
+		return
+	}
+
+	names = []string{}
+	err = res.Unmarshal(&names)
+	return
+}
+
+func (client *Client) FetchGitignoreTemplate(name string) (source string, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("gitignore/templates/%s", url.PathEscape(name)))
+	if err = checkStatus(200, "fetching gitignore template", res, err); err != nil {
+		return
+	}
+
+	template := struct {
+		Source string `json:"source"`
+	}{}
+	err = res.Unmarshal(&template)
+	source = template.Source
+	return
+}
+
+type Notification struct {
+	ID        string    `json:"id"`
+	Reason    string    `json:"reason"`
+	Unread    bool      `json:"unread"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Subject   struct {
+		Title string `json:"title"`
+		Type  string `json:"type"`
+		URL   string `json:"url"`
+	} `json:"subject"`
+}
+
+// FetchNotifications lists unread notifications for project.
+func (client *Client) FetchNotifications(project *Project) (notifications []Notification, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/notifications?per_page=100", project.Owner, project.Name)
+	notifications = []Notification{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching notifications", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		page := []Notification{}
+		if err = res.Unmarshal(&page); err != nil {
+			return
+		}
+		notifications = append(notifications, page...)
+	}
+
+	return
+}
+
+// Verification describes the outcome of GitHub verifying the signature of a
+// commit or tag object.
+type Verification struct {
+	Verified  bool   `json:"verified"`
+	Reason    string `json:"reason"`
+	Signature string `json:"signature"`
+}
+
+// FetchTagVerification reports whether GitHub could verify the signature of
+// the tag tagName. Lightweight tags are reported as unsigned.
+func (client *Client) FetchTagVerification(project *Project, tagName string) (verification *Verification, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/git/ref/tags/%s", project.Owner, project.Name, tagName))
+	if err = checkStatus(200, "fetching tag", res, err); err != nil {
+		return
+	}
+
+	ref := struct {
+		Object struct {
+			Type string `json:"type"`
+			Sha  string `json:"sha"`
+		} `json:"object"`
+	}{}
+	if err = res.Unmarshal(&ref); err != nil {
+		return
+	}
+
+	if ref.Object.Type != "tag" {
+		return &Verification{Reason: "unsigned"}, nil
+	}
+
+	res, err = api.Get(fmt.Sprintf("repos/%s/%s/git/tags/%s", project.Owner, project.Name, ref.Object.Sha))
+	if err = checkStatus(200, "fetching tag", res, err); err != nil {
+		return
+	}
+
+	tag := struct {
+		Verification *Verification `json:"verification"`
+	}{}
+	if err = res.Unmarshal(&tag); err != nil {
+		return
+	}
+
+	verification = tag.Verification
+	if verification == nil {
+		verification = &Verification{Reason: "unsigned"}
+	}
+	return
+}
+
+type Release struct {
+	Name            string         `json:"name"`
+	TagName         string         `json:"tag_name"`
+	TargetCommitish string         `json:"target_commitish"`
+	Body            string         `json:"body"`
+	Draft           bool           `json:"draft"`
+	Prerelease      bool           `json:"prerelease"`
+	Assets          []ReleaseAsset `json:"assets"`
+	TarballURL      string         `json:"tarball_url"`
+	ZipballURL      string         `json:"zipball_url"`
+	HTMLURL         string         `json:"html_url"`
+	UploadURL       string         `json:"upload_url"`
+	APIURL          string         `json:"url"`
+	CreatedAt       time.Time      `json:"created_at"`
+	PublishedAt     time.Time      `json:"published_at"`
+}
+
+type ReleaseAsset struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	Size        int64  `json:"size"`
+	DownloadURL string `json:"browser_download_url"`
+	APIURL      string `json:"url"`
+}
+
+func (client *Client) FetchReleases(project *Project, limit int, filter func(*Release) bool) (releases []Release, err error) {
+	releases = []Release{}
+	err = client.EachRelease(project, limit, filter, func(release *Release) error {
+		releases = append(releases, *release)
+		return nil
+	})
+	return
+}
+
+// EachRelease pages through releases, calling each for every release accepted
+// by filter as soon as its page has been received. Paging stops after limit
+// releases have been accepted.
+func (client *Client) EachRelease(project *Project, limit int, filter func(*Release) bool, each func(*Release) error) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/releases?per_page=%d", project.Owner, project.Name, perPage(limit, 100))
+
+	accepted := 0
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching releases", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		releasesPage := []Release{}
+		if err = res.Unmarshal(&releasesPage); err != nil {
+			return
+		}
+		for _, release := range releasesPage {
+			if filter == nil || filter(&release) {
+				if err = each(&release); err != nil {
+					return
+				}
+				accepted++
+				if limit > 0 && accepted == limit {
+					path = ""
+					break
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func (client *Client) FetchRelease(project *Project, tagName string) (*Release, error) {
+	releases, err := client.FetchReleases(project, 100, func(release *Release) bool {
+		return release.TagName == tagName
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	if len(releases) < 1 {
+		return nil, fmt.Errorf("Unable to find release with tag name `%s'", tagName)
+	}
+	return &releases[0], nil
+}
+
+func (client *Client) CreateRelease(project *Project, releaseParams *Release) (release *Release, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/releases", project.Owner, project.Name), releaseParams)
+	if err = checkStatus(201, "creating release", res, err); err != nil {
+		return
+	}
+
+	release = &Release{}
+	err = res.Unmarshal(release)
+	return
+}
+
+func (client *Client) EditRelease(release *Release, releaseParams map[string]interface{}) (updatedRelease *Release, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(release.APIURL, releaseParams)
+	if err = checkStatus(200, "editing release", res, err); err != nil {
+		return
+	}
+
+	updatedRelease = &Release{}
+	err = res.Unmarshal(updatedRelease)
+	return
+}
+
+func (client *Client) DeleteTag(project *Project, tagName string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/git/refs/tags/%s", project.Owner, project.Name, tagName))
+	return checkStatus(204, "deleting tag", res, err)
+}
+
+func (client *Client) DeleteRelease(release *Release) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(release.APIURL)
+	if err = checkStatus(204, "deleting release", res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+type LocalAsset struct {
+	Name     string
+	Label    string
+	Contents io.Reader
+	Size     int64
+	// Progress, if set, is called with the number of bytes sent so far while
+	// the asset is being uploaded. The count starts over when an upload is
+	// retried.
+	Progress func(sent int64)
+}
+
+func (client *Client) UploadReleaseAssets(release *Release, assets []LocalAsset) (doneAssets []*ReleaseAsset, err error) {
+	for _, asset := range assets {
+		var newAsset *ReleaseAsset
+		newAsset, err = client.UploadReleaseAsset(release, asset)
+		if err != nil {
+			return
+		}
+		doneAssets = append(doneAssets, newAsset)
+	}
+
+	return
+}
+
+// the number of times an upload that failed with a server or network error
+// is retried
+const maxAssetUploadRetries = 3
+
+// UploadReleaseAsset uploads a single asset, replacing an asset of the same
+// name. GitHub can't resume a partial upload, so uploads that fail with a
+// server or network error are retried from the start of the file.
+func (client *Client) UploadReleaseAsset(release *Release, asset LocalAsset) (newAsset *ReleaseAsset, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	idx := strings.Index(release.UploadURL, "{")
+	uploadURL := release.UploadURL[0:idx]
+
+	name := filepath.Base(asset.Name)
+	for _, existingAsset := range release.Assets {
+		if existingAsset.Name == name {
+			if err = client.DeleteReleaseAsset(&existingAsset); err != nil {
+				return
+			}
+			break
+		}
+	}
+
+	params := map[string]interface{}{"name": name}
+	if asset.Label != "" {
+		params["label"] = asset.Label
+	}
+	uploadPath := addQuery(uploadURL, params)
+
+	var res *simpleResponse
+	body := asset.Contents
+	for attempts := 0; ; attempts++ {
+		// wrapping the body keeps the HTTP client from closing the file, so
+		// that it can be rewound for a retry
+		res, err = api.PostFile(uploadPath, &progressReader{Reader: body, progress: asset.Progress}, asset.Size)
+		failed := err != nil || (res.StatusCode >= 500 && res.StatusCode < 600)
+		if failed && attempts < maxAssetUploadRetries {
+			time.Sleep(time.Second * time.Duration(attempts+1))
+			if seeker, ok := body.(io.Seeker); ok {
+				_, err = seeker.Seek(0, io.SeekStart)
+			} else {
+				var f *os.File
+				f, err = os.Open(asset.Name)
+				if err == nil {
+					defer f.Close()
+					body = f
+				}
+			}
+			if err != nil {
+				return
+			}
+			continue
+		}
+		if err = checkStatus(201, "uploading release asset", res, err); err != nil {
+			return
+		}
+		break
+	}
+
+	newAsset = &ReleaseAsset{}
+	err = res.Unmarshal(newAsset)
+	return
+}
+
+// progressReader reports how many bytes have been read from Reader.
+type progressReader struct {
+	io.Reader
+	sent     int64
+	progress func(int64)
+}
+
+func (r *progressReader) Read(p []byte) (n int, err error) {
+	n, err = r.Reader.Read(p)
+	r.sent += int64(n)
+	if n > 0 && r.progress != nil {
+		r.progress(r.sent)
+	}
+	return
+}
+
+func (client *Client) DeleteReleaseAsset(asset *ReleaseAsset) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(asset.APIURL)
+	err = checkStatus(204, "deleting release asset", res, err)
+
+	return
+}
+
+func (client *Client) DownloadReleaseAsset(url string) (asset io.ReadCloser, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	resp, err := api.GetFile(url, "application/octet-stream")
 	if err = checkStatus(200, "downloading asset", resp, err); err != nil {
 		return
 	}
@@ -520,9 +2613,10 @@ type CIStatusResponse struct {
 }
 
 type CIStatus struct {
-	State     string `json:"state"`
-	Context   string `json:"context"`
-	TargetURL string `json:"target_url"`
+	State       string `json:"state"`
+	Context     string `json:"context"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
 }
 
 type CheckRunsResponse struct {
@@ -530,10 +2624,79 @@ type CheckRunsResponse struct {
 }
 
 type CheckRun struct {
+	ID         int    `json:"id"`
 	Status     string `json:"status"`
 	Conclusion string `json:"conclusion"`
 	Name       string `json:"name"`
 	HTMLURL    string `json:"html_url"`
+	Output     struct {
+		AnnotationsCount int `json:"annotations_count"`
+	} `json:"output"`
+}
+
+type CheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+func (client *Client) FetchCheckRuns(project *Project, sha string) (checkRuns []CheckRun, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs?per_page=100", project.Owner, project.Name, sha)
+
+	checkRuns = []CheckRun{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.GetFile(path, checksType)
+		if err = checkStatus(200, "fetching checks", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		checksPage := CheckRunsResponse{}
+		if err = res.Unmarshal(&checksPage); err != nil {
+			return
+		}
+		checkRuns = append(checkRuns, checksPage.CheckRuns...)
+	}
+
+	return
+}
+
+func (client *Client) FetchCheckRunAnnotations(project *Project, checkRunID int) (annotations []CheckRunAnnotation, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/check-runs/%d/annotations?per_page=100", project.Owner, project.Name, checkRunID)
+
+	annotations = []CheckRunAnnotation{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.GetFile(path, checksType)
+		if err = checkStatus(200, "fetching check run annotations", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		annotationsPage := []CheckRunAnnotation{}
+		if err = res.Unmarshal(&annotationsPage); err != nil {
+			return
+		}
+		annotations = append(annotations, annotationsPage...)
+	}
+
+	return
 }
 
 func (client *Client) FetchCIStatus(project *Project, sha string) (status *CIStatusResponse, err error) {
@@ -552,18 +2715,11 @@ func (client *Client) FetchCIStatus(project *Project, sha string) (status *CISta
 		return
 	}
 
-	sortStatuses := func() {
-		sort.Slice(status.Statuses, func(a, b int) bool {
-			sA := status.Statuses[a]
-			sB := status.Statuses[b]
-			cmp := strings.Compare(strings.ToLower(sA.Context), strings.ToLower(sB.Context))
-			if cmp == 0 {
-				return strings.Compare(sA.TargetURL, sB.TargetURL) < 0
-			}
-			return cmp < 0
-		})
+	status.sortStatuses()
+
+	if !client.supports(featureCheckRuns) {
+		return
 	}
-	sortStatuses()
 
 	res, err = api.GetFile(fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", project.Owner, project.Name, sha), checksType)
 	if err == nil && (res.StatusCode == 403 || res.StatusCode == 404 || res.StatusCode == 422) {
@@ -578,6 +2734,43 @@ func (client *Client) FetchCIStatus(project *Project, sha string) (status *CISta
 		return
 	}
 
+	status.addCheckRuns(checks)
+	status.sortStatuses()
+
+	return
+}
+
+// CreateCIStatus sets the status of a commit for a single context.
+func (client *Client) CreateCIStatus(project *Project, sha string, params map[string]interface{}) (status *CIStatus, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/statuses/%s", project.Owner, project.Name, sha), params)
+	if err = checkStatus(201, "creating status", res, err); err != nil {
+		return
+	}
+
+	status = &CIStatus{}
+	err = res.Unmarshal(status)
+	return
+}
+
+func (status *CIStatusResponse) sortStatuses() {
+	sort.Slice(status.Statuses, func(a, b int) bool {
+		sA := status.Statuses[a]
+		sB := status.Statuses[b]
+		cmp := strings.Compare(strings.ToLower(sA.Context), strings.ToLower(sB.Context))
+		if cmp == 0 {
+			return strings.Compare(sA.TargetURL, sB.TargetURL) < 0
+		}
+		return cmp < 0
+	})
+}
+
+// addCheckRuns merges check runs into the list of statuses.
+func (status *CIStatusResponse) addCheckRuns(checks *CheckRunsResponse) {
 	for _, checkRun := range checks.CheckRuns {
 		state := "pending"
 		if checkRun.Status == "completed" {
@@ -590,22 +2783,49 @@ func (client *Client) FetchCIStatus(project *Project, sha string) (status *CISta
 		}
 		status.Statuses = append(status.Statuses, checkStatus)
 	}
+}
+
+func (client *Client) CreateCheckRun(project *Project, params map[string]interface{}) (checkRun *CheckRun, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
 
-	sortStatuses()
+	res, err := api.PostJSONPreview(fmt.Sprintf("repos/%s/%s/check-runs", project.Owner, project.Name), params, checksType)
+	if err = checkStatus(201, "creating check run", res, err); err != nil {
+		return
+	}
 
+	checkRun = &CheckRun{}
+	err = res.Unmarshal(checkRun)
 	return
 }
 
 type Repository struct {
-	Name          string                 `json:"name"`
-	FullName      string                 `json:"full_name"`
-	Parent        *Repository            `json:"parent"`
-	Owner         *User                  `json:"owner"`
-	Private       bool                   `json:"private"`
-	HasWiki       bool                   `json:"has_wiki"`
-	Permissions   *RepositoryPermissions `json:"permissions"`
-	HTMLURL       string                 `json:"html_url"`
-	DefaultBranch string                 `json:"default_branch"`
+	Name            string                 `json:"name"`
+	FullName        string                 `json:"full_name"`
+	Parent          *Repository            `json:"parent"`
+	Owner           *User                  `json:"owner"`
+	Private         bool                   `json:"private"`
+	HasWiki         bool                   `json:"has_wiki"`
+	Permissions     *RepositoryPermissions `json:"permissions"`
+	HTMLURL         string                 `json:"html_url"`
+	DefaultBranch   string                 `json:"default_branch"`
+	Description     string                 `json:"description"`
+	Homepage        string                 `json:"homepage"`
+	Language        string                 `json:"language"`
+	StargazersCount int                    `json:"stargazers_count"`
+	ForksCount      int                    `json:"forks_count"`
+	OpenIssuesCount int                    `json:"open_issues_count"`
+	Archived        bool                   `json:"archived"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+	Topics          []string               `json:"topics"`
+
+	AllowMergeCommit    bool `json:"allow_merge_commit"`
+	AllowSquashMerge    bool `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool `json:"allow_rebase_merge"`
+	AllowAutoMerge      bool `json:"allow_auto_merge"`
+	DeleteBranchOnMerge bool `json:"delete_branch_on_merge"`
 }
 
 type RepositoryPermissions struct {
@@ -631,6 +2851,36 @@ func (client *Client) ForkRepository(project *Project, params map[string]interfa
 	return
 }
 
+type MergeUpstreamResult struct {
+	Message    string `json:"message"`
+	MergeType  string `json:"merge_type"`
+	BaseBranch string `json:"base_branch"`
+}
+
+// MergeUpstream brings branch of the fork project up to date with its parent
+// repository on the server. The result is nil if the server doesn't offer the
+// API.
+func (client *Client) MergeUpstream(project *Project, branch string) (result *MergeUpstreamResult, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{"branch": branch}
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/merge-upstream", project.Owner, project.Name), params)
+	if err == nil && res.StatusCode == 404 && !client.supports(featureMergeUpstream) {
+		res.Body.Close()
+		return nil, nil
+	}
+	if err = checkStatus(200, "syncing fork", res, err); err != nil {
+		return
+	}
+
+	result = &MergeUpstreamResult{}
+	err = res.Unmarshal(result)
+	return
+}
+
 type Comment struct {
 	ID        int       `json:"id"`
 	Body      string    `json:"body"`
@@ -639,6 +2889,7 @@ type Comment struct {
 }
 
 type Issue struct {
+	NodeID string `json:"node_id"`
 	Number int    `json:"number"`
 	State  string `json:"state"`
 	Title  string `json:"title"`
@@ -654,6 +2905,7 @@ type Issue struct {
 	Draft               bool   `json:"draft"`
 
 	Comments  int          `json:"comments"`
+	Reactions *Reactions   `json:"reactions"`
 	Labels    []IssueLabel `json:"labels"`
 	Assignees []User       `json:"assignees"`
 	Milestone *Milestone   `json:"milestone"`
@@ -664,14 +2916,21 @@ type Issue struct {
 	RequestedReviewers []User `json:"requested_reviewers"`
 	RequestedTeams     []Team `json:"requested_teams"`
 
-	APIURL  string `json:"url"`
-	HTMLURL string `json:"html_url"`
+	APIURL        string `json:"url"`
+	HTMLURL       string `json:"html_url"`
+	RepositoryURL string `json:"repository_url"`
 
 	ClosedBy *User `json:"closed_by"`
 }
 
 type PullRequest Issue
 
+// Reactions counts the reactions to an issue or a pull request.
+type Reactions struct {
+	PlusOne  int `json:"+1"`
+	MinusOne int `json:"-1"`
+}
+
 type PullRequestSpec struct {
 	Label string      `json:"label"`
 	Ref   string      `json:"ref"`
@@ -690,39 +2949,277 @@ func (pr *PullRequest) HasRequestedReviewer(name string) bool {
 		if strings.EqualFold(user.Login, name) {
 			return true
 		}
-	}
-	return false
-}
+	}
+	return false
+}
+
+func (pr *PullRequest) HasRequestedTeam(name string) bool {
+	for _, team := range pr.RequestedTeams {
+		if strings.EqualFold(team.Slug, name) {
+			return true
+		}
+	}
+	return false
+}
+
+type IssueLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+}
+
+type User struct {
+	ID      int64  `json:"id"`
+	Login   string `json:"login"`
+	Type    string `json:"type"`
+	HTMLURL string `json:"html_url"`
+}
+
+type Team struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	Privacy     string `json:"privacy"`
+	HTMLURL     string `json:"html_url"`
+}
+
+func (client *Client) FetchTeams(org string) (teams []Team, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("orgs/%s/teams?per_page=100", org)
+
+	teams = []Team{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching teams", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		teamsPage := []Team{}
+		if err = res.Unmarshal(&teamsPage); err != nil {
+			return
+		}
+		teams = append(teams, teamsPage...)
+	}
+
+	return
+}
+
+func (client *Client) FetchTeam(org, teamSlug string) (team *Team, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("orgs/%s/teams/%s", org, teamSlug))
+	if err = checkStatus(200, "fetching team", res, err); err != nil {
+		return
+	}
+
+	team = &Team{}
+	err = res.Unmarshal(team)
+	return
+}
+
+func (client *Client) FetchTeamMembers(org, teamSlug string) (members []User, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("orgs/%s/teams/%s/members?per_page=100", org, teamSlug)
+
+	members = []User{}
+	var res *simpleResponse
+
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching team members", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
+
+		membersPage := []User{}
+		if err = res.Unmarshal(&membersPage); err != nil {
+			return
+		}
+		members = append(members, membersPage...)
+	}
+
+	return
+}
+
+// OwnerExists reports whether a user, or a team given in the "org/team"
+// format, exists on the server.
+func (client *Client) OwnerExists(owner string) (exists bool, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("users/%s", owner)
+	if parts := strings.SplitN(owner, "/", 2); len(parts) == 2 {
+		path = fmt.Sprintf("orgs/%s/teams/%s", parts[0], parts[1])
+	}
+
+	res, err := api.Get(path)
+	if err == nil && res.StatusCode == 404 {
+		res.Body.Close()
+		return false, nil
+	}
+	if err = checkStatus(200, "fetching "+owner, res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return true, nil
+}
+
+func (client *Client) CreateTeam(org string, params map[string]interface{}) (team *Team, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("orgs/%s/teams", org), params)
+	if err = checkStatus(201, "creating team", res, err); err != nil {
+		return
+	}
+
+	team = &Team{}
+	err = res.Unmarshal(team)
+	return
+}
+
+// AddTeamMember adds a user to a team with the given role and returns the
+// state of the membership, which is "pending" until the user accepts an
+// invitation to the organization.
+func (client *Client) AddTeamMember(org, teamSlug, login, role string) (state string, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{}
+	if role != "" {
+		params["role"] = role
+	}
+
+	res, err := api.PutJSON(fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", org, teamSlug, login), params)
+	if err = checkStatus(200, "adding team member", res, err); err != nil {
+		return
+	}
+
+	membership := struct {
+		State string `json:"state"`
+	}{}
+	err = res.Unmarshal(&membership)
+	state = membership.State
+	return
+}
+
+func (client *Client) AddTeamRepository(org, teamSlug string, project *Project, permission string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{}
+	if permission != "" {
+		params["permission"] = permission
+	}
+
+	res, err := api.PutJSON(fmt.Sprintf("orgs/%s/teams/%s/repos/%s/%s", org, teamSlug, project.Owner, project.Name), params)
+	if err = checkStatus(204, "adding repository to team", res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+type AuditLogEntry struct {
+	Timestamp int64  `json:"@timestamp"`
+	Action    string `json:"action"`
+	Actor     string `json:"actor"`
+	Repo      string `json:"repo"`
+	User      string `json:"user"`
+
+	// Raw holds the entry as received, including fields specific to Action.
+	Raw json.RawMessage `json:"-"`
+}
+
+// CreatedAt converts the millisecond timestamp of the entry.
+func (e *AuditLogEntry) CreatedAt() time.Time {
+	return time.Unix(0, e.Timestamp*int64(time.Millisecond)).UTC()
+}
+
+// FetchAuditLog pages through the audit log of org, newest entries first,
+// calling each for every entry as soon as its page has been received.
+func (client *Client) FetchAuditLog(org string, filterParams map[string]interface{}, each func(*AuditLogEntry) error) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	path := addQuery(fmt.Sprintf("orgs/%s/audit-log?per_page=100", org), filterParams)
+
+	var res *simpleResponse
+	for path != "" {
+		res, err = api.Get(path)
+		if err = checkStatus(200, "fetching audit log", res, err); err != nil {
+			return
+		}
+		path = res.Link("next")
 
-func (pr *PullRequest) HasRequestedTeam(name string) bool {
-	for _, team := range pr.RequestedTeams {
-		if strings.EqualFold(team.Slug, name) {
-			return true
+		entriesPage := []json.RawMessage{}
+		if err = res.Unmarshal(&entriesPage); err != nil {
+			return
+		}
+		for _, raw := range entriesPage {
+			entry := &AuditLogEntry{Raw: raw}
+			if err = json.Unmarshal(raw, entry); err != nil {
+				return
+			}
+			if err = each(entry); err != nil {
+				return
+			}
 		}
 	}
-	return false
-}
-
-type IssueLabel struct {
-	Name  string `json:"name"`
-	Color string `json:"color"`
-}
 
-type User struct {
-	Login string `json:"login"`
-}
-
-type Team struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	return
 }
 
 type Milestone struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	State        string    `json:"state"`
+	OpenIssues   int       `json:"open_issues"`
+	ClosedIssues int       `json:"closed_issues"`
+	DueOn        time.Time `json:"due_on"`
+	HTMLURL      string    `json:"html_url"`
 }
 
 func (client *Client) FetchIssues(project *Project, filterParams map[string]interface{}, limit int, filter func(*Issue) bool) (issues []Issue, err error) {
+	issues = []Issue{}
+	err = client.EachIssue(project, filterParams, limit, filter, func(issue *Issue) error {
+		issues = append(issues, *issue)
+		return nil
+	})
+	return
+}
+
+// EachIssue pages through issues, calling each for every issue accepted by
+// filter as soon as its page has been received. Paging stops after limit
+// issues have been accepted.
+func (client *Client) EachIssue(project *Project, filterParams map[string]interface{}, limit int, filter func(*Issue) bool, each func(*Issue) error) (err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
@@ -733,7 +3230,7 @@ func (client *Client) FetchIssues(project *Project, filterParams map[string]inte
 		path = addQuery(path, filterParams)
 	}
 
-	issues = []Issue{}
+	accepted := 0
 	var res *simpleResponse
 
 	for path != "" {
@@ -749,8 +3246,11 @@ func (client *Client) FetchIssues(project *Project, filterParams map[string]inte
 		}
 		for _, issue := range issuesPage {
 			if filter == nil || filter(&issue) {
-				issues = append(issues, issue)
-				if limit > 0 && len(issues) == limit {
+				if err = each(&issue); err != nil {
+					return
+				}
+				accepted++
+				if limit > 0 && accepted == limit {
 					path = ""
 					break
 				}
@@ -777,6 +3277,28 @@ func (client *Client) FetchIssue(project *Project, number string) (issue *Issue,
 	return
 }
 
+// LookupIssue is like FetchIssue, but returns a nil issue instead of an error
+// if the issue or pull request does not exist.
+func (client *Client) LookupIssue(project *Project, number int) (issue *Issue, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("repos/%s/%s/issues/%d", project.Owner, project.Name, number))
+	if err == nil && (res.StatusCode == 404 || res.StatusCode == 410) {
+		res.Body.Close()
+		return nil, nil
+	}
+	if err = checkStatus(200, "fetching issue", res, err); err != nil {
+		return
+	}
+
+	issue = &Issue{}
+	err = res.Unmarshal(issue)
+	return
+}
+
 func (client *Client) FetchComments(project *Project, number string) (comments []Comment, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -801,6 +3323,7 @@ func (client *Client) CreateIssue(project *Project, params interface{}) (issue *
 
 	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/issues", project.Owner, project.Name), params)
 	if err = checkStatus(201, "creating issue", res, err); err != nil {
+		err = rateLimitedError(res, err)
 		return
 	}
 
@@ -809,6 +3332,29 @@ func (client *Client) CreateIssue(project *Project, params interface{}) (issue *
 	return
 }
 
+// RateLimitedError is returned when GitHub rejected a request because too
+// many requests have been made. RetryAfter is how long to wait before trying
+// again.
+type RateLimitedError struct {
+	error
+	RetryAfter time.Duration
+}
+
+func rateLimitedError(res *simpleResponse, err error) error {
+	if res == nil || (res.StatusCode != 403 && res.StatusCode != 429) {
+		return err
+	}
+	if seconds, parseErr := strconv.Atoi(res.Header.Get("Retry-After")); parseErr == nil {
+		return &RateLimitedError{err, time.Duration(seconds) * time.Second}
+	}
+	if res.RateLimitRemaining() == 0 {
+		if reset := res.RateLimitReset(); reset > 0 {
+			return &RateLimitedError{err, time.Until(time.Unix(int64(reset), 0))}
+		}
+	}
+	return err
+}
+
 func (client *Client) UpdateIssue(project *Project, issueNumber int, params map[string]interface{}) (err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -866,13 +3412,65 @@ func (client *Client) FetchLabels(project *Project) (labels []IssueLabel, err er
 	return
 }
 
-func (client *Client) FetchMilestones(project *Project) (milestones []Milestone, err error) {
+func (client *Client) CreateLabel(project *Project, label IssueLabel) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/labels", project.Owner, project.Name), label)
+	if err = checkStatus(201, "creating label", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) UpdateLabel(project *Project, name string, label IssueLabel) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"new_name":    label.Name,
+		"color":       label.Color,
+		"description": label.Description,
+	}
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/labels/%s", project.Owner, project.Name, url.PathEscape(name)), params)
+	if err = checkStatus(200, "updating label", res, err); err != nil {
+		return
+	}
+
+	res.Body.Close()
+	return
+}
+
+func (client *Client) DeleteLabel(project *Project, name string) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Delete(fmt.Sprintf("repos/%s/%s/labels/%s", project.Owner, project.Name, url.PathEscape(name)))
+	if err = checkStatus(204, "deleting label", res, err); err != nil {
+		return
+	}
+
+	return
+}
+
+func (client *Client) FetchMilestones(project *Project, filterParams map[string]interface{}) (milestones []Milestone, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
 
 	path := fmt.Sprintf("repos/%s/%s/milestones?per_page=100", project.Owner, project.Name)
+	if filterParams != nil {
+		path = addQuery(path, filterParams)
+	}
 
 	milestones = []Milestone{}
 	var res *simpleResponse
@@ -894,6 +3492,38 @@ func (client *Client) FetchMilestones(project *Project) (milestones []Milestone,
 	return
 }
 
+func (client *Client) CreateMilestone(project *Project, params map[string]interface{}) (milestone *Milestone, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PostJSON(fmt.Sprintf("repos/%s/%s/milestones", project.Owner, project.Name), params)
+	if err = checkStatus(201, "creating milestone", res, err); err != nil {
+		return
+	}
+
+	milestone = &Milestone{}
+	err = res.Unmarshal(milestone)
+	return
+}
+
+func (client *Client) UpdateMilestone(project *Project, number int, params map[string]interface{}) (milestone *Milestone, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.PatchJSON(fmt.Sprintf("repos/%s/%s/milestones/%d", project.Owner, project.Name, number), params)
+	if err = checkStatus(200, "updating milestone", res, err); err != nil {
+		return
+	}
+
+	milestone = &Milestone{}
+	err = res.Unmarshal(milestone)
+	return
+}
+
 func (client *Client) GenericAPIRequest(method, path string, data interface{}, headers map[string]string, ttl int) (*simpleResponse, error) {
 	api, err := client.simpleAPI()
 	if err != nil {
@@ -982,6 +3612,144 @@ func (client *Client) CurrentUser() (user *User, err error) {
 	return
 }
 
+func (client *Client) FetchUser(login string) (user *User, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err := api.Get(fmt.Sprintf("users/%s", login))
+	if err = checkStatus(200, "fetching user", res, err); err != nil {
+		return
+	}
+
+	user = &User{}
+	err = res.Unmarshal(user)
+	return
+}
+
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+type AuthStatus struct {
+	Host      string    `json:"host"`
+	Login     string    `json:"login,omitempty"`
+	TokenType string    `json:"token_type"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	RateLimit RateLimit `json:"rate_limit"`
+
+	// Installation is set for GitHub App installation tokens, which don't
+	// belong to a user.
+	Installation *InstallationStatus `json:"installation,omitempty"`
+}
+
+type InstallationStatus struct {
+	Owner        string `json:"owner"`
+	Repositories int    `json:"repositories"`
+}
+
+// FetchAuthStatus looks up the user that the access token belongs to, along
+// with the OAuth scopes of the token and the current API rate limit. Only
+// classic and OAuth tokens have scopes; for GitHub App installation tokens,
+// the account that the app is installed on is looked up instead of a user.
+func (client *Client) FetchAuthStatus() (status *AuthStatus, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	status = &AuthStatus{
+		Host:      client.Host.Host,
+		TokenType: TokenType(client.Host.AccessToken),
+	}
+
+	var res *simpleResponse
+	if status.TokenType == AppInstallationToken {
+		var installation *installationRepositories
+		res, installation, err = client.fetchInstallationRepositories()
+		if err != nil {
+			return
+		}
+		status.Installation = &InstallationStatus{
+			Owner:        installation.owner(),
+			Repositories: installation.TotalCount,
+		}
+	} else {
+		res, err = api.Get("user")
+		if err = checkStatus(200, "getting current user", res, err); err != nil {
+			return
+		}
+
+		user := User{}
+		if err = res.Unmarshal(&user); err != nil {
+			return
+		}
+		status.Login = user.Login
+	}
+
+	status.RateLimit = RateLimit{
+		Limit:     res.RateLimitLimit(),
+		Remaining: res.RateLimitRemaining(),
+	}
+	if _, ok := res.Header[http.CanonicalHeaderKey("X-Oauth-Scopes")]; ok {
+		status.Scopes = []string{}
+		for _, scope := range strings.Split(res.Header.Get("X-Oauth-Scopes"), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				status.Scopes = append(status.Scopes, scope)
+			}
+		}
+	}
+	if reset := res.RateLimitReset(); reset > 0 {
+		status.RateLimit.Reset = time.Unix(int64(reset), 0).UTC()
+	}
+
+	return
+}
+
+type installationRepositories struct {
+	TotalCount   int          `json:"total_count"`
+	Repositories []Repository `json:"repositories"`
+}
+
+func (i *installationRepositories) owner() string {
+	if len(i.Repositories) > 0 && i.Repositories[0].Owner != nil {
+		return i.Repositories[0].Owner.Login
+	}
+	return ""
+}
+
+func (client *Client) fetchInstallationRepositories() (res *simpleResponse, installation *installationRepositories, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	res, err = api.Get("installation/repositories?per_page=1")
+	if err = checkStatus(200, "fetching installation repositories", res, err); err != nil {
+		return
+	}
+
+	installation = &installationRepositories{}
+	err = res.Unmarshal(installation)
+	return
+}
+
+// InstallationOwner finds the account that a GitHub App installation token
+// was issued for, which serves as the user for installation tokens.
+func (client *Client) InstallationOwner() (owner string, err error) {
+	_, installation, err := client.fetchInstallationRepositories()
+	if err != nil {
+		return
+	}
+	if owner = installation.owner(); owner == "" {
+		err = fmt.Errorf("Error: no repositories are accessible to this GitHub App installation.\nYou must specify GITHUB_USER via environment variable.")
+	}
+	return
+}
+
 type AuthorizationEntry struct {
 	Token string `json:"token"`
 }
@@ -1054,6 +3822,99 @@ func (client *Client) FindOrCreateToken(user, password, twoFactorCode string) (t
 	return
 }
 
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// webClient is an unauthenticated client for endpoints that are served from
+// the web host instead of the API, such as OAuth.
+func (client *Client) webClient() *simpleClient {
+	api := client.apiClient()
+	api.rootURL = client.absolute(client.Host.Host)
+	return api
+}
+
+func acceptJSON(req *http.Request) {
+	req.Header.Set("Accept", "application/json")
+}
+
+// RequestDeviceCode starts the OAuth device flow for the OAuth app clientID.
+func (client *Client) RequestDeviceCode(clientID string, scopes []string) (code *DeviceCode, err error) {
+	params := map[string]interface{}{
+		"client_id": clientID,
+		"scope":     strings.Join(scopes, " "),
+	}
+
+	res, err := client.webClient().jsonRequest("POST", "login/device/code", params, acceptJSON)
+	if err = checkStatus(200, "requesting device code", res, err); err != nil {
+		return
+	}
+
+	code = &DeviceCode{}
+	err = res.Unmarshal(code)
+	return
+}
+
+// PollDeviceToken waits for the user to authorize the device code and returns
+// the access token that was granted.
+func (client *Client) PollDeviceToken(clientID string, code *DeviceCode) (token string, err error) {
+	params := map[string]interface{}{
+		"client_id":   clientID,
+		"device_code": code.DeviceCode,
+		"grant_type":  "urn:ietf:params:oauth:grant-type:device_code",
+	}
+
+	interval := time.Duration(code.Interval) * time.Second
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for {
+		time.Sleep(interval)
+
+		res, e := client.webClient().jsonRequest("POST", "login/oauth/access_token", params, acceptJSON)
+		if err = checkStatus(200, "requesting access token", res, e); err != nil {
+			return
+		}
+
+		result := struct {
+			AccessToken      string `json:"access_token"`
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+			Interval         int    `json:"interval"`
+		}{}
+		if err = res.Unmarshal(&result); err != nil {
+			return
+		}
+
+		switch result.Error {
+		case "":
+			return result.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval = time.Duration(result.Interval) * time.Second
+		default:
+			return "", fmt.Errorf("Error requesting access token: %s", result.ErrorDescription)
+		}
+
+		if code.ExpiresIn > 0 && time.Now().After(deadline) {
+			return "", fmt.Errorf("Error requesting access token: the device code has expired")
+		}
+	}
+}
+
+// RevokeToken permanently revokes an access token.
+func (client *Client) RevokeToken(token string) (err error) {
+	params := map[string]interface{}{
+		"credentials": []string{token},
+	}
+
+	res, err := client.apiClient().PostJSON("credentials/revoke", params)
+	return checkStatus(202, "revoking access token", res, err)
+}
+
 func (client *Client) ensureAccessToken() error {
 	if client.Host.AccessToken == "" {
 		host, err := CurrentConfig().PromptForHost(client.Host.Host)
@@ -1066,6 +3927,9 @@ func (client *Client) ensureAccessToken() error {
 }
 
 func (client *Client) simpleAPI() (c *simpleClient, err error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
 	err = client.ensureAccessToken()
 	if err != nil {
 		return
@@ -1088,6 +3952,8 @@ func (client *Client) simpleAPI() (c *simpleClient, err error) {
 		}
 	}
 
+	c.RepoCacheDir = repoCacheDir()
+
 	client.cachedClient = c
 	return
 }
@@ -1131,30 +3997,11 @@ func (client *Client) FetchGist(id string) (gist *Gist, err error) {
 	return
 }
 
-func (client *Client) CreateGist(filenames []string, public bool) (gist *Gist, err error) {
+func (client *Client) CreateGist(files map[string]GistFile, public bool) (gist *Gist, err error) {
 	api, err := client.simpleAPI()
 	if err != nil {
 		return
 	}
-	files := map[string]GistFile{}
-	var basename string
-	var content []byte
-	var gf GistFile
-
-	for _, file := range filenames {
-		if file == "-" {
-			content, err = ioutil.ReadAll(os.Stdin)
-			basename = "gistfile1.txt"
-		} else {
-			content, err = ioutil.ReadFile(file)
-			basename = path.Base(file)
-		}
-		if err != nil {
-			return
-		}
-		gf = GistFile{Content: string(content)}
-		files[basename] = gf
-	}
 
 	g := Gist{
 		Files:  files,
@@ -1263,6 +4110,10 @@ func formatError(action string, e *errorInfo) error {
 		return fmt.Errorf("%s\n%s", errStr, scopeErr)
 	}
 
+	if permissionErr := ValidateSufficientPermissions(e.Response); permissionErr != nil {
+		return fmt.Errorf("%s\n%s", errStr, permissionErr)
+	}
+
 	return errors.New(errStr)
 }
 
@@ -1286,6 +4137,10 @@ func ValidateSufficientOAuthScopes(res *http.Response) error {
 	if res.StatusCode != 404 && res.StatusCode != 403 {
 		return nil
 	}
+	// fine-grained and GitHub App tokens have permissions instead of scopes
+	if _, ok := res.Header[http.CanonicalHeaderKey("X-Oauth-Scopes")]; !ok {
+		return nil
+	}
 
 	needScopes := newScopeSet(res.Header.Get("X-Accepted-Oauth-Scopes"))
 	if len(needScopes) == 0 && isGistWrite(res.Request) {
@@ -1305,6 +4160,21 @@ func ValidateSufficientOAuthScopes(res *http.Response) error {
 		needScopes)
 }
 
+// ValidateSufficientPermissions explains which permissions a fine-grained or
+// GitHub App token lacks, as indicated by `X-Accepted-Github-Permissions`
+func ValidateSufficientPermissions(res *http.Response) error {
+	if res.StatusCode != 404 && res.StatusCode != 403 {
+		return nil
+	}
+
+	permissions := res.Header.Get("X-Accepted-Github-Permissions")
+	if permissions == "" {
+		return nil
+	}
+
+	return fmt.Errorf("Your access token may have insufficient permissions. This request requires: %s", permissions)
+}
+
 func isGistWrite(req *http.Request) bool {
 	if req.Method == "GET" {
 		return false
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/github/hub/v2/internal/assert"
@@ -31,6 +32,24 @@ func TestClient_FormatError(t *testing.T) {
 	assert.Equal(t, "Error action: Unprocessable Entity (HTTP 422)\nerror message", fmt.Sprintf("%s", err))
 }
 
+func TestClient_FormatError_Permissions(t *testing.T) {
+	e := &errorInfo{
+		Response: &http.Response{
+			StatusCode: 403,
+			Status:     "403 Forbidden",
+			Header: http.Header{
+				"X-Accepted-Oauth-Scopes":       {"repo"},
+				"X-Accepted-Github-Permissions": {"issues=write; pull_requests=write"},
+			},
+		},
+		Message: "Resource not accessible by personal access token",
+	}
+
+	err := FormatError("action", e)
+	assert.Equal(t, "Error action: Forbidden (HTTP 403)\nResource not accessible by personal access token\n"+
+		"Your access token may have insufficient permissions. This request requires: issues=write; pull_requests=write", fmt.Sprintf("%s", err))
+}
+
 func TestAuthTokenNote(t *testing.T) {
 	note, err := authTokenNote(1)
 	assert.Equal(t, nil, err)
@@ -45,3 +64,50 @@ func TestAuthTokenNote(t *testing.T) {
 	assert.T(t, reg.MatchString(note))
 
 }
+
+func TestProgressReader(t *testing.T) {
+	reported := []int64{}
+	reader := &progressReader{
+		Reader:   strings.NewReader("hello world"),
+		progress: func(sent int64) { reported = append(reported, sent) },
+	}
+
+	buf := make([]byte, 5)
+	for {
+		if _, err := reader.Read(buf); err != nil {
+			break
+		}
+	}
+	assert.Equal(t, []int64{5, 10, 11}, reported)
+}
+
+func TestClient_BranchExists(t *testing.T) {
+	s := setupTestServer("")
+	defer s.Close()
+
+	s.HandleFunc("/repos/github/hub/branches/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/repos/github/hub/branches/feature%2F%231%3Fx%25":
+			w.Write([]byte(`{"name":"feature/#1?x%"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	})
+
+	client := &Client{
+		Host: &Host{Host: s.URL.Host, AccessToken: "OTOKEN"},
+		cachedClient: &simpleClient{
+			httpClient: newHTTPClient("", false, ""),
+			rootURL:    s.URL,
+		},
+	}
+	project := &Project{Owner: "github", Name: "hub"}
+
+	exists, err := client.BranchExists(project, "feature/#1?x%")
+	assert.Equal(t, nil, err)
+	assert.T(t, exists)
+
+	exists, err = client.BranchExists(project, "feature/#2")
+	assert.Equal(t, nil, err)
+	assert.T(t, !exists)
+}
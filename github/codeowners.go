@@ -0,0 +1,169 @@
+package github
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CodeownersPaths lists the locations where GitHub looks for a CODEOWNERS
+// file, in order of precedence.
+var CodeownersPaths = []string{
+	filepath.Join(".github", "CODEOWNERS"),
+	"CODEOWNERS",
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+var (
+	codeownersUserRegexp  = regexp.MustCompile(`^@[a-zA-Z0-9][a-zA-Z0-9-]*$`)
+	codeownersTeamRegexp  = regexp.MustCompile(`^@[a-zA-Z0-9][a-zA-Z0-9-]*/[a-zA-Z0-9_.-]+$`)
+	codeownersEmailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+type CodeownersRule struct {
+	Line    int
+	Pattern string
+	Owners  []string
+	matcher *regexp.Regexp
+}
+
+type CodeownersError struct {
+	Line    int
+	Message string
+}
+
+type Codeowners struct {
+	Path   string
+	Rules  []CodeownersRule
+	Errors []CodeownersError
+}
+
+// ReadCodeowners reads and parses the CODEOWNERS file of the repository in
+// workdir. It returns nil if the repository has no CODEOWNERS file.
+func ReadCodeowners(workdir string) (*Codeowners, error) {
+	for _, path := range CodeownersPaths {
+		content, err := ioutil.ReadFile(filepath.Join(workdir, path))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return ParseCodeowners(path, string(content)), nil
+	}
+	return nil, nil
+}
+
+func ParseCodeowners(path, content string) *Codeowners {
+	c := &Codeowners{Path: path}
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNumber := i + 1
+		fields := strings.Fields(line)
+		for j, field := range fields {
+			if strings.HasPrefix(field, "#") {
+				fields = fields[:j]
+				break
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule := CodeownersRule{
+			Line:    lineNumber,
+			Pattern: fields[0],
+			Owners:  fields[1:],
+		}
+
+		if strings.HasPrefix(rule.Pattern, "!") {
+			c.addError(lineNumber, "negated pattern '%s' is not supported", rule.Pattern)
+			continue
+		} else if strings.ContainsAny(rule.Pattern, "[]") {
+			c.addError(lineNumber, "character range in pattern '%s' is not supported", rule.Pattern)
+			continue
+		}
+
+		for _, owner := range rule.Owners {
+			if !IsCodeowner(owner) {
+				c.addError(lineNumber, "invalid owner '%s'", owner)
+			}
+		}
+
+		rule.matcher = codeownersPatternRegexp(rule.Pattern)
+		c.Rules = append(c.Rules, rule)
+	}
+
+	return c
+}
+
+func (c *Codeowners) addError(line int, format string, a ...interface{}) {
+	c.Errors = append(c.Errors, CodeownersError{
+		Line:    line,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+// Owners returns the owners of path according to the last matching rule.
+func (c *Codeowners) Owners(path string) []string {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].matcher.MatchString(path) {
+			return c.Rules[i].Owners
+		}
+	}
+	return []string{}
+}
+
+// IsCodeowner reports whether owner is a "@user", "@org/team", or an email
+// address.
+func IsCodeowner(owner string) bool {
+	return codeownersUserRegexp.MatchString(owner) ||
+		codeownersTeamRegexp.MatchString(owner) ||
+		codeownersEmailRegexp.MatchString(owner)
+}
+
+// codeownersPatternRegexp translates a CODEOWNERS pattern, which follows most
+// of the gitignore rules, to a regular expression matching file paths.
+func codeownersPatternRegexp(pattern string) *regexp.Regexp {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.Trim(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	expr := "^"
+	if !anchored {
+		expr += "(?:.*/)?"
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr += "(?:.*/)?"
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr += ".*"
+			i++
+		case pattern[i] == '*':
+			expr += "[^/]*"
+		case pattern[i] == '?':
+			expr += "[^/]"
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			expr += regexp.QuoteMeta(pattern[i : i+1])
+		default:
+			expr += regexp.QuoteMeta(pattern[i : i+1])
+		}
+	}
+
+	switch {
+	case dirOnly:
+		expr += "/.*"
+	case !strings.HasSuffix(pattern, "/*"):
+		// a pattern naming a directory also applies to everything inside it
+		expr += "(?:/.*)?"
+	}
+
+	return regexp.MustCompile(expr + "$")
+}
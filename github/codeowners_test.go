@@ -0,0 +1,62 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestCodeowners_Owners(t *testing.T) {
+	c := ParseCodeowners("CODEOWNERS", `# default owners
+*       @global-owner1 @global-owner2
+*.js    @js-owner #This is an inline comment.
+*.go    docs@example.com
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+/docs/  @doctocat
+/scripts/ @doctocat @octocat
+**/logs @octocat
+/apps/github
+`)
+	assert.Equal(t, 0, len(c.Errors))
+
+	tests := map[string][]string{
+		"README.md":                         {"@global-owner1", "@global-owner2"},
+		"lib/app.js":                        {"@js-owner"},
+		"main.go":                           {"docs@example.com"},
+		"build/logs/today.log":              {"@octocat"},
+		"docs/getting-started.md":           {"@doctocat"},
+		"docs/build-app/troubleshooting.md": {"@doctocat"},
+		"src/apps/index.html":               {"@octocat"},
+		"scripts/deploy.sh":                 {"@doctocat", "@octocat"},
+		"deeply/nested/logs/x.txt":          {"@octocat"},
+		"apps/github/index.html":            {},
+	}
+	for path, want := range tests {
+		assert.Equal(t, want, c.Owners(path))
+	}
+}
+
+func TestCodeowners_docsWildcard(t *testing.T) {
+	c := ParseCodeowners("CODEOWNERS", "docs/* @docs\n")
+
+	assert.Equal(t, []string{"@docs"}, c.Owners("docs/getting-started.md"))
+	assert.Equal(t, []string{}, c.Owners("docs/build-app/troubleshooting.md"))
+	assert.Equal(t, []string{}, c.Owners("src/docs/index.md"))
+}
+
+func TestCodeowners_Errors(t *testing.T) {
+	c := ParseCodeowners("CODEOWNERS", `*.rb @ruby
+!vendor/ @nobody
+*.[ch] @c-team
+lib/ octocat @org/team
+`)
+
+	assert.Equal(t, []CodeownersError{
+		{Line: 2, Message: "negated pattern '!vendor/' is not supported"},
+		{Line: 3, Message: "character range in pattern '*.[ch]' is not supported"},
+		{Line: 4, Message: "invalid owner 'octocat'"},
+	}, c.Errors)
+	assert.Equal(t, 2, len(c.Rules))
+}
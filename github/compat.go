@@ -0,0 +1,103 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// API features that older GitHub Enterprise Server releases lack.
+const (
+	featureDraftPullRequests = "draft pull requests"
+	featureCheckRuns         = "check runs"
+	featureCodeScanning      = "code scanning alerts"
+	featureTagProtection     = "tag protection rules"
+	featureMergeUpstream     = "syncing forks"
+)
+
+// minimumEnterpriseVersion is the oldest GitHub Enterprise Server release that
+// supports each feature.
+var minimumEnterpriseVersion = map[string]string{
+	featureDraftPullRequests: "2.17",
+	featureCheckRuns:         "2.15",
+	featureCodeScanning:      "3.0",
+	featureTagProtection:     "3.5",
+	featureMergeUpstream:     "3.3",
+}
+
+// enterpriseVersion is the version of GitHub Enterprise Server that the client
+// talks to. It is blank for github.com or if the version couldn't be
+// determined.
+func (client *Client) enterpriseVersion() string {
+	if client.Host == nil || client.Host.Host == GitHubHost {
+		return ""
+	}
+	if client.cachedEnterpriseVersion != nil {
+		return *client.cachedEnterpriseVersion
+	}
+
+	version := ""
+	if api, err := client.simpleAPI(); err == nil {
+		if res, err := api.GetRevalidated("meta", apiPayloadVersion, repositoryCacheTTL); err == nil && res.StatusCode == 200 {
+			meta := struct {
+				InstalledVersion string `json:"installed_version"`
+			}{}
+			if res.Unmarshal(&meta) == nil {
+				version = meta.InstalledVersion
+			}
+			if version == "" {
+				version = res.Header.Get("X-GitHub-Enterprise-Version")
+			}
+		}
+	}
+
+	client.cachedEnterpriseVersion = &version
+	return version
+}
+
+// supports reports whether the server is known to support feature. Servers
+// whose version can't be determined are assumed to support everything.
+func (client *Client) supports(feature string) bool {
+	version := client.enterpriseVersion()
+	if version == "" {
+		return true
+	}
+	return compareVersions(version, minimumEnterpriseVersion[feature]) >= 0
+}
+
+func (client *Client) unsupportedError(action, feature string) error {
+	return fmt.Errorf("Error %s: %s require GitHub Enterprise Server %s or newer\n%s runs version %s",
+		action, feature, minimumEnterpriseVersion[feature], client.Host.Host, client.enterpriseVersion())
+}
+
+// checkFeatureStatus is like checkStatus, but explains a "404 Not Found" as
+// the server being too old for feature if that is the case.
+func (client *Client) checkFeatureStatus(feature string, expectedStatus int, action string, response *simpleResponse, err error) error {
+	if err = checkStatus(expectedStatus, action, response, err); err != nil {
+		if response != nil && response.StatusCode == 404 && !client.supports(feature) {
+			return client.unsupportedError(action, feature)
+		}
+	}
+	return err
+}
+
+// compareVersions compares dotted version numbers such as "3.4.1", returning
+// a negative number, zero, or a positive number if a is older than, equal to,
+// or newer than b.
+func compareVersions(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		if i < len(partsA) {
+			numA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numB, _ = strconv.Atoi(partsB[i])
+		}
+		if numA != numB {
+			return numA - numB
+		}
+	}
+	return 0
+}
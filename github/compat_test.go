@@ -0,0 +1,23 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.T(t, compareVersions("2.16.3", "2.17") < 0)
+	assert.T(t, compareVersions("2.17.0", "2.17") == 0)
+	assert.T(t, compareVersions("3.10", "3.5") > 0)
+}
+
+func TestClient_Supports(t *testing.T) {
+	client := &Client{Host: &Host{Host: GitHubHost}}
+	assert.T(t, client.supports(featureTagProtection))
+
+	version := "3.4.2"
+	client = &Client{Host: &Host{Host: "git.my.org"}, cachedEnterpriseVersion: &version}
+	assert.T(t, client.supports(featureCodeScanning))
+	assert.T(t, !client.supports(featureTagProtection))
+}
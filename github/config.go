@@ -97,6 +97,12 @@ func (c *Config) PromptForHost(host string) (h *Host, err error) {
 	}
 	if tokenFromEnv && userFromEnv != "" {
 		h.User = userFromEnv
+	} else if tokenFromEnv && TokenType(token) == AppInstallationToken {
+		// installation tokens don't belong to a user
+		h.User, err = client.InstallationOwner()
+		if err != nil {
+			return
+		}
 	} else {
 		var currentUser *User
 		currentUser, err = client.CurrentUser()
@@ -222,6 +228,11 @@ func getPassword() (string, error) {
 	return string(passBytes), nil
 }
 
+// Save writes the configuration back to the hub configuration file.
+func (c *Config) Save() error {
+	return newConfigService().Save(configsFile(), c)
+}
+
 func (c *Config) Find(host string) *Host {
 	for _, h := range c.Hosts {
 		if h.Host == host {
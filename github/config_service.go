@@ -1,6 +1,7 @@
 package github
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 )
@@ -17,19 +18,35 @@ type configService struct {
 	Decoder configDecoder
 }
 
+// Save writes the configuration to a temporary file that then replaces
+// filename, so that the file is never left half-written.
 func (s *configService) Save(filename string, c *Config) error {
+	if target, err := filepath.EvalSymlinks(filename); err == nil {
+		filename = target
+	}
+
 	err := os.MkdirAll(filepath.Dir(filename), 0771)
 	if err != nil {
 		return err
 	}
 
-	w, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	w, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(w.Name())
+
+	if err = w.Chmod(0600); err == nil {
+		err = s.Encoder.Encode(w, c)
+	}
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return err
 	}
-	defer w.Close()
 
-	return s.Encoder.Encode(w, c)
+	return os.Rename(w.Name(), filename)
 }
 
 func (s *configService) Load(filename string, c *Config) error {
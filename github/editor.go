@@ -78,6 +78,24 @@ func (e *Editor) EditContent() (content string, err error) {
 		return
 	}
 
+	return e.parseContent(b)
+}
+
+// SavedContent reads the message left over in the file from a previous
+// attempt without opening the editor.
+func (e *Editor) SavedContent() (content string, err error) {
+	b, err := e.readContent()
+	if os.IsNotExist(err) {
+		err = fmt.Errorf("no saved %s message to recover", e.Topic)
+		return
+	} else if err != nil {
+		return
+	}
+
+	return e.parseContent(b)
+}
+
+func (e *Editor) parseContent(b []byte) (content string, err error) {
 	b = bytes.TrimSpace(b)
 	reader := bytes.NewReader(b)
 	scanner := bufio.NewScanner(reader)
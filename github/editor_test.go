@@ -76,3 +76,28 @@ func TestEditor_openAndEdit_writeFileIfNotExist(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, "hello", string(content))
 }
+
+func TestEditor_SavedContent(t *testing.T) {
+	tempFile, _ := ioutil.TempFile("", "PULLREQ")
+	tempFile.Close()
+	defer os.Remove(tempFile.Name())
+
+	ioutil.WriteFile(tempFile.Name(), []byte("title\n\nbody\n# ------------------------ >8 ------------------------\n# comment\n"), 0644)
+	editor := Editor{
+		File:  tempFile.Name(),
+		Topic: "test",
+		CS:    "#",
+		openEditor: func(program string, file string) error {
+			t.Fatal("editor should not be opened")
+			return nil
+		},
+	}
+
+	content, err := editor.SavedContent()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "title\n\nbody", content)
+
+	os.Remove(tempFile.Name())
+	_, err = editor.SavedContent()
+	assert.Equal(t, "no saved test message to recover", err.Error())
+}
@@ -0,0 +1,96 @@
+package github
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// emojiCacheTTL is how many seconds the list of emoji is reused for before it
+// is downloaded again.
+const emojiCacheTTL = 24 * 60 * 60
+
+var emojiShortcodeRegexp = regexp.MustCompile(`:([a-z0-9_+-]+):`)
+
+// FetchEmojis returns the Unicode characters for emoji shortcodes, keyed by
+// shortcode without the colons. Emoji that only exist on GitHub, such as
+// ":shipit:", have no Unicode equivalent and are left out.
+func (client *Client) FetchEmojis() (emojis map[string]string, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	// The list is cached for longer than other responses. That goes through a
+	// copy of the shared API client so that requests made concurrently, e.g.
+	// by prefetches, keep their own caching.
+	emojiAPI := *api
+	emojiAPI.CacheTTL = emojiCacheTTL
+	res, err := emojiAPI.Get("emojis")
+	if err = checkStatus(200, "fetching emojis", res, err); err != nil {
+		return
+	}
+
+	images := map[string]string{}
+	if err = res.Unmarshal(&images); err != nil {
+		return
+	}
+
+	emojis = map[string]string{}
+	for name, imageURL := range images {
+		if char, ok := emojiFromImageURL(imageURL); ok {
+			emojis[name] = char
+		}
+	}
+	return
+}
+
+// emojiFromImageURL decodes the Unicode code points that GitHub encodes in
+// the file names of emoji images, e.g. ".../unicode/1f44d.png?v8".
+func emojiFromImageURL(imageURL string) (string, bool) {
+	if !strings.Contains(imageURL, "/unicode/") {
+		return "", false
+	}
+	name := path.Base(strings.SplitN(imageURL, "?", 2)[0])
+	name = strings.TrimSuffix(name, path.Ext(name))
+
+	var char strings.Builder
+	for _, hex := range strings.Split(name, "-") {
+		codePoint, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return "", false
+		}
+		char.WriteRune(rune(codePoint))
+	}
+	return char.String(), char.Len() > 0
+}
+
+// ExpandEmoji replaces emoji shortcodes such as ":+1:" in text with their
+// Unicode characters. Unknown shortcodes, code blocks, and inline code are left
+// alone.
+func ExpandEmoji(text string, emojis map[string]string) string {
+	inFence := false
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if codeFenceRegexp.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		parts := strings.Split(line, "`")
+		for j := 0; j < len(parts); j += 2 {
+			parts[j] = emojiShortcodeRegexp.ReplaceAllStringFunc(parts[j], func(match string) string {
+				if char, ok := emojis[strings.Trim(match, ":")]; ok {
+					return char
+				}
+				return match
+			})
+		}
+		lines[i] = strings.Join(parts, "`")
+	}
+	return strings.Join(lines, "\n")
+}
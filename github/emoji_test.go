@@ -0,0 +1,60 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestEmojiFromImageURL(t *testing.T) {
+	char, ok := emojiFromImageURL("https://github.githubassets.com/images/icons/emoji/unicode/1f44d.png?v8")
+	assert.T(t, ok)
+	assert.Equal(t, "👍", char)
+
+	char, ok = emojiFromImageURL("https://github.githubassets.com/images/icons/emoji/unicode/1f1e8-1f1e6.png?v8")
+	assert.T(t, ok)
+	assert.Equal(t, "🇨🇦", char)
+
+	_, ok = emojiFromImageURL("https://github.githubassets.com/images/icons/emoji/shipit.png?v8")
+	assert.T(t, !ok)
+}
+
+func TestExpandEmoji(t *testing.T) {
+	emojis := map[string]string{"+1": "👍", "tada": "🎉"}
+	text := "Ship it :+1: :tada:\n`:tada:` stays :shipit:\n```\n:+1:\n```\ntime 10:30:00"
+	assert.Equal(t, "Ship it 👍 🎉\n`:tada:` stays :shipit:\n```\n:+1:\n```\ntime 10:30:00", ExpandEmoji(text, emojis))
+}
+
+func TestFetchEmojis_KeepsClientCacheTTL(t *testing.T) {
+	s := setupTestServer("")
+	defer s.Close()
+
+	s.HandleFunc("/emojis", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"+1":"https://github.githubassets.com/images/icons/emoji/unicode/1f44d.png?v8"}`))
+	})
+	s.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"login":"mislav"}`))
+	})
+
+	api := &simpleClient{
+		httpClient: newHTTPClient("", false, ""),
+		rootURL:    s.URL,
+	}
+	client := &Client{Host: &Host{AccessToken: "OTOKEN"}, cachedClient: api}
+
+	done := make(chan error)
+	go func() {
+		res, err := api.Get("user")
+		if err == nil {
+			res.Body.Close()
+		}
+		done <- err
+	}()
+
+	emojis, err := client.FetchEmojis()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "👍", emojis["+1"])
+	assert.Equal(t, nil, <-done)
+	assert.Equal(t, 0, api.CacheTTL)
+}
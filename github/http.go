@@ -19,6 +19,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/github/hub/v2/ui"
@@ -31,9 +32,12 @@ const patchMediaType = "application/vnd.github.v3.patch;charset=utf-8"
 const textMediaType = "text/plain;charset=utf-8"
 const checksType = "application/vnd.github.antiope-preview+json;charset=utf-8"
 const draftsType = "application/vnd.github.shadow-cat-preview+json;charset=utf-8"
+const textMatchType = "application/vnd.github.v3.text-match+json;charset=utf-8"
+const rawMediaType = "application/vnd.github.v3.raw"
 const cacheVersion = 2
 
 const (
+	rateLimitLimitHeader     = "X-Ratelimit-Limit"
 	rateLimitRemainingHeader = "X-Ratelimit-Remaining"
 	rateLimitResetHeader     = "X-Ratelimit-Reset"
 )
@@ -244,12 +248,16 @@ func cloneRequest(req *http.Request) *http.Request {
 	return dup
 }
 
-var proxyFunc func(*url.URL) (*url.URL, error)
+var (
+	proxyFunc     func(*url.URL) (*url.URL, error)
+	proxyFuncOnce sync.Once
+)
 
 func proxyFromEnvironment(req *http.Request) (*url.URL, error) {
-	if proxyFunc == nil {
+	// requests may be made from several goroutines at once, e.g. by prefetches
+	proxyFuncOnce.Do(func() {
 		proxyFunc = httpproxy.FromEnvironment().ProxyFunc()
-	}
+	})
 	return proxyFunc(req.URL)
 }
 
@@ -258,6 +266,10 @@ type simpleClient struct {
 	rootURL        *url.URL
 	PrepareRequest func(*http.Request)
 	CacheTTL       int
+
+	// RepoCacheDir is where GetRevalidated keeps responses. REST requests that
+	// change data clear it; GraphQL queries don't.
+	RepoCacheDir string
 }
 
 func (c *simpleClient) performRequest(method, path string, body io.Reader, configure func(*http.Request)) (*simpleResponse, error) {
@@ -274,10 +286,10 @@ func (c *simpleClient) performRequest(method, path string, body io.Reader, confi
 	return nil, err
 }
 
-func (c *simpleClient) performRequestURL(method string, url *url.URL, body io.Reader, configure func(*http.Request)) (res *simpleResponse, err error) {
+func (c *simpleClient) newRequest(method string, url *url.URL, body io.Reader, configure func(*http.Request)) (*http.Request, error) {
 	req, err := http.NewRequest(method, url.String(), body)
 	if err != nil {
-		return
+		return nil, err
 	}
 	if c.PrepareRequest != nil {
 		c.PrepareRequest(req)
@@ -288,6 +300,18 @@ func (c *simpleClient) performRequestURL(method string, url *url.URL, body io.Re
 	if configure != nil {
 		configure(req)
 	}
+	return req, nil
+}
+
+func (c *simpleClient) performRequestURL(method string, url *url.URL, body io.Reader, configure func(*http.Request)) (res *simpleResponse, err error) {
+	req, err := c.newRequest(method, url, body, configure)
+	if err != nil {
+		return
+	}
+
+	if c.RepoCacheDir != "" && isMutation(req) {
+		os.RemoveAll(c.RepoCacheDir)
+	}
 
 	key := cacheKey(req)
 	if cachedResponse := c.cacheRead(key, req); cachedResponse != nil {
@@ -310,56 +334,72 @@ func isGraphQL(req *http.Request) bool {
 	return req.URL.Path == "/graphql"
 }
 
+func isMutation(req *http.Request) bool {
+	switch req.Method {
+	case "POST", "PATCH", "PUT", "DELETE":
+		return !isGraphQL(req)
+	}
+	return false
+}
+
 func canCache(req *http.Request) bool {
 	return strings.EqualFold(req.Method, "GET") || isGraphQL(req)
 }
 
-func (c *simpleClient) cacheRead(key string, req *http.Request) (res *http.Response) {
-	if c.CacheTTL > 0 && canCache(req) {
-		f := cacheFile(key)
-		cacheInfo, err := os.Stat(f)
-		if err != nil {
-			return
-		}
-		if time.Since(cacheInfo.ModTime()).Seconds() > float64(c.CacheTTL) {
-			return
-		}
-		cf, err := os.Open(f)
-		if err != nil {
-			return
-		}
-		defer cf.Close()
+// GetRevalidated is like GetFile, but keeps the response in RepoCacheDir.
+// The cached response is reused for ttl seconds, after which it is only
+// downloaded again if its ETag no longer matches.
+func (c *simpleClient) GetRevalidated(path string, mimeType string, ttl int) (res *simpleResponse, err error) {
+	if c.RepoCacheDir == "" {
+		return c.GetFile(path, mimeType)
+	}
 
-		cb, err := ioutil.ReadAll(cf)
-		if err != nil {
-			return
-		}
-		parts := strings.SplitN(string(cb), "\r\n\r\n", 2)
-		if len(parts) < 2 {
-			return
-		}
+	u, err := url.Parse(path)
+	if err != nil {
+		return
+	}
+	req, err := c.newRequest("GET", c.rootURL.ResolveReference(u), nil, func(req *http.Request) {
+		req.Header.Set("Accept", mimeType)
+	})
+	if err != nil {
+		return
+	}
 
-		res = &http.Response{
-			Body:    ioutil.NopCloser(bytes.NewBufferString(parts[1])),
-			Header:  http.Header{},
-			Request: req,
-		}
-		headerLines := strings.Split(parts[0], "\r\n")
-		if len(headerLines) < 1 {
-			return
+	f := filepath.Join(c.RepoCacheDir, cacheKey(req))
+	cached, modTime := readCacheFile(f, req)
+	if cached != nil {
+		if time.Since(modTime).Seconds() <= float64(ttl) {
+			return &simpleResponse{cached}, nil
 		}
-		if proto := strings.SplitN(headerLines[0], " ", 3); len(proto) >= 3 {
-			res.Proto = proto[0]
-			res.Status = fmt.Sprintf("%s %s", proto[1], proto[2])
-			if code, _ := strconv.Atoi(proto[1]); code > 0 {
-				res.StatusCode = code
-			}
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
 		}
-		for _, line := range headerLines[1:] {
-			kv := strings.SplitN(line, ":", 2)
-			if len(kv) >= 2 {
-				res.Header.Add(kv[0], strings.TrimLeft(kv[1], " "))
-			}
+	}
+
+	httpResponse, err := c.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+
+	if httpResponse.StatusCode == 304 && cached != nil {
+		httpResponse.Body.Close()
+		now := time.Now()
+		os.Chtimes(f, now, now)
+		return &simpleResponse{cached}, nil
+	}
+
+	if httpResponse.StatusCode == 200 {
+		writeCacheFile(f, httpResponse)
+	}
+	return &simpleResponse{httpResponse}, nil
+}
+
+func (c *simpleClient) cacheRead(key string, req *http.Request) (res *http.Response) {
+	if c.CacheTTL > 0 && canCache(req) {
+		var modTime time.Time
+		res, modTime = readCacheFile(cacheFile(key), req)
+		if res != nil && time.Since(modTime).Seconds() > float64(c.CacheTTL) {
+			res = nil
 		}
 	}
 	return
@@ -367,29 +407,83 @@ func (c *simpleClient) cacheRead(key string, req *http.Request) (res *http.Respo
 
 func (c *simpleClient) cacheWrite(key string, res *http.Response) {
 	if c.CacheTTL > 0 && canCache(res.Request) && res.StatusCode < 500 && res.StatusCode != 403 {
-		bodyCopy := &bytes.Buffer{}
-		bodyReplacement := readCloserCallback{
-			Reader: io.TeeReader(res.Body, bodyCopy),
-			Closer: res.Body,
-			Callback: func() {
-				f := cacheFile(key)
-				err := os.MkdirAll(filepath.Dir(f), 0771)
-				if err != nil {
-					return
-				}
-				cf, err := os.OpenFile(f, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
-				if err != nil {
-					return
-				}
-				defer cf.Close()
-				fmt.Fprintf(cf, "%s %s\r\n", res.Proto, res.Status)
-				res.Header.Write(cf)
-				fmt.Fprintf(cf, "\r\n")
-				io.Copy(cf, bodyCopy)
-			},
+		writeCacheFile(cacheFile(key), res)
+	}
+}
+
+// readCacheFile loads a response saved by writeCacheFile along with the time
+// it was last saved or revalidated.
+func readCacheFile(f string, req *http.Request) (res *http.Response, modTime time.Time) {
+	cacheInfo, err := os.Stat(f)
+	if err != nil {
+		return
+	}
+	modTime = cacheInfo.ModTime()
+
+	cf, err := os.Open(f)
+	if err != nil {
+		return
+	}
+	defer cf.Close()
+
+	cb, err := ioutil.ReadAll(cf)
+	if err != nil {
+		return
+	}
+	parts := strings.SplitN(string(cb), "\r\n\r\n", 2)
+	if len(parts) < 2 {
+		return
+	}
+
+	res = &http.Response{
+		Body:    ioutil.NopCloser(bytes.NewBufferString(parts[1])),
+		Header:  http.Header{},
+		Request: req,
+	}
+	headerLines := strings.Split(parts[0], "\r\n")
+	if len(headerLines) < 1 {
+		return
+	}
+	if proto := strings.SplitN(headerLines[0], " ", 3); len(proto) >= 3 {
+		res.Proto = proto[0]
+		res.Status = fmt.Sprintf("%s %s", proto[1], proto[2])
+		if code, _ := strconv.Atoi(proto[1]); code > 0 {
+			res.StatusCode = code
+		}
+	}
+	for _, line := range headerLines[1:] {
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) >= 2 {
+			res.Header.Add(kv[0], strings.TrimLeft(kv[1], " "))
 		}
-		res.Body = &bodyReplacement
 	}
+	return
+}
+
+// writeCacheFile arranges for the response to be saved to f once its body has
+// been read in full.
+func writeCacheFile(f string, res *http.Response) {
+	bodyCopy := &bytes.Buffer{}
+	bodyReplacement := readCloserCallback{
+		Reader: io.TeeReader(res.Body, bodyCopy),
+		Closer: res.Body,
+		Callback: func() {
+			err := os.MkdirAll(filepath.Dir(f), 0771)
+			if err != nil {
+				return
+			}
+			cf, err := os.OpenFile(f, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return
+			}
+			defer cf.Close()
+			fmt.Fprintf(cf, "%s %s\r\n", res.Proto, res.Status)
+			res.Header.Write(cf)
+			fmt.Fprintf(cf, "\r\n")
+			io.Copy(cf, bodyCopy)
+		},
+	}
+	res.Body = &bodyReplacement
 }
 
 type readCloserCallback struct {
@@ -568,6 +662,15 @@ func (res *simpleResponse) Link(name string) string {
 	return ""
 }
 
+func (res *simpleResponse) RateLimitLimit() int {
+	if v := res.Header.Get(rateLimitLimitHeader); len(v) > 0 {
+		if num, err := strconv.Atoi(v); err == nil {
+			return num
+		}
+	}
+	return -1
+}
+
 func (res *simpleResponse) RateLimitRemaining() int {
 	if v := res.Header.Get(rateLimitRemainingHeader); len(v) > 0 {
 		if num, err := strconv.Atoi(v); err == nil {
@@ -92,3 +92,90 @@ func TestVerboseTransport_VerbosePrintln(t *testing.T) {
 	tr.verbosePrintln("foo")
 	assert.Equal(t, "\033[36mfoo\033[0m\n", b.String())
 }
+
+func TestSimpleClient_GetRevalidated(t *testing.T) {
+	s := setupTestServer("")
+	defer s.Close()
+
+	requests := 0
+	s.HandleFunc("/repos/octocat/hello", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(304)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		w.Write([]byte(`{"name":"hello"}`))
+	})
+
+	dir, _ := ioutil.TempDir("", "hub-cache")
+	defer os.RemoveAll(dir)
+
+	c := &simpleClient{
+		httpClient:   newHTTPClient("", false, ""),
+		rootURL:      s.URL,
+		RepoCacheDir: dir,
+	}
+
+	fetch := func(ttl int) string {
+		res, err := c.GetRevalidated("/repos/octocat/hello", apiPayloadVersion, ttl)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 200, res.StatusCode)
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return string(body)
+	}
+
+	assert.Equal(t, `{"name":"hello"}`, fetch(60))
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, `{"name":"hello"}`, fetch(60))
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, `{"name":"hello"}`, fetch(-1))
+	assert.Equal(t, 2, requests)
+}
+
+func TestSimpleClient_RepoCacheInvalidation(t *testing.T) {
+	s := setupTestServer("")
+	defer s.Close()
+
+	requests := 0
+	s.HandleFunc("/repos/octocat/hello", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			requests++
+		}
+		w.Write([]byte(`{"name":"hello"}`))
+	})
+	s.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{}}`))
+	})
+
+	dir, _ := ioutil.TempDir("", "hub-cache")
+	defer os.RemoveAll(dir)
+
+	c := &simpleClient{
+		httpClient:   newHTTPClient("", false, ""),
+		rootURL:      s.URL,
+		RepoCacheDir: dir,
+	}
+
+	fetch := func() {
+		res, err := c.GetRevalidated("/repos/octocat/hello", apiPayloadVersion, 60)
+		assert.Equal(t, nil, err)
+		res.Body.Close()
+	}
+
+	fetch()
+	assert.Equal(t, 1, requests)
+
+	res, err := c.PostJSON("graphql", map[string]interface{}{"query": "{ viewer { login } }"})
+	assert.Equal(t, nil, err)
+	res.Body.Close()
+	fetch()
+	assert.Equal(t, 1, requests)
+
+	res, err = c.PatchJSON("/repos/octocat/hello", map[string]interface{}{"name": "hello"})
+	assert.Equal(t, nil, err)
+	res.Body.Close()
+	fetch()
+	assert.Equal(t, 2, requests)
+}
@@ -3,16 +3,34 @@ package github
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/github/hub/v2/git"
 )
 
+var (
+	// RemoteOverride is the name of the git remote given by the global
+	// `--remote` flag. It takes precedence over `hub.defaultRemote`.
+	RemoteOverride string
+	// RepoOverride is the "[HOST/]OWNER/NAME" value of the global `--repo`
+	// flag. It takes precedence over any git remote, and lets commands that
+	// only talk to the API run outside of a git repository.
+	RepoOverride string
+)
+
 func LocalRepo() (repo *GitHubRepo, err error) {
 	repo = &GitHubRepo{}
 
 	_, err = git.Dir()
 	if err != nil {
+		if RepoOverride != "" {
+			// API-backed commands can run outside a git repository as long as
+			// the repository to operate on was given explicitly
+			repo.remotes = []Remote{}
+			err = nil
+			return
+		}
 		err = fmt.Errorf("fatal: Not a git repository")
 		return
 	}
@@ -86,8 +104,13 @@ func (r *GitHubRepo) remotesForPublish(owner string) (remotes []Remote) {
 	}
 
 	// anything other than names has higher priority
-	for _, remote := range remotesMap {
-		remotes = append([]Remote{remote}, remotes...)
+	others := []string{}
+	for name := range remotesMap {
+		others = append(others, name)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(others)))
+	for _, name := range others {
+		remotes = append([]Remote{remotesMap[name]}, remotes...)
 	}
 
 	return
@@ -119,11 +142,29 @@ func (r *GitHubRepo) DefaultBranch(remote *Remote) *Branch {
 	if remote != nil {
 		if name, err := git.SymbolicRef(fmt.Sprintf("refs/remotes/%s/HEAD", remote.Name)); err == nil {
 			b.Name = name
+			return &b
+		}
+	}
+	// bare clones have no remote-tracking refs, but their HEAD points to
+	// the default branch of the repository they were cloned from
+	if git.IsBareRepository() {
+		if name, err := git.Head(); err == nil {
+			b.Name = name
 		}
 	}
 	return &b
 }
 
+// CurrentCommit returns the SHA of HEAD. Unlike CurrentBranch, it works in
+// detached HEAD state, which is common in CI environments.
+func (r *GitHubRepo) CurrentCommit() (string, error) {
+	sha, err := git.Ref("HEAD")
+	if err != nil {
+		return "", fmt.Errorf("Aborted: could not determine the current commit")
+	}
+	return sha, nil
+}
+
 func (r *GitHubRepo) RemoteBranchAndProject(owner string, preferUpstream bool) (branch *Branch, project *Project, err error) {
 	if err = r.loadRemotes(); err != nil {
 		return
@@ -236,7 +277,40 @@ func (r *GitHubRepo) RemoteForProject(project *Project) (*Remote, error) {
 	return nil, fmt.Errorf("could not find a git remote for '%s'", project)
 }
 
+// preferredRemote returns the remote chosen with `--remote` or
+// `hub.defaultRemote`, or nil if there is no such preference.
+func (r *GitHubRepo) preferredRemote() (*Remote, error) {
+	name := RemoteOverride
+	if name == "" {
+		name, _ = git.Config("hub.defaultRemote")
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return r.RemoteByName(name)
+}
+
+func (r *GitHubRepo) hasPreference() bool {
+	if RepoOverride != "" || RemoteOverride != "" {
+		return true
+	}
+	name, _ := git.Config("hub.defaultRemote")
+	return name != ""
+}
+
+// MainRemote returns the remote for MainProject, falling back to the first
+// remote in lookup order.
 func (r *GitHubRepo) MainRemote() (*Remote, error) {
+	if RepoOverride != "" {
+		if project, err := projectFromOverride(RepoOverride); err == nil {
+			if remote, err := r.RemoteForProject(project); err == nil {
+				return remote, nil
+			}
+		}
+	} else if remote, err := r.preferredRemote(); remote != nil || err != nil {
+		return remote, err
+	}
+
 	r.loadRemotes()
 
 	if len(r.remotes) > 0 {
@@ -245,7 +319,27 @@ func (r *GitHubRepo) MainRemote() (*Remote, error) {
 	return nil, fmt.Errorf("no git remotes found")
 }
 
+// MainProject returns the GitHub repository that commands operate on. It is,
+// in order of precedence: the `--repo` flag; the remote named by the
+// `--remote` flag or the `hub.defaultRemote` config; the first remote pointing
+// to GitHub among "upstream", "github", "origin", and then the rest of the
+// remotes sorted by name.
 func (r *GitHubRepo) MainProject() (*Project, error) {
+	if RepoOverride != "" {
+		return projectFromOverride(RepoOverride)
+	}
+
+	remote, err := r.preferredRemote()
+	if err != nil {
+		return nil, err
+	} else if remote != nil {
+		project, err := remote.Project()
+		if err != nil {
+			return nil, fmt.Errorf("Aborted: git remote '%s' doesn't point to a GitHub repository", remote.Name)
+		}
+		return project, nil
+	}
+
 	r.loadRemotes()
 
 	for _, remote := range r.remotes {
@@ -256,7 +350,27 @@ func (r *GitHubRepo) MainProject() (*Project, error) {
 	return nil, fmt.Errorf("Aborted: could not find any git remote pointing to a GitHub repository")
 }
 
+func projectFromOverride(value string) (*Project, error) {
+	parts := strings.Split(value, "/")
+	for _, part := range parts {
+		if part == "" {
+			parts = nil
+		}
+	}
+	switch len(parts) {
+	case 2:
+		return NewProject(parts[0], parts[1], ""), nil
+	case 3:
+		return NewProject(parts[1], parts[2], parts[0]), nil
+	}
+	return nil, fmt.Errorf("invalid repository %q; expected OWNER/NAME or HOST/OWNER/NAME", value)
+}
+
 func (r *GitHubRepo) CurrentProject() (project *Project, err error) {
+	if r.hasPreference() {
+		return r.MainProject()
+	}
+
 	project, err = r.UpstreamProject()
 	if err != nil {
 		project, err = r.MainProject()
@@ -2,8 +2,11 @@ package github
 
 import (
 	"net/url"
+	"os"
 	"testing"
 
+	"github.com/github/hub/v2/fixtures"
+	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/internal/assert"
 )
 
@@ -22,3 +25,82 @@ func TestGitHubRepo_remotesForPublish(t *testing.T) {
 	assert.Equal(t, "Owner", remotesForPublish[0].Name)
 	assert.Equal(t, url.String(), remotesForPublish[0].URL.String())
 }
+
+func TestGitHubRepo_MainProject(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	repo.AddRemote("upstream", "https://github.com/hub/hub.git", "")
+	repo.AddRemote("mine", "https://github.com/mislav/hub.git", "")
+
+	localRepo, _ := LocalRepo()
+	project, err := localRepo.MainProject()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hub/hub", project.String())
+
+	RemoteOverride = "mine"
+	defer func() { RemoteOverride = "" }()
+	project, err = localRepo.MainProject()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "mislav/hub", project.String())
+
+	RepoOverride = "github.example.com/octocat/hello"
+	defer func() { RepoOverride = "" }()
+	project, err = localRepo.MainProject()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "octocat/hello", project.String())
+	assert.Equal(t, "github.example.com", project.Host)
+
+	RepoOverride = "octocat"
+	_, err = localRepo.MainProject()
+	assert.Equal(t, `invalid repository "octocat"; expected OWNER/NAME or HOST/OWNER/NAME`, err.Error())
+}
+
+func TestGitHubRepo_MainProject_DefaultRemote(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	repo.AddRemote("upstream", "https://github.com/hub/hub.git", "")
+	repo.AddRemote("mirror", "https://example.com/hub/hub.git", "")
+	assert.Equal(t, nil, git.Run("config", "hub.defaultRemote", "mirror"))
+
+	localRepo, _ := LocalRepo()
+	_, err := localRepo.MainProject()
+	assert.Equal(t, "Aborted: git remote 'mirror' doesn't point to a GitHub repository", err.Error())
+
+	assert.Equal(t, nil, git.Run("config", "hub.defaultRemote", "upstream"))
+	project, err := localRepo.MainProject()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hub/hub", project.String())
+}
+
+func TestGitHubRepo_DetachedHead(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	sha, err := git.Ref("HEAD")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, git.Run("checkout", "-q", sha))
+
+	localRepo, _ := LocalRepo()
+	_, err = localRepo.CurrentBranch()
+	assert.NotEqual(t, nil, err)
+
+	commit, err := localRepo.CurrentCommit()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, sha, commit)
+	assert.Equal(t, "refs/heads/master", localRepo.MasterBranch().Name)
+}
+
+func TestGitHubRepo_BareDefaultBranch(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	assert.Equal(t, nil, git.Run("branch", "-q", "trunk"))
+	assert.Equal(t, nil, git.Run("clone", "-q", "--bare", ".", "../bare.git"))
+	assert.Equal(t, nil, os.Chdir("../bare.git"))
+	assert.Equal(t, nil, git.Run("symbolic-ref", "HEAD", "refs/heads/trunk"))
+
+	localRepo, _ := LocalRepo()
+	assert.Equal(t, "refs/heads/trunk", localRepo.DefaultBranch(nil).Name)
+}
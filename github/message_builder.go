@@ -1,6 +1,7 @@
 package github
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -10,6 +11,7 @@ type MessageBuilder struct {
 	Filename          string
 	Message           string
 	Edit              bool
+	Recover           bool
 	commentedSections []string
 	editor            *Editor
 }
@@ -19,9 +21,45 @@ func (b *MessageBuilder) AddCommentedSection(section string) {
 }
 
 func (b *MessageBuilder) Extract() (title, body string, err error) {
-	content := b.Message
+	content, err := b.content()
+	if err != nil {
+		return
+	}
+
+	title, body = SplitTitleBody(content)
+	if title == "" {
+		defer b.Cleanup()
+	}
+
+	return
+}
+
+// ExtractBody is like Extract, but treats the whole message as the body for
+// texts that have no title, such as comments.
+func (b *MessageBuilder) ExtractBody() (body string, err error) {
+	content, err := b.content()
+	if err != nil {
+		return
+	}
+
+	body = strings.TrimSpace(content)
+	if body == "" {
+		defer b.Cleanup()
+	}
+
+	return
+}
+
+func (b *MessageBuilder) content() (content string, err error) {
+	content = b.Message
 
-	if b.Edit {
+	if b.Recover {
+		b.editor, err = NewEditor(b.Filename, b.Title, "")
+		if err != nil {
+			return
+		}
+		content, err = b.editor.SavedContent()
+	} else if b.Edit {
 		b.editor, err = NewEditor(b.Filename, b.Title, content)
 		if err != nil {
 			return
@@ -30,22 +68,24 @@ func (b *MessageBuilder) Extract() (title, body string, err error) {
 			b.editor.AddCommentedSection(section)
 		}
 		content, err = b.editor.EditContent()
-		if err != nil {
-			return
-		}
 	} else {
 		nl := regexp.MustCompile(`\r?\n`)
 		content = nl.ReplaceAllString(content, "\n")
 	}
 
-	title, body = SplitTitleBody(content)
-	if title == "" {
-		defer b.Cleanup()
-	}
-
 	return
 }
 
+// RecoverableError annotates an error that occurred after the message was
+// composed in a text editor with instructions for reusing the message, which
+// is kept until Cleanup is called.
+func (b *MessageBuilder) RecoverableError(err error) error {
+	if err == nil || b.editor == nil || !b.editor.isFileExist() {
+		return err
+	}
+	return fmt.Errorf("%s\nThe %s message was saved to %s; use --recover to submit it again.", err, b.Title, b.editor.File)
+}
+
 func (b *MessageBuilder) Cleanup() {
 	if b.editor != nil {
 		b.editor.DeleteFile()
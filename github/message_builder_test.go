@@ -1,6 +1,7 @@
 package github
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/github/hub/v2/internal/assert"
@@ -21,3 +22,20 @@ description`,
 	assert.Equal(t, "hello multiline text", title)
 	assert.Equal(t, "the rest is\ndescription", body)
 }
+
+func TestMessageBuilder_ExtractBody(t *testing.T) {
+	builder := &MessageBuilder{
+		Message: "first line\r\nsecond line\r\n\r\nanother paragraph\n",
+	}
+
+	body, err := builder.ExtractBody()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "first line\nsecond line\n\nanother paragraph", body)
+}
+
+func TestMessageBuilder_RecoverableError(t *testing.T) {
+	builder := &MessageBuilder{Title: "issue"}
+
+	assert.Equal(t, nil, builder.RecoverableError(nil))
+	assert.Equal(t, "HTTP 502", builder.RecoverableError(fmt.Errorf("HTTP 502")).Error())
+}
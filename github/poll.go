@@ -0,0 +1,242 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// A poller repeatedly fetches the same API resources while waiting for them
+// to change. Every request after the first one is conditional, so resources
+// that haven't changed come back as "304 Not Modified", which doesn't count
+// against the rate limit.
+type poller struct {
+	api *simpleClient
+
+	// interval is the minimum time to wait between polls. The server may ask
+	// for a longer one via the X-Poll-Interval header.
+	interval time.Duration
+	wait     time.Duration
+
+	seen map[string]*polledResource
+}
+
+type polledResource struct {
+	StatusCode int
+	ETag       string
+	Body       []byte
+
+	// Changed is false if the resource is the same as on the previous poll.
+	Changed bool
+}
+
+func newPoller(api *simpleClient, interval time.Duration) *poller {
+	return &poller{
+		api:      api,
+		interval: interval,
+		wait:     interval,
+		seen:     map[string]*polledResource{},
+	}
+}
+
+func (p *poller) get(path, mimeType string) (*polledResource, error) {
+	previous := p.seen[path]
+	res, err := p.api.performRequest("GET", path, nil, func(req *http.Request) {
+		req.Header.Set("Accept", mimeType)
+		if previous != nil && previous.ETag != "" {
+			req.Header.Set("If-None-Match", previous.ETag)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if seconds, err := strconv.Atoi(res.Header.Get("X-Poll-Interval")); err == nil {
+		if wait := time.Duration(seconds) * time.Second; wait > p.interval {
+			p.wait = wait
+		}
+	}
+
+	if res.StatusCode == 304 && previous != nil {
+		previous.Changed = false
+		return previous, nil
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resource := &polledResource{
+		StatusCode: res.StatusCode,
+		ETag:       res.Header.Get("ETag"),
+		Body:       body,
+		Changed:    true,
+	}
+	p.seen[path] = resource
+	return resource, nil
+}
+
+func (p *poller) sleep() {
+	time.Sleep(p.wait)
+}
+
+// WaitForCIStatus polls the combined status and check runs of a commit every
+// interval until done returns true, and returns the last status seen.
+func (client *Client) WaitForCIStatus(project *Project, sha string, interval time.Duration, done func(*CIStatusResponse) bool) (status *CIStatusResponse, err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	p := newPoller(api, interval)
+	statusPath := fmt.Sprintf("repos/%s/%s/commits/%s/status", project.Owner, project.Name, sha)
+	checksPath := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", project.Owner, project.Name, sha)
+
+	for {
+		var statusRes, checksRes *polledResource
+		if statusRes, err = p.get(statusPath, apiPayloadVersion); err != nil {
+			return
+		}
+		if statusRes.StatusCode != 200 {
+			err = fmt.Errorf("Error fetching statuses: %s (HTTP %d)", http.StatusText(statusRes.StatusCode), statusRes.StatusCode)
+			return
+		}
+		checksRes = &polledResource{}
+		if client.supports(featureCheckRuns) {
+			if checksRes, err = p.get(checksPath, checksType); err != nil {
+				return
+			}
+		}
+
+		if status == nil || statusRes.Changed || checksRes.Changed {
+			status = &CIStatusResponse{}
+			if err = json.Unmarshal(statusRes.Body, status); err != nil {
+				return
+			}
+			if checksRes.StatusCode == 200 {
+				checks := &CheckRunsResponse{}
+				if err = json.Unmarshal(checksRes.Body, checks); err != nil {
+					return
+				}
+				status.addCheckRuns(checks)
+			}
+			status.sortStatuses()
+
+			if done(status) {
+				return
+			}
+		}
+
+		p.sleep()
+	}
+}
+
+// WatchReleases polls the releases of a project every interval and calls each
+// for every release published after the first poll, oldest first. Drafts are
+// skipped. Polling stops once each returns false.
+func (client *Client) WatchReleases(project *Project, interval time.Duration, each func(*Release) bool) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	p := newPoller(api, interval)
+	path := fmt.Sprintf("repos/%s/%s/releases?per_page=30", project.Owner, project.Name)
+	var seen map[string]bool
+
+	for {
+		var res *polledResource
+		if res, err = p.get(path, apiPayloadVersion); err != nil {
+			return
+		}
+		if res.StatusCode != 200 {
+			err = fmt.Errorf("Error fetching releases: %s (HTTP %d)", http.StatusText(res.StatusCode), res.StatusCode)
+			return
+		}
+
+		if res.Changed {
+			releases := []Release{}
+			if err = json.Unmarshal(res.Body, &releases); err != nil {
+				return
+			}
+
+			if seen == nil {
+				seen = map[string]bool{}
+				for _, release := range releases {
+					seen[release.TagName] = !release.Draft
+				}
+			} else {
+				for i := len(releases) - 1; i >= 0; i-- {
+					release := releases[i]
+					if release.Draft || seen[release.TagName] {
+						continue
+					}
+					seen[release.TagName] = true
+					if !each(&release) {
+						return
+					}
+				}
+			}
+		}
+
+		p.sleep()
+	}
+}
+
+// WaitForWorkflowRun polls a workflow run and its jobs every interval until
+// done returns true. Polling also stops once the run is completed.
+func (client *Client) WaitForWorkflowRun(project *Project, runID int64, interval time.Duration, done func(*WorkflowRun, []WorkflowJob) bool) (err error) {
+	api, err := client.simpleAPI()
+	if err != nil {
+		return
+	}
+
+	p := newPoller(api, interval)
+	runPath := fmt.Sprintf("repos/%s/%s/actions/runs/%d", project.Owner, project.Name, runID)
+	jobsPath := fmt.Sprintf("repos/%s/%s/actions/runs/%d/jobs?per_page=100", project.Owner, project.Name, runID)
+	first := true
+
+	for {
+		var runRes, jobsRes *polledResource
+		if runRes, err = p.get(runPath, apiPayloadVersion); err != nil {
+			return
+		}
+		if runRes.StatusCode != 200 {
+			err = fmt.Errorf("Error fetching workflow run: %s (HTTP %d)", http.StatusText(runRes.StatusCode), runRes.StatusCode)
+			return
+		}
+		if jobsRes, err = p.get(jobsPath, apiPayloadVersion); err != nil {
+			return
+		}
+		if jobsRes.StatusCode != 200 {
+			err = fmt.Errorf("Error fetching workflow jobs: %s (HTTP %d)", http.StatusText(jobsRes.StatusCode), jobsRes.StatusCode)
+			return
+		}
+
+		run := &WorkflowRun{}
+		if err = json.Unmarshal(runRes.Body, run); err != nil {
+			return
+		}
+		if first || runRes.Changed || jobsRes.Changed {
+			first = false
+			jobs := struct {
+				Jobs []WorkflowJob `json:"jobs"`
+			}{}
+			if err = json.Unmarshal(jobsRes.Body, &jobs); err != nil {
+				return
+			}
+			if done(run, jobs.Jobs) {
+				return
+			}
+		}
+		if run.Status == "completed" {
+			return
+		}
+
+		p.sleep()
+	}
+}
@@ -0,0 +1,126 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestPoller_Get(t *testing.T) {
+	s := setupTestServer("")
+	defer s.Close()
+
+	s.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Poll-Interval", "60")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(304)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"state":"pending"}`))
+	})
+
+	p := newPoller(&simpleClient{
+		httpClient: newHTTPClient("", false, ""),
+		rootURL:    s.URL,
+	}, time.Second)
+
+	res, err := p.get("/status", apiPayloadVersion)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 200, res.StatusCode)
+	assert.T(t, res.Changed)
+	assert.Equal(t, `{"state":"pending"}`, string(res.Body))
+	assert.Equal(t, time.Minute, p.wait)
+
+	res, err = p.get("/status", apiPayloadVersion)
+	assert.Equal(t, nil, err)
+	assert.T(t, !res.Changed)
+	assert.Equal(t, `{"state":"pending"}`, string(res.Body))
+}
+
+func TestClient_WatchReleases(t *testing.T) {
+	s := setupTestServer("")
+	defer s.Close()
+
+	polls := 0
+	s.HandleFunc("/repos/github/hub/releases", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch polls {
+		case 1:
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(`[{"tag_name":"v1.0"},{"tag_name":"v0.9"}]`))
+		case 2:
+			assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+			w.WriteHeader(304)
+		case 3:
+			w.Header().Set("ETag", `"v2"`)
+			w.Write([]byte(`[{"tag_name":"v1.2"},{"tag_name":"v1.1"},{"tag_name":"v1.1-rc","draft":true},{"tag_name":"v1.0"}]`))
+		}
+	})
+
+	client := &Client{
+		Host: &Host{Host: s.URL.Host, AccessToken: "OTOKEN"},
+		cachedClient: &simpleClient{
+			httpClient: newHTTPClient("", false, ""),
+			rootURL:    s.URL,
+		},
+	}
+	tags := []string{}
+	err := client.WatchReleases(&Project{Owner: "github", Name: "hub"}, time.Millisecond, func(release *Release) bool {
+		tags = append(tags, release.TagName)
+		return len(tags) < 2
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"v1.1", "v1.2"}, tags)
+	assert.Equal(t, 3, polls)
+}
+
+func TestClient_WaitForWorkflowRun(t *testing.T) {
+	s := setupTestServer("")
+	defer s.Close()
+
+	runPolls := 0
+	s.HandleFunc("/repos/github/hub/actions/runs/42", func(w http.ResponseWriter, r *http.Request) {
+		runPolls++
+		if runPolls < 3 {
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(304)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(`{"id":42,"status":"in_progress"}`))
+		} else {
+			w.Write([]byte(`{"id":42,"status":"completed","conclusion":"success"}`))
+		}
+	})
+	s.HandleFunc("/repos/github/hub/actions/runs/42/jobs", func(w http.ResponseWriter, r *http.Request) {
+		if runPolls < 3 {
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(304)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(`{"jobs":[{"id":1,"name":"test","status":"in_progress"}]}`))
+		} else {
+			w.Write([]byte(`{"jobs":[{"id":1,"name":"test","status":"completed","conclusion":"success"}]}`))
+		}
+	})
+
+	client := &Client{
+		Host: &Host{Host: s.URL.Host, AccessToken: "OTOKEN"},
+		cachedClient: &simpleClient{
+			httpClient: newHTTPClient("", false, ""),
+			rootURL:    s.URL,
+		},
+	}
+	statuses := []string{}
+	err := client.WaitForWorkflowRun(&Project{Owner: "github", Name: "hub"}, 42, time.Millisecond, func(run *WorkflowRun, jobs []WorkflowJob) bool {
+		statuses = append(statuses, run.Status+"/"+jobs[0].Status)
+		return false
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"in_progress/in_progress", "completed/completed"}, statuses)
+	assert.Equal(t, 3, runPolls)
+}
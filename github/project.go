@@ -75,7 +75,7 @@ func (p *Project) GitURL(name, owner string, isSSH bool) (url string) {
 	if preferredProtocol() == "https" {
 		url = fmt.Sprintf("https://%s/%s/%s.git", host, owner, name)
 	} else if isSSH || preferredProtocol() == "ssh" {
-		url = fmt.Sprintf("git@%s:%s/%s.git", host, owner, name)
+		url = sshURL(host, owner, name)
 	} else {
 		url = fmt.Sprintf("git://%s/%s/%s.git", host, owner, name)
 	}
@@ -83,6 +83,20 @@ func (p *Project) GitURL(name, owner string, isSSH bool) (url string) {
 	return url
 }
 
+// sshURL builds an ssh URL for the repository. The `hub.<HOST>.sshHost` git
+// config can point it at a Host alias from ~/.ssh/config or at a "HOST:PORT"
+// pair such as "ssh.github.com:443" for networks that block port 22.
+func sshURL(host, owner, name string) string {
+	if sshHost, err := git.Config(fmt.Sprintf("hub.%s.sshHost", host)); err == nil && sshHost != "" {
+		host = sshHost
+	}
+
+	if strings.Contains(host, ":") {
+		return fmt.Sprintf("ssh://git@%s/%s/%s.git", host, owner, name)
+	}
+	return fmt.Sprintf("git@%s:%s/%s.git", host, owner, name)
+}
+
 // Remove the scheme from host when the host url is absolute.
 func rawHost(host string) string {
 	u, err := url.Parse(host)
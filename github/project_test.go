@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/github/hub/v2/fixtures"
+	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/internal/assert"
 )
 
@@ -130,6 +131,32 @@ func TestProject_GitURLEnterprise(t *testing.T) {
 	assert.Equal(t, "git@github.corporate.com:jingweno/gh.git", url)
 }
 
+func TestProject_GitURLSSHHost(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	defer os.Setenv("HUB_PROTOCOL", "")
+	os.Setenv("HUB_PROTOCOL", "ssh")
+
+	project := Project{
+		Name:  "foo",
+		Owner: "bar",
+		Host:  "github.com",
+	}
+
+	assert.Equal(t, nil, git.Run("config", "hub.github.com.sshHost", "ssh.github.com:443"))
+	url := project.GitURL("gh", "jingweno", true)
+	assert.Equal(t, "ssh://git@ssh.github.com:443/jingweno/gh.git", url)
+
+	assert.Equal(t, nil, git.Run("config", "hub.github.com.sshHost", "github-work"))
+	url = project.GitURL("gh", "jingweno", true)
+	assert.Equal(t, "git@github-work:jingweno/gh.git", url)
+
+	os.Setenv("HUB_PROTOCOL", "https")
+	url = project.GitURL("gh", "jingweno", true)
+	assert.Equal(t, "https://github.com/jingweno/gh.git", url)
+}
+
 func TestProject_NewProjectFromURL(t *testing.T) {
 	testConfigs := fixtures.SetupTestConfigs()
 	defer testConfigs.TearDown()
@@ -0,0 +1,132 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// IssueReference identifies an issue or pull request mentioned in a message.
+type IssueReference struct {
+	Owner  string
+	Name   string
+	Number int
+}
+
+func (r IssueReference) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Name, r.Number)
+}
+
+// Project returns the repository that the referenced issue belongs to.
+func (r IssueReference) Project(host string) *Project {
+	return NewProject(r.Owner, r.Name, host)
+}
+
+// Shorthand formats the reference the way it should appear in a message
+// submitted to project: "#N" within the same repository, "owner/repo#N"
+// otherwise.
+func (r IssueReference) Shorthand(project *Project) string {
+	if strings.EqualFold(r.Owner, project.Owner) && strings.EqualFold(r.Name, project.Name) {
+		return fmt.Sprintf("#%d", r.Number)
+	}
+	return r.String()
+}
+
+var (
+	referenceRegexp = regexp.MustCompile(`(?:^|[^\w/.&#-])(?:(https?)://([^/\s]+)/([\w.-]+)/([\w.-]+)/(?:issues|pull)/(\d+)|([\w.-]+)/([\w.-]+)#(\d+)|GH-(\d+)|#(\d+))`)
+	codeFenceRegexp = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// ExpandReferences finds references to issues and pull requests in a message
+// that will be submitted to project and rewrites them consistently: "GH-12"
+// becomes "#12", "owner/repo#34" and issue or pull request URLs for the same
+// host become "#34" if they point to project and "owner/repo#34" otherwise.
+// Code blocks and inline code are left alone. The second return value lists
+// every distinct reference found in the order of first appearance.
+func ExpandReferences(text string, project *Project) (string, []IssueReference) {
+	refs := []IssueReference{}
+	seen := map[string]bool{}
+	inFence := false
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if codeFenceRegexp.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		parts := strings.Split(line, "`")
+		for j := 0; j < len(parts); j += 2 {
+			parts[j] = expandReferencesInText(parts[j], project, func(ref IssueReference) {
+				key := strings.ToLower(ref.String())
+				if !seen[key] {
+					seen[key] = true
+					refs = append(refs, ref)
+				}
+			})
+		}
+		lines[i] = strings.Join(parts, "`")
+	}
+
+	return strings.Join(lines, "\n"), refs
+}
+
+func expandReferencesInText(text string, project *Project, found func(IssueReference)) string {
+	var out strings.Builder
+	last := 0
+
+	for _, m := range referenceRegexp.FindAllStringSubmatchIndex(text, -1) {
+		end := m[1]
+		if end < len(text) && !isReferenceBoundary(text[end]) {
+			continue
+		}
+
+		group := func(n int) string {
+			if m[2*n] < 0 {
+				return ""
+			}
+			return text[m[2*n]:m[2*n+1]]
+		}
+
+		var ref IssueReference
+		var start int
+		switch {
+		case group(2) != "":
+			if !strings.EqualFold(group(2), project.Host) {
+				continue
+			}
+			ref = IssueReference{Owner: group(3), Name: group(4)}
+			ref.Number, _ = strconv.Atoi(group(5))
+			start = m[2]
+		case group(8) != "":
+			ref = IssueReference{Owner: group(6), Name: group(7)}
+			ref.Number, _ = strconv.Atoi(group(8))
+			start = m[12]
+		case group(9) != "":
+			ref = IssueReference{Owner: project.Owner, Name: project.Name}
+			ref.Number, _ = strconv.Atoi(group(9))
+			start = m[18] - len("GH-")
+		default:
+			ref = IssueReference{Owner: project.Owner, Name: project.Name}
+			ref.Number, _ = strconv.Atoi(group(10))
+			start = m[20] - len("#")
+		}
+
+		found(ref)
+		out.WriteString(text[last:start])
+		out.WriteString(ref.Shorthand(project))
+		last = end
+	}
+
+	out.WriteString(text[last:])
+	return out.String()
+}
+
+func isReferenceBoundary(c byte) bool {
+	return !(c == '_' || c == '/' || c == '#' || c == '?' || c == '-' ||
+		c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z')
+}
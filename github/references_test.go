@@ -0,0 +1,46 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/github/hub/v2/internal/assert"
+)
+
+func TestExpandReferences(t *testing.T) {
+	project := NewProject("github", "hub", "github.com")
+
+	text, refs := ExpandReferences("Fixes #12, GH-13 and github/hub#14", project)
+	assert.Equal(t, "Fixes #12, #13 and #14", text)
+	assert.Equal(t, []IssueReference{
+		{Owner: "github", Name: "hub", Number: 12},
+		{Owner: "github", Name: "hub", Number: 13},
+		{Owner: "github", Name: "hub", Number: 14},
+	}, refs)
+
+	text, refs = ExpandReferences("See mislav/coral#3 and https://github.com/mislav/coral/pull/3.", project)
+	assert.Equal(t, "See mislav/coral#3 and mislav/coral#3.", text)
+	assert.Equal(t, []IssueReference{{Owner: "mislav", Name: "coral", Number: 3}}, refs)
+
+	text, refs = ExpandReferences("Closes https://github.com/github/hub/issues/5", project)
+	assert.Equal(t, "Closes #5", text)
+	assert.Equal(t, 1, len(refs))
+}
+
+func TestExpandReferences_Ignored(t *testing.T) {
+	project := NewProject("github", "hub", "github.com")
+
+	for _, text := range []string{
+		"https://github.com/github/hub/pull/12/files",
+		"https://github.com/github/hub/issues/12#issuecomment-1",
+		"https://example.com/github/hub/issues/12",
+		"https://github.com/github/hub#12",
+		"Use `#12` for that",
+		"foo#12 and &#123;",
+		"#12a",
+		"```\n#12\n```",
+	} {
+		expanded, refs := ExpandReferences(text, project)
+		assert.Equal(t, text, expanded)
+		assert.Equal(t, 0, len(refs))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/github/hub/v2/git"
@@ -69,9 +70,14 @@ func Remotes() (remotes []Remote, err error) {
 		}
 	}
 
-	// the rest of the remotes
-	for n, u := range remotesMap {
-		r, err := newRemote(n, u)
+	// the rest of the remotes, sorted by name
+	rest := []string{}
+	for n := range remotesMap {
+		rest = append(rest, n)
+	}
+	sort.Strings(rest)
+	for _, n := range rest {
+		r, err := newRemote(n, remotesMap[n])
 		if err == nil {
 			remotes = append(remotes, r)
 		}
@@ -83,8 +89,8 @@ func Remotes() (remotes []Remote, err error) {
 func newRemote(name string, urlMap map[string]string) (Remote, error) {
 	r := Remote{}
 
-	fetchURL, ferr := git.ParseURL(urlMap["fetch"])
-	pushURL, perr := git.ParseURL(urlMap["push"])
+	fetchURL, ferr := parseRemoteURL(urlMap["fetch"], false)
+	pushURL, perr := parseRemoteURL(urlMap["push"], true)
 	if ferr != nil && perr != nil {
 		return r, fmt.Errorf("No valid remote URLs")
 	}
@@ -99,3 +105,27 @@ func newRemote(name string, urlMap map[string]string) (Remote, error) {
 
 	return r, nil
 }
+
+// parseRemoteURL parses a remote URL as reported by git, which has already
+// applied `url.<base>.insteadOf` rules. If the rewritten URL doesn't point to a
+// known GitHub host, the rules are reversed to find the original URL.
+func parseRemoteURL(rawURL string, push bool) (*url.URL, error) {
+	u, err := git.ParseURL(rawURL)
+	if err != nil || knownGitHubHostsInclude(u.Host) {
+		return u, err
+	}
+
+	rewrites, _ := git.URLRewrites()
+	for _, rewrite := range rewrites {
+		if rewrite.Push && !push {
+			continue
+		}
+		if original, ok := rewrite.Unrewrite(rawURL); ok {
+			if ou, err := git.ParseURL(original); err == nil && knownGitHubHostsInclude(ou.Host) {
+				return ou, nil
+			}
+		}
+	}
+
+	return u, err
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/github/hub/v2/fixtures"
+	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/internal/assert"
 )
 
@@ -78,3 +79,41 @@ func TestGithubRemote_ColonSlash(t *testing.T) {
 	assert.Equal(t, remotes[1].Name, "origin")
 	assert.Equal(t, remotes[1].URL.Path, repo.Remote)
 }
+
+func TestGithubRemote_InsteadOf(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	assert.Equal(t, nil, git.Run("config", "url.https://mirror.example.com/github/.insteadOf", "https://github.com/"))
+	repo.AddRemote("upstream", "https://github.com/hub/hub.git", "")
+	repo.AddRemote("mirror", "https://mirror.example.com/hub/hub.git", "")
+
+	remotes, err := Remotes()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(remotes))
+	assert.Equal(t, "upstream", remotes[0].Name)
+	assert.Equal(t, "github.com", remotes[0].URL.Host)
+	assert.Equal(t, "/hub/hub.git", remotes[0].URL.Path)
+
+	project, err := remotes[0].Project()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "hub", project.Owner)
+	assert.Equal(t, "github.com", project.Host)
+}
+
+func TestGithubRemote_SortedByName(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	for _, name := range []string{"zeta", "alpha", "upstream", "mirror"} {
+		repo.AddRemote(name, "https://github.com/hub/"+name+".git", "")
+	}
+
+	remotes, err := Remotes()
+	assert.Equal(t, nil, err)
+	names := []string{}
+	for _, remote := range remotes {
+		names = append(names, remote.Name)
+	}
+	assert.Equal(t, []string{"upstream", "origin", "alpha", "mirror", "zeta"}, names)
+}
@@ -0,0 +1,31 @@
+package github
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/github/hub/v2/git"
+)
+
+// How long, in seconds, lookups about a repository are reused before they are
+// revalidated with the API.
+const (
+	repositoryCacheTTL           = 120
+	pullRequestForBranchCacheTTL = 30
+)
+
+// repoCacheDir is where lookups about repositories are cached for the current
+// git repository. It is blank outside of a git repository or if the
+// "hub.metadataCache" git config is false.
+func repoCacheDir() string {
+	switch enabled, _ := git.Config("hub.metadataCache"); strings.ToLower(enabled) {
+	case "false", "no", "off", "0":
+		return ""
+	}
+
+	dir, err := git.Dir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "hub-cache")
+}
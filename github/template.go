@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/github/hub/v2/git"
+	"github.com/mitchellh/go-homedir"
 )
 
 const (
@@ -15,6 +18,18 @@ const (
 	docsDir             = "docs"
 )
 
+const (
+	HubPullRequestTemplate = "hub_pull_request_template"
+	HubIssueTemplate       = "hub_issue_template"
+	HubReleaseTemplate     = "hub_release_template"
+)
+
+var editorTemplateConfigs = map[string]string{
+	HubPullRequestTemplate: "hub.pullRequestTemplate",
+	HubIssueTemplate:       "hub.issueTemplate",
+	HubReleaseTemplate:     "hub.releaseTemplate",
+}
+
 func ReadTemplate(kind, workdir string) (body string, err error) {
 	templateDir := filepath.Join(workdir, githubTemplateDir)
 
@@ -33,6 +48,175 @@ func ReadTemplate(kind, workdir string) (body string, err error) {
 	return
 }
 
+// A TemplateChoice is one of several templates kept in a directory such as
+// ".github/ISSUE_TEMPLATE/". Its name, description, default title, and labels
+// come from the YAML front matter of the file, if any.
+type TemplateChoice struct {
+	Name   string
+	About  string
+	Title  string
+	Labels []string
+	Body   string
+}
+
+// ReadTemplateChoices reads the Markdown templates in a directory named after
+// kind, which is looked up in the same places as ReadTemplate. The templates
+// are sorted by file name.
+func ReadTemplateChoices(kind, workdir string) (choices []TemplateChoice, err error) {
+	for _, dir := range []string{filepath.Join(workdir, githubTemplateDir), filepath.Join(workdir, docsDir), workdir} {
+		path := getDirPath(dir, kind)
+		if path == "" {
+			continue
+		}
+
+		var files []os.FileInfo
+		if files, err = ioutil.ReadDir(path); err != nil {
+			return
+		}
+		for _, file := range files {
+			if file.IsDir() || !strings.EqualFold(filepath.Ext(file.Name()), ".md") {
+				continue
+			}
+			var content string
+			if content, err = readContentsFromFile(filepath.Join(path, file.Name())); err != nil {
+				return
+			}
+			choice := parseTemplateFrontMatter(content)
+			if choice.Name == "" {
+				choice.Name = strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+			}
+			choices = append(choices, choice)
+		}
+		if len(choices) > 0 {
+			return
+		}
+	}
+	return
+}
+
+func getDirPath(dir, name string) string {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, file := range files {
+		if file.IsDir() && strings.EqualFold(file.Name(), name) {
+			return filepath.Join(dir, file.Name())
+		}
+	}
+	return ""
+}
+
+// parseTemplateFrontMatter splits a template into the fields of its front
+// matter and its body. Only the simple "key: value" form of YAML is supported,
+// along with lists of labels given inline or one per line.
+func parseTemplateFrontMatter(content string) (choice TemplateChoice) {
+	lines := strings.Split(content, "\n")
+	if len(lines) < 2 || strings.TrimSpace(lines[0]) != "---" {
+		choice.Body = content
+		return
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		choice.Body = content
+		return
+	}
+
+	key := ""
+	for _, line := range lines[1:end] {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "- ") {
+			if key == "labels" {
+				choice.Labels = append(choice.Labels, unquoteYAML(strings.TrimPrefix(trimmed, "- ")))
+			}
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key = strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		switch key {
+		case "name":
+			choice.Name = unquoteYAML(value)
+		case "about":
+			choice.About = unquoteYAML(value)
+		case "title":
+			choice.Title = unquoteYAML(value)
+		case "labels":
+			value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			for _, label := range strings.Split(value, ",") {
+				if label = unquoteYAML(strings.TrimSpace(label)); label != "" {
+					choice.Labels = append(choice.Labels, label)
+				}
+			}
+		}
+	}
+
+	choice.Body = strings.TrimLeft(strings.Join(lines[end+1:], "\n"), "\n")
+	return
+}
+
+func unquoteYAML(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// ReadEditorTemplate finds a template that replaces the message that hub
+// pre-fills the text editor with. The template is read from a HUB_*_TEMPLATE
+// file in the ".github" directory of the repository or, failing that, from the
+// file named by the corresponding "hub.*Template" git config. The second return
+// value is false if neither exists.
+func ReadEditorTemplate(kind, workdir string) (body string, found bool, err error) {
+	if workdir != "" {
+		var path string
+		path, _ = getFilePath(filepath.Join(workdir, githubTemplateDir), kind)
+		if path != "" {
+			body, err = readContentsFromFile(path)
+			return body, err == nil, err
+		}
+	}
+
+	configured, _ := git.Config(editorTemplateConfigs[kind])
+	if configured == "" {
+		return
+	}
+	path, err := homedir.Expand(configured)
+	if err != nil {
+		return
+	}
+	if !filepath.IsAbs(path) && workdir != "" {
+		path = filepath.Join(workdir, path)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	body = strings.TrimSuffix(strings.Replace(string(content), "\r\n", "\n", -1), "\n")
+	return body, true, nil
+}
+
+// ExpandEditorTemplate replaces "{{name}}" variables in an editor template.
+// Unknown variables are left in place.
+func ExpandEditorTemplate(body string, vars map[string]string) string {
+	replacements := []string{}
+	for name, value := range vars {
+		replacements = append(replacements, "{{"+name+"}}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(body)
+}
+
 type sortedFiles []os.FileInfo
 
 func (s sortedFiles) Len() int {
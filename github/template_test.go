@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/github/hub/v2/fixtures"
+	"github.com/github/hub/v2/git"
 	"github.com/github/hub/v2/internal/assert"
 )
 
@@ -155,3 +156,87 @@ func addGithubTemplates(r *fixtures.TestRepo, config map[string]string) {
 	r.AddFile(prTemplatePath, prContent)
 	r.AddFile(issueTemplatePath, issueContent)
 }
+
+func TestReadEditorTemplate(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	pwd, _ := os.Getwd()
+	_, found, err := ReadEditorTemplate(HubPullRequestTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, found)
+
+	repo.AddFile(filepath.Join("test.git", githubTemplateDir, "HUB_PULL_REQUEST_TEMPLATE.md"), "Merging {{branch}}\n")
+	tpl, found, err := ReadEditorTemplate(HubPullRequestTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, "Merging {{branch}}", tpl)
+
+	repo.AddFile("release.md", "Release {{tag}}\r\n")
+	git.SetGlobalConfig("hub.releaseTemplate", filepath.Join(os.Getenv("HOME"), "release.md"))
+	tpl, found, err = ReadEditorTemplate(HubReleaseTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, "Release {{tag}}", tpl)
+
+	git.SetGlobalConfig("hub.issueTemplate", "missing.md")
+	_, _, err = ReadEditorTemplate(HubIssueTemplate, pwd)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestExpandEditorTemplate(t *testing.T) {
+	body := ExpandEditorTemplate("{{tag}} from {{branch}}\n\n{{commits}}\n{{unknown}}", map[string]string{
+		"tag":     "v1.0",
+		"branch":  "main",
+		"commits": "- one\n- two",
+	})
+	assert.Equal(t, "v1.0 from main\n\n- one\n- two\n{{unknown}}", body)
+}
+
+func TestReadTemplateChoices(t *testing.T) {
+	repo := fixtures.SetupTestRepo()
+	defer repo.TearDown()
+
+	pwd, _ := os.Getwd()
+	choices, err := ReadTemplateChoices(IssueTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(choices))
+
+	templateDir := filepath.Join("test.git", githubTemplateDir, "ISSUE_TEMPLATE")
+	repo.AddFile(filepath.Join(templateDir, "bug_report.md"), `---
+name: Bug report
+about: Create a report to help us improve
+title: "[BUG] "
+labels: bug, 'needs triage'
+---
+
+**Describe the bug**
+`)
+	repo.AddFile(filepath.Join(templateDir, "feature.md"), "Describe the feature\n")
+	repo.AddFile(filepath.Join(templateDir, "config.yml"), "blank_issues_enabled: false\n")
+
+	choices, err = ReadTemplateChoices(IssueTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []TemplateChoice{
+		{
+			Name:   "Bug report",
+			About:  "Create a report to help us improve",
+			Title:  "[BUG] ",
+			Labels: []string{"bug", "needs triage"},
+			Body:   "**Describe the bug**",
+		},
+		{Name: "feature", Body: "Describe the feature"},
+	}, choices)
+
+	body, err := ReadTemplate(IssueTemplate, pwd)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", body)
+}
+
+func TestParseTemplateFrontMatter(t *testing.T) {
+	choice := parseTemplateFrontMatter("---\nname: Chore\nlabels:\n  - chore\n  - \"good first issue\"\n---\nBody")
+	assert.Equal(t, TemplateChoice{Name: "Chore", Labels: []string{"chore", "good first issue"}, Body: "Body"}, choice)
+
+	choice = parseTemplateFrontMatter("---\nNot front matter")
+	assert.Equal(t, TemplateChoice{Body: "---\nNot front matter"}, choice)
+}
@@ -0,0 +1,36 @@
+package github
+
+import "strings"
+
+const (
+	OAuthToken           = "oauth"
+	ClassicToken         = "classic"
+	FineGrainedToken     = "fine-grained"
+	AppUserToken         = "app-user"
+	AppInstallationToken = "app-installation"
+)
+
+var tokenPrefixes = []struct {
+	prefix    string
+	tokenType string
+}{
+	{"github_pat_", FineGrainedToken},
+	{"ghp_", ClassicToken},
+	{"gho_", OAuthToken},
+	{"ghu_", AppUserToken},
+	{"ghs_", AppInstallationToken},
+}
+
+// TokenType classifies an access token by its prefix. Tokens that predate
+// prefixes are OAuth tokens.
+func TokenType(token string) string {
+	if token == "" {
+		return ""
+	}
+	for _, p := range tokenPrefixes {
+		if strings.HasPrefix(token, p.prefix) {
+			return p.tokenType
+		}
+	}
+	return OAuthToken
+}
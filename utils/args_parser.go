@@ -180,6 +180,22 @@ func (p *ArgsParser) HasReceived(name string) bool {
 	return found && len(f.values) > 0
 }
 
+// SetDefault gives the flag name a value as if it had been passed, unless it
+// already was.
+func (p *ArgsParser) SetDefault(name, value string) error {
+	if n, found := p.flagAliases[name]; found {
+		name = n
+	}
+	f, found := p.flagMap[name]
+	if !found {
+		return fmt.Errorf("unknown flag: '%s'", name)
+	}
+	if len(f.values) == 0 {
+		f.addValue(value)
+	}
+	return nil
+}
+
 func NewArgsParser() *ArgsParser {
 	return &ArgsParser{
 		flagMap:     make(map[string]*argsFlag),
@@ -211,3 +211,23 @@ func TestArgsParser_WithUsage(t *testing.T) {
 	equal(t, true, p.Bool("--draft"))
 	equal(t, "hello", p.Value("--message"))
 }
+
+func TestArgsParser_SetDefault(t *testing.T) {
+	p := NewArgsParserWithUsage(`
+		-s, --state STATE
+		-L, --limit N
+		-^, --sort-ascending
+	`)
+	_, err := p.Parse([]string{"-s", "closed"})
+	equal(t, nil, err)
+
+	equal(t, nil, p.SetDefault("--state", "open"))
+	equal(t, nil, p.SetDefault("-L", "10"))
+	equal(t, nil, p.SetDefault("--sort-ascending", ""))
+	equal(t, "closed", p.Value("--state"))
+	equal(t, 10, p.Int("--limit"))
+	equal(t, true, p.Bool("--sort-ascending"))
+
+	err = p.SetDefault("--nonexistent", "1")
+	equal(t, "unknown flag: '--nonexistent'", err.Error())
+}